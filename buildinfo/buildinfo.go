@@ -16,4 +16,7 @@ var (
 	Commit = Placeholder
 	// Version is the semantic version number of the build.
 	Version = VersionPlaceholder
+	// ReleaseSigningKey is the base64 encoded ed25519 public key used to
+	// verify the signature of releases downloaded by 'gsctl upgrade self'.
+	ReleaseSigningKey = ""
 )
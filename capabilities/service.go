@@ -33,13 +33,6 @@ func New(provider string, clientWrapper *client.Wrapper) (*Service, error) {
 	s := &Service{
 		provider:      provider,
 		clientWrapper: clientWrapper,
-
-		allCapabilities: []CapabilityDefinition{
-			Autoscaling,
-			AvailabilityZones,
-			NodePools,
-			HAMasters,
-		},
 	}
 
 	err := s.initCapabilities()
@@ -47,6 +40,14 @@ func New(provider string, clientWrapper *client.Wrapper) (*Service, error) {
 		return nil, microerror.Maskf(couldNotInitializeCapabilities, err.Error())
 	}
 
+	// Collected only after initCapabilities, so that the API details are included.
+	s.allCapabilities = []CapabilityDefinition{
+		Autoscaling,
+		AvailabilityZones,
+		NodePools,
+		HAMasters,
+	}
+
 	return s, nil
 }
 
@@ -1,10 +1,12 @@
 package client
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/url"
@@ -24,6 +26,7 @@ import (
 	"github.com/giantswarm/gsclientgen/v2/client/node_pools"
 	"github.com/giantswarm/gsclientgen/v2/client/organizations"
 	"github.com/giantswarm/gsclientgen/v2/client/releases"
+	"github.com/giantswarm/gsclientgen/v2/client/users"
 	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
 	"github.com/go-openapi/runtime"
@@ -32,6 +35,7 @@ import (
 	rootcerts "github.com/hashicorp/go-rootcerts"
 
 	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 )
 
 var (
@@ -140,7 +144,7 @@ func New(conf *Configuration) (*Wrapper, error) {
 func NewWithConfig(endpointString, token string) (*Wrapper, error) {
 	endpoint := config.Config.ChooseEndpoint(endpointString)
 	ClientConfig := &Configuration{
-		AuthHeaderGetter: config.Config.AuthHeaderGetter(endpoint, token),
+		AuthHeaderGetter: ssotoken.AuthHeaderGetter(endpoint, token),
 		Endpoint:         endpoint,
 		Timeout:          20 * time.Second,
 		UserAgent:        config.UserAgent(),
@@ -246,6 +250,29 @@ type paramSetter interface {
 	SetXGiantSwarmCmdLine(*string)
 }
 
+// rawRequestParams implements paramSetter for requests made using
+// the raw HTTP client.
+type rawRequestParams struct {
+	request *http.Request
+	timeout time.Duration
+}
+
+func (r *rawRequestParams) SetTimeout(timeout time.Duration) {
+	r.timeout = timeout
+}
+
+func (r *rawRequestParams) SetXGiantSwarmActivity(activity *string) {
+	r.request.Header.Set("X-Giant-Swarm-Activity", *activity)
+}
+
+func (r *rawRequestParams) SetXRequestID(requestID *string) {
+	r.request.Header.Set("X-Request-ID", *requestID)
+}
+
+func (r *rawRequestParams) SetXGiantSwarmCmdLine(commandLine *string) {
+	r.request.Header.Set("X-Giant-Swarm-CmdLine", *commandLine)
+}
+
 // setParams takes parameters from an AuxiliaryParams input, and from the
 // client wrapper (or rather it's config) and sets request parameters
 // accordingly, independent of type.
@@ -538,6 +565,56 @@ func (w *Wrapper) ModifyNodePool(clusterID, nodePoolID string, modifyNodePoolReq
 	return response, nil
 }
 
+// ScaleNodePool sets the scaling limits of a node pool. Unlike with
+// ModifyNodePool, the maximum can be set to zero here, as the request
+// body is written directly instead of via the generated model, which
+// would omit a zero value.
+func (w *Wrapper) ScaleNodePool(clusterID, nodePoolID string, min, max int64, p *AuxiliaryParams) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"scaling": map[string]int64{
+			"min": min,
+			"max": max,
+		},
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	requestURL := fmt.Sprintf("%s/v5/clusters/%s/nodepools/%s/", strings.TrimRight(w.conf.Endpoint, "/"), url.PathEscape(clusterID), url.PathEscape(nodePoolID))
+	req, err := http.NewRequest(http.MethodPatch, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	authHeader, err := w.conf.AuthHeaderGetter()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("Content-Type", "application/json")
+
+	params := &rawRequestParams{request: req}
+	setParams(p, w, params)
+
+	httpClient := *w.rawClient
+	if params.timeout > 0 {
+		httpClient.Timeout = params.timeout
+	}
+
+	response, err := httpClient.Do(req)
+	if err != nil {
+		return clienterror.New(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		return clienterror.New(runtime.NewAPIError("scaleNodePool", string(responseBody), response.StatusCode))
+	}
+
+	return nil
+}
+
 // DeleteNodePool deletes a node pool.
 func (w *Wrapper) DeleteNodePool(clusterID, nodePoolID string, p *AuxiliaryParams) (*node_pools.DeleteNodePoolAccepted, error) {
 	params := node_pools.NewDeleteNodePoolParams().WithClusterID(clusterID).WithNodepoolID(nodePoolID)
@@ -669,6 +746,25 @@ func (w *Wrapper) GetOrganizations(p *AuxiliaryParams) (*organizations.GetOrgani
 	return response, nil
 }
 
+// GetUsers calls the API's getUsers operation using the gsclientgen client.
+// The operation is only available to admin users.
+func (w *Wrapper) GetUsers(p *AuxiliaryParams) (*users.GetUsersOK, error) {
+	params := users.NewGetUsersParams()
+	setParams(p, w, params)
+
+	authWriter, err := getAuthorization(w)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := w.gsclient.Users.GetUsers(params, authWriter)
+	if err != nil {
+		return nil, clienterror.New(err)
+	}
+
+	return response, nil
+}
+
 // GetCredential calls the API's getCredential operation using the gsclientgen client.
 func (w *Wrapper) GetCredential(organizationID string, credentialID string, p *AuxiliaryParams) (*organizations.GetCredentialOK, error) {
 	params := organizations.NewGetCredentialParams().WithOrganizationID(organizationID).WithCredentialID(credentialID)
@@ -705,6 +801,97 @@ func (w *Wrapper) SetCredentials(organizationID string, addCredentialsRequest *m
 	return response, nil
 }
 
+// GetOrganization calls the API's getOrganization operation using the gsclientgen client.
+func (w *Wrapper) GetOrganization(organizationID string, p *AuxiliaryParams) (*organizations.GetOrganizationOK, error) {
+	params := organizations.NewGetOrganizationParams().WithOrganizationID(organizationID)
+	setParams(p, w, params)
+
+	authWriter, err := getAuthorization(w)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := w.gsclient.Organizations.GetOrganization(params, authWriter)
+	if err != nil {
+		return nil, clienterror.New(err)
+	}
+
+	return response, nil
+}
+
+// CreateOrganization calls the API's addOrganization operation using the gsclientgen client.
+func (w *Wrapper) CreateOrganization(organizationID string, organization *models.V4Organization, p *AuxiliaryParams) (*organizations.AddOrganizationCreated, error) {
+	params := organizations.NewAddOrganizationParams().WithOrganizationID(organizationID).WithBody(organization)
+	setParams(p, w, params)
+
+	authWriter, err := getAuthorization(w)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := w.gsclient.Organizations.AddOrganization(params, authWriter)
+	if err != nil {
+		return nil, clienterror.New(err)
+	}
+
+	return response, nil
+}
+
+// ModifyOrganization calls the API's modifyOrganization operation using the gsclientgen client.
+// It is used to change the members of an organization.
+func (w *Wrapper) ModifyOrganization(organizationID string, body *models.ModifyOrganizationParamsBody, p *AuxiliaryParams) (*organizations.ModifyOrganizationOK, error) {
+	params := organizations.NewModifyOrganizationParams().WithOrganizationID(organizationID).WithBody(body)
+	setParams(p, w, params)
+
+	authWriter, err := getAuthorization(w)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := w.gsclient.Organizations.ModifyOrganization(params, authWriter)
+	if err != nil {
+		return nil, clienterror.New(err)
+	}
+
+	return response, nil
+}
+
+// DeleteOrganization calls the API's deleteOrganization operation using the gsclientgen client.
+func (w *Wrapper) DeleteOrganization(organizationID string, p *AuxiliaryParams) (*organizations.DeleteOrganizationOK, error) {
+	params := organizations.NewDeleteOrganizationParams().WithOrganizationID(organizationID)
+	setParams(p, w, params)
+
+	authWriter, err := getAuthorization(w)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := w.gsclient.Organizations.DeleteOrganization(params, authWriter)
+	if err != nil {
+		return nil, clienterror.New(err)
+	}
+
+	return response, nil
+}
+
+// GetCredentials calls the API's getCredentials operation using the gsclientgen client.
+func (w *Wrapper) GetCredentials(organizationID string, p *AuxiliaryParams) (*organizations.GetCredentialsOK, error) {
+	params := organizations.NewGetCredentialsParams().WithOrganizationID(organizationID)
+	setParams(p, w, params)
+
+	authWriter, err := getAuthorization(w)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := w.gsclient.Organizations.GetCredentials(params, authWriter)
+	if err != nil {
+		return nil, clienterror.New(err)
+	}
+
+	return response, nil
+}
+
 // GetClusterStatus fetches details on a cluster using the gsclientgen client.
 func (w *Wrapper) GetClusterStatus(clusterID string, p *AuxiliaryParams) (*ClusterStatus, error) {
 	params := clusters.NewGetClusterStatusParams().WithClusterID(clusterID)
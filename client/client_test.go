@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -325,6 +326,59 @@ func TestCreateAuthToken(t *testing.T) { // Our test server.
 	}
 }
 
+// TestScaleNodePool checks that zero scaling limits are sent explicitly.
+func TestScaleNodePool(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v5/clusters/f01r4/nodepools/a7k/" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "giantswarm token" {
+			t.Errorf("Unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Giant-Swarm-Activity") != "activity-name" {
+			t.Error("Header X-Giant-Swarm-Activity not available")
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if string(body) != `{"scaling":{"max":0,"min":0}}` {
+			t.Errorf("Unexpected request body %s", string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "a7k"}`))
+	}))
+	defer ts.Close()
+
+	config := &Configuration{
+		Endpoint:         ts.URL,
+		AuthHeaderGetter: func() (string, error) { return "giantswarm token", nil },
+	}
+
+	gsClient, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ap := gsClient.DefaultAuxiliaryParams()
+	ap.ActivityName = "activity-name"
+
+	err = gsClient.ScaleNodePool("f01r4", "a7k", 0, 0, ap)
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err = gsClient.ScaleNodePool("f01r4", "unknown", 0, 0, ap)
+	if !clienterror.IsNotFoundError(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
 // TestDeleteAuthToken checks out how to issue an authenticted request
 // using the new client.
 func TestDeleteAuthToken(t *testing.T) { // Our test server.
@@ -18,6 +18,7 @@ import (
 	"github.com/giantswarm/gsclientgen/v2/client/node_pools"
 	"github.com/giantswarm/gsclientgen/v2/client/organizations"
 	"github.com/giantswarm/gsclientgen/v2/client/releases"
+	"github.com/giantswarm/gsclientgen/v2/client/users"
 )
 
 // APIError is our structure to carry all error information we care about
@@ -574,6 +575,154 @@ func New(err error) *APIError {
 		}
 	}
 
+	// get credentials
+	if myerr, ok := err.(*organizations.GetCredentialsDefault); ok {
+		return &APIError{
+			ErrorDetails:   myerr.Payload.Message,
+			ErrorMessage:   myerr.Error(),
+			HTTPStatusCode: myerr.Code(),
+			OriginalError:  myerr,
+		}
+	}
+
+	// get organization
+	if myerr, ok := err.(*organizations.GetOrganizationUnauthorized); ok {
+		return &APIError{
+			ErrorMessage:   "Unauthorized",
+			ErrorDetails:   "You don't have permission to view this organization.",
+			HTTPStatusCode: http.StatusUnauthorized,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.GetOrganizationNotFound); ok {
+		return &APIError{
+			ErrorMessage:   "Organization not found",
+			ErrorDetails:   "The organization could not be found.",
+			HTTPStatusCode: http.StatusNotFound,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.GetOrganizationDefault); ok {
+		return &APIError{
+			ErrorDetails:   myerr.Payload.Message,
+			ErrorMessage:   myerr.Error(),
+			HTTPStatusCode: myerr.Code(),
+			OriginalError:  myerr,
+		}
+	}
+
+	// add organization
+	if myerr, ok := err.(*organizations.AddOrganizationUnauthorized); ok {
+		return &APIError{
+			ErrorMessage:   "Unauthorized",
+			ErrorDetails:   "You don't have permission to create organizations in this installation.",
+			HTTPStatusCode: http.StatusUnauthorized,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.AddOrganizationConflict); ok {
+		return &APIError{
+			ErrorMessage:   "Organization already exists",
+			ErrorDetails:   "An organization with this ID exists already.",
+			HTTPStatusCode: http.StatusConflict,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.AddOrganizationDefault); ok {
+		return &APIError{
+			ErrorDetails:   myerr.Payload.Message,
+			ErrorMessage:   myerr.Error(),
+			HTTPStatusCode: myerr.Code(),
+			OriginalError:  myerr,
+		}
+	}
+
+	// modify organization
+	if myerr, ok := err.(*organizations.ModifyOrganizationBadRequest); ok {
+		return &APIError{
+			ErrorMessage:   "Bad request",
+			ErrorDetails:   myerr.Payload.Message,
+			HTTPStatusCode: http.StatusBadRequest,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.ModifyOrganizationUnauthorized); ok {
+		return &APIError{
+			ErrorMessage:   "Unauthorized",
+			ErrorDetails:   "You don't have permission to modify this organization.",
+			HTTPStatusCode: http.StatusUnauthorized,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.ModifyOrganizationNotFound); ok {
+		return &APIError{
+			ErrorMessage:   "Organization not found",
+			ErrorDetails:   "The organization to be modified could not be found.",
+			HTTPStatusCode: http.StatusNotFound,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.ModifyOrganizationDefault); ok {
+		return &APIError{
+			ErrorDetails:   myerr.Payload.Message,
+			ErrorMessage:   myerr.Error(),
+			HTTPStatusCode: myerr.Code(),
+			OriginalError:  myerr,
+		}
+	}
+
+	// delete organization
+	if myerr, ok := err.(*organizations.DeleteOrganizationUnauthorized); ok {
+		return &APIError{
+			ErrorMessage:   "Unauthorized",
+			ErrorDetails:   "You don't have permission to delete this organization.",
+			HTTPStatusCode: http.StatusUnauthorized,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.DeleteOrganizationNotFound); ok {
+		return &APIError{
+			ErrorMessage:   "Organization not found",
+			ErrorDetails:   "The organization to be deleted could not be found.",
+			HTTPStatusCode: http.StatusNotFound,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.DeleteOrganizationConflict); ok {
+		return &APIError{
+			ErrorMessage:   "Organization cannot be deleted",
+			ErrorDetails:   "The organization still owns clusters. Please delete them first.",
+			HTTPStatusCode: http.StatusConflict,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*organizations.DeleteOrganizationDefault); ok {
+		return &APIError{
+			ErrorDetails:   myerr.Payload.Message,
+			ErrorMessage:   myerr.Error(),
+			HTTPStatusCode: myerr.Code(),
+			OriginalError:  myerr,
+		}
+	}
+
+	// get users
+	if myerr, ok := err.(*users.GetUsersUnauthorized); ok {
+		return &APIError{
+			ErrorMessage:   "Unauthorized",
+			ErrorDetails:   "You don't have permission to list users in this installation.",
+			HTTPStatusCode: http.StatusUnauthorized,
+			OriginalError:  myerr,
+		}
+	}
+	if myerr, ok := err.(*users.GetUsersDefault); ok {
+		return &APIError{
+			ErrorDetails:   myerr.Payload.Message,
+			ErrorMessage:   myerr.Error(),
+			HTTPStatusCode: myerr.Code(),
+			OriginalError:  myerr,
+		}
+	}
+
 	// HTTP level error cases
 	if runtimeAPIError, ok := err.(*runtime.APIError); ok {
 		ae := &APIError{
@@ -3,6 +3,7 @@ package clustercache
 import (
 	"fmt"
 	"path"
+	"sort"
 	"time"
 
 	"github.com/fatih/color"
@@ -20,18 +21,40 @@ import (
 )
 
 const (
-	listClustersActivityName = "list-clusters"
-	clusterCacheFileName     = "clustercache.yaml"
+	listClustersActivityName  = "list-clusters"
+	listNodePoolsActivityName = "list-nodepools"
+	clusterCacheFileName      = "clustercache.yaml"
 
 	cacheDuration = time.Hour * 24 * 7 // 7 days.
 	timeLayout    = time.RFC3339
 )
 
-// EndpointCache stores the IDs stored in an
-// endpoint-specific cache, and also its expiry date.
+// Entry holds the cached details of a single cluster.
+type Entry struct {
+	ID          string   `yaml:"id" json:"id"`
+	Name        string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Owner       string   `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Release     string   `yaml:"release,omitempty" json:"release_version,omitempty"`
+	CreateDate  string   `yaml:"created,omitempty" json:"create_date,omitempty"`
+	NodePoolIDs []string `yaml:"nodepool_ids,omitempty" json:"nodepool_ids,omitempty"`
+	// Cached is the time the entry has been written, in RFC3339 format.
+	Cached string `yaml:"cached" json:"cached"`
+}
+
+// IsExpired returns true if the entry is older than the cache duration,
+// or if its timestamp can't be parsed.
+func (e Entry) IsExpired() bool {
+	cached, err := time.Parse(timeLayout, e.Cached)
+	if err != nil {
+		return true
+	}
+
+	return time.Now().After(cached.Add(cacheDuration))
+}
+
+// EndpointCache stores the cluster entries cached for one endpoint.
 type EndpointCache struct {
-	Expiry string   `yaml:"expiry"`
-	IDs    []string `yaml:"ids"`
+	Clusters []Entry `yaml:"clusters"`
 }
 
 // Endpoints stores a map with the keys being API endpoints,
@@ -46,59 +69,77 @@ type Cache struct {
 // GetID gets the cluster ID for a provided name/ID
 // by checking in both the user cache and on the API.
 func GetID(endpoint string, clusterNameOrID string, clientWrapper *client.Wrapper) (string, error) {
-	// Check if the cluster ID is already in the cache,
+	// Check if the cluster ID or name is already in the cache,
 	// and skip the API request if it is.
-	isInCache := IsInCache(endpoint, clusterNameOrID)
-	if isInCache {
-		return clusterNameOrID, nil
-	}
+	matching := findInCache(endpoint, clusterNameOrID)
+	if len(matching) == 1 {
+		return matching[0].ID, nil
+	} else if len(matching) > 1 {
+		id, err := handleNameCollision(clusterNameOrID, matching)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
 
-	auxParams := clientWrapper.DefaultAuxiliaryParams()
-	auxParams.ActivityName = listClustersActivityName
+		return id, nil
+	}
 
-	// Get a list of all clusters.
-	response, err := clientWrapper.GetClusters(auxParams)
+	clusters, err := fetchClusters(clientWrapper)
 	if err != nil {
-		switch {
-		case clienterror.IsUnauthorizedError(err):
-			return "", microerror.Mask(errors.NotAuthorizedError)
-
-		case clienterror.IsAccessForbiddenError(err):
-			return "", microerror.Mask(errors.AccessForbiddenError)
-
-		default:
-			return "", microerror.Mask(err)
-		}
+		return "", microerror.Mask(err)
 	}
 
-	var (
-		// IDs that correspond to the same cluster name.
-		matchingIDs []string
-		// All IDs returned from the response.
-		allClusterIDs = make([]string, 0, len(response.Payload))
-	)
-	for _, cluster := range response.Payload {
-		allClusterIDs = append(allClusterIDs, cluster.ID)
+	CacheClusters(endpoint, clusters)
+
+	// Clusters that correspond to the same cluster name.
+	var matchingClusters []*models.V4ClusterListItem
+	for _, cluster := range clusters {
 		// Check if this is the cluster we're looking for.
 		if matchesValidation(clusterNameOrID, cluster) {
-			matchingIDs = append(matchingIDs, cluster.ID)
+			matchingClusters = append(matchingClusters, cluster)
 		}
 	}
+	matching = entriesFromClusters(matchingClusters)
 
-	CacheIDs(endpoint, allClusterIDs)
-
-	if matchingIDs == nil {
+	if len(matching) == 0 {
 		// There are no IDs that correspond to that cluster name
 		return "", microerror.Mask(errors.ClusterNotFoundError)
-	} else if len(matchingIDs) > 1 {
+	} else if len(matching) > 1 {
 		// There are multiple IDs that correspond to that cluster name.
 		// Help the user decide which one to pick.
-		id := handleNameCollision(clusterNameOrID, response.Payload)
+		id, err := handleNameCollision(clusterNameOrID, matching)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
 
 		return id, nil
 	}
 
-	return matchingIDs[0], nil
+	return matching[0].ID, nil
+}
+
+// GetOwner returns the organization owning the cluster with the given ID,
+// from the cache if possible, otherwise from the API.
+func GetOwner(endpoint string, clusterID string, clientWrapper *client.Wrapper) (string, error) {
+	for _, entry := range List(endpoint) {
+		if entry.ID == clusterID && entry.Owner != "" && !entry.IsExpired() {
+			return entry.Owner, nil
+		}
+	}
+
+	clusters, err := fetchClusters(clientWrapper)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	CacheClusters(endpoint, clusters)
+
+	for _, cluster := range clusters {
+		if cluster.ID == clusterID && cluster.DeleteDate == nil {
+			return cluster.Owner, nil
+		}
+	}
+
+	return "", microerror.Mask(errors.ClusterNotFoundError)
 }
 
 // New creates a new Cache object.
@@ -112,40 +153,258 @@ func New() *Cache {
 // IsInCache checks if a cluster ID is present in the
 // persistent cluster cache.
 func IsInCache(endpoint string, ID string) bool {
-	var (
-		err                error
-		endpointExpiration time.Time
+	for _, entry := range List(endpoint) {
+		if entry.ID == ID && !entry.IsExpired() {
+			return true
+		}
+	}
 
-		now = time.Now()
-	)
-	// Read the existing cache file.
+	return false
+}
+
+// List returns all cluster entries cached for an endpoint,
+// including expired ones, sorted by ID.
+func List(endpoint string) []Entry {
 	existing, err := read(config.FileSystem)
 	if err != nil {
-		return false
+		return nil
+	}
+
+	entries := existing.Endpoints[endpoint].Clusters
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+// CacheClusters replaces the cached entries for an endpoint with the
+// clusters given. Clusters which are being deleted are skipped. Node pool IDs
+// already known for a cluster are kept.
+func CacheClusters(endpoint string, clusters []*models.V4ClusterListItem) {
+	storeEntries(endpoint, entriesFromClusters(clusters))
+}
+
+// CacheNodePoolIDs stores the node pool IDs of a cluster.
+func CacheNodePoolIDs(endpoint string, clusterID string, nodePoolIDs []string) {
+	fs := config.FileSystem
+
+	cache, _ := read(fs)
+	if cache == nil {
+		cache = New()
 	}
 
-	c := existing.Endpoints[endpoint]
-	for _, cID := range c.IDs {
-		// Check if the cache is expired.
-		endpointExpiration, err = time.Parse(timeLayout, c.Expiry)
-		if err != nil || now.After(endpointExpiration) {
-			return false
+	endpointCache := cache.Endpoints[endpoint]
+
+	found := false
+	for i := range endpointCache.Clusters {
+		if endpointCache.Clusters[i].ID == clusterID {
+			endpointCache.Clusters[i].NodePoolIDs = nodePoolIDs
+			found = true
 		}
-		// This is the one we're looking for.
-		if cID == ID {
-			return true
+	}
+
+	if !found {
+		endpointCache.Clusters = append(endpointCache.Clusters, Entry{
+			ID:          clusterID,
+			NodePoolIDs: nodePoolIDs,
+			Cached:      time.Now().Format(timeLayout),
+		})
+	}
+
+	cache.Endpoints[endpoint] = endpointCache
+
+	_ = write(fs, cache)
+}
+
+// Invalidate removes all cached entries for an endpoint. It should be called
+// whenever clusters get created, deleted or renamed.
+func Invalidate(endpoint string) {
+	fs := config.FileSystem
+
+	cache, err := read(fs)
+	if err != nil {
+		return
+	}
+
+	if _, ok := cache.Endpoints[endpoint]; !ok {
+		return
+	}
+
+	delete(cache.Endpoints, endpoint)
+
+	_ = write(fs, cache)
+}
+
+// InvalidateNodePools removes the cached node pool IDs of a cluster. It should
+// be called whenever node pools get created or deleted.
+func InvalidateNodePools(endpoint string, clusterID string) {
+	fs := config.FileSystem
+
+	cache, err := read(fs)
+	if err != nil {
+		return
+	}
+
+	endpointCache := cache.Endpoints[endpoint]
+	for i := range endpointCache.Clusters {
+		if endpointCache.Clusters[i].ID == clusterID {
+			endpointCache.Clusters[i].NodePoolIDs = nil
 		}
 	}
 
-	return false
+	_ = write(fs, cache)
 }
 
-// CacheIDs adds cluster IDs to a persistent cache,
-// which can be used for decreasing timeout in getting
-// cluster IDs, for commands that take both cluster names and IDs.
-func CacheIDs(endpoint string, c []string) {
+// Clear removes the cache for one endpoint, or for all endpoints
+// if the given endpoint is empty.
+func Clear(endpoint string) error {
+	fs := config.FileSystem
+
+	if endpoint == "" {
+		filePath := path.Join(config.ConfigDirPath, clusterCacheFileName)
+		exists, _ := afero.Exists(fs, filePath)
+		if !exists {
+			return nil
+		}
+
+		err := fs.Remove(filePath)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	cache, err := read(fs)
+	if err != nil {
+		// Nothing to clear.
+		return nil
+	}
+
+	delete(cache.Endpoints, endpoint)
+
+	err = write(fs, cache)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Refresh fetches all clusters and, where supported, their node pools from
+// the API and replaces the cache for the endpoint with the result.
+func Refresh(endpoint string, clientWrapper *client.Wrapper) ([]Entry, error) {
+	clusters, err := fetchClusters(clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	entries := entriesFromClusters(clusters)
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = listNodePoolsActivityName
+
+	for i := range entries {
+		response, err := clientWrapper.GetNodePools(entries[i].ID, auxParams)
+		if err != nil {
+			// Clusters without node pool support respond with an error here,
+			// which we deliberately ignore.
+			continue
+		}
+
+		for _, np := range response.Payload {
+			entries[i].NodePoolIDs = append(entries[i].NodePoolIDs, np.ID)
+		}
+		sort.Strings(entries[i].NodePoolIDs)
+	}
+
+	// Drop what we had before, so that stale node pool IDs don't survive.
+	err = Clear(endpoint)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	storeEntries(endpoint, entries)
+
+	return entries, nil
+}
+
+// findInCache returns all non-expired entries matching the given
+// cluster name or ID. An ID match takes precedence.
+func findInCache(endpoint string, nameOrID string) []Entry {
+	var matching []Entry
+
+	for _, entry := range List(endpoint) {
+		if entry.IsExpired() {
+			continue
+		}
+		if entry.ID == nameOrID {
+			return []Entry{entry}
+		}
+		if entry.Name == nameOrID {
+			matching = append(matching, entry)
+		}
+	}
+
+	return matching
+}
+
+// fetchClusters gets the list of clusters from the API.
+func fetchClusters(clientWrapper *client.Wrapper) ([]*models.V4ClusterListItem, error) {
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = listClustersActivityName
+
+	response, err := clientWrapper.GetClusters(auxParams)
+	if err != nil {
+		switch {
+		case clienterror.IsUnauthorizedError(err):
+			return nil, microerror.Mask(errors.NotAuthorizedError)
+
+		case clienterror.IsAccessForbiddenError(err):
+			return nil, microerror.Mask(errors.AccessForbiddenError)
+
+		default:
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return response.Payload, nil
+}
+
+// entriesFromClusters converts a cluster list into cache entries,
+// skipping clusters which are being deleted.
+func entriesFromClusters(clusters []*models.V4ClusterListItem) []Entry {
+	entries := make([]Entry, 0, len(clusters))
+	now := time.Now().Format(timeLayout)
+
+	for _, cluster := range clusters {
+		if cluster.DeleteDate != nil {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			ID:         cluster.ID,
+			Name:       cluster.Name,
+			Owner:      cluster.Owner,
+			Release:    cluster.ReleaseVersion,
+			CreateDate: cluster.CreateDate,
+			Cached:     now,
+		})
+	}
+
+	return entries
+}
+
+func matchesValidation(nameOrID string, cluster *models.V4ClusterListItem) bool {
+	return cluster.DeleteDate == nil && (cluster.ID == nameOrID || cluster.Name == nameOrID)
+}
+
+// storeEntries replaces the entries of an endpoint in the persistent cache,
+// keeping node pool IDs known from before.
+func storeEntries(endpoint string, entries []Entry) {
 	// Let's not store an empty list.
-	if len(c) == 0 {
+	if len(entries) == 0 {
 		return
 	}
 
@@ -160,24 +419,52 @@ func CacheIDs(endpoint string, c []string) {
 		}
 	}
 
-	// Add the cache to a certain endpoint.
-	cache.Endpoints[endpoint] = EndpointCache{
-		Expiry: time.Now().Add(cacheDuration).Format(timeLayout),
-		IDs:    c,
+	nodePoolIDs := map[string][]string{}
+	for _, entry := range cache.Endpoints[endpoint].Clusters {
+		nodePoolIDs[entry.ID] = entry.NodePoolIDs
 	}
 
-	// Write the cache file.
-	err := write(fs, cache)
-	if err != nil {
-		return
+	for i := range entries {
+		if entries[i].NodePoolIDs == nil {
+			entries[i].NodePoolIDs = nodePoolIDs[entries[i].ID]
+		}
 	}
-}
 
-func matchesValidation(nameOrID string, cluster *models.V4ClusterListItem) bool {
-	return cluster.DeleteDate == nil && (cluster.ID == nameOrID || cluster.Name == nameOrID)
+	cache.Endpoints[endpoint] = EndpointCache{
+		Clusters: entries,
+	}
+
+	_ = write(fs, cache)
 }
 
-func handleNameCollision(nameOrID string, clusters []*models.V4ClusterListItem) string {
+// handleNameCollision lets the user choose one of several clusters with the
+// same name. If the user doesn't choose, CommandAbortedError is returned.
+func handleNameCollision(nameOrID string, entries []Entry) (string, error) {
+	if confirm.IsInteractive() {
+		options := make([]confirm.Option, 0, len(entries))
+		for _, entry := range entries {
+			releaseVersion := entry.Release
+			if releaseVersion == "" {
+				releaseVersion = "n/a"
+			}
+
+			options = append(options, confirm.Option{
+				Value: entry.ID,
+				Label: fmt.Sprintf("%s  %s  %s  %s", entry.ID, entry.Owner, releaseVersion, util.ShortDate(util.ParseDate(entry.CreateDate))),
+			})
+		}
+
+		fmt.Println(fmt.Sprintf("Multiple clusters found with the name '%s'.", nameOrID))
+
+		id, err := confirm.Pick("Please select the cluster that you want to use", options)
+		if confirm.IsAborted(err) {
+			return "", microerror.Maskf(errors.CommandAbortedError, "no cluster selected for name '%s'", nameOrID)
+		} else if err != nil {
+			return "", microerror.Mask(err)
+		}
+		return id, nil
+	}
+
 	var (
 		clusterIDs     []string
 		createdDate    string
@@ -186,17 +473,15 @@ func handleNameCollision(nameOrID string, clusters []*models.V4ClusterListItem)
 		table = []string{color.CyanString("ID | ORGANIZATION | RELEASE | CREATED")}
 	)
 
-	for _, cluster := range clusters {
-		if matchesValidation(nameOrID, cluster) {
-			clusterIDs = append(clusterIDs, cluster.ID)
-			createdDate = util.ShortDate(util.ParseDate(cluster.CreateDate))
-			releaseVersion = cluster.ReleaseVersion
-			if releaseVersion == "" {
-				releaseVersion = "n/a"
-			}
-
-			table = append(table, fmt.Sprintf("%5s | %5s | %5s | %5s\n", cluster.ID, cluster.Owner, releaseVersion, createdDate))
+	for _, entry := range entries {
+		clusterIDs = append(clusterIDs, entry.ID)
+		createdDate = util.ShortDate(util.ParseDate(entry.CreateDate))
+		releaseVersion = entry.Release
+		if releaseVersion == "" {
+			releaseVersion = "n/a"
 		}
+
+		table = append(table, fmt.Sprintf("%5s | %5s | %5s | %5s\n", entry.ID, entry.Owner, releaseVersion, createdDate))
 	}
 
 	printNameCollisionTable(nameOrID, table)
@@ -206,9 +491,9 @@ func handleNameCollision(nameOrID string, clusters []*models.V4ClusterListItem)
 		clusterIDs,
 	)
 	if !confirmed {
-		return ""
+		return "", microerror.Maskf(errors.CommandAbortedError, "no cluster selected for name '%s'", nameOrID)
 	}
-	return id
+	return id, nil
 }
 
 func printNameCollisionTable(name string, table []string) {
@@ -233,6 +518,10 @@ func read(fs afero.Fs) (*Cache, error) {
 		return nil, err
 	}
 
+	if cache.Endpoints == nil {
+		cache.Endpoints = Endpoints{}
+	}
+
 	return cache, nil
 }
 
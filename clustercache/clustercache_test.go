@@ -222,8 +222,58 @@ asd1sd  giantswarm    11.0.1   2020 Mar 04, 09:11 UTC
 	}
 }
 
+// Test_GetOwner tests resolving the owner from the cache and from the API.
+func Test_GetOwner(t *testing.T) {
+	requests := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"id": "fow72", "name": "Production", "owner": "acme", "create_date": "2017-05-16T09:30:31.192170835Z"},
+			{"id": "del01", "name": "Deleted", "owner": "acme", "delete_date": "2019-10-10T07:24:55.192170835Z"}
+		]`))
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testutils.TempClusterCache(fs, fmt.Sprintf(`endpoints:
+  %s:
+    clusters:
+    - id: ca4e1
+      owner: cached_org
+      cached: "%s"`, mockServer.URL, time.Now().Format(timeLayout)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.NewWithConfig(mockServer.URL, "test-token")
+	if err != nil {
+		t.Fatalf("Error in client creation: %s", err)
+	}
+
+	owner, err := GetOwner(mockServer.URL, "ca4e1", clientWrapper)
+	if err != nil || owner != "cached_org" || requests != 0 {
+		t.Errorf("Expected owner from cache without API request, got %q, %v, %d requests", owner, err, requests)
+	}
+
+	owner, err = GetOwner(mockServer.URL, "fow72", clientWrapper)
+	if err != nil || owner != "acme" {
+		t.Errorf("Expected owner 'acme' from API, got %q, %v", owner, err)
+	}
+
+	_, err = GetOwner(mockServer.URL, "del01", clientWrapper)
+	if !errors.IsClusterNotFoundError(err) {
+		t.Errorf("Expected ClusterNotFoundError, got %v", err)
+	}
+}
+
 func Test_IsInClusterCache(t *testing.T) {
-	nonExpiredDate := time.Now().Add(time.Hour * 24).Format(timeLayout)
+	nonExpiredDate := time.Now().Add(-time.Hour * 24).Format(timeLayout)
 
 	testCases := []struct {
 		clusterNameOrID string
@@ -241,31 +291,42 @@ func Test_IsInClusterCache(t *testing.T) {
 			endpoint:        "mock-endpoint",
 			cacheYAML: fmt.Sprintf(`endpoints:
   other-endpoint:
-    expiry: "%s"
-    ids:
-    - 2sg4i`, nonExpiredDate),
+    clusters:
+    - id: 2sg4i
+      cached: "%s"`, nonExpiredDate),
 			expectedResult: false,
 		}, {
 			clusterNameOrID: "2sg4i",
 			endpoint:        "mock-endpoint",
 			cacheYAML: `endpoints:
   mock-endpoint:
-    expiry: "2010-03-03T13:17:16+01:00"
-    ids:
-    - 2sg4i`,
+    clusters:
+    - id: 2sg4i
+      cached: "2010-03-03T13:17:16+01:00"`,
 			expectedResult: false,
 		}, {
 			clusterNameOrID: "2sg4i",
 			endpoint:        "mock-endpoint",
 			cacheYAML: fmt.Sprintf(`endpoints:
+  mock-endpoint:
+    clusters:
+    - id: 2sg4i
+      cached: "%s"
+    - id: 123asd
+      cached: "%s"
+    - id: 1239d1
+      cached: "%s"`, nonExpiredDate, nonExpiredDate, nonExpiredDate),
+			expectedResult: true,
+		}, {
+			// Cache file in the format used by earlier versions.
+			clusterNameOrID: "2sg4i",
+			endpoint:        "mock-endpoint",
+			cacheYAML: fmt.Sprintf(`endpoints:
   mock-endpoint:
     expiry: "%s"
     ids:
-    - 2sg4i
-    - 123asd
-    - 1239d1
-    - 99sad0`, nonExpiredDate),
-			expectedResult: true,
+    - 2sg4i`, nonExpiredDate),
+			expectedResult: false,
 		},
 	}
 
@@ -288,67 +349,160 @@ func Test_IsInClusterCache(t *testing.T) {
 	}
 }
 
-func Test_CacheClusterIDs(t *testing.T) {
-	nonExpiredDate := time.Now().Add(cacheDuration).Format(timeLayout)
+// Test_GetIDFromCache tests name resolution without an API round trip.
+func Test_GetIDFromCache(t *testing.T) {
+	now := time.Now().Format(timeLayout)
+
+	cacheYAML := fmt.Sprintf(`endpoints:
+  %%s:
+    clusters:
+    - id: fow72
+      name: My dearest production cluster
+      owner: acme
+      cached: "%s"
+    - id: 2sg4i
+      name: Some expired cluster
+      owner: acme
+      cached: "2010-03-03T13:17:16+01:00"
+`, now)
 
 	testCases := []struct {
-		clusterIDs       []string
+		clusterNameOrID string
+		expectedID      string
+		expectAPICall   bool
+	}{
+		{
+			clusterNameOrID: "My dearest production cluster",
+			expectedID:      "fow72",
+			expectAPICall:   false,
+		}, {
+			clusterNameOrID: "fow72",
+			expectedID:      "fow72",
+			expectAPICall:   false,
+		}, {
+			clusterNameOrID: "Some expired cluster",
+			expectedID:      "2sg4i",
+			expectAPICall:   true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			apiCalled := false
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				apiCalled = true
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`[
+					{
+						"create_date": "2017-04-16T09:30:31.192170835Z",
+						"id": "2sg4i",
+						"name": "Some expired cluster",
+						"owner": "acme",
+						"path": "/v4/clusters/2sg4i/"
+					}
+				]`))
+			}))
+			defer mockServer.Close()
+
+			fs := afero.NewMemMapFs()
+			_, err := testutils.TempConfig(fs, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = testutils.TempClusterCache(fs, fmt.Sprintf(cacheYAML, mockServer.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clientWrapper, err := client.NewWithConfig(mockServer.URL, "test-token")
+			if err != nil {
+				t.Fatalf("Error in client creation: %s", err)
+			}
+
+			id, err := GetID(mockServer.URL, tc.clusterNameOrID, clientWrapper)
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+			if id != tc.expectedID {
+				t.Errorf("Case %d - Expected ID %q, got %q", i, tc.expectedID, id)
+			}
+			if apiCalled != tc.expectAPICall {
+				t.Errorf("Case %d - Expected API call %v, got %v", i, tc.expectAPICall, apiCalled)
+			}
+		})
+	}
+}
+
+func Test_CacheClusters(t *testing.T) {
+	now := time.Now().Format(timeLayout)
+	dd := strfmt.NewDateTime()
+
+	testCases := []struct {
+		clusters         []*models.V4ClusterListItem
 		initialCacheYAML string
 		cacheYAML        string
 		endpoint         string
 	}{
 		{
-			clusterIDs: []string{"1239d1", "99sad0"},
-			endpoint:   "other-endpoint",
+			clusters: []*models.V4ClusterListItem{
+				{ID: "1239d1", Name: "One", Owner: "acme", ReleaseVersion: "11.0.0"},
+				{ID: "99sad0", Name: "Two", Owner: "acme"},
+			},
+			endpoint: "other-endpoint",
 			initialCacheYAML: `endpoints:
   mock-endpoint:
-    expiry: "2010-03-03T13:17:16+01:00"
-    ids:
-    - 2sg4i
-    - 123asd`,
+    clusters:
+    - id: 2sg4i
+      cached: "2010-03-03T13:17:16+01:00"
+`,
 			cacheYAML: fmt.Sprintf(`endpoints:
   mock-endpoint:
-    expiry: "2010-03-03T13:17:16+01:00"
-    ids:
-    - 2sg4i
-    - 123asd
+    clusters:
+    - id: 2sg4i
+      cached: "2010-03-03T13:17:16+01:00"
   other-endpoint:
-    expiry: "%s"
-    ids:
-    - 1239d1
-    - 99sad0
-`, nonExpiredDate),
-		}, {
-			clusterIDs:       []string{"1239d1", "99sad0"},
-			endpoint:         "mock-endpoint",
-			initialCacheYAML: "",
-			cacheYAML: fmt.Sprintf(`endpoints:
-  mock-endpoint:
-    expiry: "%s"
-    ids:
-    - 1239d1
-    - 99sad0
-`, nonExpiredDate),
+    clusters:
+    - id: 1239d1
+      name: One
+      owner: acme
+      release: 11.0.0
+      cached: "%s"
+    - id: 99sad0
+      name: Two
+      owner: acme
+      cached: "%s"
+`, now, now),
 		}, {
-			clusterIDs: []string{"1239d1", "99sad0", "asd10h"},
-			endpoint:   "mock-endpoint",
+			// Node pool IDs are kept, deleted clusters are skipped.
+			clusters: []*models.V4ClusterListItem{
+				{ID: "1239d1", Name: "One renamed", Owner: "acme"},
+				{ID: "99sad0", Name: "Two", Owner: "acme", DeleteDate: &dd},
+			},
+			endpoint: "mock-endpoint",
 			initialCacheYAML: `endpoints:
   mock-endpoint:
-    expiry: "2010-03-03T13:17:16+01:00"
-    ids:
-    - 1239d1
-    - 99sad0
+    clusters:
+    - id: 1239d1
+      name: One
+      nodepool_ids:
+      - a7k
+      cached: "2010-03-03T13:17:16+01:00"
+    - id: 99sad0
+      cached: "2010-03-03T13:17:16+01:00"
 `,
 			cacheYAML: fmt.Sprintf(`endpoints:
   mock-endpoint:
-    expiry: "%s"
-    ids:
-    - 1239d1
-    - 99sad0
-    - asd10h
-`, nonExpiredDate),
+    clusters:
+    - id: 1239d1
+      name: One renamed
+      owner: acme
+      nodepool_ids:
+      - a7k
+      cached: "%s"
+`, now),
 		}, {
-			clusterIDs:       []string{},
+			clusters:         []*models.V4ClusterListItem{},
 			endpoint:         "mock-endpoint",
 			initialCacheYAML: "",
 			cacheYAML:        "",
@@ -367,13 +521,59 @@ func Test_CacheClusterIDs(t *testing.T) {
 			defer fs.Remove(clusterCacheFilePath)
 
 			// output
-			CacheIDs(tc.endpoint, tc.clusterIDs)
+			CacheClusters(tc.endpoint, tc.clusters)
 			cacheContent, _ := afero.ReadFile(fs, clusterCacheFilePath)
 
-			if diff := cmp.Diff(string(cacheContent), tc.cacheYAML); diff != "" {
+			if diff := cmp.Diff(tc.cacheYAML, string(cacheContent)); diff != "" {
 				t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
 			}
-
 		})
 	}
 }
+
+func Test_Invalidate(t *testing.T) {
+	now := time.Now().Format(timeLayout)
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testutils.TempClusterCache(fs, fmt.Sprintf(`endpoints:
+  mock-endpoint:
+    clusters:
+    - id: 1239d1
+      nodepool_ids:
+      - a7k
+      cached: "%s"
+  other-endpoint:
+    clusters:
+    - id: 99sad0
+      cached: "%s"
+`, now, now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	InvalidateNodePools("mock-endpoint", "1239d1")
+	entries := List("mock-endpoint")
+	if len(entries) != 1 || entries[0].NodePoolIDs != nil {
+		t.Errorf("Expected node pool IDs to be removed, got %#v", entries)
+	}
+
+	Invalidate("mock-endpoint")
+	if IsInCache("mock-endpoint", "1239d1") {
+		t.Error("Expected cluster to be removed from cache")
+	}
+	if !IsInCache("other-endpoint", "99sad0") {
+		t.Error("Expected other endpoint to be unaffected")
+	}
+
+	err = Clear("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsInCache("other-endpoint", "99sad0") {
+		t.Error("Expected cache to be empty")
+	}
+}
@@ -0,0 +1,170 @@
+// Package clustertemplate manages cluster definition templates. Templates
+// are cluster definition YAML files using Go template syntax, stored by
+// name in the gsctl configuration directory.
+package clustertemplate
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+)
+
+const (
+	// dirName is the name of the directory within the
+	// configuration directory holding the templates.
+	dirName = "templates"
+
+	fileExtension = ".yaml"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Template is a stored cluster definition template.
+type Template struct {
+	Name string
+	Path string
+	// Parameters are the names of the values the template references.
+	Parameters []string
+}
+
+// Dir returns the path of the directory templates are stored in.
+func Dir() string {
+	return path.Join(config.ConfigDirPath, dirName)
+}
+
+// ValidateName checks whether the name can be used for a template.
+func ValidateName(name string) error {
+	if !nameRegexp.MatchString(name) {
+		return microerror.Maskf(invalidNameError, "'%s' is not a valid template name", name)
+	}
+
+	return nil
+}
+
+func filePath(name string) string {
+	return path.Join(Dir(), name+fileExtension)
+}
+
+// Store saves a template under the given name. The content must be
+// a parseable template. An existing template with the same name is
+// only replaced if overwrite is true.
+func Store(fs afero.Fs, name string, content []byte, overwrite bool) error {
+	err := ValidateName(name)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	_, err = parseTemplate(content)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	exists, err := afero.Exists(fs, filePath(name))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if exists && !overwrite {
+		return microerror.Maskf(alreadyExistsError, "template '%s' already exists", name)
+	}
+
+	err = fs.MkdirAll(Dir(), 0700)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = afero.WriteFile(fs, filePath(name), content, config.ConfigFilePermission)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Load returns the content of the template with the given name.
+func Load(fs afero.Fs, name string) ([]byte, error) {
+	err := ValidateName(name)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	content, err := afero.ReadFile(fs, filePath(name))
+	if os.IsNotExist(err) {
+		return nil, microerror.Maskf(notFoundError, "template '%s' does not exist", name)
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return content, nil
+}
+
+// Delete removes the template with the given name.
+func Delete(fs afero.Fs, name string) error {
+	err := ValidateName(name)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	exists, err := afero.Exists(fs, filePath(name))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if !exists {
+		return microerror.Maskf(notFoundError, "template '%s' does not exist", name)
+	}
+
+	err = fs.Remove(filePath(name))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// List returns all stored templates, sorted by name. Templates which can't
+// be parsed are listed without parameters.
+func List(fs afero.Fs) ([]Template, error) {
+	exists, err := afero.DirExists(fs, Dir())
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if !exists {
+		return []Template{}, nil
+	}
+
+	infos, err := afero.ReadDir(fs, Dir())
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	templates := []Template{}
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), fileExtension) {
+			continue
+		}
+
+		t := Template{
+			Name: strings.TrimSuffix(info.Name(), fileExtension),
+			Path: path.Join(Dir(), info.Name()),
+		}
+
+		content, err := afero.ReadFile(fs, t.Path)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		t.Parameters, _ = Parameters(content)
+
+		templates = append(templates, t)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
@@ -0,0 +1,168 @@
+package clustertemplate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+const testTemplate = `api_version: v5
+owner: {{ .owner }}
+name: {{ .name }}
+release_version: 12.0.0
+nodepools:
+- name: Default
+  node_spec:
+    aws:
+      instance_type: {{ .nodepool.instance_type }}
+{{- if .ha }}
+master_nodes:
+  high_availability: true
+{{- end }}
+`
+
+// Test_StoreLoadDelete tests the life cycle of a stored template.
+func Test_StoreLoadDelete(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := List(fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("Expected no templates, got %d", len(templates))
+	}
+
+	err = Store(fs, "dev", []byte(testTemplate), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Store(fs, "dev", []byte(testTemplate), false)
+	if !IsAlreadyExists(err) {
+		t.Errorf("Expected alreadyExistsError, got %v", err)
+	}
+	err = Store(fs, "dev", []byte(testTemplate), true)
+	if err != nil {
+		t.Errorf("Unexpected error when overwriting: %s", err)
+	}
+
+	err = Store(fs, "broken", []byte("name: {{ .name"), false)
+	if !IsInvalidTemplate(err) {
+		t.Errorf("Expected invalidTemplateError, got %v", err)
+	}
+	err = Store(fs, "../evil", []byte(testTemplate), false)
+	if !IsInvalidName(err) {
+		t.Errorf("Expected invalidNameError, got %v", err)
+	}
+
+	content, err := Load(fs, "dev")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if string(content) != testTemplate {
+		t.Errorf("Loaded template not as stored: %s", string(content))
+	}
+
+	templates, err = List(fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 || templates[0].Name != "dev" {
+		t.Fatalf("Unexpected templates %#v", templates)
+	}
+	if diff := cmp.Diff([]string{"ha", "name", "nodepool.instance_type", "owner"}, templates[0].Parameters); diff != "" {
+		t.Errorf("Parameters not as expected (-want +got):\n%s", diff)
+	}
+
+	err = Delete(fs, "dev")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	_, err = Load(fs, "dev")
+	if !IsNotFound(err) {
+		t.Errorf("Expected notFoundError, got %v", err)
+	}
+	err = Delete(fs, "dev")
+	if !IsNotFound(err) {
+		t.Errorf("Expected notFoundError, got %v", err)
+	}
+}
+
+// Test_Render tests rendering with values from a file and --set pairs.
+func Test_Render(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	err := afero.WriteFile(fs, "/values.yaml", []byte("owner: acme\nname: Dev\nnodepool:\n  instance_type: m5.xlarge\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := ReadValues(fs, "/values.yaml", []string{"name=Staging", "nodepool.instance_type=m5.2xlarge", "ha=true"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	rendered, err := Render([]byte(testTemplate), values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `api_version: v5
+owner: acme
+name: Staging
+release_version: 12.0.0
+nodepools:
+- name: Default
+  node_spec:
+    aws:
+      instance_type: m5.2xlarge
+master_nodes:
+  high_availability: true
+`
+	if diff := cmp.Diff(expected, string(rendered)); diff != "" {
+		t.Errorf("Rendered template not as expected (-want +got):\n%s", diff)
+	}
+
+	// A referenced value is missing.
+	_, err = Render([]byte(testTemplate), map[string]interface{}{"owner": "acme"})
+	if !IsRenderFailed(err) {
+		t.Errorf("Expected renderFailedError, got %v", err)
+	}
+
+	// Plain YAML is passed through.
+	plain := "owner: acme\nname: Plain\n"
+	rendered, err = Render([]byte(plain), nil)
+	if err != nil || string(rendered) != plain {
+		t.Errorf("Expected plain YAML to be unchanged, got %q, %v", string(rendered), err)
+	}
+}
+
+// Test_ReadValuesErrors tests malformed values.
+func Test_ReadValuesErrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	testCases := []struct {
+		valuesPath string
+		set        []string
+	}{
+		{"", []string{"novalue"}},
+		{"", []string{"=value"}},
+		{"", []string{"a..b=value"}},
+		{"", []string{"a=1", "a.b=2"}},
+		{"/does-not-exist.yaml", nil},
+	}
+
+	for i, tc := range testCases {
+		_, err := ReadValues(fs, tc.valuesPath, tc.set)
+		if !IsInvalidValues(err) {
+			t.Errorf("Case %d - Expected invalidValuesError, got %v", i, err)
+		}
+	}
+}
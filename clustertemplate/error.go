@@ -0,0 +1,60 @@
+package clustertemplate
+
+import "github.com/giantswarm/microerror"
+
+var invalidNameError = &microerror.Error{
+	Kind: "invalidNameError",
+	Desc: "Template names may only contain letters, digits, '.', '_' and '-', and must start with a letter or digit",
+}
+
+// IsInvalidName asserts invalidNameError.
+func IsInvalidName(err error) bool {
+	return microerror.Cause(err) == invalidNameError
+}
+
+var notFoundError = &microerror.Error{
+	Kind: "notFoundError",
+	Desc: "There is no template with this name",
+}
+
+// IsNotFound asserts notFoundError.
+func IsNotFound(err error) bool {
+	return microerror.Cause(err) == notFoundError
+}
+
+var alreadyExistsError = &microerror.Error{
+	Kind: "alreadyExistsError",
+	Desc: "A template with this name already exists",
+}
+
+// IsAlreadyExists asserts alreadyExistsError.
+func IsAlreadyExists(err error) bool {
+	return microerror.Cause(err) == alreadyExistsError
+}
+
+var invalidTemplateError = &microerror.Error{
+	Kind: "invalidTemplateError",
+}
+
+// IsInvalidTemplate asserts invalidTemplateError.
+func IsInvalidTemplate(err error) bool {
+	return microerror.Cause(err) == invalidTemplateError
+}
+
+var invalidValuesError = &microerror.Error{
+	Kind: "invalidValuesError",
+}
+
+// IsInvalidValues asserts invalidValuesError.
+func IsInvalidValues(err error) bool {
+	return microerror.Cause(err) == invalidValuesError
+}
+
+var renderFailedError = &microerror.Error{
+	Kind: "renderFailedError",
+}
+
+// IsRenderFailed asserts renderFailedError.
+func IsRenderFailed(err error) bool {
+	return microerror.Cause(err) == renderFailedError
+}
@@ -0,0 +1,196 @@
+package clustertemplate
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// parseTemplate parses a cluster definition template. Referencing a value
+// which has not been given is an error when rendering.
+func parseTemplate(content []byte) (*template.Template, error) {
+	t, err := template.New("definition").Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, microerror.Maskf(invalidTemplateError, err.Error())
+	}
+
+	return t, nil
+}
+
+// Render executes the template with the given values and returns the
+// resulting cluster definition YAML. Content without template actions
+// is returned as is.
+func Render(content []byte, values map[string]interface{}) ([]byte, error) {
+	t, err := parseTemplate(content)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	var buf bytes.Buffer
+	err = t.Execute(&buf, values)
+	if err != nil {
+		return nil, microerror.Maskf(renderFailedError, err.Error())
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ReadValues assembles the values to render a template with. Values are
+// read from the YAML file at valuesPath first, if given. Then the
+// key=value pairs in set are applied on top, where keys can use dots
+// to address nested values, e. g. 'nodepool.min=3'.
+func ReadValues(fs afero.Fs, valuesPath string, set []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	if valuesPath != "" {
+		data, err := afero.ReadFile(fs, valuesPath)
+		if err != nil {
+			return nil, microerror.Maskf(invalidValuesError, err.Error())
+		}
+
+		raw := map[interface{}]interface{}{}
+		err = yaml.Unmarshal(data, &raw)
+		if err != nil {
+			return nil, microerror.Maskf(invalidValuesError, "values file '%s' is not valid YAML: %s", valuesPath, err.Error())
+		}
+
+		values = normalize(raw).(map[string]interface{})
+	}
+
+	for _, pair := range set {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, microerror.Maskf(invalidValuesError, "'%s' is not in the form key=value", pair)
+		}
+
+		err := setValue(values, strings.Split(strings.TrimSpace(parts[0]), "."), parts[1])
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return values, nil
+}
+
+// setValue sets the value under the path of keys, creating
+// intermediate maps as needed.
+func setValue(values map[string]interface{}, keys []string, value string) error {
+	current := values
+	for i, key := range keys {
+		if key == "" {
+			return microerror.Maskf(invalidValuesError, "key '%s' contains an empty segment", strings.Join(keys, "."))
+		}
+
+		if i == len(keys)-1 {
+			current[key] = value
+			return nil
+		}
+
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			if _, exists := current[key]; exists {
+				return microerror.Maskf(invalidValuesError, "key '%s' is not a map", strings.Join(keys[:i+1], "."))
+			}
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		current = next
+	}
+
+	return nil
+}
+
+// normalize converts the maps YAML unmarshalling creates into maps with
+// string keys, which is what templates and setValue expect.
+func normalize(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[interface{}]interface{}:
+		out := map[string]interface{}{}
+		for key, value := range v {
+			out[fmt.Sprintf("%v", key)] = normalize(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = normalize(value)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+// Parameters returns the names of the values referenced by a template,
+// sorted alphabetically. Nested values are given in dot notation. Fields
+// referenced within 'range' and 'with' blocks are relative to the block's
+// value and are not included.
+func Parameters(content []byte) ([]string, error) {
+	t, err := parseTemplate(content)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	found := map[string]bool{}
+	for _, tpl := range t.Templates() {
+		if tpl.Tree != nil {
+			collectFields(tpl.Tree.Root, found)
+		}
+	}
+
+	params := make([]string, 0, len(found))
+	for name := range found {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+
+	return params, nil
+}
+
+func collectFields(node parse.Node, found map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, found)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, found)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, found)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, found)
+		}
+	case *parse.FieldNode:
+		found[strings.Join(n.Ident, ".")] = true
+	case *parse.IfNode:
+		collectFields(n.Pipe, found)
+		collectFields(n.List, found)
+		collectFields(n.ElseList, found)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, found)
+		collectFields(n.ElseList, found)
+	case *parse.WithNode:
+		collectFields(n.Pipe, found)
+		collectFields(n.ElseList, found)
+	}
+}
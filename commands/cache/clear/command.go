@@ -0,0 +1,110 @@
+// Package clear implements the 'cache clear' sub-command.
+package clear
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+var (
+	// Command performs the "cache clear" function
+	Command = &cobra.Command{
+		Use:   "clear",
+		Short: "Clear the cluster cache",
+		Long: `Removes the cached cluster details for the selected endpoint, or for all
+endpoints when --all is given.
+
+Examples:
+
+  gsctl cache clear
+
+  gsctl cache clear --all
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	cmdAllEndpoints bool
+
+	arguments Arguments
+)
+
+func init() {
+	Command.Flags().BoolVarP(&cmdAllEndpoints, "all", "", false, "Clear the cache for all endpoints")
+}
+
+// Arguments are the arguments we pass to the actual functions
+// clearing the cache.
+type Arguments struct {
+	apiEndpoint  string
+	allEndpoints bool
+}
+
+// collectArguments returns Arguments
+// with settings loaded from flags etc.
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+
+	return Arguments{
+		apiEndpoint:  endpoint,
+		allEndpoints: cmdAllEndpoints,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	errors.HandleCommonErrors(err)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" && !args.allEndpoints {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	err := clearCache(arguments)
+	if err != nil {
+		errors.HandleCommonErrors(err)
+
+		fmt.Println(color.RedString("Could not clear the cluster cache"))
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	if arguments.allEndpoints {
+		fmt.Println(color.GreenString("The cluster cache has been cleared for all endpoints."))
+	} else {
+		fmt.Println(color.GreenString("The cluster cache has been cleared for endpoint %s.", arguments.apiEndpoint))
+	}
+}
+
+func clearCache(args Arguments) error {
+	endpoint := args.apiEndpoint
+	if args.allEndpoints {
+		endpoint = ""
+	}
+
+	err := clustercache.Clear(endpoint)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package clear
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_clearCache tests clearing the cache for one and for all endpoints.
+func Test_clearCache(t *testing.T) {
+	now := time.Now().Format(time.RFC3339)
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testutils.TempClusterCache(fs, fmt.Sprintf(`endpoints:
+  https://foo:
+    clusters:
+    - id: 2sg4i
+      cached: "%s"
+  https://bar:
+    clusters:
+    - id: fow72
+      cached: "%s"
+`, now, now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(Arguments{})
+	if !errors.IsEndpointMissingError(err) {
+		t.Errorf("Expected endpoint missing error, got %#v", err)
+	}
+
+	err = clearCache(Arguments{apiEndpoint: "https://foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if clustercache.IsInCache("https://foo", "2sg4i") {
+		t.Error("Expected cache for https://foo to be cleared")
+	}
+	if !clustercache.IsInCache("https://bar", "fow72") {
+		t.Error("Expected cache for https://bar to be kept")
+	}
+
+	err = clearCache(Arguments{allEndpoints: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if clustercache.IsInCache("https://bar", "fow72") {
+		t.Error("Expected cache for https://bar to be cleared")
+	}
+
+	// Clearing a cache that doesn't exist is fine.
+	err = clearCache(Arguments{allEndpoints: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
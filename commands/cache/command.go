@@ -0,0 +1,29 @@
+// Package cache holds the 'cache *' sub-commands.
+package cache
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/cache/clear"
+	"github.com/giantswarm/gsctl/commands/cache/refresh"
+	"github.com/giantswarm/gsctl/commands/cache/show"
+)
+
+var (
+	// Command is the command to manage the local cluster cache.
+	Command = &cobra.Command{
+		Use:   "cache",
+		Short: "Show, clear or refresh the local cluster cache",
+		Long: `Manage the local cache of cluster details.
+
+gsctl remembers the IDs, names, organizations, releases and node pool IDs of
+the clusters it has seen, per endpoint. This allows resolving cluster names
+without contacting the API every time. Cached entries expire after 7 days.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(clear.Command)
+	Command.AddCommand(refresh.Command)
+	Command.AddCommand(show.Command)
+}
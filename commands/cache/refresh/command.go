@@ -0,0 +1,117 @@
+// Package refresh implements the 'cache refresh' sub-command.
+package refresh
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+var (
+	// Command performs the "cache refresh" function
+	Command = &cobra.Command{
+		Use:   "refresh",
+		Short: "Refresh the cluster cache",
+		Long: `Fetches all clusters and their node pools for the selected endpoint
+from the API and replaces the cached details with the result.
+
+Examples:
+
+  gsctl cache refresh
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments are the arguments we pass to the actual functions
+// refreshing the cache.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	scheme            string
+	userProvidedToken string
+}
+
+// collectArguments returns Arguments
+// with settings loaded from flags etc.
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	entries, err := refreshCache(arguments)
+	if err != nil {
+		client.HandleErrors(err)
+		errors.HandleCommonErrors(err)
+
+		if clientErr, ok := err.(*clienterror.APIError); ok {
+			fmt.Println(color.RedString(clientErr.ErrorMessage))
+			if clientErr.ErrorDetails != "" {
+				fmt.Println(clientErr.ErrorDetails)
+			}
+		} else {
+			fmt.Println(color.RedString("Error: %s", err.Error()))
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("Cached details of %d cluster(s) for endpoint %s.", len(entries), arguments.apiEndpoint))
+}
+
+func refreshCache(args Arguments) ([]clustercache.Entry, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	entries, err := clustercache.Refresh(args.apiEndpoint, clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return entries, nil
+}
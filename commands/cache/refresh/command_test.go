@@ -0,0 +1,87 @@
+package refresh
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_refreshCache tests fetching clusters and node pools into the cache.
+func Test_refreshCache(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "2sg4i", "name": "Node pools cluster", "owner": "acme", "release_version": "11.0.0", "create_date": "2019-10-10T07:24:55.192170835Z"},
+				{"id": "fow72", "name": "Old cluster", "owner": "acme", "release_version": "8.5.0", "create_date": "2019-10-10T07:24:55.192170835Z"},
+				{"id": "del01", "name": "Deleted cluster", "owner": "acme", "delete_date": "2019-10-10T07:24:55.192170835Z"}
+			]`))
+		case "/v5/clusters/2sg4i/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "b8l"}, {"id": "a7k"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testutils.TempClusterCache(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		apiEndpoint: mockServer.URL,
+		authToken:   "token",
+	}
+
+	err = verifyPreconditions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	entries, err := refreshCache(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	cached := clustercache.List(mockServer.URL)
+	if len(cached) != 2 {
+		t.Fatalf("Expected 2 cached entries, got %d", len(cached))
+	}
+	if cached[0].ID != "2sg4i" || len(cached[0].NodePoolIDs) != 2 || cached[0].NodePoolIDs[0] != "a7k" {
+		t.Errorf("Unexpected cache entry %#v", cached[0])
+	}
+	if cached[1].ID != "fow72" || cached[1].NodePoolIDs != nil {
+		t.Errorf("Unexpected cache entry %#v", cached[1])
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: mockServer.URL})
+	if !errors.IsNotLoggedInError(err) {
+		t.Errorf("Expected not logged in error, got %#v", err)
+	}
+}
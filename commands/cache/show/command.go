@@ -0,0 +1,158 @@
+// Package show implements the 'cache show' sub-command.
+package show
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/util"
+)
+
+var (
+	// Command performs the "cache show" function
+	Command = &cobra.Command{
+		Use:   "show",
+		Short: "Show cached cluster details",
+		Long: `Prints the cluster details cached for the selected endpoint.
+
+Examples:
+
+  gsctl cache show
+
+  gsctl cache show --output json
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+func init() {
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+}
+
+// Arguments are the arguments we pass to the actual functions
+// listing cache entries.
+type Arguments struct {
+	apiEndpoint  string
+	outputFormat string
+}
+
+// collectArguments returns Arguments
+// with settings loaded from flags etc.
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+
+	return Arguments{
+		apiEndpoint:  endpoint,
+		outputFormat: flags.OutputFormat,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	errors.HandleCommonErrors(err)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.outputFormat != formatting.OutputFormatJSON && args.outputFormat != formatting.OutputFormatTable {
+		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	output, err := getOutput(arguments)
+	if err != nil {
+		errors.HandleCommonErrors(err)
+
+		fmt.Println(color.RedString(err.Error()))
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+// getOutput returns the cache entries for the endpoint as a table or JSON.
+func getOutput(args Arguments) (string, error) {
+	entries := clustercache.List(args.apiEndpoint)
+
+	if args.outputFormat == formatting.OutputFormatJSON {
+		if entries == nil {
+			entries = []clustercache.Entry{}
+		}
+
+		output, err := json.MarshalIndent(entries, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		return string(output), nil
+	}
+
+	if len(entries) == 0 {
+		return color.YellowString("No clusters cached for endpoint %s", args.apiEndpoint), nil
+	}
+
+	headers := []string{
+		color.CyanString("ID"),
+		color.CyanString("NAME"),
+		color.CyanString("ORGANIZATION"),
+		color.CyanString("RELEASE"),
+		color.CyanString("NODE POOLS"),
+		color.CyanString("CACHED"),
+	}
+	table := []string{strings.Join(headers, "|")}
+
+	for _, entry := range entries {
+		cached := "n/a"
+		if cachedTime, err := time.Parse(time.RFC3339, entry.Cached); err == nil {
+			cached = util.ShortDate(cachedTime.UTC())
+		}
+		if entry.IsExpired() {
+			cached += " (expired)"
+		}
+
+		table = append(table, strings.Join([]string{
+			entry.ID,
+			valueOrNA(entry.Name),
+			valueOrNA(entry.Owner),
+			valueOrNA(entry.Release),
+			valueOrNA(strings.Join(entry.NodePoolIDs, ",")),
+			cached,
+		}, "|"))
+	}
+
+	return columnize.SimpleFormat(table), nil
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return "n/a"
+	}
+
+	return value
+}
@@ -0,0 +1,116 @@
+package show
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+var cacheYAML = fmt.Sprintf(`endpoints:
+  https://foo:
+    clusters:
+    - id: 2sg4i
+      name: Some cluster
+      owner: acme
+      release: 11.0.0
+      nodepool_ids:
+      - a7k
+      - b8l
+      cached: "%s"
+    - id: fow72
+      cached: "2010-03-03T13:17:16+01:00"
+`, time.Now().Format(time.RFC3339))
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_verifyPreconditions tests the validation of arguments.
+func Test_verifyPreconditions(t *testing.T) {
+	var testCases = []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{
+			Arguments{apiEndpoint: "https://foo", outputFormat: formatting.OutputFormatTable},
+			nil,
+		},
+		{
+			Arguments{apiEndpoint: "", outputFormat: formatting.OutputFormatTable},
+			errors.IsEndpointMissingError,
+		},
+		{
+			Arguments{apiEndpoint: "https://foo", outputFormat: "yaml"},
+			errors.IsOutputFormatInvalid,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := verifyPreconditions(tc.args)
+		if err != nil {
+			if tc.errorMatcher == nil {
+				t.Errorf("Case %d - Unexpected error: %s", i, err)
+			} else if !tc.errorMatcher(err) {
+				t.Errorf("Case %d - Error did not match expected type. Got '%s'", i, err)
+			}
+		} else if tc.errorMatcher != nil {
+			t.Errorf("Case %d - Expected error, got nil", i)
+		}
+	}
+}
+
+// Test_getOutput tests the table and JSON output.
+func Test_getOutput(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testutils.TempClusterCache(fs, cacheYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := getOutput(Arguments{apiEndpoint: "https://foo", outputFormat: formatting.OutputFormatTable})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, expected := range []string{"2sg4i", "Some cluster", "acme", "11.0.0", "a7k,b8l", "(expired)"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected table to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	output, err = getOutput(Arguments{apiEndpoint: "https://foo", outputFormat: formatting.OutputFormatJSON})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var entries []clustercache.Entry
+	err = json.Unmarshal([]byte(output), &entries)
+	if err != nil {
+		t.Fatalf("Could not parse JSON output: %s", err)
+	}
+	if len(entries) != 2 || entries[0].ID != "2sg4i" || entries[0].Owner != "acme" {
+		t.Errorf("Unexpected JSON output: %s", output)
+	}
+
+	output, err = getOutput(Arguments{apiEndpoint: "https://bar", outputFormat: formatting.OutputFormatTable})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.Contains(output, "No clusters cached") {
+		t.Errorf("Expected empty notice, got:\n%s", output)
+	}
+}
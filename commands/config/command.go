@@ -0,0 +1,50 @@
+// Package config holds the 'config *' sub-commands.
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/config/export"
+	"github.com/giantswarm/gsctl/commands/config/get"
+	importcmd "github.com/giantswarm/gsctl/commands/config/import"
+	"github.com/giantswarm/gsctl/commands/config/renameendpointalias"
+	"github.com/giantswarm/gsctl/commands/config/set"
+	"github.com/giantswarm/gsctl/commands/config/unset"
+	"github.com/giantswarm/gsctl/commands/config/view"
+)
+
+var (
+	// Command is the command to inspect and edit the configuration.
+	Command = &cobra.Command{
+		Use:   "config",
+		Short: "View and edit the gsctl configuration",
+		Long: `View and edit the gsctl configuration, which is stored in the file
+config.yaml in the configuration directory (see --config-dir).
+
+Single values are addressed by keys. Endpoints can be referred to by alias
+or by URL. These keys are supported:
+
+  selected_endpoint                   The endpoint used by default. Can be set and unset.
+  last_version_check                  Time of the last check for a gsctl update. Read only.
+  endpoints.<endpoint>.alias          Short name of the endpoint. Can be set and unset.
+  endpoints.<endpoint>.provider       Provider of the installation. Can be set once.
+  endpoints.<endpoint>.webui_url      URL of the web UI, used by 'gsctl open'. Can be set and unset.
+  endpoints.<endpoint>.email          Email address used to log in. Read only.
+  endpoints.<endpoint>.auth_scheme    Authentication scheme. Read only.
+  endpoints.<endpoint>.token          Authentication token. Read only, always redacted.
+  endpoints.<endpoint>.refresh_token  SSO refresh token. Read only, always redacted.
+
+Credentials can only be changed via 'gsctl login' and 'gsctl logout'.
+All changes are validated before they are written.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(export.Command)
+	Command.AddCommand(get.Command)
+	Command.AddCommand(importcmd.Command)
+	Command.AddCommand(renameendpointalias.Command)
+	Command.AddCommand(set.Command)
+	Command.AddCommand(unset.Command)
+	Command.AddCommand(view.Command)
+}
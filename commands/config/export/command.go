@@ -0,0 +1,52 @@
+// Package export implements the 'config export' sub-command.
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/pkg/configedit"
+)
+
+var (
+	// Command performs the "config export" function
+	Command = &cobra.Command{
+		Use:   "export [<endpoint> ...]",
+		Short: "Export endpoint definitions",
+		Long: `Prints the definitions of all endpoints, or of the given endpoints, as
+YAML. The output contains the URL, alias, provider and web UI URL of each
+endpoint, but no credentials, so it can be shared and imported on another
+machine using 'gsctl config import'.
+
+Endpoints can be given by alias or URL.
+
+Examples:
+
+  gsctl config export > endpoints.yaml
+
+  gsctl config export myinstallation https://api.other.example.com
+`,
+		Run: printResult,
+	}
+)
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	out, err := configedit.Export(positionalArgs)
+	if err != nil {
+		if configedit.IsEndpointNotFound(err) {
+			fmt.Println(color.RedString("Endpoint not found"))
+			fmt.Printf("Details: %s\n", err.Error())
+			fmt.Println("Use 'gsctl list endpoints' to list the configured endpoints.")
+			os.Exit(1)
+		}
+
+		fmt.Println(color.RedString("Could not export the endpoint definitions"))
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Print(string(out))
+}
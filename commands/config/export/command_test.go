@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_printResult tests that no credentials are exported.
+func Test_printResult(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+    provider: aws
+    alias: example
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := testutils.CaptureOutput(func() {
+		printResult(Command, []string{"example"})
+	})
+
+	expected := `endpoints:
+  https://api.example.com:
+    alias: example
+    provider: aws
+`
+	if output != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+	}
+	if strings.Contains(output, "token") {
+		t.Error("Output contains credentials")
+	}
+}
@@ -0,0 +1,107 @@
+// Package get implements the 'config get' sub-command.
+package get
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/configedit"
+)
+
+var (
+	// Command performs the "config get" function
+	Command = &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a configuration value",
+		Long: `Prints a single value of the configuration. Tokens are redacted.
+
+See 'gsctl config --help' for the supported keys.
+
+Examples:
+
+  gsctl config get selected_endpoint
+
+  gsctl config get endpoints.myinstallation.provider
+
+  gsctl config get endpoints.https://api.example.com.alias
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments are the arguments we pass to the actual functions.
+type Arguments struct {
+	key string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	key := ""
+	if len(positionalArgs) > 0 {
+		key = positionalArgs[0]
+	}
+
+	return Arguments{
+		key: key,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.key == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "key")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	value, err := configedit.Get(arguments.key)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(value)
+}
+
+func handleError(err error) {
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "No key specified"
+		subtext = "Please specify the configuration key as a positional argument. See 'gsctl config --help' for the supported keys."
+	case configedit.IsInvalidKey(err):
+		headline = "Invalid key"
+		subtext = fmt.Sprintf("Details: %s\nSee 'gsctl config --help' for the supported keys.", err.Error())
+	case configedit.IsEndpointNotFound(err):
+		headline = "Endpoint not found"
+		subtext = fmt.Sprintf("Details: %s\nUse 'gsctl list endpoints' to list the configured endpoints.", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
@@ -0,0 +1,46 @@
+package get
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_printResult tests printing a value by endpoint alias.
+func Test_printResult(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+    provider: aws
+    alias: example
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(collectArguments([]string{}))
+	if !errors.IsRequiredFlagMissingError(err) {
+		t.Errorf("Expected required flag missing error, got %v", err)
+	}
+
+	arguments = collectArguments([]string{"endpoints.example.provider"})
+	output := testutils.CaptureOutput(func() {
+		printResult(Command, []string{})
+	})
+	if output != "aws\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
@@ -0,0 +1,202 @@
+// Package importcmd implements the 'config import' sub-command.
+package importcmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/configedit"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+const (
+	standardInputSpecialPath = "-"
+)
+
+var (
+	// Command performs the "config import" function
+	Command = &cobra.Command{
+		Use:   "import",
+		Short: "Import endpoint definitions",
+		Long: `Adds endpoint definitions, as created by 'gsctl config export', to the
+configuration. Endpoints which are already defined get their alias, provider
+and web UI URL updated. Fields that are empty in the file don't change
+existing values.
+
+Credentials are not imported. Use 'gsctl login' afterwards to log in to the
+new endpoints.
+
+All definitions are validated before anything is changed.
+
+Examples:
+
+  gsctl config import --file endpoints.yaml
+
+  gsctl config import --file endpoints.yaml --dry-run
+
+  cat endpoints.yaml | gsctl config import --file -
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments are the arguments we pass to the actual functions.
+type Arguments struct {
+	dryRun        bool
+	fileSystem    afero.Fs
+	inputYAMLFile string
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().StringVarP(&flags.InputYAMLFile, "file", "f", "", "Path to the file with endpoint definitions. Use '-' to read from STDIN.")
+	Command.Flags().BoolVarP(&flags.DryRun, "dry-run", "", false, "If set, only print what would be done, without changing anything.")
+}
+
+func collectArguments() Arguments {
+	return Arguments{
+		dryRun:        flags.DryRun,
+		fileSystem:    config.FileSystem,
+		inputYAMLFile: flags.InputYAMLFile,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.inputYAMLFile == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "--file")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	results, err := importDefinitions(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(getOutput(results, arguments.dryRun))
+}
+
+func handleError(err error) {
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "No file specified"
+		subtext = "Please specify the file with endpoint definitions via --file."
+	case errors.IsYAMLFileNotReadable(err):
+		headline = "Could not read file"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case configedit.IsInvalidImport(err):
+		headline = "Invalid endpoint definitions"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case configedit.IsInvalidAlias(err):
+		headline = "Invalid alias"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case configedit.IsAliasInUse(err):
+		headline = "Alias is already in use for a different endpoint"
+		subtext = fmt.Sprintf("Details: %s\nUse 'gsctl config rename-endpoint-alias' to rename the existing alias first.", err.Error())
+	case configedit.IsProviderImmutable(err):
+		headline = "The provider of an endpoint cannot be changed"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case configedit.IsInvalidValue(err), webui.IsInvalidBaseURL(err):
+		headline = "Invalid value"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	fmt.Println("Nothing has been changed.")
+}
+
+// importDefinitions reads the definitions file and imports it.
+func importDefinitions(args Arguments) ([]configedit.ImportResult, error) {
+	var content []byte
+	var err error
+
+	if args.inputYAMLFile == standardInputSpecialPath {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = afero.ReadFile(args.fileSystem, args.inputYAMLFile)
+	}
+	if err != nil {
+		return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+	}
+
+	results, err := configedit.Import(content, args.dryRun)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return results, nil
+}
+
+func getOutput(results []configedit.ImportResult, dryRun bool) string {
+	rows := []string{color.CyanString("ENDPOINT") + "|" + color.CyanString("ALIAS") + "|" + color.CyanString("RESULT")}
+	numChanged := 0
+	for _, r := range results {
+		alias := r.Alias
+		if alias == "" {
+			alias = "n/a"
+		}
+
+		status := r.Status
+		if r.Status != configedit.ImportStatusUnchanged {
+			numChanged++
+			if dryRun {
+				status = "would be " + status
+			}
+		}
+
+		rows = append(rows, r.Endpoint+"|"+alias+"|"+status)
+	}
+
+	output := columnize.SimpleFormat(rows) + "\n\n"
+
+	switch {
+	case dryRun:
+		output += color.YellowString("Dry run, nothing has been changed.")
+	case numChanged == 0:
+		output += color.GreenString("All endpoints are up to date.")
+	default:
+		output += color.GreenString("%d endpoint(s) imported.", numChanged)
+		output += "\nUse 'gsctl login <email> -e <endpoint>' to log in to new endpoints."
+	}
+
+	return output
+}
@@ -0,0 +1,78 @@
+package importcmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_importDefinitions tests importing from a file, with and without dry run.
+func Test_importDefinitions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+    alias: example
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filePath := dir + "/endpoints.yaml"
+	err = afero.WriteFile(fs, filePath, []byte(`endpoints:
+  https://api.example.com:
+    provider: aws
+  https://api.other.example.com:
+    alias: other
+    provider: kvm
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(Arguments{})
+	if !errors.IsRequiredFlagMissingError(err) {
+		t.Errorf("Expected required flag missing error, got %v", err)
+	}
+
+	_, err = importDefinitions(Arguments{fileSystem: fs, inputYAMLFile: dir + "/missing.yaml"})
+	if !errors.IsYAMLFileNotReadable(err) {
+		t.Errorf("Expected file not readable error, got %v", err)
+	}
+
+	args := Arguments{dryRun: true, fileSystem: fs, inputYAMLFile: filePath}
+	results, err := importDefinitions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	output := getOutput(results, true)
+	if !strings.Contains(output, "would be added") || !strings.Contains(output, "would be updated") || config.Config.NumEndpoints() != 1 {
+		t.Errorf("Unexpected dry run output:\n%s", output)
+	}
+
+	args.dryRun = false
+	results, err = importDefinitions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(results) != 2 || config.Config.NumEndpoints() != 2 {
+		t.Errorf("Unexpected results %#v", results)
+	}
+	if !config.Config.HasEndpointAlias("other") || config.Config.EndpointConfig("https://api.example.com").Provider != "aws" {
+		t.Error("Expected endpoint definitions to be imported")
+	}
+}
@@ -0,0 +1,107 @@
+// Package renameendpointalias implements the 'config rename-endpoint-alias' sub-command.
+package renameendpointalias
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/configedit"
+)
+
+var (
+	// Command performs the "config rename-endpoint-alias" function
+	Command = &cobra.Command{
+		Use:   "rename-endpoint-alias <old-alias> <new-alias>",
+		Short: "Rename the alias of an endpoint",
+		Long: `Gives an endpoint a new alias. The new alias must not be in use for a
+different endpoint.
+
+Example:
+
+  gsctl config rename-endpoint-alias gauss production
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments are the arguments we pass to the actual functions.
+type Arguments struct {
+	oldAlias string
+	newAlias string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	args := Arguments{}
+	if len(positionalArgs) > 0 {
+		args.oldAlias = positionalArgs[0]
+	}
+	if len(positionalArgs) > 1 {
+		args.newAlias = positionalArgs[1]
+	}
+
+	return args
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.oldAlias == "" || args.newAlias == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "old and new alias")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	endpoint, err := configedit.RenameEndpointAlias(arguments.oldAlias, arguments.newAlias)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("The alias of endpoint %s has been changed from '%s' to '%s'.", endpoint, arguments.oldAlias, arguments.newAlias))
+}
+
+func handleError(err error) {
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "Missing argument"
+		subtext = "Please specify the current alias and the new alias as positional arguments."
+	case configedit.IsEndpointNotFound(err):
+		headline = "Endpoint not found"
+		subtext = fmt.Sprintf("There is no endpoint with alias '%s'. Use 'gsctl list endpoints' to list the configured endpoints.", arguments.oldAlias)
+	case configedit.IsInvalidAlias(err):
+		headline = "Invalid alias"
+		subtext = "Aliases may only contain letters, digits, '-' and '_', and must start with a letter or digit."
+	case configedit.IsAliasInUse(err):
+		headline = "Alias is already in use for a different endpoint"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
@@ -0,0 +1,47 @@
+package renameendpointalias
+
+import (
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_printResult tests renaming an alias.
+func Test_printResult(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+    alias: gauss
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(collectArguments([]string{"gauss"}))
+	if !errors.IsRequiredFlagMissingError(err) {
+		t.Errorf("Expected required flag missing error, got %v", err)
+	}
+
+	arguments = collectArguments([]string{"gauss", "production"})
+	testutils.CaptureOutput(func() {
+		printResult(Command, []string{})
+	})
+
+	if config.Config.HasEndpointAlias("gauss") || !config.Config.HasEndpointAlias("production") {
+		t.Error("Expected alias to be renamed")
+	}
+}
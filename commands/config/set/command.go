@@ -0,0 +1,126 @@
+// Package set implements the 'config set' sub-command.
+package set
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/configedit"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+var (
+	// Command performs the "config set" function
+	Command = &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a configuration value",
+		Long: `Validates and stores a single value of the configuration.
+
+See 'gsctl config --help' for the supported keys. Credentials cannot be
+set this way, please use 'gsctl login' instead.
+
+Examples:
+
+  gsctl config set selected_endpoint myinstallation
+
+  gsctl config set endpoints.https://api.example.com.alias myinstallation
+
+  gsctl config set endpoints.myinstallation.webui_url https://console.example.com
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments are the arguments we pass to the actual functions.
+type Arguments struct {
+	key   string
+	value string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	args := Arguments{}
+	if len(positionalArgs) > 0 {
+		args.key = positionalArgs[0]
+	}
+	if len(positionalArgs) > 1 {
+		args.value = positionalArgs[1]
+	}
+
+	return args
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.key == "" || args.value == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "key and value")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	err := configedit.Set(arguments.key, arguments.value)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("%s has been set to %s.", arguments.key, arguments.value))
+}
+
+func handleError(err error) {
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "Missing argument"
+		subtext = "Please specify the configuration key and the value as positional arguments. See 'gsctl config --help' for the supported keys."
+	case configedit.IsInvalidKey(err):
+		headline = "Invalid key"
+		subtext = fmt.Sprintf("Details: %s\nSee 'gsctl config --help' for the supported keys.", err.Error())
+	case configedit.IsReadOnlyKey(err):
+		headline = "This value cannot be set"
+		subtext = "Credentials can only be changed via 'gsctl login' and 'gsctl logout'. See 'gsctl config --help' for the keys which can be set."
+	case configedit.IsEndpointNotFound(err):
+		headline = "Endpoint not found"
+		subtext = fmt.Sprintf("Details: %s\nUse 'gsctl list endpoints' to list the configured endpoints.", err.Error())
+	case configedit.IsInvalidAlias(err):
+		headline = "Invalid alias"
+		subtext = "Aliases may only contain letters, digits, '-' and '_', and must start with a letter or digit."
+	case configedit.IsAliasInUse(err):
+		headline = "Alias is already in use for a different endpoint"
+		subtext = fmt.Sprintf("Details: %s\nUse 'gsctl config rename-endpoint-alias' to rename the other endpoint's alias first.", err.Error())
+	case configedit.IsProviderImmutable(err):
+		headline = "The provider cannot be changed"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case configedit.IsInvalidValue(err), webui.IsInvalidBaseURL(err):
+		headline = "Invalid value"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
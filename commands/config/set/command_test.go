@@ -0,0 +1,47 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_printResult tests setting an endpoint alias.
+func Test_printResult(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(collectArguments([]string{"selected_endpoint"}))
+	if !errors.IsRequiredFlagMissingError(err) {
+		t.Errorf("Expected required flag missing error, got %v", err)
+	}
+
+	arguments = collectArguments([]string{"endpoints.https://api.example.com.alias", "example"})
+	testutils.CaptureOutput(func() {
+		printResult(Command, []string{})
+	})
+
+	endpoint, err := config.Config.EndpointByAlias("example")
+	if err != nil || endpoint != "https://api.example.com" {
+		t.Errorf("Expected alias to be set, got %q, %v", endpoint, err)
+	}
+}
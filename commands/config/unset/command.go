@@ -0,0 +1,109 @@
+// Package unset implements the 'config unset' sub-command.
+package unset
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/configedit"
+)
+
+var (
+	// Command performs the "config unset" function
+	Command = &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a configuration value",
+		Long: `Removes a single value from the configuration.
+
+See 'gsctl config --help' for the supported keys. To remove an endpoint
+including its credentials, use 'gsctl logout' instead.
+
+Examples:
+
+  gsctl config unset selected_endpoint
+
+  gsctl config unset endpoints.myinstallation.webui_url
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments are the arguments we pass to the actual functions.
+type Arguments struct {
+	key string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	key := ""
+	if len(positionalArgs) > 0 {
+		key = positionalArgs[0]
+	}
+
+	return Arguments{
+		key: key,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.key == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "key")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	err := configedit.Unset(arguments.key)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("%s has been unset.", arguments.key))
+}
+
+func handleError(err error) {
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "No key specified"
+		subtext = "Please specify the configuration key as a positional argument. See 'gsctl config --help' for the supported keys."
+	case configedit.IsInvalidKey(err):
+		headline = "Invalid key"
+		subtext = fmt.Sprintf("Details: %s\nSee 'gsctl config --help' for the supported keys.", err.Error())
+	case configedit.IsReadOnlyKey(err):
+		headline = "This value cannot be unset"
+		subtext = "Credentials can only be removed via 'gsctl logout'. See 'gsctl config --help' for the keys which can be unset."
+	case configedit.IsEndpointNotFound(err):
+		headline = "Endpoint not found"
+		subtext = fmt.Sprintf("Details: %s\nUse 'gsctl list endpoints' to list the configured endpoints.", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
@@ -0,0 +1,49 @@
+package unset
+
+import (
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_printResult tests unsetting the selected endpoint.
+func Test_printResult(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(collectArguments([]string{}))
+	if !errors.IsRequiredFlagMissingError(err) {
+		t.Errorf("Expected required flag missing error, got %v", err)
+	}
+
+	arguments = collectArguments([]string{"selected_endpoint"})
+	testutils.CaptureOutput(func() {
+		printResult(Command, []string{})
+	})
+
+	if config.Config.SelectedEndpoint != "" || config.Config.Token != "" {
+		t.Errorf("Expected no endpoint to be selected, got %q", config.Config.SelectedEndpoint)
+	}
+	if config.Config.NumEndpoints() != 1 {
+		t.Error("Expected the endpoint to be kept")
+	}
+}
@@ -0,0 +1,39 @@
+// Package view implements the 'config view' sub-command.
+package view
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/pkg/configedit"
+)
+
+var (
+	// Command performs the "config view" function
+	Command = &cobra.Command{
+		Use:   "view",
+		Short: "Print the configuration",
+		Long: `Prints the complete gsctl configuration as YAML, including the web UI
+URLs configured per endpoint. Tokens are redacted.
+
+Example:
+
+  gsctl config view
+`,
+		Run: printResult,
+	}
+)
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	out, err := configedit.View()
+	if err != nil {
+		fmt.Println(color.RedString("Could not display the configuration"))
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Print(string(out))
+}
@@ -0,0 +1,33 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_printResult tests that tokens are redacted in the output.
+func Test_printResult(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: email@example.com
+    token: some-token
+    alias: example
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := testutils.CaptureOutput(func() {
+		printResult(Command, []string{})
+	})
+
+	if strings.Contains(output, "some-token") || !strings.Contains(output, "token: REDACTED") {
+		t.Errorf("Unexpected output:\n%s", output)
+	}
+}
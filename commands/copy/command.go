@@ -0,0 +1,20 @@
+package copy
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/copy/nodepools"
+)
+
+var (
+	// Command is the command to copy things
+	Command = &cobra.Command{
+		Use:   "copy",
+		Short: "Copy node pools",
+		Long:  `Lets you copy node pools from one cluster to another.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(nodepools.Command)
+}
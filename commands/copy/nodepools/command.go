@@ -0,0 +1,299 @@
+// Package nodepools implements the "copy nodepools" command.
+package nodepools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/nodepoolspec"
+)
+
+var (
+	// Command is the cobra command for 'gsctl copy nodepools'
+	Command = &cobra.Command{
+		Use:     "nodepools",
+		Aliases: []string{"nps"},
+		Short:   "Copy all node pools of a cluster to another cluster",
+		Long: `Create node pools in a cluster, copied from all node pools of another cluster.
+
+Each new node pool gets the same name, availability zones, instance type,
+spot instance settings and scaling as the node pool it is copied from.
+
+Availability zones which are not available in the installation are mapped
+to the zone with the same letter, if possible. Otherwise the command fails
+before creating any node pool.
+
+Examples:
+
+  gsctl copy nodepools --from "Old cluster" --to "New cluster"
+
+  gsctl copy nodepools --from f01r4 --to g02s5
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	cmdFrom string
+	cmdTo   string
+
+	arguments Arguments
+)
+
+const (
+	activityName = "copy-nodepools"
+)
+
+func init() {
+	Command.Flags().StringVarP(&cmdFrom, "from", "", "", "Name or ID of the cluster to copy the node pools from.")
+	Command.Flags().StringVarP(&cmdTo, "to", "", "", "Name or ID of the cluster to create the node pools in.")
+}
+
+// Arguments represents all the ways the user can influence the command.
+type Arguments struct {
+	APIEndpoint         string
+	AuthToken           string
+	FromClusterNameOrID string
+	ToClusterNameOrID   string
+	UserProvidedToken   string
+	Verbose             bool
+}
+
+// copyResult describes one node pool created.
+type copyResult struct {
+	sourceNodePoolID  string
+	nodePoolID        string
+	nodePoolName      string
+	availabilityZones []string
+}
+
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	return Arguments{
+		APIEndpoint:         endpoint,
+		AuthToken:           token,
+		FromClusterNameOrID: strings.TrimSpace(cmdFrom),
+		ToClusterNameOrID:   strings.TrimSpace(cmdTo),
+		UserProvidedToken:   flags.Token,
+		Verbose:             flags.Verbose,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.APIEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.AuthToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.FromClusterNameOrID == "" || args.ToClusterNameOrID == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "--from and --to must both be given.")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	clientWrapper, err := client.NewWithConfig(arguments.APIEndpoint, arguments.UserProvidedToken)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	results, err := copyNodePools(arguments, clientWrapper)
+	if len(results) > 0 {
+		fmt.Println(formatResults(results))
+	}
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	if len(results) == 0 {
+		fmt.Println(color.YellowString("The cluster '%s' has no node pools to copy.", arguments.FromClusterNameOrID))
+		return
+	}
+
+	fmt.Println(color.GreenString("%d node pool(s) in cluster '%s' are launching.", len(results), arguments.ToClusterNameOrID))
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "Missing flag"
+		subtext = "Please specify the clusters to copy from and to using --from and --to. See --help for examples."
+	case errors.IsClusterNotFoundError(err):
+		headline = "Cluster not found"
+		subtext = "Please check the cluster names or IDs. Use 'gsctl list clusters' to list all clusters."
+	case errors.IsClusterDoesNotSupportNodePools(err):
+		headline = "This cluster does not support node pools"
+		subtext = "Both clusters must support node pools."
+	case nodepoolspec.IsUnavailableZones(err):
+		headline = "Availability zones not available"
+		subtext = err.Error() + "\nNo node pools have been created."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// copyNodePools creates node pools in the target cluster based on all node pools
+// of the source cluster. All requests are prepared before the first one is sent,
+// so that invalid availability zones don't leave us with a partial copy.
+func copyNodePools(args Arguments, clientWrapper *client.Wrapper) ([]copyResult, error) {
+	sourceClusterID, err := clustercache.GetID(args.APIEndpoint, args.FromClusterNameOrID, clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	targetClusterID, err := clustercache.GetID(args.APIEndpoint, args.ToClusterNameOrID, clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	if args.Verbose {
+		fmt.Println(color.WhiteString("Fetching node pools of cluster '%s'", sourceClusterID))
+	}
+
+	response, err := clientWrapper.GetNodePools(sourceClusterID, auxParams)
+	if err != nil {
+		if clienterror.IsNotFoundError(err) {
+			return nil, microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
+		}
+
+		return nil, microerror.Mask(err)
+	}
+
+	sort.Slice(response.Payload, func(i, j int) bool {
+		return response.Payload[i].ID < response.Payload[j].ID
+	})
+
+	infoResponse, err := clientWrapper.GetInfo(auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	var availableZones []string
+	if infoResponse.Payload.General != nil && infoResponse.Payload.General.AvailabilityZones != nil {
+		availableZones = infoResponse.Payload.General.AvailabilityZones.Zones
+	}
+
+	sourceIDs := []string{}
+	requests := []*models.V5AddNodePoolRequest{}
+	for _, item := range response.Payload {
+		np, err := nodepoolspec.NodePoolFromListItem(item)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		request := nodepoolspec.AddRequestFromNodePool(np)
+		if request.AvailabilityZones != nil {
+			request.AvailabilityZones.Zones, err = nodepoolspec.MapAvailabilityZones(request.AvailabilityZones.Zones, availableZones)
+			if err != nil {
+				if args.Verbose {
+					fmt.Println(color.WhiteString("Node pool '%s' cannot be copied", np.ID))
+				}
+				return nil, microerror.Mask(err)
+			}
+		}
+
+		sourceIDs = append(sourceIDs, np.ID)
+		requests = append(requests, request)
+	}
+
+	results := []copyResult{}
+	for i, request := range requests {
+		if args.Verbose {
+			bodyJSON, _ := json.Marshal(request)
+			fmt.Println(color.WhiteString("Creating copy of node pool '%s': ", sourceIDs[i]) + string(bodyJSON))
+		}
+
+		created, err := clientWrapper.CreateNodePool(targetClusterID, request, auxParams)
+		if err != nil {
+			if clienterror.IsNotFoundError(err) {
+				err = errors.ClusterDoesNotSupportNodePoolsError
+			} else if clienterror.IsBadRequestError(err) {
+				return results, microerror.Maskf(errors.BadRequestError, err.Error())
+			}
+
+			return results, microerror.Mask(err)
+		}
+
+		results = append(results, copyResult{
+			sourceNodePoolID:  sourceIDs[i],
+			nodePoolID:        created.Payload.ID,
+			nodePoolName:      created.Payload.Name,
+			availabilityZones: created.Payload.AvailabilityZones,
+		})
+	}
+
+	if len(results) > 0 {
+		// The cached node pool IDs are outdated now.
+		clustercache.InvalidateNodePools(args.APIEndpoint, targetClusterID)
+	}
+
+	return results, nil
+}
+
+// formatResults renders the node pools created as a table.
+func formatResults(results []copyResult) string {
+	rows := []string{strings.Join([]string{
+		color.CyanString("COPIED FROM"),
+		color.CyanString("NEW ID"),
+		color.CyanString("NAME"),
+		color.CyanString("AZ"),
+	}, "|")}
+
+	for _, r := range results {
+		rows = append(rows, strings.Join([]string{
+			r.sourceNodePoolID,
+			r.nodePoolID,
+			r.nodePoolName,
+			strings.Join(r.availabilityZones, ","),
+		}, "|"))
+	}
+
+	return columnize.SimpleFormat(rows)
+}
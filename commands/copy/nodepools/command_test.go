@@ -0,0 +1,170 @@
+package nodepools
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/nodepoolspec"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// copyMockServer simulates the API with a source cluster having two node pools
+// in eu-west-1 and an installation offering the given zones.
+func copyMockServer(t *testing.T, zones string, requestBodies *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "old-id", "name": "Old cluster", "owner": "acme"},
+				{"id": "new-id", "name": "New cluster", "owner": "acme"}
+			]`))
+		case r.Method == "GET" && r.URL.Path == "/v5/clusters/old-id/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{
+					"id": "b8l",
+					"name": "Batch jobs",
+					"availability_zones": ["eu-west-1c"],
+					"scaling": {"min": 0, "max": 4},
+					"node_spec": {"aws": {"instance_type": "c5.xlarge", "use_alike_instance_types": true, "instance_distribution": {"on_demand_base_capacity": 0, "on_demand_percentage_above_base_capacity": 0}}}
+				},
+				{
+					"id": "a7k",
+					"name": "General purpose",
+					"availability_zones": ["eu-west-1a", "eu-west-1b"],
+					"scaling": {"min": 2, "max": 5},
+					"node_spec": {"aws": {"instance_type": "m5.xlarge", "use_alike_instance_types": false, "instance_distribution": {"on_demand_base_capacity": 0, "on_demand_percentage_above_base_capacity": 100}}}
+				}
+			]`))
+		case r.Method == "GET" && r.URL.Path == "/v4/info/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"general": {"provider": "aws", "availability_zones": {"max": 3, "default": 1, "zones": ` + zones + `}}}`))
+		case r.Method == "POST" && r.URL.Path == "/v5/clusters/new-id/nodepools/":
+			body, _ := ioutil.ReadAll(r.Body)
+			*requestBodies = append(*requestBodies, strings.TrimSpace(string(body)))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(fmt.Sprintf(`{"id": "n%dx", "name": "copy"}`, len(*requestBodies))))
+		default:
+			t.Errorf("Unsupported route %s %s called in mock server", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+}
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests the required flags.
+func Test_verifyPreconditions(t *testing.T) {
+	testCases := []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", FromClusterNameOrID: "old-id", ToClusterNameOrID: "new-id"},
+			errorMatcher: nil,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", FromClusterNameOrID: "old-id"},
+			errorMatcher: errors.IsRequiredFlagMissingError,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", ToClusterNameOrID: "new-id"},
+			errorMatcher: errors.IsRequiredFlagMissingError,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := verifyPreconditions(tc.args)
+		if tc.errorMatcher == nil && err != nil {
+			t.Errorf("Case %d - Unexpected error: %s", i, err)
+		} else if tc.errorMatcher != nil && !tc.errorMatcher(err) {
+			t.Errorf("Case %d - Error not matching expectation, got %v", i, err)
+		}
+	}
+}
+
+// Test_copyNodePools tests copying node pools into a different region.
+func Test_copyNodePools(t *testing.T) {
+	requestBodies := []string{}
+	mockServer := copyMockServer(t, `["eu-central-1a", "eu-central-1b", "eu-central-1c"]`, &requestBodies)
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{APIEndpoint: mockServer.URL, FromClusterNameOrID: "Old cluster", ToClusterNameOrID: "new-id"}
+
+	results, err := copyNodePools(args, clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(results) != 2 || results[0].sourceNodePoolID != "a7k" || results[1].sourceNodePoolID != "b8l" {
+		t.Fatalf("Unexpected results %#v", results)
+	}
+
+	expected := []string{
+		`{"availability_zones":{"zones":["eu-central-1a","eu-central-1b"]},"name":"General purpose","node_spec":{"aws":{"instance_distribution":{"on_demand_base_capacity":0,"on_demand_percentage_above_base_capacity":100},"instance_type":"m5.xlarge","use_alike_instance_types":false}},"scaling":{"max":5,"min":2}}`,
+		`{"availability_zones":{"zones":["eu-central-1c"]},"name":"Batch jobs","node_spec":{"aws":{"instance_distribution":{"on_demand_base_capacity":0,"on_demand_percentage_above_base_capacity":0},"instance_type":"c5.xlarge","use_alike_instance_types":true}},"scaling":{"max":4,"min":0}}`,
+	}
+	for i := range expected {
+		if requestBodies[i] != expected[i] {
+			t.Errorf("Request body %d not as expected.\nExpected: %s\nGot:      %s", i, expected[i], requestBodies[i])
+		}
+	}
+}
+
+// Test_copyNodePoolsUnavailableZones tests that nothing is created
+// if a zone can't be mapped to the target region.
+func Test_copyNodePoolsUnavailableZones(t *testing.T) {
+	requestBodies := []string{}
+	mockServer := copyMockServer(t, `["eu-central-1a", "eu-central-1b"]`, &requestBodies)
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{APIEndpoint: mockServer.URL, FromClusterNameOrID: "old-id", ToClusterNameOrID: "New cluster"}
+
+	_, err = copyNodePools(args, clientWrapper)
+	if !nodepoolspec.IsUnavailableZones(err) {
+		t.Fatalf("Expected unavailableZonesError, got %v", err)
+	}
+	if len(requestBodies) != 0 {
+		t.Errorf("Expected no node pool to be created, got %d", len(requestBodies))
+	}
+}
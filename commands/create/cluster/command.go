@@ -21,13 +21,16 @@ package cluster
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/Masterminds/semver"
 	"github.com/fatih/color"
 	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
 	"github.com/juju/errgo"
 	"github.com/spf13/afero"
@@ -35,10 +38,13 @@ import (
 
 	"github.com/giantswarm/gsctl/capabilities"
 	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/clustertemplate"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/commands/types"
 	"github.com/giantswarm/gsctl/flags"
 	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
 )
 
 // Arguments contains all possible input parameter needed
@@ -46,17 +52,37 @@ import (
 type Arguments struct {
 	APIEndpoint           string
 	AuthToken             string
+	Concurrency           int
 	CreateDefaultNodePool bool
 	ClusterName           string
 	FileSystem            afero.Fs
 	InputYAMLFile         string
 	Owner                 string
 	ReleaseVersion        string
+	RenderOnly            bool
 	Scheme                string
+	Set                   []string
 	MasterHA              *bool
+	TemplateName          string
 	UserProvidedToken     string
+	ValuesFile            string
 	Verbose               bool
 	OutputFormat          string
+
+	// DefaultOwner is the owner for definitions without one, if Owner is
+	// empty. It is picked once when creating several clusters.
+	DefaultOwner string
+	// Output receives progress messages. Defaults to standard output.
+	Output io.Writer
+}
+
+// out returns the writer for progress messages.
+func (a Arguments) out() io.Writer {
+	if a.Output == nil {
+		return os.Stdout
+	}
+
+	return a.Output
 }
 
 // collectArguments gets arguments from flags and returns an Arguments object.
@@ -83,14 +109,19 @@ func collectArguments(cmd *cobra.Command) Arguments {
 		APIEndpoint:           endpoint,
 		AuthToken:             token,
 		ClusterName:           flags.ClusterName,
+		Concurrency:           flags.Concurrency,
 		CreateDefaultNodePool: flags.CreateDefaultNodePool,
 		FileSystem:            config.FileSystem,
 		InputYAMLFile:         flags.InputYAMLFile,
 		MasterHA:              haMasters,
 		Owner:                 flags.Owner,
 		ReleaseVersion:        normalizedReleaseVersion,
+		RenderOnly:            flags.RenderOnly,
 		Scheme:                scheme,
+		Set:                   flags.Set,
+		TemplateName:          flags.Template,
 		UserProvidedToken:     flags.Token,
+		ValuesFile:            flags.ValuesFile,
 		Verbose:               flags.OutputFormat != formatting.OutputFormatJSON && flags.Verbose,
 		OutputFormat:          flags.OutputFormat,
 	}
@@ -147,6 +178,28 @@ For details about the cluster definition YAML format, see
 Note that you can also use command line flags to override some settings
 from the YAML definition.
 
+Templates
+---------
+
+The definition YAML can be a Go template. Values are given via a YAML file
+using --values, and via --set key=value flags, which take precedence.
+Nested values can be set using dots in the key, e. g. --set nodepool.min=3.
+Referencing a value which has not been given is an error.
+
+Templates can be stored under a name using 'gsctl create template' and then
+be used via --template instead of --file. Use --render-only to print the
+rendered definition without creating a cluster.
+
+Multiple clusters
+-----------------
+
+A definition file can contain several cluster definitions, either as YAML
+documents separated by '---' or as a list. All definitions are checked before
+any cluster is created. The clusters are then created in parallel, by default
+three at a time (see --concurrency). If a cluster cannot be created, the
+others are still created, and the failure is reported for that cluster.
+The --name flag cannot be used with multiple definitions.
+
 Defaults
 --------
 
@@ -182,6 +235,13 @@ Examples:
 
   cat my-cluster.yaml | gsctl create cluster -f -
 
+  gsctl create cluster --file ./cluster-template.yaml \
+    --values ./dev-values.yaml --set name="Dev cluster"
+
+  gsctl create cluster --template dev --set owner=acme --render-only
+
+  gsctl create cluster --file ./environments.yaml --concurrency 2
+
 With Bash and other compatible shells, the syntax shown below can be used to
 create a YAML defininition and pass it to the command in one go, without the
 need for a file:
@@ -209,6 +269,9 @@ suppress the creation of the default node pool by setting the flag
 	// the client wrapper we will use in this command.
 	clientWrapper *client.Wrapper
 
+	// capabilitiesMutex serializes capability checks of concurrent cluster creations.
+	capabilitiesMutex sync.Mutex
+
 	arguments Arguments
 )
 
@@ -226,6 +289,11 @@ func initFlags() {
 	Command.Flags().BoolVar(&flags.MasterHA, "master-ha", true, "When true, the cluster will provide high-availability Kubernetes masters.")
 	Command.Flags().BoolVarP(&flags.CreateDefaultNodePool, "create-default-nodepool", "", true, "Whether a default node pool should be created if none is specified in the definition. Requires node pool support.")
 	Command.Flags().StringVarP(&flags.OutputFormat, "output", "", "", fmt.Sprintf("Output format. Specifying '%s' will change output to be JSON formatted.", formatting.OutputFormatJSON))
+	Command.Flags().StringVarP(&flags.Template, "template", "", "", "Name of a stored cluster definition template to use instead of --file. See 'gsctl list templates'.")
+	Command.Flags().StringArrayVarP(&flags.Set, "set", "", nil, "Value to render the definition template with, as key=value. Can be used multiple times.")
+	Command.Flags().StringVarP(&flags.ValuesFile, "values", "", "", "Path to a YAML file with values to render the definition template with.")
+	Command.Flags().BoolVarP(&flags.RenderOnly, "render-only", "", false, "If set, the rendered cluster definition is printed and no cluster is created.")
+	Command.Flags().IntVarP(&flags.Concurrency, "concurrency", "", 3, "Maximum number of clusters to create in parallel, if the definition contains several clusters.")
 }
 
 // printValidation runs our pre-checks.
@@ -246,6 +314,14 @@ func printValidation(cmd *cobra.Command, positionalArgs []string) {
 		case errors.IsConflictingFlagsError(err):
 			headline = "Conflicting flags used"
 			subtext = "When specifying a definition via a YAML file, certain flags must not be used."
+			if arguments.InputYAMLFile != "" && arguments.TemplateName != "" {
+				subtext = "Please use either --file or --template, but not both."
+			} else if arguments.RenderOnly {
+				subtext = "The --render-only flag cannot be combined with --output."
+			}
+		case errors.IsRequiredFlagMissingError(err):
+			headline = "No definition given"
+			subtext = "The flags --set, --values and --render-only require a definition via --file or --template."
 		default:
 			headline = err.Error()
 		}
@@ -261,7 +337,25 @@ func printValidation(cmd *cobra.Command, positionalArgs []string) {
 
 // printResult calls addCluster() and creates user-friendly output of the result
 func printResult(cmd *cobra.Command, positionalArgs []string) {
-	result, err := addCluster(arguments)
+	if arguments.RenderOnly {
+		printRendered(arguments)
+		return
+	}
+
+	definitions, err := readDefinitions(arguments)
+	if err == nil && len(definitions) > 1 {
+		printMultipleResults(addClusters(arguments, definitions))
+		return
+	}
+
+	var result *creationResult
+	if err == nil {
+		var definitionYAML []byte
+		if len(definitions) == 1 {
+			definitionYAML = definitions[0].Data
+		}
+		result, err = addCluster(arguments, definitionYAML)
+	}
 
 	if arguments.OutputFormat == formatting.OutputFormatJSON {
 		printJSONOutput(result, err)
@@ -269,95 +363,7 @@ func printResult(cmd *cobra.Command, positionalArgs []string) {
 	}
 
 	if err != nil {
-		client.HandleErrors(err)
-		errors.HandleCommonErrors(err)
-
-		var headline string
-		var subtext string
-		richError, richErrorOK := err.(*errgo.Err)
-
-		switch {
-		case IsHAMastersNotSupported(err):
-			var haMastersRequiredVersion string
-			{
-				for _, requiredRelease := range capabilities.HAMasters.RequiredReleasePerProvider {
-					if requiredRelease.Provider == config.Config.Provider {
-						haMastersRequiredVersion = requiredRelease.ReleaseVersion.String()
-
-						break
-					}
-				}
-			}
-
-			headline = "Feature not supported"
-
-			if haMastersRequiredVersion == "" {
-				subtext = fmt.Sprintf("Master node high availability is not supported by your provider. (%s)", strings.ToUpper(config.Config.Provider))
-			} else {
-				subtext = fmt.Sprintf("Master node high availability is only supported by releases %s and higher.", haMastersRequiredVersion)
-			}
-		case IsMustProvideSingleMasterType(err):
-			headline = "Conflicting master node configuration"
-			subtext = "The workload cluster release you're trying to use supports master node high availability.\nPlease remove the 'master' attribute from your cluster definition and use the 'master_nodes' attribute instead."
-		case errors.IsClusterOwnerMissingError(err):
-			headline = "No owner organization set"
-			subtext = "Please specify an owner organization for the cluster via the --owner flag."
-			if arguments.InputYAMLFile != "" {
-				subtext = "Please specify an owner organization for the cluster in your definition file or set one via the --owner flag."
-			}
-		case errors.IsYAMLNotParseable(err):
-			headline = "Could not parse YAML"
-			if arguments.InputYAMLFile == standardInputSpecialPath {
-				subtext = "The YAML data given via STDIN could not be parsed into a cluster definition."
-			} else {
-				subtext = fmt.Sprintf("The YAML data read from file '%s' could not be parsed into a cluster definition.", arguments.InputYAMLFile)
-			}
-		case errors.IsYAMLFileNotReadable(err):
-			if arguments.InputYAMLFile == standardInputSpecialPath {
-				headline = "Could not read YAML from STDIN"
-				subtext = "The YAML definition given via standard input could not be parsed.\n"
-				subtext += fmt.Sprintf("Details: %s", err.Error())
-			} else {
-				headline = "Could not read YAML file"
-				subtext = fmt.Sprintf("The file '%s' could not be read. Please make sure that it is readable and contains valid YAML.\n", arguments.InputYAMLFile)
-				subtext += fmt.Sprintf("Details: %s", err.Error())
-			}
-		case errors.IsIncompatibleSettings(err):
-			headline = "Incompatible settings"
-			subtext = "The provided cluster details/definition are not compatible with the capabilities of the installation and/or workload cluster release.\n"
-			subtext += fmt.Sprintf("Error details: %s", err.Error())
-		case errors.IsCouldNotCreateJSONRequestBodyError(err):
-			headline = "Could not create the JSON body for cluster creation API request"
-			subtext = "There seems to be a problem in parsing the cluster definition. Please contact Giant Swarm via Slack or via support@giantswarm.io with details on how you executes this command."
-		case errors.IsNotAuthorizedError(err):
-			headline = "Not authorized"
-			subtext = "No cluster has been created, as you are are not authenticated or not authorized to perform this action."
-			subtext += " Please check your credentials or, to make sure, use 'gsctl login' to log in again."
-		case errors.IsOrganizationNotFoundError(err):
-			headline = "Organization not found"
-			subtext = "The organization set to own the cluster does not exist."
-		case errors.IsCouldNotCreateClusterError(err):
-			headline = "The cluster could not be created."
-			subtext = "You might try again in a few moments. If that doesn't work, please contact the Giant Swarm support team."
-			subtext += " Sorry for the inconvenience!"
-
-			// more details for backend side / connection errors
-			subtext += "\n\nDetails:\n"
-			if richErrorOK {
-				subtext += richError.Message()
-			} else {
-				subtext += err.Error()
-			}
-
-		default:
-			headline = err.Error()
-		}
-
-		// output error information
-		fmt.Println(color.RedString(headline))
-		if subtext != "" {
-			fmt.Println(subtext)
-		}
+		handleError(err)
 		os.Exit(1)
 	}
 
@@ -396,6 +402,141 @@ func printResult(cmd *cobra.Command, positionalArgs []string) {
 	fmt.Printf("    %s \n\n", color.YellowString("gsctl create kubeconfig --help"))
 }
 
+// printRendered prints the rendered cluster definition, after making sure
+// that it is a valid definition.
+func printRendered(args Arguments) {
+	definitionYAML, err := renderDefinition(args)
+	if err == nil {
+		var documents []clusterdefinition.Document
+		documents, err = clusterdefinition.Split(definitionYAML)
+		if err == nil && len(documents) > 1 {
+			err = clusterdefinition.LintDocuments(documents)
+		} else if err == nil {
+			err = clusterdefinition.Lint(definitionYAML).Err()
+		}
+	}
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Print(string(definitionYAML))
+}
+
+// handleError prints user-friendly information on errors
+// occurring during cluster creation.
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	var headline string
+	var subtext string
+	richError, richErrorOK := err.(*errgo.Err)
+
+	switch {
+	case IsHAMastersNotSupported(err):
+		var haMastersRequiredVersion string
+		{
+			for _, requiredRelease := range capabilities.HAMasters.RequiredReleasePerProvider {
+				if requiredRelease.Provider == config.Config.Provider {
+					haMastersRequiredVersion = requiredRelease.ReleaseVersion.String()
+
+					break
+				}
+			}
+		}
+
+		headline = "Feature not supported"
+
+		if haMastersRequiredVersion == "" {
+			subtext = fmt.Sprintf("Master node high availability is not supported by your provider. (%s)", strings.ToUpper(config.Config.Provider))
+		} else {
+			subtext = fmt.Sprintf("Master node high availability is only supported by releases %s and higher.", haMastersRequiredVersion)
+		}
+	case IsMustProvideSingleMasterType(err):
+		headline = "Conflicting master node configuration"
+		subtext = "The workload cluster release you're trying to use supports master node high availability.\nPlease remove the 'master' attribute from your cluster definition and use the 'master_nodes' attribute instead."
+	case errors.IsClusterOwnerMissingError(err):
+		headline = "No owner organization set"
+		subtext = "Please specify an owner organization for the cluster via the --owner flag."
+		if arguments.InputYAMLFile != "" {
+			subtext = "Please specify an owner organization for the cluster in your definition file or set one via the --owner flag."
+		}
+	case errors.IsYAMLNotParseable(err):
+		headline = "Could not parse YAML"
+		if arguments.InputYAMLFile == standardInputSpecialPath {
+			subtext = "The YAML data given via STDIN could not be parsed into a cluster definition."
+		} else {
+			subtext = fmt.Sprintf("The YAML data read from file '%s' could not be parsed into a cluster definition.", arguments.InputYAMLFile)
+		}
+	case errors.IsYAMLFileNotReadable(err):
+		if arguments.InputYAMLFile == standardInputSpecialPath {
+			headline = "Could not read YAML from STDIN"
+			subtext = "The YAML definition given via standard input could not be parsed.\n"
+			subtext += fmt.Sprintf("Details: %s", err.Error())
+		} else {
+			headline = "Could not read YAML file"
+			subtext = fmt.Sprintf("The file '%s' could not be read. Please make sure that it is readable and contains valid YAML.\n", arguments.InputYAMLFile)
+			subtext += fmt.Sprintf("Details: %s", err.Error())
+		}
+	case errors.IsConflictingFlagsError(err):
+		headline = "Conflicting flags used"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case errors.IsIncompatibleSettings(err):
+		headline = "Incompatible settings"
+		subtext = "The provided cluster details/definition are not compatible with the capabilities of the installation and/or workload cluster release.\n"
+		subtext += fmt.Sprintf("Error details: %s", err.Error())
+	case clusterdefinition.IsInvalidDefinition(err):
+		headline = "Invalid cluster definition"
+		subtext = "Please fix these problems and try again. Use 'gsctl validate' to check a definition without creating a cluster.\n\n"
+		subtext += strings.TrimPrefix(err.Error(), "invalid definition error: ")
+	case clustertemplate.IsNotFound(err):
+		headline = "Template not found"
+		subtext = fmt.Sprintf("There is no template named '%s'. Use 'gsctl list templates' to list all stored templates.", arguments.TemplateName)
+	case clustertemplate.IsInvalidTemplate(err):
+		headline = "Invalid template"
+		subtext = fmt.Sprintf("The cluster definition could not be parsed as a template.\nDetails: %s", err.Error())
+	case clustertemplate.IsInvalidValues(err):
+		headline = "Invalid template values"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case clustertemplate.IsRenderFailed(err):
+		headline = "Could not render the cluster definition template"
+		subtext = "Please make sure that all values referenced by the template are given via --values or --set.\n"
+		subtext += fmt.Sprintf("Details: %s", err.Error())
+	case errors.IsCouldNotCreateJSONRequestBodyError(err):
+		headline = "Could not create the JSON body for cluster creation API request"
+		subtext = "There seems to be a problem in parsing the cluster definition. Please contact Giant Swarm via Slack or via support@giantswarm.io with details on how you executes this command."
+	case errors.IsNotAuthorizedError(err):
+		headline = "Not authorized"
+		subtext = "No cluster has been created, as you are are not authenticated or not authorized to perform this action."
+		subtext += " Please check your credentials or, to make sure, use 'gsctl login' to log in again."
+	case errors.IsOrganizationNotFoundError(err):
+		headline = "Organization not found"
+		subtext = "The organization set to own the cluster does not exist."
+	case errors.IsCouldNotCreateClusterError(err):
+		headline = "The cluster could not be created."
+		subtext = "You might try again in a few moments. If that doesn't work, please contact the Giant Swarm support team."
+		subtext += " Sorry for the inconvenience!"
+
+		// more details for backend side / connection errors
+		subtext += "\n\nDetails:\n"
+		if richErrorOK {
+			subtext += richError.Message()
+		} else {
+			subtext += err.Error()
+		}
+
+	default:
+		headline = err.Error()
+	}
+
+	// output error information
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
 func printJSONOutput(result *creationResult, creationErr error) {
 	var outputBytes []byte
 	var err error
@@ -425,6 +566,21 @@ func printJSONOutput(result *creationResult, creationErr error) {
 
 // verifyPreconditions checks preconditions and returns an error in case.
 func verifyPreconditions(args Arguments) error {
+	if args.InputYAMLFile != "" && args.TemplateName != "" {
+		return microerror.Mask(errors.ConflictingFlagsError)
+	}
+	if args.InputYAMLFile == "" && args.TemplateName == "" && (len(args.Set) > 0 || args.ValuesFile != "" || args.RenderOnly) {
+		return microerror.Mask(errors.RequiredFlagMissingError)
+	}
+	if args.RenderOnly {
+		if args.OutputFormat != "" {
+			return microerror.Mask(errors.ConflictingFlagsError)
+		}
+
+		// Rendering doesn't need the API.
+		return nil
+	}
+
 	if args.APIEndpoint == "" {
 		return microerror.Mask(errors.EndpointMissingError)
 	}
@@ -473,14 +629,40 @@ func getLatestActiveReleaseVersion(clientWrapper *client.Wrapper, auxParams *cli
 	return activeReleases[0].String(), nil
 }
 
+// validateDefinition checks the definition YAML against the installation
+// and prints warnings. Active releases are only fetched if the definition
+// selects a release which is not overridden via flag.
+func validateDefinition(args Arguments, definitionYAML []byte, info *models.V4InfoResponse, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) error {
+	validation := clusterdefinition.Lint(definitionYAML)
+
+	installation := clusterdefinition.NewInstallation(info, nil)
+	if validation.Err() == nil && args.ReleaseVersion == "" && validation.ReleaseVersion() != "" {
+		response, err := clientWrapper.GetReleases(auxParams)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		installation = clusterdefinition.NewInstallation(info, response.Payload)
+	}
+
+	validation.Check(installation)
+
+	if args.OutputFormat != formatting.OutputFormatJSON {
+		for _, p := range validation.Warnings() {
+			fmt.Println(color.YellowString("Warning: %s", p.String()))
+		}
+	}
+
+	return validation.Err()
+}
+
 func isVersionProductionReady(version *semver.Version) bool {
 	return len(version.Prerelease()) < 1 && len(version.Metadata()) < 1
 }
 
 // addCluster collects information to decide whether to create a cluster
 // via the v4 or v5 API endpoint, then calls the according functions
-// and returns results.
-func addCluster(args Arguments) (*creationResult, error) {
+// and returns results. definitionYAML is nil if no definition has been given.
+func addCluster(args Arguments, definitionYAML []byte) (*creationResult, error) {
 	var err error
 
 	clientWrapper, err := client.NewWithConfig(args.APIEndpoint, args.UserProvidedToken)
@@ -492,7 +674,7 @@ func addCluster(args Arguments) (*creationResult, error) {
 	auxParams.ActivityName = createClusterActivityName
 
 	if args.Verbose {
-		fmt.Println(color.WhiteString("Fetching installation information"))
+		fmt.Fprintln(args.out(), color.WhiteString("Fetching installation information"))
 	}
 
 	info, err := clientWrapper.GetInfo(auxParams)
@@ -510,15 +692,13 @@ func addCluster(args Arguments) (*creationResult, error) {
 
 	// Process YAML definition (if given), so we can take a 'release_version' key into consideration.
 	var definitionInterface interface{}
-	if args.InputYAMLFile == standardInputSpecialPath {
-		definitionInterface, err = readDefinitionFromSTDIN()
-
+	if definitionYAML != nil {
+		err = validateDefinition(args, definitionYAML, info.Payload, clientWrapper, auxParams)
 		if err != nil {
-			return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+			return nil, microerror.Mask(err)
 		}
-	} else if args.InputYAMLFile != "" {
-		// definition from file (and optionally flags)
-		definitionInterface, err = readDefinitionFromFile(args.FileSystem, args.InputYAMLFile)
+
+		definitionInterface, err = readDefinitionFromYAML(definitionYAML)
 		if err != nil {
 			return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
 		}
@@ -570,23 +750,28 @@ func addCluster(args Arguments) (*creationResult, error) {
 		}
 
 		if args.Verbose {
-			fmt.Println(color.WhiteString("Determined workload cluster release version %s is the latest, so this will be used.", latest))
+			fmt.Fprintln(args.out(), color.WhiteString("Determined workload cluster release version %s is the latest, so this will be used.", latest))
 		}
 		wantedRelease = latest
 	}
 
-	// Fetch node pools capabilities info.
+	// Fetch node pools capabilities info. The capabilities service updates
+	// package level definitions, so this must not run concurrently when
+	// creating several clusters.
+	capabilitiesMutex.Lock()
 	capabilityService, err := capabilities.New(config.Config.Provider, clientWrapper)
 	if err != nil {
+		capabilitiesMutex.Unlock()
 		return nil, microerror.Mask(err)
 	}
 
 	if args.Verbose {
-		fmt.Println(color.WhiteString("Fetching installation capabilities"))
+		fmt.Fprintln(args.out(), color.WhiteString("Fetching installation capabilities"))
 	}
 
 	nodePoolsEnabled, _ = capabilityService.HasCapability(wantedRelease, capabilities.NodePools)
 	haMastersEnabled, _ = capabilityService.HasCapability(wantedRelease, capabilities.HAMasters)
+	capabilitiesMutex.Unlock()
 
 	// Fail for edge cases:
 	// - User uses v5 definition, but the installation doesn't support node pools.
@@ -613,7 +798,7 @@ func addCluster(args Arguments) (*creationResult, error) {
 
 	if nodePoolsEnabled {
 		if args.Verbose {
-			fmt.Println(color.WhiteString("Using the v5 API to create a cluster with node pool support"))
+			fmt.Fprintln(args.out(), color.WhiteString("Using the v5 API to create a cluster with node pool support"))
 		}
 
 		if result.DefinitionV5 == nil {
@@ -645,7 +830,7 @@ func addCluster(args Arguments) (*creationResult, error) {
 
 	} else {
 		if args.Verbose {
-			fmt.Println(color.WhiteString("Using the v4 API to create a cluster"))
+			fmt.Fprintln(args.out(), color.WhiteString("Using the v4 API to create a cluster"))
 		}
 
 		if result.DefinitionV4 == nil {
@@ -663,6 +848,9 @@ func addCluster(args Arguments) (*creationResult, error) {
 		result.Location = location
 	}
 
+	// The cached cluster list is outdated now.
+	clustercache.Invalidate(args.APIEndpoint)
+
 	return result, nil
 }
 
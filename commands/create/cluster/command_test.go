@@ -15,6 +15,7 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/commands/types"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -28,6 +29,22 @@ selected_endpoint: https://foo
 updated: 2017-09-29T11:23:15+02:00
 `
 
+// readAndAddCluster reads the definition given in args and creates
+// a single cluster, like printResult does.
+func readAndAddCluster(args Arguments) (*creationResult, error) {
+	definitions, err := readDefinitions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitionYAML []byte
+	if len(definitions) > 0 {
+		definitionYAML = definitions[0].Data
+	}
+
+	return addCluster(args, definitionYAML)
+}
+
 // Test_CollectArgs tests whether collectArguments produces the expected results.
 func Test_CollectArgs(t *testing.T) {
 	var testCases = []struct {
@@ -41,6 +58,7 @@ func Test_CollectArgs(t *testing.T) {
 			Arguments{
 				APIEndpoint:           "https://foo",
 				AuthToken:             "some-token",
+				Concurrency:           3,
 				CreateDefaultNodePool: true,
 				Scheme:                "giantswarm",
 				MasterHA:              nil,
@@ -51,6 +69,7 @@ func Test_CollectArgs(t *testing.T) {
 			Arguments{
 				APIEndpoint:           "https://foo",
 				AuthToken:             "some-token",
+				Concurrency:           3,
 				CreateDefaultNodePool: true,
 				Scheme:                "giantswarm",
 				MasterHA:              toBoolPtr(false),
@@ -65,6 +84,7 @@ func Test_CollectArgs(t *testing.T) {
 			Arguments{
 				APIEndpoint:           "https://foo",
 				AuthToken:             "some-token",
+				Concurrency:           3,
 				ClusterName:           "ClusterName",
 				CreateDefaultNodePool: true,
 				Owner:                 "acme",
@@ -80,6 +100,7 @@ func Test_CollectArgs(t *testing.T) {
 			Arguments{
 				APIEndpoint:           "https://foo",
 				AuthToken:             "some-token",
+				Concurrency:           3,
 				CreateDefaultNodePool: true,
 				ReleaseVersion:        "1.2.3",
 				Scheme:                "giantswarm",
@@ -91,12 +112,24 @@ func Test_CollectArgs(t *testing.T) {
 			Arguments{
 				APIEndpoint:           "https://foo",
 				AuthToken:             "some-token",
+				Concurrency:           3,
 				CreateDefaultNodePool: true,
 				Scheme:                "giantswarm",
 				MasterHA:              nil,
 				OutputFormat:          "json",
 			},
 		},
+		{
+			[]string{"--concurrency=5"},
+			Arguments{
+				APIEndpoint:           "https://foo",
+				AuthToken:             "some-token",
+				Concurrency:           5,
+				CreateDefaultNodePool: true,
+				Scheme:                "giantswarm",
+				MasterHA:              nil,
+			},
+		},
 	}
 
 	fs := afero.NewMemMapFs()
@@ -143,6 +176,32 @@ func Test_verifyPreconditions(t *testing.T) {
 			},
 			errors.IsOutputFormatInvalid,
 		},
+		// file and template
+		{
+			Arguments{
+				InputYAMLFile: "cluster.yaml",
+				TemplateName:  "dev",
+			},
+			errors.IsConflictingFlagsError,
+		},
+		// values without definition
+		{
+			Arguments{
+				APIEndpoint: "https://mock-url",
+				AuthToken:   "mock-token",
+				Set:         []string{"owner=acme"},
+			},
+			errors.IsRequiredFlagMissingError,
+		},
+		// render-only with JSON output
+		{
+			Arguments{
+				InputYAMLFile: "cluster.yaml",
+				RenderOnly:    true,
+				OutputFormat:  "json",
+			},
+			errors.IsConflictingFlagsError,
+		},
 	}
 
 	fs := afero.NewMemMapFs()
@@ -633,6 +692,13 @@ func Test_CreateClusterSuccessfully(t *testing.T) {
 						"changelog": [],
 						"components": []
 					},
+					{
+						"timestamp": "2019-02-01T12:00:00Z",
+						"version": "1.2.3",
+						"active": true,
+						"changelog": [],
+						"components": []
+					},
 					{
 						"timestamp": "2019-09-23T12:00:00Z",
 						"version": "9.0.0",
@@ -646,6 +712,13 @@ func Test_CreateClusterSuccessfully(t *testing.T) {
 						"active": true,
 						"changelog": [],
 						"components": []
+					},
+					{
+						"timestamp": "2020-12-01T12:00:00Z",
+						"version": "14.2.0",
+						"active": true,
+						"changelog": [],
+						"components": []
 					}
 				]`))
 				} else if r.Method == "POST" && r.URL.String() == "/v5/clusters/f6e8r/nodepools/" {
@@ -673,7 +746,7 @@ func Test_CreateClusterSuccessfully(t *testing.T) {
 				t.Errorf("Case %d - Validation error: %s", i, err.Error())
 			}
 
-			result, err := addCluster(*tc.inputArgs)
+			result, err := readAndAddCluster(*tc.inputArgs)
 			if err != nil {
 				t.Errorf("Case %d - Execution error: %s", i, err.Error())
 			}
@@ -727,6 +800,17 @@ func Test_CreateClusterExecutionFailures(t *testing.T) {
 			responseStatus:     400,
 			errorMatcher:       errors.IsYAMLFileNotReadable,
 		},
+		{
+			description: "YAML definition with a typo",
+			inputArgs: &Arguments{
+				Owner:         "owner",
+				AuthToken:     "some-token",
+				InputYAMLFile: "/typo.yaml",
+			},
+			serverResponseJSON: []byte(``),
+			responseStatus:     400,
+			errorMatcher:       clusterdefinition.IsInvalidDefinition,
+		},
 	}
 
 	fs := afero.NewMemMapFs()
@@ -734,6 +818,10 @@ func Test_CreateClusterExecutionFailures(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	err = afero.WriteFile(fs, "/typo.yaml", []byte("api_version: v5\nnodepool:\n- name: Default\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	for i, testCase := range testCases {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -774,7 +862,7 @@ func Test_CreateClusterExecutionFailures(t *testing.T) {
 			if err != nil {
 				t.Errorf("Unexpected error in argument validation: %#v", err)
 			} else {
-				_, err := addCluster(*testCase.inputArgs)
+				_, err := readAndAddCluster(*testCase.inputArgs)
 				if err == nil {
 					t.Errorf("Test case %d did not yield an execution error.", i)
 				}
@@ -1274,12 +1362,11 @@ selected_endpoint: ` + mockServer.URL
 		t.Error(err)
 	}
 
-	addClusterFunc := addCluster
 	print := printJSONOutput
 
 	jsonRepresentation := testutils.CaptureOutput(func() {
 		// output
-		print(addClusterFunc(args))
+		print(readAndAddCluster(args))
 	})
 
 	t.Log(jsonRepresentation)
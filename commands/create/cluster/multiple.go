@@ -0,0 +1,336 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
+)
+
+const (
+	resultCreated           = "created"
+	resultCreatedWithErrors = "created-with-errors"
+	resultError             = "error"
+)
+
+// clusterResult is the outcome of creating one of several clusters.
+type clusterResult struct {
+	definition clusterdefinition.Document
+	result     *creationResult
+	err        error
+}
+
+// JSONOutputItem is the JSON output for one cluster when creating
+// several clusters at once.
+type JSONOutputItem struct {
+	// Name of the cluster, as given in the definition
+	Name string `json:"name,omitempty"`
+	// ID of the cluster
+	ID string `json:"id,omitempty"`
+	// Result for this cluster. One of 'created', 'created-with-errors' and 'error'
+	Result string `json:"result"`
+	// Error message, if the cluster could not be created
+	Error string `json:"error,omitempty"`
+}
+
+func (r clusterResult) status() string {
+	switch {
+	case r.err != nil:
+		return resultError
+	case r.result.HasErrors:
+		return resultCreatedWithErrors
+	default:
+		return resultCreated
+	}
+}
+
+// addClusters creates a cluster for each definition, using up to
+// args.Concurrency workers in parallel. All definitions are linted before
+// the first cluster is created. An error is only returned if no cluster
+// creation has been attempted. Per cluster errors are part of the results.
+func addClusters(args Arguments, definitions []clusterdefinition.Document) ([]clusterResult, error) {
+	if args.ClusterName != "" {
+		return nil, microerror.Maskf(errors.ConflictingFlagsError, "--name cannot be used with a definition containing several clusters")
+	}
+
+	err := clusterdefinition.LintDocuments(definitions)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// Make sure the provider is known before the workers start, so that
+	// they don't all try to write it to the config.
+	if config.Config.Provider == "" {
+		clientWrapper, err := client.NewWithConfig(args.APIEndpoint, args.UserProvidedToken)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		auxParams := clientWrapper.DefaultAuxiliaryParams()
+		auxParams.ActivityName = createClusterActivityName
+
+		info, err := clientWrapper.GetInfo(auxParams)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		err = config.Config.SetProvider(info.Payload.General.Provider)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	// Pick an owner for definitions without one now, as the workers
+	// can't share the terminal for prompts.
+	if args.Owner == "" && args.OutputFormat != formatting.OutputFormatJSON && anyOwnerMissing(definitions) {
+		clientWrapper, err := client.NewWithConfig(args.APIEndpoint, args.UserProvidedToken)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		// Without a terminal, clusters without owner fail individually.
+		owner, err := picker.Organization(clientWrapper)
+		if err != nil && !confirm.IsNotInteractive(err) {
+			return nil, microerror.Maskf(errors.ClusterOwnerMissingError, err.Error())
+		}
+		args.DefaultOwner = owner
+	}
+
+	workers := args.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(definitions) {
+		workers = len(definitions)
+	}
+
+	if args.Verbose {
+		fmt.Println(color.WhiteString("Creating %d clusters, up to %d at a time", len(definitions), workers))
+	}
+
+	results := make([]clusterResult, len(definitions))
+	indexes := make(chan int)
+
+	var outputMutex sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				output := &prefixWriter{
+					mutex:  &outputMutex,
+					out:    args.out(),
+					prefix: outputPrefix(definitions[i]),
+				}
+				clusterArgs := args
+				clusterArgs.Output = output
+
+				result, err := addCluster(clusterArgs, definitions[i].Data)
+				output.Flush()
+				results[i] = clusterResult{definition: definitions[i], result: result, err: err}
+			}
+		}()
+	}
+
+	for i := range definitions {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, nil
+}
+
+// anyOwnerMissing returns true if one of the definitions has no owner.
+func anyOwnerMissing(definitions []clusterdefinition.Document) bool {
+	for _, d := range definitions {
+		var def struct {
+			Owner string `yaml:"owner"`
+		}
+		// Invalid definitions have been reported by the linter already.
+		_ = yaml.Unmarshal(d.Data, &def)
+		if def.Owner == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// outputPrefix returns the prefix for progress messages about a cluster.
+func outputPrefix(d clusterdefinition.Document) string {
+	if d.Name != "" {
+		return color.CyanString("[%d %s] ", d.Index+1, d.Name)
+	}
+
+	return color.CyanString("[%d] ", d.Index+1)
+}
+
+// prefixWriter writes complete lines, each starting with prefix, to out.
+// Writers for several clusters share a mutex, so that their lines don't
+// get mixed up.
+type prefixWriter struct {
+	mutex  *sync.Mutex
+	out    io.Writer
+	prefix string
+	buffer []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.writeLine(w.buffer[:i])
+		w.buffer = w.buffer[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes remaining output not terminated by a newline.
+func (w *prefixWriter) Flush() {
+	if len(w.buffer) > 0 {
+		w.writeLine(w.buffer)
+		w.buffer = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	fmt.Fprintf(w.out, "%s%s\n", w.prefix, line)
+}
+
+// printMultipleResults prints the results of creating several clusters
+// as a table, or as a JSON array, and exits with a non-zero exit code
+// if any cluster could not be created.
+func printMultipleResults(results []clusterResult, err error) {
+	if arguments.OutputFormat == formatting.OutputFormatJSON {
+		printMultipleJSONOutput(results, err)
+		return
+	}
+
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	rows := []string{strings.Join([]string{
+		color.CyanString("#"),
+		color.CyanString("NAME"),
+		color.CyanString("ID"),
+		color.CyanString("RESULT"),
+	}, "|")}
+
+	failed := 0
+	for _, r := range results {
+		id := "n/a"
+		name := r.definition.Name
+		if name == "" {
+			name = "n/a"
+		}
+		status := color.GreenString(r.status())
+		switch r.status() {
+		case resultError:
+			failed++
+			status = color.RedString(r.status())
+		case resultCreatedWithErrors:
+			status = color.YellowString(r.status())
+		}
+		if r.result != nil {
+			id = r.result.ID
+		}
+
+		rows = append(rows, strings.Join([]string{
+			strconv.Itoa(r.definition.Index + 1),
+			name,
+			id,
+			status,
+		}, "|"))
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+
+	if failed > 0 {
+		fmt.Println("")
+		for _, r := range results {
+			if r.err != nil {
+				fmt.Println(color.RedString("Cluster %d (line %d) could not be created: %s", r.definition.Index+1, r.definition.Line, errorMessage(r.err)))
+			}
+		}
+
+		fmt.Println(color.RedString("\n%d of %d clusters could not be created.", failed, len(results)))
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("\nAll %d clusters are launching.", len(results)))
+	fmt.Println("Use 'gsctl create kubeconfig --cluster=<id>' to add a key pair and settings for kubectl.")
+}
+
+func printMultipleJSONOutput(results []clusterResult, creationErr error) {
+	if creationErr != nil {
+		printJSONOutput(nil, creationErr)
+		return
+	}
+
+	items := []JSONOutputItem{}
+	failed := false
+	for _, r := range results {
+		item := JSONOutputItem{Name: r.definition.Name, Result: r.status()}
+		if r.err != nil {
+			item.Error = errorMessage(r.err)
+			failed = true
+		} else {
+			item.ID = r.result.ID
+			failed = failed || r.result.HasErrors
+		}
+
+		items = append(items, item)
+	}
+
+	outputBytes, err := json.MarshalIndent(items, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(outputBytes))
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// errorMessage returns a one line description of an error
+// which occurred while creating one of several clusters.
+func errorMessage(err error) string {
+	if apiErr, ok := microerror.Cause(err).(*clienterror.APIError); ok {
+		return apiErr.ErrorMessage
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,208 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+const multipleDefinitionsYAML = `- name: Dev
+  owner: acme
+  release_version: 1.0.0
+- name: Staging
+  owner: non-existing-owner
+  release_version: 1.0.0
+---
+name: Prod
+owner: acme
+release_version: 1.0.0
+`
+
+// multipleMockServer creates v4 clusters, using the lower case
+// cluster name as ID, and fails for unknown organizations.
+func multipleMockServer(t *testing.T, created *[]string) *httptest.Server {
+	var mutex sync.Mutex
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/info/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"general": {"provider": "aws", "availability_zones": {"default": 1, "max": 3}},
+				"features": {"nodepools": {"release_version_minimum": "9.0.0"}}
+			}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/releases/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"timestamp": "2019-01-01T12:00:00Z", "version": "1.0.0", "active": true, "changelog": [], "components": []}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v4/clusters/":
+			body, _ := ioutil.ReadAll(r.Body)
+			definition := map[string]interface{}{}
+			err := json.Unmarshal(body, &definition)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if definition["owner"] != "acme" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Organization not found"}`))
+				return
+			}
+
+			id := strings.ToLower(definition["name"].(string))
+			mutex.Lock()
+			*created = append(*created, id)
+			mutex.Unlock()
+
+			w.Header().Set("Location", "/v4/clusters/"+id+"/")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"code": "RESOURCE_CREATED", "message": "Yeah!"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// Test_addClusters tests creating several clusters, one of which fails.
+func Test_addClusters(t *testing.T) {
+	created := []string{}
+	mockServer := multipleMockServer(t, &created)
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, configYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = afero.WriteFile(fs, "/clusters.yaml", []byte(multipleDefinitionsYAML), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	args := Arguments{
+		APIEndpoint:       mockServer.URL,
+		AuthToken:         "token",
+		UserProvidedToken: "token",
+		Concurrency:       2,
+		FileSystem:        fs,
+		InputYAMLFile:     "/clusters.yaml",
+		Output:            &output,
+		Verbose:           true,
+	}
+
+	definitions, err := readDefinitions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(definitions) != 3 {
+		t.Fatalf("Expected 3 definitions, got %d", len(definitions))
+	}
+
+	results, err := addClusters(args, definitions)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	statuses := []string{}
+	for _, r := range results {
+		statuses = append(statuses, r.definition.Name+": "+r.status())
+	}
+	expected := []string{"Dev: created", "Staging: error", "Prod: created"}
+	if diff := cmp.Diff(expected, statuses); diff != "" {
+		t.Errorf("Results unequal (-want +got):\n%s", diff)
+	}
+
+	if results[0].result.ID != "dev" || results[2].result.ID != "prod" {
+		t.Errorf("Unexpected cluster IDs %q and %q", results[0].result.ID, results[2].result.ID)
+	}
+	if !errors.IsOrganizationNotFoundError(results[1].err) {
+		t.Errorf("Expected OrganizationNotFoundError, got %v", results[1].err)
+	}
+	if len(created) != 2 {
+		t.Errorf("Expected 2 clusters to be created, got %v", created)
+	}
+
+	// Progress messages of the workers must be prefixed with the cluster.
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if !strings.HasPrefix(line, "[1 Dev] ") && !strings.HasPrefix(line, "[2 Staging] ") && !strings.HasPrefix(line, "[3 Prod] ") {
+			t.Errorf("Line without cluster prefix: %q", line)
+		}
+	}
+	if !strings.Contains(output.String(), "[3 Prod] Requesting new cluster") {
+		t.Errorf("Expected progress messages, got:\n%s", output.String())
+	}
+}
+
+func Test_anyOwnerMissing(t *testing.T) {
+	definitions, err := clusterdefinition.Split([]byte(multipleDefinitionsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anyOwnerMissing(definitions) {
+		t.Error("Expected all definitions to have an owner")
+	}
+
+	definitions, err = clusterdefinition.Split([]byte("- name: Dev\n  owner: acme\n- name: Staging\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !anyOwnerMissing(definitions) {
+		t.Error("Expected a definition without owner")
+	}
+}
+
+// Test_addClustersInvalid tests that no cluster is created if one of the
+// definitions is invalid, and that --name can't be used.
+func Test_addClustersInvalid(t *testing.T) {
+	created := []string{}
+	mockServer := multipleMockServer(t, &created)
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, configYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		APIEndpoint:       mockServer.URL,
+		AuthToken:         "token",
+		UserProvidedToken: "token",
+	}
+
+	definitions, err := clusterdefinition.Split([]byte("- name: Dev\n  owner: acme\n- name: Prod\n  ownr: acme\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	_, err = addClusters(args, definitions)
+	if !clusterdefinition.IsInvalidDefinition(err) {
+		t.Errorf("Expected invalid definition error, got %v", err)
+	} else if !strings.Contains(err.Error(), "definition 2: 4:3: error: unknown field 'ownr'") {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+
+	args.ClusterName = "Name"
+	_, err = addClusters(args, definitions)
+	if !errors.IsConflictingFlagsError(err) {
+		t.Errorf("Expected ConflictingFlagsError, got %v", err)
+	}
+
+	if len(created) != 0 {
+		t.Errorf("Expected no clusters to be created, got %v", created)
+	}
+}
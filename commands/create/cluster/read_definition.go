@@ -4,11 +4,14 @@ import (
 	"bufio"
 	"os"
 
-	"github.com/giantswarm/gsctl/commands/errors"
-	"github.com/giantswarm/gsctl/commands/types"
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/afero"
 	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/commands/types"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
 )
 
 // readDefinitionFromYAML reads a cluster definition from YAML data.
@@ -54,9 +57,8 @@ func readDefinitionFromFile(fs afero.Fs, path string) (interface{}, error) {
 	return readDefinitionFromYAML(data)
 }
 
-// readDefinitionFromSTDIN reads a YAML definition coming via standard input.
-// TODO: provide unit test
-func readDefinitionFromSTDIN() (interface{}, error) {
+// readSTDIN reads YAML data coming via standard input.
+func readSTDIN() ([]byte, error) {
 	yamlString := ""
 	scanner := bufio.NewScanner(os.Stdin)
 
@@ -68,10 +70,68 @@ func readDefinitionFromSTDIN() (interface{}, error) {
 		return nil, microerror.Mask(err)
 	}
 
-	def, err := readDefinitionFromYAML([]byte(yamlString))
+	return []byte(yamlString), nil
+}
+
+// renderDefinition reads the cluster definition from a file, from STDIN or
+// from a stored template, and renders it with the template values given.
+// It returns nil if no definition has been given.
+func renderDefinition(args Arguments) ([]byte, error) {
+	var content []byte
+	var err error
+
+	switch {
+	case args.TemplateName != "":
+		content, err = clustertemplate.Load(args.FileSystem, args.TemplateName)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	case args.InputYAMLFile == standardInputSpecialPath:
+		content, err = readSTDIN()
+		if err != nil {
+			return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+		}
+	case args.InputYAMLFile != "":
+		content, err = afero.ReadFile(args.FileSystem, args.InputYAMLFile)
+		if err != nil {
+			return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+		}
+	default:
+		return nil, nil
+	}
+
+	values, err := clustertemplate.ReadValues(args.FileSystem, args.ValuesFile, args.Set)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	rendered, err := clustertemplate.Render(content, values)
 	if err != nil {
-		return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+		return nil, microerror.Mask(err)
 	}
 
-	return def, nil
+	return rendered, nil
+}
+
+// readDefinitions renders the cluster definition and splits it into
+// single cluster definitions. It returns nil if no definition has been given.
+func readDefinitions(args Arguments) ([]clusterdefinition.Document, error) {
+	definitionYAML, err := renderDefinition(args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if definitionYAML == nil {
+		return nil, nil
+	}
+
+	documents, err := clusterdefinition.Split(definitionYAML)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if len(documents) == 0 {
+		// Let validation report the empty definition.
+		return []clusterdefinition.Document{{Line: 1, Data: definitionYAML}}, nil
+	}
+
+	return documents, nil
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/spf13/afero"
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/giantswarm/gsctl/clustertemplate"
 	"github.com/giantswarm/gsctl/commands/types"
+	"github.com/giantswarm/gsctl/testutils"
 )
 
 // Test_ReadDefinitionFiles tests the readDefinitionFromFile with all
@@ -539,3 +541,62 @@ func Test_CreateFromBadYAML01(t *testing.T) {
 		t.Fatalf("expected owner to be empty, got %q", def.Owner)
 	}
 }
+
+// Test_renderDefinition tests rendering definition templates from a file
+// and from the template store.
+func Test_renderDefinition(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tpl := "api_version: v5\nowner: {{ .owner }}\nname: {{ .name }}\nrelease_version: 12.0.0\n"
+	err = afero.WriteFile(fs, "/cluster.yaml", []byte(tpl), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = afero.WriteFile(fs, "/values.yaml", []byte("owner: acme\nname: Dev\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = clustertemplate.Store(fs, "dev", []byte(tpl), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &types.ClusterDefinitionV5{
+		APIVersion:     "v5",
+		Owner:          "acme",
+		Name:           "Staging",
+		ReleaseVersion: "12.0.0",
+	}
+
+	for _, args := range []Arguments{
+		{FileSystem: fs, InputYAMLFile: "/cluster.yaml", ValuesFile: "/values.yaml", Set: []string{"name=Staging"}},
+		{FileSystem: fs, TemplateName: "dev", Set: []string{"owner=acme", "name=Staging"}},
+	} {
+		rendered, err := renderDefinition(args)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		def, err := readDefinitionFromYAML(rendered)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(expected, def); diff != "" {
+			t.Errorf("Definition not as expected (-want +got):\n%s", diff)
+		}
+	}
+
+	_, err = renderDefinition(Arguments{FileSystem: fs, TemplateName: "dev", Set: []string{"owner=acme"}})
+	if !clustertemplate.IsRenderFailed(err) {
+		t.Errorf("Expected renderFailedError, got %v", err)
+	}
+
+	_, err = renderDefinition(Arguments{FileSystem: fs, TemplateName: "prod"})
+	if !clustertemplate.IsNotFound(err) {
+		t.Errorf("Expected notFoundError, got %v", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/commands/types"
 	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/picker"
 )
 
 // updateDefinitionFromFlagsV4 extend/overwrites a clusterDefinition based on the
@@ -85,6 +86,15 @@ func createAddClusterBodyV4(d *types.ClusterDefinitionV4) *models.V4AddClusterRe
 func addClusterV4(def *types.ClusterDefinitionV4, args Arguments, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) (id, location string, err error) {
 	// Let user-provided arguments (flags) overwrite/extend definition from YAML.
 
+	if def.Owner == "" {
+		def.Owner = args.DefaultOwner
+	}
+
+	// In interactive sessions, let the user pick a missing owner.
+	if def.Owner == "" && args.OutputFormat != formatting.OutputFormatJSON {
+		def.Owner, _ = picker.Organization(clientWrapper)
+	}
+
 	// Validate definition
 	if def.Owner == "" {
 		return "", "", microerror.Mask(errors.ClusterOwnerMissingError)
@@ -99,17 +109,17 @@ func addClusterV4(def *types.ClusterDefinitionV4, args Arguments, clientWrapper
 
 	// Preview in YAML format
 	if args.Verbose {
-		fmt.Println("\nDefinition for the requested cluster:")
+		fmt.Fprintln(args.out(), "\nDefinition for the requested cluster:")
 		d, marshalErr := yaml.Marshal(addClusterBody)
 		if marshalErr != nil {
 			log.Fatalf("error: %v", marshalErr)
 		}
-		fmt.Printf(color.CyanString(string(d)))
-		fmt.Println()
+		fmt.Fprintf(args.out(), color.CyanString(string(d)))
+		fmt.Fprintln(args.out())
 	}
 
 	if args.OutputFormat != formatting.OutputFormatJSON {
-		fmt.Printf("Requesting new cluster for organization '%s'\n", color.CyanString(def.Owner))
+		fmt.Fprintf(args.out(), "Requesting new cluster for organization '%s'\n", color.CyanString(def.Owner))
 	}
 
 	// perform API call
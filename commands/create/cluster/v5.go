@@ -11,6 +11,7 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/commands/types"
 	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/picker"
 	"github.com/giantswarm/gsctl/pkg/provider"
 )
 
@@ -150,6 +151,15 @@ func createAddNodePoolBody(def *types.NodePoolDefinition) *models.V5AddNodePoolR
 }
 
 func addClusterV5(def *types.ClusterDefinitionV5, args Arguments, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) (string, bool, error) {
+	if def.Owner == "" {
+		def.Owner = args.DefaultOwner
+	}
+
+	// In interactive sessions, let the user pick a missing owner.
+	if def.Owner == "" && args.OutputFormat != formatting.OutputFormatJSON {
+		def.Owner, _ = picker.Organization(clientWrapper)
+	}
+
 	// Validate definition
 	if def.Owner == "" {
 		return "", true, microerror.Mask(errors.ClusterOwnerMissingError)
@@ -158,7 +168,7 @@ func addClusterV5(def *types.ClusterDefinitionV5, args Arguments, clientWrapper
 	clusterRequestBody := createAddClusterBodyV5(def)
 
 	if args.OutputFormat != formatting.OutputFormatJSON {
-		fmt.Printf("Requesting new cluster for organization '%s'\n", color.CyanString(def.Owner))
+		fmt.Fprintf(args.out(), "Requesting new cluster for organization '%s'\n", color.CyanString(def.Owner))
 	}
 
 	response, err := clientWrapper.CreateClusterV5(clusterRequestBody, auxParams)
@@ -174,20 +184,20 @@ func addClusterV5(def *types.ClusterDefinitionV5, args Arguments, clientWrapper
 			nodePoolRequestBody := createAddNodePoolBody(np)
 
 			if args.OutputFormat != formatting.OutputFormatJSON {
-				fmt.Printf("Adding node pool %d\n", i+1)
+				fmt.Fprintf(args.out(), "Adding node pool %d\n", i+1)
 			}
 
 			npResponse, err := clientWrapper.CreateNodePool(response.Payload.ID, nodePoolRequestBody, auxParams)
 			if err != nil {
-				fmt.Println(color.RedString("Error creating node pool %d: %s", i+1, err.Error()))
+				fmt.Fprintln(args.out(), color.RedString("Error creating node pool %d: %s", i+1, err.Error()))
 				hasErrors = true
 			} else if args.Verbose {
-				fmt.Println(color.WhiteString("Added node pool %d with ID %s named '%s'", i+1, npResponse.Payload.ID, npResponse.Payload.Name))
+				fmt.Fprintln(args.out(), color.WhiteString("Added node pool %d with ID %s named '%s'", i+1, npResponse.Payload.ID, npResponse.Payload.Name))
 			}
 		}
 	} else if args.CreateDefaultNodePool {
 		if args.OutputFormat != formatting.OutputFormatJSON {
-			fmt.Println("Adding a default node pool")
+			fmt.Fprintln(args.out(), "Adding a default node pool")
 		}
 
 		nodePoolRequestBody := &models.V5AddNodePoolRequest{}
@@ -200,10 +210,10 @@ func addClusterV5(def *types.ClusterDefinitionV5, args Arguments, clientWrapper
 
 		npResponse, err := clientWrapper.CreateNodePool(response.Payload.ID, nodePoolRequestBody, auxParams)
 		if err != nil {
-			fmt.Println(color.RedString("Error creating default node pool: %s", err.Error()))
+			fmt.Fprintln(args.out(), color.RedString("Error creating default node pool: %s", err.Error()))
 			hasErrors = true
 		} else if args.Verbose {
-			fmt.Println(color.WhiteString("Added default node pool with ID %s", npResponse.Payload.ID))
+			fmt.Fprintln(args.out(), color.WhiteString("Added default node pool with ID %s", npResponse.Payload.ID))
 		}
 	}
 
@@ -212,10 +222,10 @@ func addClusterV5(def *types.ClusterDefinitionV5, args Arguments, clientWrapper
 		labelsRequest := models.V5SetClusterLabelsRequest{Labels: def.Labels}
 		_, err := clientWrapper.UpdateClusterLabels(response.Payload.ID, &labelsRequest, auxParams)
 		if err != nil {
-			fmt.Println(color.RedString("Error attaching labels %s", err.Error()))
+			fmt.Fprintln(args.out(), color.RedString("Error attaching labels %s", err.Error()))
 			hasErrors = true
 		} else if args.Verbose {
-			fmt.Println(color.WhiteString("Attached labels to cluster with ID %s named '%s'", response.Payload.ID, response.Payload.Name))
+			fmt.Fprintln(args.out(), color.WhiteString("Attached labels to cluster with ID %s named '%s'", response.Payload.ID, response.Payload.Name))
 		}
 	}
 
@@ -7,14 +7,16 @@ import (
 	"github.com/giantswarm/gsctl/commands/create/keypair"
 	"github.com/giantswarm/gsctl/commands/create/kubeconfig"
 	"github.com/giantswarm/gsctl/commands/create/nodepool"
+	"github.com/giantswarm/gsctl/commands/create/organization"
+	"github.com/giantswarm/gsctl/commands/create/template"
 )
 
 var (
 	// Command is the command to create things.
 	Command = &cobra.Command{
 		Use:   "create",
-		Short: "Create clusters, key pairs, node pools",
-		Long:  `Lets you create things like clusters, key pairs or kubectl configuration files`,
+		Short: "Create clusters, key pairs, node pools, organizations, templates",
+		Long:  `Lets you create things like clusters, key pairs, organizations or kubectl configuration files`,
 	}
 )
 
@@ -23,4 +25,6 @@ func init() {
 	Command.AddCommand(keypair.Command)
 	Command.AddCommand(kubeconfig.Command)
 	Command.AddCommand(nodepool.Command)
+	Command.AddCommand(organization.Command)
+	Command.AddCommand(template.Command)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/giantswarm/gsctl/clustercache"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/nodepoolspec"
 	"github.com/giantswarm/gsctl/pkg/provider"
 )
 
@@ -129,6 +130,18 @@ Examples:
   By setting this value to '-1', the maximum price will be set
   to the on-demand price of the instance.
 
+  # Copying a node pool:
+
+  To create a node pool with the same availability zones, instance type,
+  spot instance settings and scaling as an existing node pool, possibly
+  in another cluster, use the --from flag:
+
+    gsctl create nodepool "New cluster" --from "Old cluster"/a7k9m
+
+  Only --name, --nodes-min and --nodes-max can be combined with --from,
+  to change these settings for the copy. Availability zones not available
+  in the installation are mapped to the zone with the same letter, if possible.
+
 `,
 
 		// PreRun checks a few general things, like authentication.
@@ -141,6 +154,7 @@ Examples:
 	cmdAwsEc2InstanceType   string
 	cmdAvailabilityZonesNum int
 	cmdAvailabilityZones    []string
+	cmdFrom                 string
 
 	arguments Arguments
 )
@@ -168,6 +182,7 @@ func initFlags() {
 	Command.Flags().Int64VarP(&flags.AWSSpotPercentage, "aws-spot-percentage", "", 0, "Percentage of spot instances used once the on-demand base capacity is fullfilled (AWS only). A number of 40 would mean that 60% will be on-demand and 40% will be spot instances.")
 	Command.Flags().BoolVarP(&flags.AzureSpotInstances, "azure-spot-instances", "", false, "Whether the node pool must use spot instances or on-demand.")
 	Command.Flags().Float64VarP(&flags.AzureSpotInstancesMaxPrice, "azure-spot-instances-max-price", "", -1, "Max bid hourly price for a single instance. -1 means on-demand price.")
+	Command.Flags().StringVarP(&cmdFrom, "from", "", "", "Existing node pool to copy the settings from, given as <cluster-name/cluster-id>/<nodepool-id>.")
 }
 
 // Arguments defines the arguments this command can take into consideration.
@@ -176,6 +191,7 @@ type Arguments struct {
 	AuthToken                  string
 	AvailabilityZonesList      []string
 	AvailabilityZonesNum       int
+	AvailableZones             []string
 	MaxNumOfAvailabilityZones  int
 	ClusterNameOrID            string
 	VmSize                     string
@@ -185,6 +201,8 @@ type Arguments struct {
 	SpotPercentage             int64
 	AzureSpotInstances         bool
 	AzureSpotInstancesMaxPrice float64
+	FromClusterNameOrID        string
+	FromNodePoolID             string
 	Name                       string
 	Provider                   string
 	ScalingMax                 int64
@@ -231,10 +249,22 @@ func collectArguments(cmd *cobra.Command, positionalArgs []string) (Arguments, e
 	}
 
 	var maxNumOfAZs int
+	var availableZones []string
 	{
 		if info.General.AvailabilityZones.Max != nil {
 			maxNumOfAZs = int(*info.General.AvailabilityZones.Max)
 		}
+		availableZones = info.General.AvailabilityZones.Zones
+	}
+
+	var fromClusterNameOrID, fromNodePoolID string
+	if cmdFrom != "" {
+		parts := strings.Split(cmdFrom, "/")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return Arguments{}, microerror.Mask(errors.NodePoolIDMalformedError)
+		}
+		fromClusterNameOrID = strings.TrimSpace(parts[0])
+		fromNodePoolID = strings.TrimSpace(parts[1])
 	}
 
 	return Arguments{
@@ -242,6 +272,7 @@ func collectArguments(cmd *cobra.Command, positionalArgs []string) (Arguments, e
 		AuthToken:                  token,
 		AvailabilityZonesList:      zones,
 		AvailabilityZonesNum:       cmdAvailabilityZonesNum,
+		AvailableZones:             availableZones,
 		ClusterNameOrID:            positionalArgs[0],
 		InstanceType:               flags.WorkerAwsEc2InstanceType,
 		VmSize:                     flags.WorkerAzureVMSize,
@@ -250,6 +281,8 @@ func collectArguments(cmd *cobra.Command, positionalArgs []string) (Arguments, e
 		SpotPercentage:             flags.AWSSpotPercentage,
 		AzureSpotInstances:         flags.AzureSpotInstances,
 		AzureSpotInstancesMaxPrice: flags.AzureSpotInstancesMaxPrice,
+		FromClusterNameOrID:        fromClusterNameOrID,
+		FromNodePoolID:             fromNodePoolID,
 		Name:                       flags.Name,
 		Provider:                   info.General.Provider,
 		MaxNumOfAvailabilityZones:  maxNumOfAZs,
@@ -305,6 +338,16 @@ func verifyPreconditions(args Arguments) error {
 		return microerror.Mask(errors.ClusterNameOrIDMissingError)
 	}
 
+	// When copying a node pool, only name and scaling can be changed.
+	if args.FromNodePoolID != "" {
+		if len(args.AvailabilityZonesList) > 0 || args.AvailabilityZonesNum != 0 ||
+			args.InstanceType != "" || args.VmSize != "" || args.UseAlikeInstanceTypes ||
+			args.OnDemandBaseCapacity != 0 || args.SpotPercentage != 0 ||
+			args.AzureSpotInstances || args.AzureSpotInstancesMaxPrice != -1 {
+			return microerror.Maskf(errors.ConflictingFlagsError, "the flag --from can only be combined with --name, --nodes-min and --nodes-max.")
+		}
+	}
+
 	// AZ flags plausibility
 	if len(args.AvailabilityZonesList) > 0 && args.AvailabilityZonesNum != 0 {
 		return microerror.Maskf(errors.ConflictingFlagsError, "the flags --availability-zones and --num-availability-zones cannot be combined.")
@@ -362,6 +405,9 @@ func printValidation(cmd *cobra.Command, positionalArgs []string) {
 	case IsInvalidAvailabilityZones(err):
 		headline = "Invalid availability zones"
 		subtext = strings.Replace(err.Error(), "invalid availability zones error: ", "", 1)
+	case errors.IsNodePoolIDMalformedError(err):
+		headline = "Bad format for the --from flag"
+		subtext = "Please provide cluster name/ID and node pool ID separated by a slash. See --help for examples."
 	case errors.IsConflictingFlagsError(err):
 		headline = "Conflicting flags used"
 		// Removing the 'conflicting flags error:' from the beginning
@@ -384,6 +430,58 @@ func printValidation(cmd *cobra.Command, positionalArgs []string) {
 func createNodePool(args Arguments, clusterID string, clientWrapper *client.Wrapper) (*result, error) {
 	r := &result{}
 
+	var err error
+	var requestBody *models.V5AddNodePoolRequest
+	if args.FromNodePoolID != "" {
+		requestBody, err = requestBodyFromNodePool(args, clientWrapper)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	} else {
+		requestBody = requestBodyFromArguments(args)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	if args.Verbose {
+		fmt.Println(color.WhiteString("Submitting node pool creation request"))
+		bodyJSON, _ := json.Marshal(requestBody)
+		fmt.Println(color.WhiteString("Request body: ") + string(bodyJSON))
+	}
+
+	response, err := clientWrapper.CreateNodePool(clusterID, requestBody, auxParams)
+
+	if err != nil {
+		// return specific error types for the cases we care about most.
+		if clienterror.IsAccessForbiddenError(err) {
+			return nil, microerror.Mask(errors.AccessForbiddenError)
+		}
+		if clienterror.IsNotFoundError(err) {
+			return nil, microerror.Mask(errors.ClusterNotFoundError)
+		}
+		if clienterror.IsBadRequestError(err) {
+			return nil, microerror.Maskf(errors.BadRequestError, err.Error())
+		}
+		if clienterror.IsInternalServerError(err) {
+			return nil, microerror.Maskf(errors.InternalServerError, err.Error())
+		}
+
+		return r, microerror.Mask(err)
+	}
+
+	r.nodePoolID = response.Payload.ID
+	r.nodePoolName = response.Payload.Name
+	r.availabilityZonesList = response.Payload.AvailabilityZones
+
+	// The cached node pool IDs are outdated now.
+	clustercache.InvalidateNodePools(args.APIEndpoint, clusterID)
+
+	return r, nil
+}
+
+// requestBodyFromArguments creates the creation request based on the flags given.
+func requestBodyFromArguments(args Arguments) *models.V5AddNodePoolRequest {
 	requestBody := &models.V5AddNodePoolRequest{
 		Name: args.Name,
 	}
@@ -441,40 +539,58 @@ func createNodePool(args Arguments, clusterID string, clientWrapper *client.Wrap
 		}
 	}
 
+	return requestBody
+}
+
+// requestBodyFromNodePool creates the creation request based on an existing
+// node pool, applying the name and scaling flags if given.
+func requestBodyFromNodePool(args Arguments, clientWrapper *client.Wrapper) (*models.V5AddNodePoolRequest, error) {
+	sourceClusterID, err := clustercache.GetID(args.APIEndpoint, args.FromClusterNameOrID, clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
 	auxParams := clientWrapper.DefaultAuxiliaryParams()
 	auxParams.ActivityName = activityName
 
 	if args.Verbose {
-		fmt.Println(color.WhiteString("Submitting node pool creation request"))
-		bodyJSON, _ := json.Marshal(requestBody)
-		fmt.Println(color.WhiteString("Request body: ") + string(bodyJSON))
+		fmt.Println(color.WhiteString("Fetching node pool %s/%s to copy", sourceClusterID, args.FromNodePoolID))
 	}
 
-	response, err := clientWrapper.CreateNodePool(clusterID, requestBody, auxParams)
-
+	response, err := clientWrapper.GetNodePool(sourceClusterID, args.FromNodePoolID, auxParams)
 	if err != nil {
-		// return specific error types for the cases we care about most.
-		if clienterror.IsAccessForbiddenError(err) {
-			return nil, microerror.Mask(errors.AccessForbiddenError)
-		}
 		if clienterror.IsNotFoundError(err) {
-			return nil, microerror.Mask(errors.ClusterNotFoundError)
-		}
-		if clienterror.IsBadRequestError(err) {
-			return nil, microerror.Maskf(errors.BadRequestError, err.Error())
-		}
-		if clienterror.IsInternalServerError(err) {
-			return nil, microerror.Maskf(errors.InternalServerError, err.Error())
+			return nil, microerror.Mask(errors.NodePoolNotFoundError)
 		}
 
-		return r, microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
-	r.nodePoolID = response.Payload.ID
-	r.nodePoolName = response.Payload.Name
-	r.availabilityZonesList = response.Payload.AvailabilityZones
+	requestBody := nodepoolspec.AddRequestFromNodePool(response.Payload)
 
-	return r, nil
+	if requestBody.AvailabilityZones != nil {
+		requestBody.AvailabilityZones.Zones, err = nodepoolspec.MapAvailabilityZones(requestBody.AvailabilityZones.Zones, args.AvailableZones)
+		if err != nil {
+			return nil, microerror.Maskf(invalidAvailabilityZonesError, err.Error())
+		}
+	}
+
+	if args.Name != "" {
+		requestBody.Name = args.Name
+	}
+	if args.ScalingMinSet || args.ScalingMax != 0 {
+		if requestBody.Scaling == nil {
+			requestBody.Scaling = &models.V5AddNodePoolRequestScaling{}
+		}
+		if args.ScalingMinSet {
+			requestBody.Scaling.Min = &args.ScalingMin
+		}
+		if args.ScalingMax != 0 {
+			requestBody.Scaling.Max = args.ScalingMax
+		}
+	}
+
+	return requestBody, nil
 }
 
 func printResult(cmd *cobra.Command, positionalArgs []string) {
@@ -1,9 +1,11 @@
 package nodepool
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/giantswarm/microerror"
@@ -367,6 +369,90 @@ func TestSuccess(t *testing.T) {
 	}
 }
 
+// TestCreateFromNodePool tests copying an existing node pool, including
+// mapping its availability zones to the installation's region.
+func TestCreateFromNodePool(t *testing.T) {
+	var requestBody []byte
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "cluster-id", "name": "New cluster", "owner": "acme"},
+				{"id": "old-id", "name": "Old cluster", "owner": "acme"}
+			]`))
+		case r.Method == "GET" && r.URL.Path == "/v5/clusters/old-id/nodepools/a7k/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "a7k",
+				"name": "Batch jobs",
+				"availability_zones": ["eu-west-1a", "eu-west-1c"],
+				"scaling": {"min": 2, "max": 8},
+				"node_spec": {"aws": {"instance_type": "c5.xlarge", "use_alike_instance_types": true, "instance_distribution": {"on_demand_base_capacity": 1, "on_demand_percentage_above_base_capacity": 30}}}
+			}`))
+		case r.Method == "POST" && r.URL.Path == "/v5/clusters/cluster-id/nodepools/":
+			requestBody, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "m0ckr", "name": "Batch jobs"}`))
+		default:
+			t.Errorf("Unsupported route %s %s called in mock server", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		APIEndpoint:                mockServer.URL,
+		AuthToken:                  "token",
+		AvailableZones:             []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+		AzureSpotInstancesMaxPrice: -1,
+		ClusterNameOrID:            "cluster-id",
+		FromClusterNameOrID:        "Old cluster",
+		FromNodePoolID:             "a7k",
+		ScalingMax:                 12,
+		Provider:                   "aws",
+	}
+
+	err = verifyPreconditions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error '%s'", err)
+	}
+
+	clientWrapper, err := client.NewWithConfig(args.APIEndpoint, args.UserProvidedToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := createNodePool(args, args.ClusterNameOrID, clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error '%s'", err)
+	}
+	if r.nodePoolID != "m0ckr" {
+		t.Errorf("Expected ID %q, got %q", "m0ckr", r.nodePoolID)
+	}
+
+	expected := `{"availability_zones":{"zones":["eu-central-1a","eu-central-1c"]},"name":"Batch jobs","node_spec":{"aws":{"instance_distribution":{"on_demand_base_capacity":1,"on_demand_percentage_above_base_capacity":30},"instance_type":"c5.xlarge","use_alike_instance_types":true}},"scaling":{"max":12,"min":2}}`
+	if strings.TrimSpace(string(requestBody)) != expected {
+		t.Errorf("Request body not as expected.\nExpected: %s\nGot:      %s", expected, string(requestBody))
+	}
+
+	// A zone letter which doesn't exist in the target region.
+	args.AvailableZones = []string{"eu-central-1a", "eu-central-1b"}
+	_, err = createNodePool(args, args.ClusterNameOrID, clientWrapper)
+	if !IsInvalidAvailabilityZones(err) {
+		t.Errorf("Expected invalidAvailabilityZonesError, got %v", err)
+	}
+}
+
 // TestVerifyPreconditions tests cases where validating preconditions fails.
 func TestVerifyPreconditions(t *testing.T) {
 	var testCases = []struct {
@@ -442,6 +528,20 @@ func TestVerifyPreconditions(t *testing.T) {
 			},
 			errors.IsWorkersMinMaxInvalid,
 		},
+		// Copying a node pool, but setting the instance type.
+		{
+			Arguments{
+				AuthToken:                  "token",
+				APIEndpoint:                "https://mock-url",
+				ClusterNameOrID:            "cluster-id",
+				FromClusterNameOrID:        "other-cluster-id",
+				FromNodePoolID:             "a7k",
+				InstanceType:               "something-big",
+				AzureSpotInstancesMaxPrice: -1,
+				Provider:                   "aws",
+			},
+			errors.IsConflictingFlagsError,
+		},
 	}
 
 	fs := afero.NewMemMapFs()
@@ -0,0 +1,243 @@
+// Package organization implements the 'create organization' sub-command.
+package organization
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+)
+
+const (
+	createOrganizationActivityName = "create-organization"
+)
+
+var (
+	// Command performs the "create organization" function
+	Command = &cobra.Command{
+		Use:     "organization <organization-id>",
+		Aliases: []string{"org", "organisation"},
+		Short:   "Create an organization",
+		Long: `Creates a new organization.
+
+The organization ID must be unique within the installation. You can then
+add members using 'gsctl update organization add-member'.
+
+Examples:
+
+  gsctl create organization acme
+
+  gsctl create organization acme --output json
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for creating an organization.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	organizationID    string
+	outputFormat      string
+	scheme            string
+	userProvidedToken string
+	verbose           bool
+}
+
+// JSONOutput contains the fields included in JSON output of the create organization command when called with json output flag
+type JSONOutput struct {
+	// ID of the organization
+	ID string `json:"id,omitempty"`
+	// Result of the command. should be 'created'
+	Result string `json:"result"`
+	// Error which occured
+	Error error `json:"error,omitempty"`
+}
+
+func init() {
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "", "", fmt.Sprintf("Output format. Specifying '%s' will change output to be JSON formatted.", formatting.OutputFormatJSON))
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	organizationID := ""
+	if len(positionalArgs) > 0 {
+		organizationID = positionalArgs[0]
+	}
+
+	// cobra sets defaults from other commands to the OutputFormat flag
+	// but we don't have "table" here, so if it's "table", set it to empty string
+	if flags.OutputFormat == formatting.OutputFormatTable {
+		flags.OutputFormat = ""
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		organizationID:    organizationID,
+		outputFormat:      flags.OutputFormat,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+		verbose:           flags.OutputFormat != formatting.OutputFormatJSON && flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments(cmdLineArgs)
+	err := verifyPreconditions(arguments)
+
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotSpecifiedError(err):
+		headline = "No organization ID given"
+		subtext = "Please specify the ID of the organization to create as a positional argument. See --help for details."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	os.Exit(1)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.organizationID == "" {
+		return microerror.Mask(errors.OrganizationNotSpecifiedError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.outputFormat != "" && args.outputFormat != formatting.OutputFormatJSON {
+		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is invalid for gsctl create organization. Valid options: '%s'", args.outputFormat, formatting.OutputFormatJSON))
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	err := createOrganization(arguments)
+
+	if arguments.outputFormat == formatting.OutputFormatJSON {
+		printJSONOutput(arguments.organizationID, err)
+		return
+	}
+
+	if err != nil {
+		client.HandleErrors(err)
+		errors.HandleCommonErrors(err)
+
+		headline := ""
+		subtext := ""
+
+		switch {
+		case errors.IsOrganizationAlreadyExistsError(err):
+			headline = fmt.Sprintf("Organization '%s' already exists", arguments.organizationID)
+			subtext = "Please choose a different organization ID."
+		default:
+			headline = err.Error()
+		}
+
+		fmt.Println(color.RedString(headline))
+		if subtext != "" {
+			fmt.Println(subtext)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("Organization '%s' has been created.", arguments.organizationID))
+	fmt.Printf("Add members using 'gsctl update organization add-member -o %s --email <email>'.\n", arguments.organizationID)
+}
+
+func printJSONOutput(organizationID string, creationErr error) {
+	var jsonResult JSONOutput
+
+	if creationErr != nil {
+		jsonResult = JSONOutput{Result: "error", Error: creationErr}
+	} else {
+		jsonResult = JSONOutput{ID: organizationID, Result: "created"}
+	}
+
+	outputBytes, err := json.MarshalIndent(jsonResult, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(outputBytes))
+	if creationErr != nil {
+		os.Exit(1)
+	}
+}
+
+// createOrganization performs the API call to create the organization.
+func createOrganization(args Arguments) error {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = createOrganizationActivityName
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Sending API request to create organization"))
+	}
+
+	organization := &models.V4Organization{
+		ID:      args.organizationID,
+		Members: []*models.V4OrganizationMembersItems{},
+	}
+
+	_, err = clientWrapper.CreateOrganization(args.organizationID, organization, auxParams)
+	if err != nil {
+		if clienterror.IsUnauthorizedError(err) {
+			return microerror.Mask(errors.NotAuthorizedError)
+		}
+		if clienterror.IsAccessForbiddenError(err) {
+			return microerror.Mask(errors.AccessForbiddenError)
+		}
+		if clienterror.IsConflictError(err) {
+			return microerror.Mask(errors.OrganizationAlreadyExistsError)
+		}
+
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package organization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests argument validation.
+func Test_verifyPreconditions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{
+			Arguments{apiEndpoint: "https://api.example.com", authToken: "token", organizationID: "acme"},
+			nil,
+		},
+		{
+			Arguments{apiEndpoint: "https://api.example.com", authToken: "token", organizationID: "acme", outputFormat: "json"},
+			nil,
+		},
+		{
+			Arguments{authToken: "token", organizationID: "acme"},
+			errors.IsEndpointMissingError,
+		},
+		{
+			Arguments{apiEndpoint: "https://api.example.com", authToken: "token"},
+			errors.IsOrganizationNotSpecifiedError,
+		},
+		{
+			Arguments{apiEndpoint: "https://api.example.com", organizationID: "acme"},
+			errors.IsNotLoggedInError,
+		},
+		{
+			Arguments{apiEndpoint: "https://api.example.com", authToken: "token", organizationID: "acme", outputFormat: "yaml"},
+			errors.IsOutputFormatInvalid,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			err := verifyPreconditions(tc.args)
+			if tc.errorMatcher == nil {
+				if err != nil {
+					t.Errorf("Case %d - Unexpected error: %s", i, err)
+				}
+			} else if !tc.errorMatcher(err) {
+				t.Errorf("Case %d - Error did not match expected type. Got '%v'", i, err)
+			}
+		})
+	}
+}
+
+// Test_createOrganization tests the API call and error mapping.
+func Test_createOrganization(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPut && r.URL.Path == "/v4/organizations/acme/" {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "acme", "members": []}`))
+		} else if r.Method == http.MethodPut && r.URL.Path == "/v4/organizations/existing/" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"code": "RESOURCE_ALREADY_EXISTS", "message": "Organization already exists"}`))
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = createOrganization(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme"})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err = createOrganization(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "existing"})
+	if !errors.IsOrganizationAlreadyExistsError(err) {
+		t.Errorf("Expected OrganizationAlreadyExistsError, got '%v'", err)
+	}
+}
@@ -0,0 +1,176 @@
+// Package template implements the 'create template' sub-command.
+package template
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+const (
+	standardInputSpecialPath = "-"
+)
+
+var (
+	// Command performs the "create template" function
+	Command = &cobra.Command{
+		Use:   "template <name>",
+		Short: "Store a cluster definition template",
+		Long: `Stores a cluster definition template under a name, so that it can be used
+with 'gsctl create cluster --template <name>'.
+
+A template is a cluster definition YAML file which can use Go template
+syntax to reference values, e. g. '{{ .owner }}'. Values are given when
+creating a cluster, via --values and --set. See 'gsctl create cluster --help'
+for details.
+
+Templates are stored in the 'templates' directory within the gsctl
+configuration directory.
+
+Examples:
+
+  gsctl create template dev --file ./dev-cluster-template.yaml
+
+  cat template.yaml | gsctl create template dev --file - --force
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for storing a template.
+type Arguments struct {
+	FileSystem    afero.Fs
+	Force         bool
+	InputYAMLFile string
+	Name          string
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().StringVarP(&flags.InputYAMLFile, "file", "f", "", "Path to the template file. Use '-' to read from STDIN.")
+	Command.Flags().BoolVarP(&flags.Force, "force", "", false, "If set, an existing template with the same name will be replaced.")
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	name := ""
+	if len(positionalArgs) > 0 {
+		name = positionalArgs[0]
+	}
+
+	return Arguments{
+		FileSystem:    config.FileSystem,
+		Force:         flags.Force,
+		InputYAMLFile: flags.InputYAMLFile,
+		Name:          name,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.Name == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "template name")
+	}
+	if args.InputYAMLFile == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "--file")
+	}
+
+	err := clustertemplate.ValidateName(args.Name)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	err := storeTemplate(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("Template '%s' has been stored.", arguments.Name))
+	fmt.Printf("Use it with 'gsctl create cluster --template %s'.\n", arguments.Name)
+}
+
+func handleError(err error) {
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "Missing argument"
+		subtext = "Please specify the template name as a positional argument and the template file via --file."
+	case clustertemplate.IsInvalidName(err):
+		headline = "Invalid template name"
+		subtext = "Template names may only contain letters, digits, '.', '_' and '-', and must start with a letter or digit."
+	case clustertemplate.IsAlreadyExists(err):
+		headline = "Template already exists"
+		subtext = fmt.Sprintf("There already is a template named '%s'. Use --force to replace it.", arguments.Name)
+	case clustertemplate.IsInvalidTemplate(err):
+		headline = "Invalid template"
+		subtext = fmt.Sprintf("The file could not be parsed as a template.\nDetails: %s", err.Error())
+	case errors.IsYAMLFileNotReadable(err):
+		headline = "Could not read template file"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// storeTemplate reads the template file and stores its content.
+func storeTemplate(args Arguments) error {
+	var content []byte
+	var err error
+
+	if args.InputYAMLFile == standardInputSpecialPath {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = afero.ReadFile(args.FileSystem, args.InputYAMLFile)
+	}
+	if err != nil {
+		return microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+	}
+
+	err = clustertemplate.Store(args.FileSystem, args.Name, content, args.Force)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
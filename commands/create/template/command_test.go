@@ -0,0 +1,70 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_verifyPreconditions tests the required arguments.
+func Test_verifyPreconditions(t *testing.T) {
+	testCases := []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{Arguments{Name: "dev", InputYAMLFile: "template.yaml"}, nil},
+		{Arguments{InputYAMLFile: "template.yaml"}, errors.IsRequiredFlagMissingError},
+		{Arguments{Name: "dev"}, errors.IsRequiredFlagMissingError},
+		{Arguments{Name: "dev/prod", InputYAMLFile: "template.yaml"}, clustertemplate.IsInvalidName},
+	}
+
+	for i, tc := range testCases {
+		err := verifyPreconditions(tc.args)
+		if tc.errorMatcher == nil && err != nil {
+			t.Errorf("Case %d - Unexpected error: %s", i, err)
+		} else if tc.errorMatcher != nil && !tc.errorMatcher(err) {
+			t.Errorf("Case %d - Error not matching expectation, got %v", i, err)
+		}
+	}
+}
+
+// Test_storeTemplate tests storing a template from a file.
+func Test_storeTemplate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = afero.WriteFile(fs, "/template.yaml", []byte("owner: {{ .owner }}\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{FileSystem: fs, Name: "dev", InputYAMLFile: "/template.yaml"}
+	err = storeTemplate(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = storeTemplate(args)
+	if !clustertemplate.IsAlreadyExists(err) {
+		t.Errorf("Expected alreadyExistsError, got %v", err)
+	}
+
+	args.Force = true
+	err = storeTemplate(args)
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	args.InputYAMLFile = "/does-not-exist.yaml"
+	err = storeTemplate(args)
+	if !errors.IsYAMLFileNotReadable(err) {
+		t.Errorf("Expected YAMLFileNotReadableError, got %v", err)
+	}
+}
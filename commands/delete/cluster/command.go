@@ -18,6 +18,7 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/confirm"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/picker"
 )
 
 // Arguments represents all argument that can be passed to our
@@ -119,6 +120,17 @@ func printValidation(cmd *cobra.Command, args []string) {
 	arguments = collectArguments(args)
 
 	err := validatePreconditions(arguments)
+	if errors.IsClusterNameOrIDMissingError(err) && !arguments.force && arguments.outputFormat == "" {
+		// In interactive sessions, let the user pick a cluster. As picking
+		// requires to be logged in, and the deletion still has to be
+		// confirmed, we skip this when forced or in JSON output mode.
+		if config.Config.Token != "" || arguments.token != "" {
+			if clusterID := picker.MissingCluster(arguments.apiEndpoint, arguments.userProvidedToken); clusterID != "" {
+				arguments.clusterNameOrID = clusterID
+				err = validatePreconditions(arguments)
+			}
+		}
+	}
 	if err != nil {
 		client.HandleErrors(err)
 		errors.HandleCommonErrors(err)
@@ -295,5 +307,8 @@ func deleteCluster(args Arguments) (bool, error) {
 		return false, microerror.Maskf(errors.CouldNotDeleteClusterError, err.Error())
 	}
 
+	// The cached cluster list is outdated now.
+	clustercache.Invalidate(args.apiEndpoint)
+
 	return true, nil
 }
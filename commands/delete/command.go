@@ -6,6 +6,8 @@ import (
 	"github.com/giantswarm/gsctl/commands/delete/cluster"
 	"github.com/giantswarm/gsctl/commands/delete/endpoint"
 	"github.com/giantswarm/gsctl/commands/delete/nodepool"
+	"github.com/giantswarm/gsctl/commands/delete/organization"
+	"github.com/giantswarm/gsctl/commands/delete/template"
 )
 
 var (
@@ -13,7 +15,7 @@ var (
 	Command = &cobra.Command{
 		Use:   "delete",
 		Short: "Delete things",
-		Long:  `Lets you delete a cluster, a node pool, or an API endpoint`,
+		Long:  `Lets you delete a cluster, a node pool, an organization, an API endpoint, or a cluster definition template`,
 	}
 )
 
@@ -21,4 +23,6 @@ func init() {
 	Command.AddCommand(cluster.Command)
 	Command.AddCommand(nodepool.Command)
 	Command.AddCommand(endpoint.Command)
+	Command.AddCommand(organization.Command)
+	Command.AddCommand(template.Command)
 }
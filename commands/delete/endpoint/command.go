@@ -14,6 +14,7 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/confirm"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 )
 
 // Arguments represents all argument that can be passed to our
@@ -167,7 +168,9 @@ func deleteEndpoint(args Arguments) (bool, error) {
 	}
 
 	// Delete Endpoint
-	err := config.Config.DeleteEndpoint(args.APIEndpoint)
+	err := ssotoken.UpdateConfig(func() error {
+		return config.Config.DeleteEndpoint(args.APIEndpoint)
+	})
 	if err != nil {
 		if config.IsEndpointNotDefinedError(err) {
 			return false, microerror.Mask(errors.EndpointNotFoundError)
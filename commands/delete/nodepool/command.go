@@ -211,6 +211,9 @@ func deleteNodePool(args *Arguments) (bool, error) {
 		return false, microerror.Mask(err)
 	}
 
+	// The cached node pool IDs are outdated now.
+	clustercache.InvalidateNodePools(args.APIEndpoint, clusterID)
+
 	return true, nil
 }
 
@@ -0,0 +1,251 @@
+// Package organization implements the 'delete organization' sub-command.
+package organization
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+)
+
+const (
+	deleteOrganizationActivityName = "delete-organization"
+)
+
+var (
+	// Command performs the "delete organization" function
+	Command = &cobra.Command{
+		Use:     "organization <organization-id>",
+		Aliases: []string{"org", "organisation"},
+		Short:   "Delete an organization",
+		Long: `Deletes an organization.
+
+Organizations that still own clusters cannot be deleted. Please delete the
+clusters first.
+
+Examples:
+
+  gsctl delete organization acme
+
+  gsctl delete organization acme --force
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments represents all argument that can be passed to our
+// business function.
+type Arguments struct {
+	apiEndpoint string
+	authToken   string
+	// don't prompt
+	force             bool
+	organizationID    string
+	outputFormat      string
+	scheme            string
+	userProvidedToken string
+	verbose           bool
+}
+
+// JSONOutput contains the fields included in JSON output of the delete organization command when called with json output flag
+type JSONOutput struct {
+	// Result of the command. should be 'deleted'
+	Result string `json:"result"`
+	// ID of the organization
+	ID string `json:"id"`
+	// Error which occured
+	Error error `json:"error,omitempty"`
+}
+
+func init() {
+	Command.Flags().BoolVarP(&flags.Force, "force", "", false, "If set, no interactive confirmation will be required (risky!).")
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "", "", fmt.Sprintf("Output format. Specifying '%s' will change output to be JSON formatted. It also disables any confirmations.", formatting.OutputFormatJSON))
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	organizationID := ""
+	if len(positionalArgs) > 0 {
+		organizationID = positionalArgs[0]
+	}
+
+	// cobra sets defaults from other commands to the OutputFormat flag
+	// but we don't have "table" here, so if it's "table", set it to empty string
+	if flags.OutputFormat == formatting.OutputFormatTable {
+		flags.OutputFormat = ""
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		force:             flags.Force,
+		organizationID:    organizationID,
+		outputFormat:      flags.OutputFormat,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments(cmdLineArgs)
+	err := verifyPreconditions(arguments)
+
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotSpecifiedError(err):
+		headline = "No organization ID given"
+		subtext = "Please specify the ID of the organization to delete as a positional argument. See --help for details."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	os.Exit(1)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.organizationID == "" {
+		return microerror.Mask(errors.OrganizationNotSpecifiedError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.outputFormat != "" && args.outputFormat != formatting.OutputFormatJSON {
+		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is invalid for gsctl delete organization. Valid options: '%s'", args.outputFormat, formatting.OutputFormatJSON))
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	deleted, err := deleteOrganization(arguments)
+
+	if arguments.outputFormat == formatting.OutputFormatJSON {
+		printJSONOutput(arguments.organizationID, err)
+		return
+	}
+
+	if err != nil {
+		client.HandleErrors(err)
+		errors.HandleCommonErrors(err)
+
+		headline := ""
+		subtext := ""
+
+		switch {
+		case errors.IsOrganizationNotFoundError(err):
+			headline = fmt.Sprintf("Organization '%s' not found", arguments.organizationID)
+			subtext = "The organization you tried to delete doesn't seem to exist. Check 'gsctl list organizations' to make sure."
+		case clienterror.IsConflictError(err):
+			headline = fmt.Sprintf("Organization '%s' cannot be deleted", arguments.organizationID)
+			subtext = "The organization still owns clusters. Please delete them first."
+		default:
+			headline = err.Error()
+		}
+
+		fmt.Println(color.RedString(headline))
+		if subtext != "" {
+			fmt.Println(subtext)
+		}
+		os.Exit(1)
+	}
+
+	if deleted {
+		fmt.Println(color.GreenString("Organization '%s' has been deleted.", arguments.organizationID))
+	} else if arguments.verbose {
+		fmt.Println(color.GreenString("Aborted."))
+	}
+}
+
+func printJSONOutput(organizationID string, deletionErr error) {
+	var jsonResult JSONOutput
+
+	if deletionErr != nil {
+		jsonResult = JSONOutput{Result: "error", Error: deletionErr}
+	} else {
+		jsonResult = JSONOutput{Result: "deleted", ID: organizationID}
+	}
+
+	outputBytes, err := json.MarshalIndent(jsonResult, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(outputBytes))
+	if deletionErr != nil {
+		os.Exit(1)
+	}
+}
+
+// deleteOrganization asks for confirmation, if required, and performs the
+// deletion API call. It returns true if the organization has been deleted.
+func deleteOrganization(args Arguments) (bool, error) {
+	if !args.force && args.outputFormat != formatting.OutputFormatJSON {
+		confirmed := confirm.AskStrict(
+			fmt.Sprintf("Do you really want to delete organization '%s'? Please type '%s' to confirm", args.organizationID, args.organizationID),
+			args.organizationID,
+		)
+		if !confirmed {
+			return false, nil
+		}
+	}
+
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = deleteOrganizationActivityName
+
+	_, err = clientWrapper.DeleteOrganization(args.organizationID, auxParams)
+	if err != nil {
+		if clienterror.IsUnauthorizedError(err) {
+			return false, microerror.Mask(errors.NotAuthorizedError)
+		}
+		if clienterror.IsAccessForbiddenError(err) {
+			return false, microerror.Mask(errors.AccessForbiddenError)
+		}
+		if clienterror.IsNotFoundError(err) {
+			return false, microerror.Mask(errors.OrganizationNotFoundError)
+		}
+
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
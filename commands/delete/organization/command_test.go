@@ -0,0 +1,56 @@
+package organization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_deleteOrganization tests the API call and error mapping.
+func Test_deleteOrganization(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodDelete && r.URL.Path == "/v4/organizations/acme/" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"code": "RESOURCE_DELETED", "message": "The organization has been deleted"}`))
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "The organization could not be found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := deleteOrganization(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme", force: true})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if !deleted {
+		t.Error("Expected organization to be deleted")
+	}
+
+	_, err = deleteOrganization(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "unknown", force: true})
+	if !errors.IsOrganizationNotFoundError(err) {
+		t.Errorf("Expected OrganizationNotFoundError, got '%v'", err)
+	}
+}
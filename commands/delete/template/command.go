@@ -0,0 +1,142 @@
+// Package template implements the 'delete template' sub-command.
+package template
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+var (
+	// Command performs the "delete template" function
+	Command = &cobra.Command{
+		Use:   "template <name>",
+		Short: "Delete a cluster definition template",
+		Long: `Deletes a stored cluster definition template.
+
+Example:
+
+  gsctl delete template dev
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for deleting a template.
+type Arguments struct {
+	FileSystem afero.Fs
+	Force      bool
+	Name       string
+	Verbose    bool
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().BoolVarP(&flags.Force, "force", "", false, "If set, no interactive confirmation will be required.")
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	name := ""
+	if len(positionalArgs) > 0 {
+		name = positionalArgs[0]
+	}
+
+	return Arguments{
+		FileSystem: config.FileSystem,
+		Force:      flags.Force,
+		Name:       name,
+		Verbose:    flags.Verbose,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.Name == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "template name")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	deleted, err := deleteTemplate(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	if deleted {
+		fmt.Println(color.GreenString("Template '%s' has been deleted.", arguments.Name))
+	} else if arguments.Verbose {
+		fmt.Println(color.GreenString("Aborted."))
+	}
+}
+
+func handleError(err error) {
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "No template name specified"
+		subtext = "Please specify the name of the template to delete as a positional argument."
+	case clustertemplate.IsNotFound(err), clustertemplate.IsInvalidName(err):
+		headline = "Template not found"
+		subtext = fmt.Sprintf("There is no template named '%s'. Use 'gsctl list templates' to list all stored templates.", arguments.Name)
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// deleteTemplate removes the template after confirmation. It returns
+// false if the user did not confirm.
+func deleteTemplate(args Arguments) (bool, error) {
+	if !args.Force {
+		confirmed := confirm.Ask(fmt.Sprintf("Do you really want to delete template '%s'?", args.Name))
+		if !confirmed {
+			return false, nil
+		}
+	}
+
+	err := clustertemplate.Delete(args.FileSystem, args.Name)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
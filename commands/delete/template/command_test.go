@@ -0,0 +1,37 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_deleteTemplate tests deleting a stored template.
+func Test_deleteTemplate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = clustertemplate.Store(fs, "dev", []byte("owner: {{ .owner }}\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := deleteTemplate(Arguments{FileSystem: fs, Name: "dev", Force: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !deleted {
+		t.Error("Expected template to be deleted")
+	}
+
+	_, err = deleteTemplate(Arguments{FileSystem: fs, Name: "dev", Force: true})
+	if !clustertemplate.IsNotFound(err) {
+		t.Errorf("Expected notFoundError, got %v", err)
+	}
+}
@@ -0,0 +1,440 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	rootcerts "github.com/hashicorp/go-rootcerts"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/version"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
+)
+
+const (
+	statusPass = "pass"
+	statusWarn = "warn"
+	statusFail = "fail"
+
+	// requestTimeout limits DNS lookups and HTTP requests.
+	requestTimeout = 5 * time.Second
+
+	// maxClockSkew is the clock difference to the API we warn about.
+	maxClockSkew = time.Minute
+
+	// certExpiryWarning is how long before expiry we warn about the
+	// endpoint's certificate.
+	certExpiryWarning = 14 * 24 * time.Hour
+)
+
+var (
+	// getenv and lookPath can be replaced in tests.
+	getenv   = os.Getenv
+	lookPath = exec.LookPath
+
+	proxyVariables = []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY"}
+)
+
+// checkResult is the outcome of a single check.
+type checkResult struct {
+	name    string
+	status  string
+	message string
+	hint    string
+}
+
+func pass(name, message string) checkResult {
+	return checkResult{name: name, status: statusPass, message: message}
+}
+
+func warn(name, message, hint string) checkResult {
+	return checkResult{name: name, status: statusWarn, message: message, hint: hint}
+}
+
+func fail(name, message, hint string) checkResult {
+	return checkResult{name: name, status: statusFail, message: message, hint: hint}
+}
+
+// probe is the result of a request to the API endpoint, shared by
+// several checks.
+type probe struct {
+	response *http.Response
+	// requestTime is the local time in the middle of the request.
+	requestTime time.Time
+	err         error
+	// caErr is set if the CA certificates could not be loaded.
+	caErr error
+}
+
+// runChecks runs all checks in order and returns their results.
+func runChecks(args Arguments) []checkResult {
+	results := []checkResult{
+		checkConfigFilePermissions(args),
+		checkCertsDir(args),
+		checkProxy(args),
+	}
+
+	if args.apiEndpoint == "" {
+		results = append(results, fail("API endpoint", "No endpoint selected", "Select an endpoint using 'gsctl select endpoint' or log in using 'gsctl login'."))
+	} else {
+		results = append(results, checkDNS(args))
+
+		p := probeEndpoint(args)
+		results = append(results, checkTLS(args, p), checkClockSkew(p))
+	}
+
+	results = append(results,
+		checkToken(args),
+		checkBinary("kubectl", "Install kubectl to use the kubeconfigs created by 'gsctl create kubeconfig'."),
+		checkBinary("kubie", "Optional. Install kubie to use 'gsctl create kubeconfig --kubie'."),
+		checkVersion(args),
+	)
+
+	return results
+}
+
+// checkConfigFilePermissions makes sure that the config file, which holds
+// auth tokens, is only accessible by the user.
+func checkConfigFilePermissions(args Arguments) checkResult {
+	name := "Config file"
+
+	info, err := args.fileSystem.Stat(config.ConfigFilePath)
+	if os.IsNotExist(err) {
+		return warn(name, fmt.Sprintf("%s does not exist yet", config.ConfigFilePath), "It gets created when you log in using 'gsctl login'.")
+	} else if err != nil {
+		return fail(name, err.Error(), "Make sure the configuration directory is readable.")
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		return warn(name, fmt.Sprintf("%s has permissions %s and is accessible by other users", config.ConfigFilePath, info.Mode().Perm()),
+			fmt.Sprintf("The file contains auth tokens. Restrict access using 'chmod %o %s'.", config.ConfigFilePermission, config.ConfigFilePath))
+	}
+
+	return pass(name, fmt.Sprintf("%s is only accessible by you", config.ConfigFilePath))
+}
+
+// checkCertsDir makes sure that key pairs can be stored in the certs
+// directory, or in the config directory if the former doesn't exist yet.
+func checkCertsDir(args Arguments) checkResult {
+	name := "Certificates directory"
+
+	dir := config.CertsDirPath
+	if exists, _ := afero.DirExists(args.fileSystem, dir); !exists {
+		dir = config.ConfigDirPath
+	}
+
+	file, err := afero.TempFile(args.fileSystem, dir, ".doctor")
+	if err != nil {
+		return fail(name, fmt.Sprintf("%s is not writable", dir), fmt.Sprintf("Make sure that you have write permissions for %s.", dir))
+	}
+	file.Close()
+	args.fileSystem.Remove(file.Name())
+
+	return pass(name, fmt.Sprintf("%s is writable", dir))
+}
+
+// checkProxy reports the proxy settings from the environment and makes sure
+// the proxy URLs are valid.
+func checkProxy(args Arguments) checkResult {
+	name := "Proxy"
+
+	settings := []string{}
+	for _, variable := range proxyVariables {
+		value := getenv(variable)
+		if value == "" {
+			value = getenv(strings.ToLower(variable))
+		}
+		if value == "" {
+			continue
+		}
+
+		if variable != "NO_PROXY" {
+			u, err := url.Parse(value)
+			if err != nil || u.Host == "" {
+				return fail(name, fmt.Sprintf("%s is not a valid URL: %q", variable, value), fmt.Sprintf("Set %s to a URL like 'http://proxy.example.com:3128'.", variable))
+			}
+		}
+
+		settings = append(settings, variable+"="+value)
+	}
+
+	if len(settings) == 0 {
+		return pass(name, "No proxy configured")
+	}
+
+	message := strings.Join(settings, ", ")
+	if args.apiEndpoint != "" {
+		if u, err := url.Parse(args.apiEndpoint); err == nil && bypassesProxy(u.Hostname()) {
+			message += fmt.Sprintf(" (%s is not proxied)", u.Hostname())
+		}
+	}
+
+	return pass(name, message)
+}
+
+// bypassesProxy returns true if the host is matched by NO_PROXY.
+func bypassesProxy(host string) bool {
+	noProxy := getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = getenv("no_proxy")
+	}
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimPrefix(strings.TrimSpace(entry), ".")
+		if entry == "" {
+			continue
+		}
+		if entry == "*" || host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkDNS resolves the endpoint's host name.
+func checkDNS(args Arguments) checkResult {
+	name := "DNS"
+
+	u, err := url.Parse(args.apiEndpoint)
+	if err != nil {
+		return fail(name, fmt.Sprintf("Invalid endpoint URL %s", args.apiEndpoint), "Check the endpoint using 'gsctl list endpoints'.")
+	}
+
+	host := u.Hostname()
+	if net.ParseIP(host) != nil {
+		return pass(name, fmt.Sprintf("%s is an IP address", host))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return fail(name, fmt.Sprintf("%s could not be resolved: %s", host, err), "Check your network and DNS configuration. If you need a VPN to reach the API, make sure it is connected.")
+	}
+
+	return pass(name, fmt.Sprintf("%s resolves to %s", host, strings.Join(addresses, ", ")))
+}
+
+// probeEndpoint sends a request to the endpoint's root URL, the same way
+// the 'ping' command does.
+func probeEndpoint(args Arguments) probe {
+	u, err := url.Parse(args.apiEndpoint)
+	if err != nil {
+		return probe{err: microerror.Mask(err)}
+	}
+	u, err = u.Parse("/")
+	if err != nil {
+		return probe{err: microerror.Mask(err)}
+	}
+
+	request, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return probe{err: microerror.Mask(err)}
+	}
+	request.Header.Set("User-Agent", config.UserAgent())
+
+	tlsConfig := &tls.Config{}
+	err = rootcerts.ConfigureTLS(tlsConfig, &rootcerts.Config{
+		CAFile: args.caFile,
+		CAPath: args.caPath,
+	})
+	if err != nil {
+		return probe{err: microerror.Mask(err), caErr: err}
+	}
+
+	probeClient := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	start := time.Now()
+	response, err := probeClient.Do(request)
+	if err != nil {
+		return probe{err: err}
+	}
+	response.Body.Close()
+
+	return probe{
+		response:    response,
+		requestTime: start.Add(time.Since(start) / 2),
+	}
+}
+
+// checkTLS evaluates the TLS connection of the probe.
+func checkTLS(args Arguments, p probe) checkResult {
+	name := "TLS"
+
+	caHint := "If your API uses a custom certificate authority, point GSCTL_CAFILE to its certificate or GSCTL_CAPATH to a directory of certificates."
+
+	if p.err != nil {
+		var unknownAuthority x509.UnknownAuthorityError
+		var hostname x509.HostnameError
+		var invalid x509.CertificateInvalidError
+
+		switch {
+		case stderrors.As(p.err, &unknownAuthority):
+			return fail(name, "The certificate is signed by an unknown authority", caHint)
+		case stderrors.As(p.err, &hostname):
+			return fail(name, fmt.Sprintf("The certificate is not valid for the host: %s", hostname.Error()), "Make sure you use the correct endpoint URL.")
+		case stderrors.As(p.err, &invalid):
+			return fail(name, fmt.Sprintf("The certificate is invalid: %s", invalid.Error()), caHint)
+		}
+
+		if p.caErr != nil {
+			return fail(name, fmt.Sprintf("Could not load CA certificates: %s", p.caErr), "Check the GSCTL_CAFILE and GSCTL_CAPATH environment variables.")
+		}
+
+		return fail(name, fmt.Sprintf("Could not connect: %s", p.err), "Check your network, proxy and VPN settings. 'gsctl ping' tests the connection, too.")
+	}
+
+	if p.response.TLS == nil {
+		return warn(name, "The endpoint does not use TLS", "Use an https:// endpoint URL, unless you are sure that an insecure connection is acceptable.")
+	}
+
+	certs := p.response.TLS.PeerCertificates
+	if len(certs) == 0 {
+		return pass(name, "Certificate chain verified")
+	}
+
+	leaf := certs[0]
+	message := fmt.Sprintf("Certificate for %s issued by %s verified", leaf.Subject.CommonName, leaf.Issuer.CommonName)
+	if args.caFile != "" || args.caPath != "" {
+		message += " using GSCTL_CAFILE/GSCTL_CAPATH"
+	}
+
+	if time.Until(leaf.NotAfter) < certExpiryWarning {
+		return warn(name, message+fmt.Sprintf(", but it expires on %s", leaf.NotAfter.Format(time.RFC3339)), "Please let your administrator know.")
+	}
+
+	return pass(name, message)
+}
+
+// checkClockSkew compares the local time with the Date header of the
+// probe response. A wrong clock makes tokens appear expired or not yet valid.
+func checkClockSkew(p probe) checkResult {
+	name := "Clock"
+
+	if p.err != nil {
+		return warn(name, "Could not compare, as the API is not reachable", "")
+	}
+
+	serverTime, err := http.ParseTime(p.response.Header.Get("Date"))
+	if err != nil {
+		return warn(name, "Could not compare, as the API response has no valid Date header", "")
+	}
+
+	skew := p.requestTime.Sub(serverTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	// The Date header only has a precision of one second.
+	skew = skew.Truncate(time.Second)
+
+	if skew > maxClockSkew {
+		return fail(name, fmt.Sprintf("Your clock differs from the API's clock by %s", skew), "Synchronize your clock, e. g. by enabling NTP.")
+	}
+
+	return pass(name, fmt.Sprintf("Your clock differs from the API's clock by %s", skew))
+}
+
+// checkToken checks whether the auth token is valid. SSO tokens are only
+// checked locally, so that they don't get refreshed as a side effect.
+func checkToken(args Arguments) checkResult {
+	name := "Auth token"
+	loginHint := "Log in using 'gsctl login'."
+
+	if args.token == "" {
+		return fail(name, "You are not logged in", loginHint)
+	}
+
+	if args.scheme == ssotoken.Scheme {
+		expiry, err := ssotoken.Expiry(args.token)
+		if err != nil {
+			return fail(name, "The SSO token cannot be decoded", "Log in again using 'gsctl login --sso'.")
+		}
+		if expiry.IsZero() {
+			return pass(name, "The SSO token does not expire")
+		}
+
+		if time.Now().After(expiry) {
+			ec := config.Config.EndpointConfig(args.apiEndpoint)
+			if args.userProvidedToken == "" && ec != nil && ec.RefreshToken != "" {
+				return warn(name, fmt.Sprintf("The SSO token expired at %s", expiry.Format(time.RFC3339)), "It gets refreshed automatically with the next command.")
+			}
+			return fail(name, fmt.Sprintf("The SSO token expired at %s", expiry.Format(time.RFC3339)), "Log in again using 'gsctl login --sso'.")
+		}
+
+		return pass(name, fmt.Sprintf("The SSO token is valid until %s", expiry.Format(time.RFC3339)))
+	}
+
+	if args.apiEndpoint == "" {
+		return warn(name, "Not verified, as no endpoint is selected", "")
+	}
+
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return fail(name, err.Error(), "")
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = "doctor"
+
+	_, err = clientWrapper.GetInfo(auxParams)
+	if clienterror.IsUnauthorizedError(err) {
+		return fail(name, "The token was rejected by the API", loginHint)
+	} else if err != nil {
+		return warn(name, fmt.Sprintf("Could not verify the token: %s", err), "")
+	}
+
+	return pass(name, "The token is accepted by the API")
+}
+
+// checkBinary looks up an executable in the PATH.
+func checkBinary(name, hint string) checkResult {
+	binaryPath, err := lookPath(name)
+	if err != nil {
+		return warn(name, "Not found in your PATH", hint)
+	}
+
+	return pass(name, fmt.Sprintf("Found at %s", binaryPath))
+}
+
+// checkVersion checks whether a newer gsctl version is available.
+func checkVersion(args Arguments) checkResult {
+	name := fmt.Sprintf("%s version", config.ProgramName)
+
+	if args.currentVersion == "" {
+		return warn(name, "This is a development build", "")
+	}
+
+	latest, updateAvailable, err := version.CheckUpdate(args.versionCheckURL, args.currentVersion)
+	if err != nil {
+		return warn(name, fmt.Sprintf("Could not check for updates: %s", err), "")
+	}
+
+	if updateAvailable {
+		return warn(name, fmt.Sprintf("%s is outdated, %s is available", args.currentVersion, latest),
+			fmt.Sprintf("See https://github.com/giantswarm/gsctl/releases/tag/%s for details.", latest))
+	}
+
+	return pass(name, fmt.Sprintf("%s is the latest version", args.currentVersion))
+}
@@ -0,0 +1,139 @@
+// Package doctor implements the 'doctor' command.
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/buildinfo"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+var (
+	// Command is the "doctor" go command
+	Command = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose connectivity and setup problems",
+		Long: `Runs a list of checks on your setup and the connection to the API endpoint
+and prints whether each of them passed, gave a warning, or failed, together
+with a hint on how to fix the problem.
+
+The checks cover:
+
+  - Permissions of the configuration file
+  - Writability of the certificates directory
+  - Proxy settings from the environment (HTTP_PROXY, HTTPS_PROXY, NO_PROXY)
+  - DNS resolution of the API endpoint host
+  - TLS certificate validation, using GSCTL_CAFILE and GSCTL_CAPATH if set
+  - Clock skew compared to the API's Date header
+  - Validity of the auth token
+  - Availability of the kubectl and kubie binaries
+  - Whether a newer gsctl version is available
+
+The command exits with a non-zero exit code if any check failed.
+
+Examples:
+
+  gsctl doctor
+
+  gsctl doctor --endpoint api.example.com
+`,
+		Run: printResult,
+	}
+)
+
+// Arguments specifies all the arguments to be used for our business function.
+type Arguments struct {
+	apiEndpoint       string
+	caFile            string
+	caPath            string
+	currentVersion    string
+	fileSystem        afero.Fs
+	scheme            string
+	token             string
+	userProvidedToken string
+	versionCheckURL   string
+}
+
+// collectArguments fills arguments from user input, config, and environment.
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		caFile:            os.Getenv("GSCTL_CAFILE"),
+		caPath:            os.Getenv("GSCTL_CAPATH"),
+		currentVersion:    currentVersion(),
+		fileSystem:        config.FileSystem,
+		scheme:            config.Config.ChooseScheme(endpoint, flags.Token),
+		token:             config.Config.ChooseToken(endpoint, flags.Token),
+		userProvidedToken: flags.Token,
+		versionCheckURL:   config.VersionCheckURL,
+	}
+}
+
+// currentVersion returns the version of this build, or an empty string
+// for development builds.
+func currentVersion() string {
+	if buildinfo.Version == buildinfo.VersionPlaceholder {
+		return ""
+	}
+
+	return buildinfo.Version
+}
+
+// printResult runs the checks and prints the results.
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	results := runChecks(collectArguments())
+
+	fmt.Println(formatResults(results))
+
+	for _, r := range results {
+		if r.status == statusFail {
+			os.Exit(1)
+		}
+	}
+}
+
+// formatResults renders the results as a table, followed by a summary.
+func formatResults(results []checkResult) string {
+	rows := []string{}
+	counts := map[string]int{}
+
+	for _, r := range results {
+		counts[r.status]++
+
+		var status string
+		switch r.status {
+		case statusPass:
+			status = color.GreenString("PASS")
+		case statusWarn:
+			status = color.YellowString("WARN")
+		default:
+			status = color.RedString("FAIL")
+		}
+
+		rows = append(rows, status+"|"+r.name+"|"+r.message)
+		if r.hint != "" {
+			rows = append(rows, "||"+color.CyanString("Hint: "+r.hint))
+		}
+	}
+
+	summary := fmt.Sprintf("%d passed, %d warnings, %d failed", counts[statusPass], counts[statusWarn], counts[statusFail])
+	if counts[statusFail] > 0 {
+		summary = color.RedString(summary)
+	} else if counts[statusWarn] > 0 {
+		summary = color.YellowString(summary)
+	} else {
+		summary = color.GreenString(summary)
+	}
+
+	return strings.Join([]string{columnize.SimpleFormat(rows), "", summary}, "\n")
+}
@@ -0,0 +1,283 @@
+package doctor
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp executes the command with --help.
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+func tempConfig(t *testing.T, endpoint, token, scheme, refreshToken string) Arguments {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, fmt.Sprintf(`endpoints:
+  %s:
+    email: email@example.com
+    token: %s
+    auth_scheme: %s
+    refresh_token: %s
+selected_endpoint: %s
+`, endpoint, token, scheme, refreshToken, endpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := collectArguments()
+	args.fileSystem = fs
+	args.caFile = ""
+	args.caPath = ""
+
+	return args
+}
+
+func Test_checkConfigFilePermissions(t *testing.T) {
+	args := tempConfig(t, "https://api.example.com", "token", "giantswarm", "")
+
+	err := args.fileSystem.Chmod(config.ConfigFilePath, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := checkConfigFilePermissions(args)
+	if result.status != statusWarn || !strings.Contains(result.hint, "chmod 600") {
+		t.Errorf("Unexpected result %#v", result)
+	}
+
+	err = args.fileSystem.Chmod(config.ConfigFilePath, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = checkConfigFilePermissions(args)
+	if result.status != statusPass {
+		t.Errorf("Unexpected result %#v", result)
+	}
+
+	result = checkCertsDir(args)
+	if result.status != statusPass {
+		t.Errorf("Unexpected result %#v", result)
+	}
+}
+
+func Test_checkProxy(t *testing.T) {
+	var testCases = []struct {
+		env            map[string]string
+		expectedStatus string
+		expectedChunk  string
+	}{
+		{
+			env:            map[string]string{},
+			expectedStatus: statusPass,
+			expectedChunk:  "No proxy configured",
+		},
+		{
+			env:            map[string]string{"https_proxy": "http://proxy.example.com:3128", "NO_PROXY": ".example.com"},
+			expectedStatus: statusPass,
+			expectedChunk:  "HTTPS_PROXY=http://proxy.example.com:3128, NO_PROXY=.example.com (api.example.com is not proxied)",
+		},
+		{
+			env:            map[string]string{"HTTP_PROXY": "proxy.example.com"},
+			expectedStatus: statusFail,
+			expectedChunk:  "HTTP_PROXY is not a valid URL",
+		},
+	}
+
+	defer func() { getenv = os.Getenv }()
+
+	for i, tc := range testCases {
+		getenv = func(key string) string { return tc.env[key] }
+
+		result := checkProxy(Arguments{apiEndpoint: "https://api.example.com"})
+		if result.status != tc.expectedStatus || !strings.Contains(result.message, tc.expectedChunk) {
+			t.Errorf("Case %d - unexpected result %#v", i, result)
+		}
+	}
+}
+
+// Test_checkTLS tests the certificate validation, with and without CA file.
+func Test_checkTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	args := Arguments{apiEndpoint: server.URL}
+	result := checkTLS(args, probeEndpoint(args))
+	if result.status != statusFail || !strings.Contains(result.hint, "GSCTL_CAFILE") {
+		t.Errorf("Unexpected result without CA file %#v", result)
+	}
+
+	dir, err := ioutil.TempDir("", "doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	args.caFile = path.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err = ioutil.WriteFile(args.caFile, caPEM, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result = checkTLS(args, probeEndpoint(args))
+	if result.status != statusPass || !strings.Contains(result.message, "GSCTL_CAFILE") {
+		t.Errorf("Unexpected result with CA file %#v", result)
+	}
+
+	args.caFile = path.Join(dir, "missing.pem")
+	result = checkTLS(args, probeEndpoint(args))
+	if result.status != statusFail || !strings.Contains(result.message, "Could not load CA certificates") {
+		t.Errorf("Unexpected result with missing CA file %#v", result)
+	}
+}
+
+// Test_checkClockSkew tests the comparison with the Date header.
+func Test_checkClockSkew(t *testing.T) {
+	var testCases = []struct {
+		offset         time.Duration
+		expectedStatus string
+	}{
+		{0, statusPass},
+		{-10 * time.Minute, statusFail},
+		{10 * time.Minute, statusFail},
+	}
+
+	for i, tc := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Date", time.Now().Add(tc.offset).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		args := Arguments{apiEndpoint: server.URL}
+		p := probeEndpoint(args)
+
+		result := checkClockSkew(p)
+		if result.status != tc.expectedStatus {
+			t.Errorf("Case %d - unexpected result %#v", i, result)
+		}
+
+		result = checkTLS(args, p)
+		if result.status != statusWarn {
+			t.Errorf("Case %d - expected warning for plain HTTP, got %#v", i, result)
+		}
+
+		server.Close()
+	}
+}
+
+func Test_checkToken(t *testing.T) {
+	enc := base64.RawURLEncoding
+	expiredToken := "header." + enc.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(-time.Hour).Unix()))) + ".signature"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "giantswarm valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code": "PERMISSION_DENIED", "message": "Unauthorized"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"general": {"provider": "aws"}}`))
+	}))
+	defer server.Close()
+
+	var testCases = []struct {
+		token          string
+		scheme         string
+		refreshToken   string
+		expectedStatus string
+	}{
+		{"valid-token", "giantswarm", "", statusPass},
+		{"invalid-token", "giantswarm", "", statusFail},
+		{"", "giantswarm", "", statusFail},
+		{expiredToken, "Bearer", "refresh-token", statusWarn},
+		{expiredToken, "Bearer", "", statusFail},
+	}
+
+	for i, tc := range testCases {
+		args := tempConfig(t, server.URL, tc.token, tc.scheme, tc.refreshToken)
+
+		result := checkToken(args)
+		if result.status != tc.expectedStatus {
+			t.Errorf("Case %d - unexpected result %#v", i, result)
+		}
+	}
+}
+
+func Test_checkBinary(t *testing.T) {
+	defer func() { lookPath = exec.LookPath }()
+
+	lookPath = func(file string) (string, error) {
+		if file == "kubectl" {
+			return "/usr/local/bin/kubectl", nil
+		}
+		return "", exec.ErrNotFound
+	}
+
+	result := checkBinary("kubectl", "")
+	if result.status != statusPass || !strings.Contains(result.message, "/usr/local/bin/kubectl") {
+		t.Errorf("Unexpected result %#v", result)
+	}
+
+	result = checkBinary("kubie", "hint")
+	if result.status != statusWarn || result.hint != "hint" {
+		t.Errorf("Unexpected result %#v", result)
+	}
+}
+
+func Test_checkVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://github.com/giantswarm/gsctl/releases/tag/1.2.3")
+		w.WriteHeader(http.StatusFound)
+	}))
+	defer server.Close()
+
+	var testCases = []struct {
+		version        string
+		expectedStatus string
+	}{
+		{"", statusWarn},
+		{"1.0.0", statusWarn},
+		{"1.2.3", statusPass},
+	}
+
+	for i, tc := range testCases {
+		result := checkVersion(Arguments{currentVersion: tc.version, versionCheckURL: server.URL})
+		if result.status != tc.expectedStatus {
+			t.Errorf("Case %d - unexpected result %#v", i, result)
+		}
+	}
+}
+
+func Test_formatResults(t *testing.T) {
+	output := formatResults([]checkResult{
+		pass("DNS", "resolves"),
+		warn("kubie", "Not found in your PATH", "Install kubie"),
+		fail("TLS", "unknown authority", "Set GSCTL_CAFILE"),
+	})
+
+	for _, chunk := range []string{"PASS", "WARN", "FAIL", "Hint: Install kubie", "1 passed, 1 warnings, 1 failed"} {
+		if !strings.Contains(output, chunk) {
+			t.Errorf("Output does not contain %q:\n%s", chunk, output)
+		}
+	}
+}
@@ -269,6 +269,36 @@ func IsOrganizationNotSpecifiedError(err error) bool {
 	return microerror.Cause(err) == OrganizationNotSpecifiedError
 }
 
+// OrganizationAlreadyExistsError means that an organization with the given ID exists already
+var OrganizationAlreadyExistsError = &microerror.Error{
+	Kind: "OrganizationAlreadyExistsError",
+}
+
+// IsOrganizationAlreadyExistsError asserts OrganizationAlreadyExistsError
+func IsOrganizationAlreadyExistsError(err error) bool {
+	return microerror.Cause(err) == OrganizationAlreadyExistsError
+}
+
+// OrganizationMemberAlreadyExistsError means that the user to add is a member of the organization already
+var OrganizationMemberAlreadyExistsError = &microerror.Error{
+	Kind: "OrganizationMemberAlreadyExistsError",
+}
+
+// IsOrganizationMemberAlreadyExistsError asserts OrganizationMemberAlreadyExistsError
+func IsOrganizationMemberAlreadyExistsError(err error) bool {
+	return microerror.Cause(err) == OrganizationMemberAlreadyExistsError
+}
+
+// OrganizationMemberNotFoundError means that the user to remove is not a member of the organization
+var OrganizationMemberNotFoundError = &microerror.Error{
+	Kind: "OrganizationMemberNotFoundError",
+}
+
+// IsOrganizationMemberNotFoundError asserts OrganizationMemberNotFoundError
+func IsOrganizationMemberNotFoundError(err error) bool {
+	return microerror.Cause(err) == OrganizationMemberNotFoundError
+}
+
 // CredentialNotFoundError means that the specified credential could not be found
 var CredentialNotFoundError = &microerror.Error{
 	Kind: "CredentialNotFoundError",
@@ -493,6 +523,17 @@ func IsInvalidCredentialsError(err error) bool {
 	return microerror.Cause(err) == InvalidCredentialsError
 }
 
+// CredentialsFormatInvalidError means that a cloud provider credential value,
+// like an AWS role ARN or an Azure subscription ID, has an invalid format
+var CredentialsFormatInvalidError = &microerror.Error{
+	Kind: "CredentialsFormatInvalidError",
+}
+
+// IsCredentialsFormatInvalidError asserts CredentialsFormatInvalidError
+func IsCredentialsFormatInvalidError(err error) bool {
+	return microerror.Cause(err) == CredentialsFormatInvalidError
+}
+
 // KubectlMissingError means that the 'kubectl' executable is not available
 var KubectlMissingError = &microerror.Error{
 	Kind: "KubectlMissingError",
@@ -619,6 +660,28 @@ func IsCredentialsAlreadySetError(err error) bool {
 	return microerror.Cause(err) == CredentialsAlreadySetError
 }
 
+// CredentialsNotSetError means the user tried replacing the credentials of an
+// org that has no credentials yet.
+var CredentialsNotSetError = &microerror.Error{
+	Kind: "CredentialsNotSetError",
+}
+
+// IsCredentialsNotSetError asserts CredentialsNotSetError.
+func IsCredentialsNotSetError(err error) bool {
+	return microerror.Cause(err) == CredentialsNotSetError
+}
+
+// CredentialsRotationNotSupportedError means the API refused to replace
+// existing credentials of an org.
+var CredentialsRotationNotSupportedError = &microerror.Error{
+	Kind: "CredentialsRotationNotSupportedError",
+}
+
+// IsCredentialsRotationNotSupportedError asserts CredentialsRotationNotSupportedError.
+func IsCredentialsRotationNotSupportedError(err error) bool {
+	return microerror.Cause(err) == CredentialsRotationNotSupportedError
+}
+
 // UpdateCheckFailed means that checking for a newer gsctl version failed.
 var UpdateCheckFailed = &microerror.Error{
 	Kind: "UpdateCheckFailed",
@@ -9,6 +9,7 @@ import (
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 )
 
 // HandleCommonErrors is a common function to handle certain errors happening in
@@ -39,6 +40,10 @@ func HandleCommonErrors(err error) {
 			headline = "Unable to refresh your SSO token."
 			subtext = err.Error() + "\n"
 			subtext += "Please try loging in again using: gsctl login --sso"
+		case ssotoken.IsReloginRequired(err):
+			headline = "Your SSO session has expired."
+			subtext = "Details: " + err.Error() + "\n"
+			subtext += "Please log in again using 'gsctl login --sso' or 'gsctl login --device-code'."
 		case IsNotLoggedInError(err):
 			headline = "You are not logged in."
 			subtext = "Use 'gsctl login' to login or '--auth-token' to pass a valid auth token."
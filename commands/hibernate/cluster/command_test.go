@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/hibernation"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests the argument combinations.
+func Test_verifyPreconditions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://foo", authToken: "token"})
+	if !errors.IsClusterNameOrIDMissingError(err) {
+		t.Errorf("Expected ClusterNameOrIDMissingError, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://foo", authToken: "token", clusterNameOrID: "f01r4", selector: "env=dev"})
+	if !errors.IsConflictingFlagsError(err) {
+		t.Errorf("Expected ConflictingFlagsError, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://foo", authToken: "token", selector: "env=dev"})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+// Test_getPlans tests planning the hibernation of clusters matching a selector.
+func Test_getPlans(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v5/clusters/by_label/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "g02s5", "name": "Dev 2", "path": "/v5/clusters/g02s5/"},
+				{"id": "f01r4", "name": "Dev 1", "path": "/v5/clusters/f01r4/"},
+				{"id": "h03t6", "name": "Dev 3", "path": "/v5/clusters/h03t6/"}
+			]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/f01r4/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "a1b", "name": "Default", "scaling": {"min": 3, "max": 10}}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/g02s5/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cluster h03t6 has been hibernated before.
+	err = hibernation.Store(mockServer.URL, "h03t6", []hibernation.NodePoolScaling{{ID: "x9y", Min: 1, Max: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plans, err := getPlans(Arguments{apiEndpoint: mockServer.URL, selector: "env=dev"}, clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(plans) != 3 {
+		t.Fatalf("Expected 3 plans, got %d", len(plans))
+	}
+	if diff := cmp.Diff([]hibernation.NodePoolScaling{{ID: "a1b", Name: "Default", Min: 3, Max: 10}}, plans[0].nodePools); diff != "" {
+		t.Errorf("Node pools not as expected (-want +got):\n%s", diff)
+	}
+	if plans[1].skipReason != "cluster has no node pools" {
+		t.Errorf("Unexpected skip reason for %s: %q", plans[1].target.ID, plans[1].skipReason)
+	}
+	if !strings.HasPrefix(plans[2].skipReason, "already hibernated") {
+		t.Errorf("Unexpected skip reason for %s: %q", plans[2].target.ID, plans[2].skipReason)
+	}
+	if countActionable(plans) != 1 {
+		t.Errorf("Expected 1 actionable plan, got %d", countActionable(plans))
+	}
+
+	output := formatPlans(plans)
+	if !strings.Contains(output, "3/10") || !strings.Contains(output, "0/0") {
+		t.Errorf("Output does not show the scaling change:\n%s", output)
+	}
+}
@@ -0,0 +1,20 @@
+package hibernate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/hibernate/cluster"
+)
+
+var (
+	// Command is the command to hibernate things
+	Command = &cobra.Command{
+		Use:   "hibernate",
+		Short: "Hibernate clusters",
+		Long:  `Lets you scale all node pools of a cluster down to zero, to be restored later using 'gsctl wake'.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(cluster.Command)
+}
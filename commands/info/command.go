@@ -1,6 +1,7 @@
 package info
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,13 +10,17 @@ import (
 	"github.com/giantswarm/columnize"
 	"github.com/giantswarm/gscliauth/config"
 	clientinfo "github.com/giantswarm/gsclientgen/v2/client/info"
+	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/giantswarm/gsctl/buildinfo"
+	"github.com/giantswarm/gsctl/capabilities"
 	"github.com/giantswarm/gsctl/client"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
 )
 
 const (
@@ -35,9 +40,14 @@ var (
 	arguments Arguments
 )
 
+func init() {
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' or '%s' for machine-readable output. Defaults to human-friendly table output.", formatting.OutputFormatJSON, formatting.OutputFormatYAML))
+}
+
 // Arguments represents the arguments we can make use of in this command
 type Arguments struct {
 	apiEndpoint       string
+	outputFormat      string
 	scheme            string
 	token             string
 	userProvidedToken string
@@ -53,6 +63,7 @@ func collectArguments() Arguments {
 
 	return Arguments{
 		apiEndpoint:       endpoint,
+		outputFormat:      flags.OutputFormat,
 		scheme:            scheme,
 		token:             token,
 		userProvidedToken: flags.Token,
@@ -72,13 +83,47 @@ type infoResult struct {
 	configFilePath       string
 	kubeConfigPaths      []string
 	infoResponse         *clientinfo.GetInfoOK
+	releaseCapabilities  []ReleaseCapabilities
 	environmentVariables map[string]string
 }
 
-// validatePreconditions simply returns nil, as the command should work under
-// all conditions.
+// Output is the structure we render for machine-readable output.
+type Output struct {
+	Version              string                 `json:"version"`
+	BuildDate            string                 `json:"build_date"`
+	CommitHash           string                 `json:"commit_hash"`
+	ConfigFilePath       string                 `json:"config_file_path"`
+	KubeConfigPaths      []string               `json:"kubeconfig_paths"`
+	APIEndpoint          string                 `json:"api_endpoint"`
+	APIEndpointAlias     string                 `json:"api_endpoint_alias"`
+	Email                string                 `json:"email"`
+	LoggedIn             bool                   `json:"logged_in"`
+	AuthToken            string                 `json:"auth_token,omitempty"`
+	Installation         *models.V4InfoResponse `json:"installation"`
+	ReleaseCapabilities  []ReleaseCapabilities  `json:"release_capabilities"`
+	EnvironmentVariables map[string]string      `json:"environment_variables"`
+
+	// Error describes a problem collecting the information. The other
+	// fields contain what could be collected anyway.
+	Error string `json:"error,omitempty"`
+}
+
+// ReleaseCapabilities lists the capabilities an active release provides
+// on the installation.
+type ReleaseCapabilities struct {
+	ReleaseVersion string   `json:"release_version"`
+	Capabilities   []string `json:"capabilities"`
+}
+
+// validatePreconditions only checks the output format, as the command should
+// otherwise work under all conditions.
 func validatePreconditions(args Arguments) error {
-	return nil
+	switch args.outputFormat {
+	case formatting.OutputFormatTable, formatting.OutputFormatJSON, formatting.OutputFormatYAML:
+		return nil
+	}
+
+	return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
 }
 
 // printValidation prints if there is anything missing from user input or config.
@@ -89,6 +134,9 @@ func printValidation(cmd *cobra.Command, extraArgs []string) {
 	if err != nil {
 		client.HandleErrors(err)
 		errors.HandleCommonErrors(err)
+
+		fmt.Println(color.RedString(err.Error()))
+		os.Exit(1)
 	}
 }
 
@@ -96,6 +144,16 @@ func printValidation(cmd *cobra.Command, extraArgs []string) {
 func printInfo(cmd *cobra.Command, args []string) {
 	result, err := info(arguments)
 
+	if arguments.outputFormat != formatting.OutputFormatTable {
+		// The error goes into the document, so that the output stays
+		// valid JSON or YAML.
+		printMachineReadable(result, err, arguments)
+		if err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	output := []string{}
 
 	if result.version != buildinfo.VersionPlaceholder && result.version != "" {
@@ -193,7 +251,77 @@ func printInfo(cmd *cobra.Command, args []string) {
 
 	if err != nil {
 		fmt.Println()
+	}
+	handleInfoError(err)
+}
 
+// printMachineReadable prints the info result as JSON or YAML, including
+// the error which occurred while collecting it, if any.
+func printMachineReadable(result infoResult, infoErr error, args Arguments) {
+	output, err := getMachineReadableOutput(result, infoErr, args)
+	if err != nil {
+		fmt.Println(color.RedString("Could not render output:"))
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+// getMachineReadableOutput renders the info result in the requested format.
+func getMachineReadableOutput(result infoResult, infoErr error, args Arguments) (string, error) {
+	out := Output{
+		Version:              result.version,
+		BuildDate:            result.buildDate,
+		CommitHash:           result.commitHash,
+		ConfigFilePath:       result.configFilePath,
+		KubeConfigPaths:      result.kubeConfigPaths,
+		APIEndpoint:          result.apiEndpoint,
+		APIEndpointAlias:     result.apiEndpointAlias,
+		Email:                result.email,
+		LoggedIn:             result.token != "",
+		ReleaseCapabilities:  result.releaseCapabilities,
+		EnvironmentVariables: result.environmentVariables,
+	}
+	if args.verbose {
+		out.AuthToken = result.token
+	}
+	if result.infoResponse != nil {
+		out.Installation = result.infoResponse.Payload
+	}
+	if infoErr != nil {
+		out.Error = infoErr.Error()
+	}
+
+	jsonBytes, err := json.MarshalIndent(out, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if args.outputFormat == formatting.OutputFormatJSON {
+		return string(jsonBytes), nil
+	}
+
+	// Going through JSON first lets YAML output use the same keys as JSON,
+	// as the API models only carry JSON tags.
+	var generic interface{}
+	err = yaml.Unmarshal(jsonBytes, &generic)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	yamlBytes, err := yaml.Marshal(generic)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return strings.TrimSuffix(string(yamlBytes), "\n"), nil
+}
+
+// handleInfoError prints an error that occurred while collecting info
+// and exits, if there is one.
+func handleInfoError(err error) {
+	if err != nil {
 		// if this is a common error, handle it in the standard way and exit.
 		client.HandleErrors(err)
 		errors.HandleCommonErrors(err)
@@ -251,11 +379,58 @@ func info(args Arguments) (infoResult, error) {
 		}
 
 		result.infoResponse = response
+
+		// Capabilities are only part of the machine-readable output.
+		if args.outputFormat != formatting.OutputFormatTable && response.Payload.General != nil && response.Payload.General.Provider != "" {
+			releaseCapabilities, err := getReleaseCapabilities(response.Payload.General.Provider, clientWrapper, auxParams)
+			if err != nil {
+				return result, microerror.Mask(err)
+			}
+
+			result.releaseCapabilities = releaseCapabilities
+		}
 	}
 
 	return result, nil
 }
 
+// getReleaseCapabilities resolves the capabilities of every active release.
+func getReleaseCapabilities(provider string, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]ReleaseCapabilities, error) {
+	capabilityService, err := capabilities.New(provider, clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	response, err := clientWrapper.GetReleases(auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	out := []ReleaseCapabilities{}
+	for _, release := range response.Payload {
+		if !release.Active || release.Version == nil {
+			continue
+		}
+
+		caps, err := capabilityService.GetCapabilities(*release.Version)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		names := []string{}
+		for _, c := range caps {
+			names = append(names, c.Name)
+		}
+
+		out = append(out, ReleaseCapabilities{
+			ReleaseVersion: *release.Version,
+			Capabilities:   names,
+		})
+	}
+
+	return out, nil
+}
+
 func getEnvironmentVariables() map[string]string {
 	// all environment variables relevant to gsctl
 	vars := []string{
@@ -1,12 +1,20 @@
 package info
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/giantswarm/microerror"
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
 
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/formatting"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -78,3 +86,130 @@ func Test_InfoWithTempDirAndToken(t *testing.T) {
 		t.Error("Expected empty email, got ", infoResult.email)
 	}
 }
+
+// Test_ValidateOutputFormat tests the output format validation.
+func Test_ValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{formatting.OutputFormatTable, formatting.OutputFormatJSON, formatting.OutputFormatYAML} {
+		err := validatePreconditions(Arguments{outputFormat: format})
+		if err != nil {
+			t.Errorf("Unexpected error for format '%s': %s", format, err)
+		}
+	}
+
+	err := validatePreconditions(Arguments{outputFormat: "xml"})
+	if !errors.IsOutputFormatInvalid(err) {
+		t.Errorf("Expected OutputFormatInvalidError, got '%v'", err)
+	}
+}
+
+// Test_InfoMachineReadable tests JSON and YAML output including the
+// installation info and release capabilities.
+func Test_InfoMachineReadable(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v4/info/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"general": {"installation_name": "codename", "provider": "aws"},
+				"features": {
+					"nodepools": {"release_version_minimum": "10.0.0"},
+					"ha_masters": {"release_version_minimum": "11.4.0"}
+				},
+				"workers": {
+					"count_per_cluster": {"max": 20, "default": 3},
+					"instance_type": {"options": ["m3.medium", "m3.large"], "default": "m3.large"}
+				}
+			}`))
+		case "/v4/releases/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"version": "9.0.0", "timestamp": "2019-01-01T00:00:00Z", "active": true, "changelog": [], "components": []},
+				{"version": "11.4.0", "timestamp": "2020-01-01T00:00:00Z", "active": true, "changelog": [], "components": []},
+				{"version": "11.3.0", "timestamp": "2020-01-01T00:00:00Z", "active": false, "changelog": [], "components": []}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		apiEndpoint:       mockServer.URL,
+		outputFormat:      formatting.OutputFormatJSON,
+		token:             "token",
+		userProvidedToken: "token",
+	}
+
+	result, err := info(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedCapabilities := []ReleaseCapabilities{
+		{ReleaseVersion: "9.0.0", Capabilities: []string{"Autoscaling", "AvailabilityZones"}},
+		{ReleaseVersion: "11.4.0", Capabilities: []string{"Autoscaling", "AvailabilityZones", "NodePools", "HAMasters"}},
+	}
+	if diff := cmp.Diff(expectedCapabilities, result.releaseCapabilities); diff != "" {
+		t.Errorf("Capabilities not as expected (-want +got):\n%s", diff)
+	}
+
+	output, err := getMachineReadableOutput(result, nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if strings.Contains(output, "auth_token") {
+		t.Errorf("Non-verbose output must not contain the auth token, got:\n%s", output)
+	}
+	var decoded Output
+	err = json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		t.Fatalf("Output is not valid JSON: %s", err)
+	}
+	if decoded.Installation == nil || decoded.Installation.Workers.InstanceType.Default != "m3.large" {
+		t.Errorf("Installation info not as expected, got:\n%s", output)
+	}
+
+	args.outputFormat = formatting.OutputFormatYAML
+	output, err = getMachineReadableOutput(result, nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var decodedYAML map[string]interface{}
+	err = yaml.Unmarshal([]byte(output), &decodedYAML)
+	if err != nil {
+		t.Fatalf("Output is not valid YAML: %s", err)
+	}
+	if decodedYAML["logged_in"] != true {
+		t.Errorf("Expected logged_in to be true, got:\n%s", output)
+	}
+	if !strings.Contains(output, "release_version_minimum: 10.0.0") {
+		t.Errorf("Expected feature minimum release in output, got:\n%s", output)
+	}
+	if _, ok := decodedYAML["error"]; ok {
+		t.Errorf("Expected no error field, got:\n%s", output)
+	}
+
+	// An error is part of the document, so it stays valid.
+	args.outputFormat = formatting.OutputFormatJSON
+	output, err = getMachineReadableOutput(result, microerror.Mask(errors.NotLoggedInError), args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	decoded = Output{}
+	err = json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		t.Fatalf("Output is not valid JSON: %s", err)
+	}
+	if decoded.Error != errors.NotLoggedInError.Error() || decoded.APIEndpoint != mockServer.URL {
+		t.Errorf("Expected error and partial result in output, got:\n%s", output)
+	}
+}
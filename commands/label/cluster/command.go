@@ -0,0 +1,433 @@
+// Package cluster implements the 'label cluster' sub-command.
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/labels"
+)
+
+const (
+	labelClusterActivityName = "label-cluster"
+)
+
+var (
+	// Command is the cobra command for 'gsctl label cluster'
+	Command = &cobra.Command{
+		Use:   "cluster [<cluster-name-or-id>] <key>=<value>|<key>- ...",
+		Short: "Add, change or remove cluster labels",
+		Long: `Adds, changes or removes labels of one cluster, or of all clusters matching
+a label selector.
+
+Labels are given as 'key=value' to set a label, or as 'key-' to remove it.
+Changing the value of an existing label requires --overwrite. Removing a
+label which doesn't exist is not an error.
+
+Label keys may have a prefix, which must be a DNS subdomain, followed by a
+slash, e. g. 'example.com/team'. The name part of a key, as well as a value,
+can have up to 63 characters, consisting of alphanumeric characters, '-',
+'_' and '.', starting and ending with an alphanumeric character.
+
+Selectors use the same syntax as in kubectl. Requirements are separated by
+commas and all have to be met:
+
+  key                    the label is present
+  !key                   the label is not present
+  key=value              the label has the value
+  key!=value             the label is not present or has another value
+  key in (v1,v2)         the label has one of the values
+  key notin (v1,v2)      the label is not present or has none of the values
+
+Only clusters with node pool support can have labels.
+
+Examples:
+
+  gsctl label cluster f01r4 environment=testing team=upstream
+
+  gsctl label cluster "Development cluster" environment=staging --overwrite
+
+  gsctl label cluster f01r4 team-
+
+  gsctl label cluster --selector 'environment in (dev,testing)' owner=team-a --dry-run
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for labelling clusters.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	changes           []string
+	clusterNameOrID   string
+	dryRun            bool
+	overwrite         bool
+	selector          string
+	userProvidedToken string
+	verbose           bool
+}
+
+// target is a cluster to label, with its current labels.
+type target struct {
+	id     string
+	name   string
+	labels map[string]string
+}
+
+// plan is what we are going to do with one cluster.
+type plan struct {
+	target target
+	// changes are the label changes which actually modify the cluster.
+	changes []labels.Change
+}
+
+func init() {
+	Command.Flags().StringVarP(&flags.Selector, "selector", "l", "", "Label selector query to select the clusters to label, instead of a single cluster.")
+	Command.Flags().BoolVarP(&flags.Overwrite, "overwrite", "", false, "If set, existing labels may get a new value.")
+	Command.Flags().BoolVarP(&flags.DryRun, "dry-run", "", false, "If set, only print what would be done, without changing anything.")
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	clusterNameOrID := ""
+	changes := positionalArgs
+	if flags.Selector == "" && len(positionalArgs) > 0 {
+		clusterNameOrID = positionalArgs[0]
+		changes = positionalArgs[1:]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		changes:           changes,
+		clusterNameOrID:   clusterNameOrID,
+		dryRun:            flags.DryRun,
+		overwrite:         flags.Overwrite,
+		selector:          flags.Selector,
+		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+// verifyPreconditions checks the arguments, including the syntax of
+// the label changes and the selector, before any API call is made.
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.clusterNameOrID == "" && args.selector == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	}
+	if len(args.changes) == 0 {
+		return microerror.Mask(noLabelChangesError)
+	}
+
+	_, err := labels.ParseChanges(args.changes)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if args.selector != "" {
+		_, err = labels.ParseSelector(args.selector)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	clientWrapper, err := client.NewWithConfig(arguments.apiEndpoint, arguments.userProvidedToken)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = labelClusterActivityName
+
+	plans, err := getPlans(arguments, clientWrapper, auxParams)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(formatPlans(plans))
+
+	if countActionable(plans) == 0 {
+		fmt.Println(color.YellowString("All labels are already set as requested, nothing to change."))
+		return
+	}
+
+	if arguments.dryRun {
+		fmt.Println(color.YellowString("Dry run, no changes made."))
+		return
+	}
+
+	failed := false
+	for _, p := range plans {
+		if len(p.changes) == 0 {
+			continue
+		}
+
+		err = applyPlan(p, clientWrapper, auxParams)
+		if err != nil {
+			failed = true
+			fmt.Println(color.RedString("Cluster '%s' could not be labeled: %s", p.target.id, err.Error()))
+			continue
+		}
+
+		fmt.Println(color.GreenString("Cluster '%s' has been labeled.", p.target.id))
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsClusterNameOrIDMissingError(err):
+		headline = "No cluster name or ID specified"
+		subtext = "Please specify the cluster as the first positional argument, or use --selector. See --help for details."
+	case IsNoLabelChanges(err):
+		headline = "No label changes given"
+		subtext = "Please specify labels as 'key=value' to set them or as 'key-' to remove them. See --help for details."
+	case labels.IsInvalidLabel(err):
+		headline = "Invalid label"
+		subtext = err.Error()
+	case labels.IsInvalidSelector(err):
+		headline = "Invalid label selector"
+		subtext = err.Error()
+	case IsLabelExists(err):
+		headline = "Labels already set"
+		subtext = err.Error() + "\nUse --overwrite to change the values. No cluster has been modified."
+	case errors.IsClusterNotFoundError(err):
+		headline = "Cluster not found"
+		subtext = "Please check the cluster name or ID. Only clusters with node pool support can have labels."
+	case IsNoMatchingClusters(err):
+		headline = "No clusters match the selector"
+		subtext = "Use 'gsctl list clusters --selector' to check which clusters a selector matches."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// getPlans finds the clusters to label and determines the changes to make.
+// If any label would be overwritten without --overwrite, an error is
+// returned, so that no cluster gets modified.
+func getPlans(args Arguments, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]plan, error) {
+	changes, err := labels.ParseChanges(args.changes)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	targets, err := findTargets(args, clientWrapper, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	plans := []plan{}
+	conflicts := []string{}
+	for _, t := range targets {
+		p := plan{target: t}
+
+		for _, c := range changes {
+			current, exists := t.labels[c.Key]
+
+			switch {
+			case c.Value == nil && !exists:
+				// Nothing to remove.
+			case c.Value != nil && exists && current == *c.Value:
+				// Already set.
+			case c.Value != nil && exists && !args.overwrite:
+				conflicts = append(conflicts, fmt.Sprintf("cluster '%s': '%s' already has the value '%s'", t.id, c.Key, current))
+			default:
+				p.changes = append(p.changes, c)
+			}
+		}
+
+		plans = append(plans, p)
+	}
+
+	if len(conflicts) > 0 {
+		return nil, microerror.Maskf(labelExistsError, "%s", strings.Join(conflicts, "\n"))
+	}
+
+	return plans, nil
+}
+
+// findTargets returns the cluster given by name or ID, or all clusters
+// matching the selector, with their current labels.
+func findTargets(args Arguments, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]target, error) {
+	if args.selector == "" {
+		clusterID, err := clustercache.GetID(args.apiEndpoint, args.clusterNameOrID, clientWrapper)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if args.verbose {
+			fmt.Println(color.WhiteString("Fetching labels of cluster '%s'", clusterID))
+		}
+
+		response, err := clientWrapper.GetClusterV5(clusterID, auxParams)
+		if err != nil {
+			if clienterror.IsNotFoundError(err) {
+				return nil, microerror.Maskf(errors.ClusterNotFoundError, "cluster with id '%s' not found or not a v5 cluster", clusterID)
+			}
+			return nil, microerror.Mask(err)
+		}
+
+		return []target{{id: clusterID, name: response.Payload.Name, labels: response.Payload.Labels}}, nil
+	}
+
+	selector, err := labels.ParseSelector(args.selector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	selectorString := selector.String()
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching clusters matching '%s'", selectorString))
+	}
+
+	response, err := clientWrapper.GetClustersByLabel(&models.V5ListClustersByLabelRequest{Labels: &selectorString}, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	targets := []target{}
+	for _, c := range response.Payload {
+		if c.DeleteDate != nil {
+			continue
+		}
+		targets = append(targets, target{id: c.ID, name: c.Name, labels: c.Labels})
+	}
+
+	if len(targets) == 0 {
+		return nil, microerror.Maskf(noMatchingClustersError, "selector '%s'", selectorString)
+	}
+
+	return targets, nil
+}
+
+// applyPlan sends the label changes of one cluster to the API.
+func applyPlan(p plan, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) error {
+	request := &models.V5SetClusterLabelsRequest{Labels: map[string]*string{}}
+	for _, c := range p.changes {
+		request.Labels[c.Key] = c.Value
+	}
+
+	_, err := clientWrapper.UpdateClusterLabels(p.target.id, request, auxParams)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// countActionable returns the number of clusters with changes.
+func countActionable(plans []plan) int {
+	count := 0
+	for _, p := range plans {
+		if len(p.changes) > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// formatPlans renders the plans as a table, with one row per change.
+func formatPlans(plans []plan) string {
+	rows := []string{strings.Join([]string{
+		color.CyanString("CLUSTER ID"),
+		color.CyanString("CLUSTER NAME"),
+		color.CyanString("LABEL"),
+		color.CyanString("CURRENT VALUE"),
+		color.CyanString("NEW VALUE"),
+	}, "|")}
+
+	for _, p := range plans {
+		if len(p.changes) == 0 {
+			rows = append(rows, strings.Join([]string{
+				p.target.id,
+				p.target.name,
+				color.YellowString("no changes"),
+			}, "|"))
+			continue
+		}
+
+		for _, c := range p.changes {
+			current, exists := p.target.labels[c.Key]
+			if !exists {
+				current = "n/a"
+			}
+
+			newValue := color.RedString("(removed)")
+			if c.Value != nil {
+				newValue = *c.Value
+			}
+
+			rows = append(rows, strings.Join([]string{
+				p.target.id,
+				p.target.name,
+				c.Key,
+				current,
+				newValue,
+			}, "|"))
+		}
+	}
+
+	return columnize.SimpleFormat(rows)
+}
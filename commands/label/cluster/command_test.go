@@ -0,0 +1,181 @@
+package cluster
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/labels"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// labelMockServer serves two clusters with labels and records label changes.
+func labelMockServer(t *testing.T, changes map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "f01r4", "name": "Dev", "owner": "acme"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/f01r4/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "f01r4", "name": "Dev", "labels": {"environment": "dev", "team": "a"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v5/clusters/by_label/":
+			body, _ := ioutil.ReadAll(r.Body)
+			request := map[string]string{}
+			_ = json.Unmarshal(body, &request)
+			if request["labels"] != "environment in (dev,testing)" {
+				t.Errorf("Unexpected selector %q", request["labels"])
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "f01r4", "name": "Dev", "labels": {"environment": "dev", "team": "a"}},
+				{"id": "g02s5", "name": "Testing", "labels": {"environment": "testing", "team": "b"}}
+			]`))
+		case r.Method == http.MethodPut && (r.URL.Path == "/v5/clusters/f01r4/labels/" || r.URL.Path == "/v5/clusters/g02s5/labels/"):
+			body, _ := ioutil.ReadAll(r.Body)
+			request := map[string]map[string]interface{}{}
+			_ = json.Unmarshal(body, &request)
+			changes[r.URL.Path] = request["labels"]
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"labels": {}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+}
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests argument validation, which must
+// happen before any API call.
+func Test_verifyPreconditions(t *testing.T) {
+	testCases := []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{
+			args:         Arguments{apiEndpoint: "https://foo", authToken: "token", clusterNameOrID: "f01r4", changes: []string{"environment=dev", "team-"}},
+			errorMatcher: nil,
+		},
+		{
+			args:         Arguments{apiEndpoint: "https://foo", authToken: "token", selector: "environment notin (prod)", changes: []string{"team=b"}},
+			errorMatcher: nil,
+		},
+		{
+			args:         Arguments{apiEndpoint: "https://foo", authToken: "token", changes: []string{"team=b"}},
+			errorMatcher: errors.IsClusterNameOrIDMissingError,
+		},
+		{
+			args:         Arguments{apiEndpoint: "https://foo", authToken: "token", clusterNameOrID: "f01r4"},
+			errorMatcher: IsNoLabelChanges,
+		},
+		{
+			args:         Arguments{apiEndpoint: "https://foo", authToken: "token", clusterNameOrID: "f01r4", changes: []string{"team"}},
+			errorMatcher: labels.IsInvalidLabel,
+		},
+		{
+			args:         Arguments{apiEndpoint: "https://foo", authToken: "token", selector: "environment in (dev", changes: []string{"team=b"}},
+			errorMatcher: labels.IsInvalidSelector,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := verifyPreconditions(tc.args)
+		if tc.errorMatcher == nil && err != nil {
+			t.Errorf("Case %d - Unexpected error: %s", i, err)
+		} else if tc.errorMatcher != nil && !tc.errorMatcher(err) {
+			t.Errorf("Case %d - Error not matching expectation, got %v", i, err)
+		}
+	}
+}
+
+// Test_labelCluster tests planning and applying changes for a single cluster.
+func Test_labelCluster(t *testing.T) {
+	changes := map[string]map[string]interface{}{}
+	mockServer := labelMockServer(t, changes)
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{apiEndpoint: mockServer.URL, clusterNameOrID: "f01r4", changes: []string{"environment=dev", "owner=me", "team-", "missing-"}}
+
+	plans, err := getPlans(args, clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(plans) != 1 || len(plans[0].changes) != 2 {
+		t.Fatalf("Expected two effective changes, got %#v", plans)
+	}
+
+	err = applyPlan(plans[0], clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	applied := changes["/v5/clusters/f01r4/labels/"]
+	if len(applied) != 2 || applied["owner"] != "me" || applied["team"] != nil {
+		t.Errorf("Unexpected label changes %v", applied)
+	}
+	if _, ok := applied["team"]; !ok {
+		t.Errorf("Expected 'team' to be removed, got %v", applied)
+	}
+}
+
+// Test_labelClustersOverwrite tests that existing labels are
+// only changed with --overwrite, for all selected clusters.
+func Test_labelClustersOverwrite(t *testing.T) {
+	mockServer := labelMockServer(t, map[string]map[string]interface{}{})
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{apiEndpoint: mockServer.URL, selector: "environment in (dev, testing)", changes: []string{"team=b"}}
+
+	_, err = getPlans(args, clientWrapper, nil)
+	if !IsLabelExists(err) {
+		t.Fatalf("Expected labelExistsError, got %v", err)
+	}
+
+	args.overwrite = true
+	plans, err := getPlans(args, clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if countActionable(plans) != 1 || plans[0].target.id != "f01r4" {
+		t.Errorf("Expected only cluster f01r4 to change, got %#v", plans)
+	}
+}
@@ -0,0 +1,33 @@
+package cluster
+
+import "github.com/giantswarm/microerror"
+
+var labelExistsError = &microerror.Error{
+	Kind: "labelExistsError",
+	Desc: "The label already has a different value",
+}
+
+// IsLabelExists asserts labelExistsError.
+func IsLabelExists(err error) bool {
+	return microerror.Cause(err) == labelExistsError
+}
+
+var noLabelChangesError = &microerror.Error{
+	Kind: "noLabelChangesError",
+	Desc: "No label changes given",
+}
+
+// IsNoLabelChanges asserts noLabelChangesError.
+func IsNoLabelChanges(err error) bool {
+	return microerror.Cause(err) == noLabelChangesError
+}
+
+var noMatchingClustersError = &microerror.Error{
+	Kind: "noMatchingClustersError",
+	Desc: "No clusters match the label selector",
+}
+
+// IsNoMatchingClusters asserts noMatchingClustersError.
+func IsNoMatchingClusters(err error) bool {
+	return microerror.Cause(err) == noMatchingClustersError
+}
@@ -0,0 +1,20 @@
+package label
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/label/cluster"
+)
+
+var (
+	// Command is the command to label things
+	Command = &cobra.Command{
+		Use:   "label",
+		Short: "Add, change or remove labels",
+		Long:  `Lets you add, change or remove labels of clusters, similar to 'kubectl label'.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(cluster.Command)
+}
@@ -17,8 +17,10 @@ import (
 
 	"github.com/giantswarm/gsctl/clustercache"
 	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/pkg/labels"
 	"github.com/giantswarm/gsctl/pkg/sortable"
 	"github.com/giantswarm/gsctl/pkg/table"
+	"github.com/giantswarm/gsctl/pkg/watch"
 
 	"github.com/giantswarm/gsctl/client"
 	"github.com/giantswarm/gsctl/client/clienterror"
@@ -46,6 +48,23 @@ Examples:
   gsctl list clusters --selector environment=testing
 
   gsctl list clusters --sort org
+
+  gsctl list clusters --filter 'release>=12.0.0,organization=acme'
+
+  gsctl list clusters --with-status --filter 'eol=yes'
+
+  gsctl list clusters --with-status --watch --interval 30s
+
+With --with-status, the status, node pools and release details of each cluster
+are fetched in parallel, adding the columns WORKERS, NODES READY, NODE POOLS,
+KUBERNETES, EOL and CONDITION. If some of these requests fail, the affected
+cells show 'n/a'. NODES READY is taken from the node pool status, so it is
+only available for clusters with node pools.
+
+With --watch, the list is refreshed periodically until interrupted. In a
+terminal, the table is redrawn in place and changed cells are highlighted.
+Otherwise, only added, modified and deleted clusters are printed, or JSON
+events with --output json.
 `,
 		PreRun: printValidation,
 		Run:    printResult,
@@ -57,6 +76,12 @@ Examples:
 
 	cmdSort string
 
+	cmdFilter string
+
+	cmdWithStatus bool
+
+	cmdConcurrency int
+
 	arguments Arguments
 )
 
@@ -69,6 +94,13 @@ const (
 	tableColOrg           = "organization"
 	tableColRelease       = "release"
 	tableColDeletingSince = "deleting-since"
+
+	tableColWorkers    = "workers"
+	tableColNodesReady = "nodes-ready"
+	tableColNodePools  = "nodepools"
+	tableColKubernetes = "kubernetes"
+	tableColEOL        = "eol"
+	tableColCondition  = "condition"
 )
 
 var tableCols = [...]string{
@@ -80,6 +112,16 @@ var tableCols = [...]string{
 	tableColDeletingSince,
 }
 
+// statusTableCols are the columns added with --with-status.
+var statusTableCols = [...]string{
+	tableColWorkers,
+	tableColNodesReady,
+	tableColNodePools,
+	tableColKubernetes,
+	tableColEOL,
+	tableColCondition,
+}
+
 func init() {
 	initFlags()
 }
@@ -90,17 +132,28 @@ func initFlags() {
 	Command.Flags().BoolVarP(&cmdShowDeleted, "show-deleting", "", false, "Show clusters which are currently being deleted (only with cluster release > 10.0.0).")
 	Command.Flags().StringVarP(&cmdSelector, "selector", "l", "", "Label selector query to filter clusters on.")
 	Command.Flags().StringVarP(&cmdSort, "sort", "s", "id", fmt.Sprintf("Sort by one of the fields %s", getFormattedFilterFields(tableCols[:])))
+	Command.Flags().StringVarP(&cmdFilter, "filter", "", "", "Only list clusters matching all of the comma-separated conditions, e. g. 'release>=12.0.0,organization=acme'. Supported operators are =, !=, >, >=, <, <=.")
+	Command.Flags().BoolVarP(&cmdWithStatus, "with-status", "", false, fmt.Sprintf("Fetch and show status details for each cluster. Adds the fields %s.", strings.Join(statusTableCols[:], ", ")))
+	Command.Flags().IntVarP(&cmdConcurrency, "concurrency", "", 5, "Maximum number of clusters to fetch status details for in parallel, with --with-status.")
+	Command.Flags().BoolVarP(&flags.Watch, "watch", "w", false, "Refresh the list periodically, until interrupted.")
+	Command.Flags().DurationVarP(&flags.WatchInterval, "interval", "", watch.DefaultInterval, "Time between two refreshes, with --watch.")
 }
 
 type Arguments struct {
 	apiEndpoint       string
 	authToken         string
+	concurrency       int
+	filter            string
 	outputFormat      string
 	scheme            string
 	selector          string
 	showDeleting      bool
 	sortBy            string
 	userProvidedToken string
+	verbose           bool
+	watch             bool
+	watchInterval     time.Duration
+	withStatus        bool
 }
 
 func collectArguments() Arguments {
@@ -111,12 +164,18 @@ func collectArguments() Arguments {
 	return Arguments{
 		apiEndpoint:       endpoint,
 		authToken:         token,
+		concurrency:       cmdConcurrency,
+		filter:            cmdFilter,
 		outputFormat:      flags.OutputFormat,
 		scheme:            scheme,
 		selector:          cmdSelector,
 		showDeleting:      cmdShowDeleted,
 		sortBy:            cmdSort,
 		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+		watch:             flags.Watch,
+		watchInterval:     flags.WatchInterval,
+		withStatus:        cmdWithStatus,
 	}
 }
 
@@ -132,6 +191,16 @@ func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
 	errors.HandleCommonErrors(err)
 
 	// Display error
+	if labels.IsInvalidSelector(err) {
+		fmt.Println(color.RedString("Invalid label selector"))
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	if table.IsInvalidFilterError(err) {
+		fmt.Println(color.RedString("Invalid filter expression"))
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
 	fmt.Println(color.RedString(err.Error()))
 
 	os.Exit(1)
@@ -147,13 +216,49 @@ func verifyListClusterPreconditions(args Arguments) error {
 	if args.outputFormat != formatting.OutputFormatJSON && args.outputFormat != formatting.OutputFormatTable {
 		return microerror.Maskf(errors.OutputFormatInvalidError, "Output format '%s' is unknown", args.outputFormat)
 	}
+	if args.selector != "" {
+		_, err := labels.ParseSelector(args.selector)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+	if args.filter != "" {
+		_, err := createTable(args).ParseFilter(args.filter)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+	if args.watch {
+		err := watch.ValidateInterval(args.watchInterval)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
 
 	return nil
 }
 
 // printResult prints a table with all clusters the user has access to
 func printResult(cmd *cobra.Command, cmdLineArgs []string) {
-	output, err := getClustersOutput(arguments)
+	var (
+		output string
+		err    error
+	)
+
+	if arguments.watch {
+		err = watch.Run(watch.Config{
+			Fetch: func() (*watch.Frame, error) {
+				return getClustersFrame(arguments)
+			},
+			Interval:    arguments.watchInterval,
+			Interactive: watch.IsTerminal(),
+			JSON:        arguments.outputFormat == formatting.OutputFormatJSON,
+			Title:       cmd.CommandPath(),
+		})
+	} else {
+		output, err = getClustersOutput(arguments)
+	}
+
 	if err != nil {
 		client.HandleErrors(err)
 		errors.HandleCommonErrors(err)
@@ -172,20 +277,8 @@ func printResult(cmd *cobra.Command, cmdLineArgs []string) {
 				subtext = clientErr.ErrorDetails
 			}
 
-		case table.IsFieldNotFoundError(err):
-			headline = fmt.Sprintf("Cannot sort by attribute '%s'.", arguments.sortBy)
-			subtext = fmt.Sprintf(
-				"The attribute '%s' does not exist.\nYou can sort by any of these attributes: %v",
-				arguments.sortBy,
-				strings.Join(tableCols[:], ", "),
-			)
-
-		case table.IsMultipleFieldsMatchingError(err):
-			headline = fmt.Sprintf("Multiple attributes found for token '%s'.", arguments.sortBy)
-			subtext = fmt.Sprintf(
-				"Please provide the complete attribute.\nYou can sort by any of these attributes: %v",
-				strings.Join(tableCols[:], ", "),
-			)
+		case table.IsOptionsError(err):
+			headline, subtext = table.OptionsErrorMessage(err, arguments.sortBy, tableCols[:])
 
 		default:
 			headline = fmt.Sprintf("Error: %s", err.Error())
@@ -218,12 +311,26 @@ func getFormattedFilterFields(colNames []string) string {
 	return result
 }
 
-// getClustersOutput returns a table of clusters the user has access to
-func getClustersOutput(args Arguments) (string, error) {
+// clustersData is what has been fetched from the API to list clusters.
+type clustersData struct {
+	// clusters are the clusters to list. Deleted clusters are only
+	// included if seeing them is desired.
+	clusters []*models.V4ClusterListItem
+
+	// numDeleted is the number of clusters currently being deleted.
+	numDeleted int
+
+	// statuses holds the status details by cluster ID, if requested.
+	statuses map[string]*clusterStatus
+}
+
+// fetchClusters fetches the clusters the user has access to
+// and, if requested, their status details.
+func fetchClusters(args Arguments) (*clustersData, error) {
 	var err error
 	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
 	if err != nil {
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
 	auxParams := clientWrapper.DefaultAuxiliaryParams()
@@ -232,8 +339,14 @@ func getClustersOutput(args Arguments) (string, error) {
 	var response *clusters.GetClustersOK
 
 	if args.selector != "" {
+		selector, err := labels.ParseSelector(args.selector)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		selectorString := selector.String()
+
 		params := &models.V5ListClustersByLabelRequest{
-			Labels: &args.selector,
+			Labels: &selectorString,
 		}
 		response, err = clientWrapper.GetClustersByLabel(params, auxParams)
 	} else {
@@ -242,33 +355,61 @@ func getClustersOutput(args Arguments) (string, error) {
 
 	if err != nil {
 		if clienterror.IsUnauthorizedError(err) {
-			return "", microerror.Mask(errors.NotAuthorizedError)
+			return nil, microerror.Mask(errors.NotAuthorizedError)
 		}
 		if clienterror.IsAccessForbiddenError(err) {
-			return "", microerror.Mask(errors.AccessForbiddenError)
+			return nil, microerror.Mask(errors.AccessForbiddenError)
 		}
 
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
-	// Create the cluster list table.
-	cTable := createTable(args)
+	// A selector only returns a subset of clusters,
+	// which shouldn't replace the cached list.
+	if args.selector == "" {
+		clustercache.CacheClusters(args.apiEndpoint, response.Payload)
+	}
 
-	if args.outputFormat == formatting.OutputFormatJSON {
-		// Filter deleted clusters if seeing them is not desired.
-		var clusterList []*models.V4ClusterListItem
-		{
-			for _, cluster := range response.Payload {
-				if cluster.DeleteDate != nil && !args.showDeleting {
-					continue
-				}
+	data := &clustersData{}
+
+	// Filter deleted clusters if seeing them is not desired.
+	for _, cluster := range response.Payload {
+		if cluster.DeleteDate != nil {
+			data.numDeleted++
 
-				clusterList = append(clusterList, cluster)
+			if !args.showDeleting {
+				continue
 			}
 		}
 
+		data.clusters = append(data.clusters, cluster)
+	}
+
+	if args.withStatus {
+		data.statuses = fetchStatuses(data.clusters, args.concurrency, clientWrapper)
+	}
+
+	return data, nil
+}
+
+// getClustersOutput returns a table of clusters the user has access to
+func getClustersOutput(args Arguments) (string, error) {
+	data, err := fetchClusters(args)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	// Create the cluster list table.
+	cTable := createTable(args)
+
+	filter, err := cTable.ParseFilter(args.filter)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if args.outputFormat == formatting.OutputFormatJSON {
 		var output string
-		output, err = getJSONOutput(clusterList, cTable, arguments)
+		output, err = getJSONOutput(data, cTable, filter, args)
 		if err != nil {
 			return "", microerror.Mask(err)
 		}
@@ -276,31 +417,89 @@ func getClustersOutput(args Arguments) (string, error) {
 		return output, nil
 	}
 
-	numDeletedClusters := 0
-	numOtherClusters := 0
-	clusterIDs := make([]string, 0, len(response.Payload))
+	numClusters, err := fillTable(cTable, data, filter, args)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	// This function's output string.
+	output := ""
 
-	rows := make([][]string, 0, len(response.Payload))
-	for _, cluster := range response.Payload {
+	// Only show table when there is content.
+	if cTable.NumRows() > 0 {
+		output += cTable.String()
+	} else {
+		output += getEmptyTableMessage(numClusters)
+	}
+
+	if notes := getNotes(data, args); notes != "" {
+		output += "\n\n" + notes
+	}
+
+	return output, nil
+}
+
+// getClustersFrame returns the current state of the clusters for watch mode.
+func getClustersFrame(args Arguments) (*watch.Frame, error) {
+	data, err := fetchClusters(args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	cTable := createTable(args)
+
+	filter, err := cTable.ParseFilter(args.filter)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if args.outputFormat == formatting.OutputFormatJSON {
+		clustersAsMapList, err := getJSONMapList(data, cTable, filter, args)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		frame := &watch.Frame{Objects: map[string]interface{}{}}
+		for _, clusterMap := range clustersAsMapList {
+			frame.Objects[fmt.Sprintf("%v", clusterMap["id"])] = clusterMap
+		}
+
+		return frame, nil
+	}
+
+	numClusters, err := fillTable(cTable, data, filter, args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	frame := &watch.Frame{
+		Footer: getNotes(data, args),
+		Format: cTable.Format,
+	}
+	if cTable.NumRows() > 0 {
+		frame.Header = cTable.Header()
+		frame.Rows = cTable.VisibleRows()
+	} else {
+		frame.Footer = strings.TrimSpace(getEmptyTableMessage(numClusters) + "\n\n" + frame.Footer)
+	}
+
+	return frame, nil
+}
+
+// fillTable adds the clusters as rows to the table, filtered and sorted.
+// It returns the number of clusters before filtering.
+func fillTable(cTable *table.Table, data *clustersData, filter table.Filter, args Arguments) (int, error) {
+	rows := make([][]string, 0, len(data.clusters))
+	for _, cluster := range data.clusters {
 		created := util.ShortDate(util.ParseDate(cluster.CreateDate))
 		deleted := "n/a"
 
 		var secondsSinceDelete float64
 
 		if cluster.DeleteDate != nil {
-			numDeletedClusters++
-
-			if !args.showDeleting {
-				continue
-			}
-
 			deleted = util.ShortDate(util.ParseDate(cluster.DeleteDate.String()))
 			deleteTime := time.Time(*cluster.DeleteDate)
 			secondsSinceDelete = time.Now().Sub(deleteTime).Seconds()
-		} else {
-			clusterIDs = append(clusterIDs, cluster.ID)
-
-			numOtherClusters++
 		}
 
 		releaseVersion := cluster.ReleaseVersion
@@ -308,15 +507,18 @@ func getClustersOutput(args Arguments) (string, error) {
 			releaseVersion = "n/a"
 		}
 
+		// The 'Deleting since' cell is always present, so that the
+		// status cells line up with their columns, even if it's hidden.
 		fields := []string{
 			cluster.ID,
 			cluster.Owner,
 			cluster.Name,
 			releaseVersion,
 			created,
+			color.RedString(deleted),
 		}
-		if args.showDeleting {
-			fields = append(fields, color.RedString(deleted))
+		if status, ok := data.statuses[cluster.ID]; ok {
+			fields = append(fields, status.fields()...)
 		}
 
 		// Highlight row in red if old.
@@ -329,34 +531,77 @@ func getClustersOutput(args Arguments) (string, error) {
 		rows = append(rows, fields)
 	}
 	cTable.SetRows(rows)
+	cTable.FilterRows(filter)
 
-	err = sortTable(cTable, args)
+	err := sortTable(cTable, args)
 	if err != nil {
-		return "", microerror.Mask(err)
+		return 0, microerror.Mask(err)
 	}
 
-	clustercache.CacheIDs(args.apiEndpoint, clusterIDs)
+	return len(rows), nil
+}
 
-	// This function's output string.
-	output := ""
+// getEmptyTableMessage returns what to display instead of an empty table.
+func getEmptyTableMessage(numClusters int) string {
+	if numClusters > 0 {
+		return color.YellowString("No clusters matching the filter")
+	}
 
-	// Only show table when there is content.
-	if len(rows) > 0 {
-		output += cTable.String()
+	return color.YellowString("No clusters")
+}
+
+// getNotes returns the notes to display below the table, or an empty string.
+func getNotes(data *clustersData, args Arguments) string {
+	var notes []string
+
+	if !args.showDeleting && data.numDeleted > 0 {
+		if data.numDeleted == 1 {
+			notes = append(notes, fmt.Sprintf("There is 1 additional cluster currently being deleted. Add the %s flag to see it.", color.CyanString("--show-deleting")))
+		} else {
+			notes = append(notes, fmt.Sprintf("There are %d additional clusters currently being deleted. Add the %s flag to see them.", data.numDeleted, color.CyanString("--show-deleting")))
+		}
+	}
+
+	if note := getIncompleteStatusNote(data.clusters, data.statuses, args); note != "" {
+		notes = append(notes, note)
+	}
+
+	return strings.Join(notes, "\n\n")
+}
+
+// getIncompleteStatusNote returns a note on the clusters for which
+// not all status details could be fetched, or an empty string.
+func getIncompleteStatusNote(clusterList []*models.V4ClusterListItem, statuses map[string]*clusterStatus, args Arguments) string {
+	var incomplete []*models.V4ClusterListItem
+	for _, cluster := range clusterList {
+		if status, ok := statuses[cluster.ID]; ok && len(status.errors) > 0 {
+			incomplete = append(incomplete, cluster)
+		}
+	}
+
+	if len(incomplete) == 0 {
+		return ""
+	}
+
+	var output string
+	if len(incomplete) == 1 {
+		output = color.YellowString("Status details could not be fetched completely for 1 cluster.")
 	} else {
-		output += color.YellowString("No clusters")
+		output = color.YellowString("Status details could not be fetched completely for %d clusters.", len(incomplete))
 	}
 
-	if !args.showDeleting && numDeletedClusters > 0 {
-		output += "\n\n"
-		if numDeletedClusters == 1 {
-			output += fmt.Sprintf("There is 1 additional cluster currently being deleted. Add the %s flag to see it.", color.CyanString("--show-deleting"))
-		} else {
-			output += fmt.Sprintf("There are %d additional clusters currently being deleted. Add the %s flag to see them.", numDeletedClusters, color.CyanString("--show-deleting"))
+	if !args.verbose {
+		output += fmt.Sprintf(" Add the %s flag for details.", color.CyanString("--verbose"))
+		return output
+	}
+
+	for _, cluster := range incomplete {
+		for _, err := range statuses[cluster.ID].errors {
+			output += fmt.Sprintf("\n%s: %s", cluster.ID, err.Error())
 		}
 	}
 
-	return output, nil
+	return output
 }
 
 func createTable(args Arguments) *table.Table {
@@ -373,6 +618,7 @@ func createTable(args Arguments) *table.Table {
 		{
 			Name:        tableColOrg,
 			DisplayName: "ORGANIZATION",
+			Aliases:     []string{"owner"},
 			Sortable: sortable.Sortable{
 				SortType: sortable.String,
 			},
@@ -408,6 +654,55 @@ func createTable(args Arguments) *table.Table {
 			Hidden: !args.showDeleting,
 		},
 	}
+
+	if args.withStatus {
+		headers = append(headers, []table.Column{
+			{
+				Name:        tableColWorkers,
+				DisplayName: "WORKERS",
+				Sortable: sortable.Sortable{
+					SortType: sortable.String,
+				},
+			},
+			{
+				Name:        tableColNodesReady,
+				DisplayName: "NODES READY",
+				Sortable: sortable.Sortable{
+					SortType: sortable.String,
+				},
+			},
+			{
+				Name:        tableColNodePools,
+				DisplayName: "NODE POOLS",
+				Sortable: sortable.Sortable{
+					SortType: sortable.String,
+				},
+			},
+			{
+				Name:        tableColKubernetes,
+				DisplayName: "KUBERNETES",
+				Aliases:     []string{"k8s"},
+				Sortable: sortable.Sortable{
+					SortType: sortable.Semver,
+				},
+			},
+			{
+				Name:        tableColEOL,
+				DisplayName: "EOL",
+				Sortable: sortable.Sortable{
+					SortType: sortable.String,
+				},
+			},
+			{
+				Name:        tableColCondition,
+				DisplayName: "CONDITION",
+				Sortable: sortable.Sortable{
+					SortType: sortable.String,
+				},
+			},
+		}...)
+	}
+
 	t.SetColumns(headers)
 
 	return &t
@@ -433,20 +728,20 @@ func sortTable(cTable *table.Table, args Arguments) error {
 	return nil
 }
 
-func getJSONOutput(clusterList []*models.V4ClusterListItem, cTable *table.Table, args Arguments) (string, error) {
+func getJSONOutput(data *clustersData, cTable *table.Table, filter table.Filter, args Arguments) (string, error) {
 	var (
 		err    error
 		output []byte
 	)
 
 	// take the shortest route. no need to call json.Marshal
-	if clusterList == nil || len(clusterList) == 0 {
+	if len(data.clusters) == 0 {
 		return "[]", nil
 	}
 
-	// If there is nothing to sort, let's get this over with.
-	if len(clusterList) < 2 {
-		output, err = json.MarshalIndent(clusterList, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	// If there is nothing to filter or sort, let's get this over with.
+	if len(data.clusters) < 2 && len(filter) == 0 && data.statuses == nil {
+		output, err = json.MarshalIndent(data.clusters, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
 		if err != nil {
 			return "", microerror.Mask(err)
 		}
@@ -454,6 +749,27 @@ func getJSONOutput(clusterList []*models.V4ClusterListItem, cTable *table.Table,
 		return string(output), nil
 	}
 
+	clustersAsMapList, err := getJSONMapList(data, cTable, filter, args)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	if len(clustersAsMapList) == 0 {
+		return "[]", nil
+	}
+
+	output, err = json.MarshalIndent(clustersAsMapList, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return string(output), nil
+}
+
+// getJSONMapList returns the clusters as maps with the JSON field names as keys,
+// including the status details if fetched, filtered and sorted like the table.
+func getJSONMapList(data *clustersData, cTable *table.Table, filter table.Filter, args Arguments) ([]map[string]interface{}, error) {
+	var err error
+
 	sortByColumnName := tableColID
 	var sortByColumn table.Column
 	if args.sortBy != "" {
@@ -465,12 +781,12 @@ func getJSONOutput(clusterList []*models.V4ClusterListItem, cTable *table.Table,
 
 		colName, err = cTable.GetColumnNameFromInitials(sortByColumnName)
 		if err != nil {
-			return "", microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 
 		_, sortByColumn, err = cTable.GetColumnByName(colName)
 		if err != nil {
-			return "", microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 	}
 
@@ -482,6 +798,12 @@ func getJSONOutput(clusterList []*models.V4ClusterListItem, cTable *table.Table,
 		tableColOrg:           "owner",
 		tableColRelease:       "release_version",
 		tableColDeletingSince: "delete_date",
+		tableColWorkers:       "workers",
+		tableColNodesReady:    "nodes_ready",
+		tableColNodePools:     "node_pools",
+		tableColKubernetes:    "kubernetes_version",
+		tableColEOL:           "kubernetes_version_eol",
+		tableColCondition:     "condition",
 	}
 
 	// Convert cluster list to map, with the json field names as keys,
@@ -489,22 +811,31 @@ func getJSONOutput(clusterList []*models.V4ClusterListItem, cTable *table.Table,
 	var clustersAsMapList []map[string]interface{}
 	{
 		var j []byte
-		j, err = json.Marshal(clusterList)
+		j, err = json.Marshal(data.clusters)
 		if err != nil {
-			return "", microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 		err = json.Unmarshal(j, &clustersAsMapList)
 		if err != nil {
-			return "", microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 	}
 
-	table.SortMapSliceUsingColumnData(clustersAsMapList, sortByColumn, fieldMapping)
-
-	output, err = json.MarshalIndent(clustersAsMapList, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
-	if err != nil {
-		return "", microerror.Mask(err)
+	// Add the status details, if fetched.
+	if data.statuses != nil {
+		for _, clusterMap := range clustersAsMapList {
+			status, ok := data.statuses[fmt.Sprintf("%v", clusterMap["id"])]
+			if !ok {
+				continue
+			}
+			for key, value := range status.jsonFields() {
+				clusterMap[key] = value
+			}
+		}
 	}
 
-	return string(output), nil
+	clustersAsMapList = table.FilterMapSliceUsingColumnData(clustersAsMapList, filter, fieldMapping)
+	table.SortMapSliceUsingColumnData(clustersAsMapList, sortByColumn, fieldMapping)
+
+	return clustersAsMapList, nil
 }
@@ -1,13 +1,16 @@
 package clusters
 
 import (
+	"encoding/json"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/testutils"
 	"github.com/spf13/afero"
 )
@@ -266,3 +269,245 @@ func Test_ListClustersBySelector(t *testing.T) {
 
 	t.Log(jsonRepresentation)
 }
+
+// Test_ListClustersFiltered tests the --filter flag with table and JSON output.
+func Test_ListClustersFiltered(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{
+				"create_date": "2020-05-01T09:45:43Z",
+				"id": "eq9ar",
+				"name": "cluster1",
+				"owner": "acme",
+				"release_version": "11.2.1"
+			},
+			{
+				"create_date": "2020-05-06T10:07:28Z",
+				"id": "kr3pb",
+				"name": "cluster2",
+				"owner": "acme",
+				"release_version": "12.0.0"
+			},
+			{
+				"create_date": "2020-05-07T10:07:28Z",
+				"id": "wx8ak",
+				"name": "cluster3",
+				"owner": "other",
+				"release_version": "12.1.0"
+			}
+		]`))
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, outputFormat := range []string{"table", "json"} {
+		args := Arguments{
+			apiEndpoint:  mockServer.URL,
+			authToken:    "testtoken",
+			filter:       "release>=12.0.0,owner=acme",
+			outputFormat: outputFormat,
+		}
+
+		err = verifyListClusterPreconditions(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := getClustersOutput(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(output, "kr3pb") || strings.Contains(output, "eq9ar") || strings.Contains(output, "wx8ak") {
+			t.Errorf("Output format %s - Unexpected output:\n%s", outputFormat, output)
+		}
+	}
+}
+
+// Test_verifyListClusterPreconditionsFilter tests validating the filter expression.
+func Test_verifyListClusterPreconditionsFilter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		apiEndpoint:  "https://foo",
+		authToken:    "testtoken",
+		filter:       "flavor=vanilla",
+		outputFormat: "table",
+	}
+
+	err = verifyListClusterPreconditions(args)
+	if !table.IsInvalidFilterError(err) {
+		t.Errorf("Expected invalidFilterError, got %v", err)
+	}
+}
+
+// Test_ListClustersWithStatus tests the --with-status flag, where
+// fetching the node pools of one cluster fails.
+func Test_ListClustersWithStatus(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"create_date": "2020-05-01T09:45:43Z", "id": "v4abc", "name": "v4 cluster", "owner": "acme", "release_version": "9.0.0", "path": "/v4/clusters/v4abc/"},
+				{"create_date": "2020-05-06T10:07:28Z", "id": "v5def", "name": "v5 cluster", "owner": "acme", "release_version": "11.0.0", "path": "/v5/clusters/v5def/"},
+				{"create_date": "2020-05-07T10:07:28Z", "id": "v5err", "name": "broken cluster", "owner": "acme", "release_version": "11.0.0", "path": "/v5/clusters/v5err/"}
+			]`))
+		case "/v4/clusters/v4abc/status/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"cluster": {
+					"conditions": [
+						{"lastTransitionTime": "2020-05-01T10:00:00Z", "status": "True", "type": "Created"},
+						{"lastTransitionTime": "2020-06-01T10:00:00Z", "status": "True", "type": "Updating"}
+					],
+					"nodes": [
+						{"name": "master", "version": "9.0.0", "labels": {"role": "master"}},
+						{"name": "worker-1", "version": "9.0.0", "labels": {"role": "worker"}},
+						{"name": "worker-2", "version": "9.0.0", "labels": {"role": "worker"}}
+					],
+					"scaling": {"desiredCapacity": 3}
+				}
+			}`))
+		case "/v4/clusters/v5def/status/", "/v4/clusters/v5err/status/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"cluster": {"conditions": [{"lastTransitionTime": "2020-05-06T10:00:00Z", "status": "True", "type": "Created"}]}}`))
+		case "/v5/clusters/v5def/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "a7rc4", "name": "Pool 1", "scaling": {"min": 2, "max": 5}, "status": {"nodes": 4, "nodes_ready": 4}},
+				{"id": "6feel", "name": "Pool 2", "scaling": {"min": 3, "max": 15}, "status": {"nodes": 10, "nodes_ready": 9}}
+			]`))
+		case "/v5/clusters/v5err/nodepools/":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code": "INTERNAL_ERROR", "message": "Something went wrong"}`))
+		case "/v4/releases/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"timestamp": "2019-10-15T12:00:00Z", "version": "9.0.0", "active": true, "changelog": [], "components": [{"name": "kubernetes", "version": "1.14.6"}]},
+				{"timestamp": "2020-04-15T12:00:00Z", "version": "11.0.0", "active": true, "changelog": [], "components": [{"name": "kubernetes", "version": "1.16.8"}]}
+			]`))
+		case "/v4/info/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"general": {"kubernetes_versions": [{"minor_version": "1.14", "eol_date": "2020-01-01"}, {"minor_version": "1.16", "eol_date": "2100-01-01"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		apiEndpoint:  mockServer.URL,
+		authToken:    "testtoken",
+		concurrency:  2,
+		outputFormat: "table",
+		withStatus:   true,
+	}
+
+	output, err := getClustersOutput(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	lines := strings.Split(table.RemoveColors(output), "\n")
+	expectedPrefixes := []string{
+		"ID      ORGANIZATION   NAME             RELEASE   CREATED                  WORKERS   NODES READY   NODE POOLS   KUBERNETES   EOL   CONDITION",
+		"v4abc   acme           v4 cluster       9.0.0     2020 May 01, 09:45 UTC   3         n/a           n/a          1.14.6       yes   Updating",
+		"v5def   acme           v5 cluster       11.0.0    2020 May 06, 10:07 UTC   14        13            2            1.16.8       no    Created",
+		"v5err   acme           broken cluster   11.0.0    2020 May 07, 10:07 UTC   n/a       n/a           n/a          1.16.8       no    Created",
+		"",
+		"Status details could not be fetched completely for 1 cluster.",
+	}
+	if len(lines) != len(expectedPrefixes) {
+		t.Fatalf("Unexpected output:\n%s", output)
+	}
+	for i, prefix := range expectedPrefixes {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("Line %d - expected prefix\n%q\ngot\n%q", i, prefix, lines[i])
+		}
+	}
+
+	args.outputFormat = "json"
+	args.filter = "workers>5"
+	output, err = getClustersOutput(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var decoded []map[string]interface{}
+	err = json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		t.Fatalf("Output is not valid JSON: %s", err)
+	}
+	if len(decoded) != 1 || decoded[0]["id"] != "v5def" || decoded[0]["nodes_ready"] != float64(13) || decoded[0]["kubernetes_version_eol"] != false {
+		t.Errorf("Unexpected JSON output:\n%s", output)
+	}
+}
+
+// Test_getClustersFrame tests the cluster state used in watch mode.
+func Test_getClustersFrame(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"create_date": "2020-05-01T09:45:43Z", "id": "eq9ar", "name": "cluster1", "owner": "acme", "release_version": "11.2.1"},
+			{"create_date": "2020-05-06T10:07:28Z", "id": "kr3pb", "name": "cluster2", "owner": "acme", "release_version": "12.0.0"},
+			{"create_date": "2020-05-07T10:07:28Z", "delete_date": "2020-05-08T10:07:28Z", "id": "wx8ak", "name": "cluster3", "owner": "other", "release_version": "12.1.0"}
+		]`))
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		apiEndpoint:  mockServer.URL,
+		authToken:    "testtoken",
+		outputFormat: "table",
+		sortBy:       "release",
+	}
+
+	frame, err := getClustersFrame(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(frame.Header) != 5 || len(frame.Rows) != 2 || frame.Rows[0][0] != "eq9ar" || len(frame.Rows[0]) != 5 {
+		t.Errorf("Unexpected frame: %#v", frame)
+	}
+	if !strings.Contains(frame.Footer, "1 additional cluster currently being deleted") {
+		t.Errorf("Unexpected footer: %s", frame.Footer)
+	}
+
+	args.outputFormat = "json"
+	args.filter = "release>=12.0.0"
+	frame, err = getClustersFrame(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, ok := frame.Objects["kr3pb"]; !ok || len(frame.Objects) != 1 {
+		t.Errorf("Unexpected frame objects: %#v", frame.Objects)
+	}
+}
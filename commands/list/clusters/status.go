@@ -0,0 +1,233 @@
+package clusters
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/pkg/releaseinfo"
+)
+
+var (
+	// statusRequestTimeout is the timeout for each request made
+	// to fetch the status details of a cluster.
+	statusRequestTimeout = 10 * time.Second
+)
+
+// clusterStatus holds the details shown for a cluster with --with-status.
+// Fields are nil or empty if the details could not be fetched.
+type clusterStatus struct {
+	workers           *int64
+	nodesReady        *int64
+	nodePools         *int64
+	kubernetesVersion string
+	kubernetesEOL     *bool
+	condition         string
+
+	// errors contains the errors of all failed requests for the cluster.
+	errors []error
+}
+
+// isV5 returns whether the cluster supports node pools.
+func isV5(cluster *models.V4ClusterListItem) bool {
+	return strings.HasPrefix(cluster.Path, "/v5/")
+}
+
+// fetchStatuses fetches the status details of all given clusters, using up to
+// workers concurrent requests. The result is keyed by cluster ID. Failed requests
+// don't abort the others, their errors are recorded in the cluster's status.
+func fetchStatuses(clusterList []*models.V4ClusterListItem, workers int, clientWrapper *client.Wrapper) map[string]*clusterStatus {
+	statuses := make(map[string]*clusterStatus, len(clusterList))
+	if len(clusterList) == 0 {
+		return statuses
+	}
+
+	// Release data is the same for all clusters, so it's only fetched once.
+	releaseInfo, releaseInfoErr := releaseinfo.New(releaseinfo.Config{ClientWrapper: clientWrapper})
+
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(clusterList) {
+		workers = len(clusterList)
+	}
+
+	results := make([]*clusterStatus, len(clusterList))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = fetchStatus(clusterList[i], clientWrapper)
+			}
+		}()
+	}
+
+	for i := range clusterList {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, cluster := range clusterList {
+		status := results[i]
+
+		if releaseInfoErr != nil {
+			status.errors = append(status.errors, microerror.Mask(releaseInfoErr))
+		} else if cluster.ReleaseVersion != "" {
+			releaseData, err := releaseInfo.GetReleaseData(cluster.ReleaseVersion)
+			if err == nil {
+				status.kubernetesVersion = releaseData.K8sVersion
+				status.kubernetesEOL = &releaseData.IsK8sVersionEOL
+			}
+		}
+
+		statuses[cluster.ID] = status
+	}
+
+	return statuses
+}
+
+// fetchStatus fetches the cluster status and, for clusters supporting
+// node pools, the node pools of one cluster.
+func fetchStatus(cluster *models.V4ClusterListItem, clientWrapper *client.Wrapper) *clusterStatus {
+	status := &clusterStatus{}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = listClustersActivityName
+	auxParams.Timeout = statusRequestTimeout
+
+	clusterStatusResponse, err := clientWrapper.GetClusterStatus(cluster.ID, auxParams)
+	if err != nil {
+		status.errors = append(status.errors, microerror.Mask(err))
+	} else if clusterStatusResponse.Cluster != nil {
+		status.condition = latestCondition(clusterStatusResponse)
+
+		// The v4 cluster status lists nodes without their readiness,
+		// so nodesReady stays unknown.
+		if !isV5(cluster) {
+			workers := int64(clusterStatusResponse.Cluster.Scaling.DesiredCapacity)
+			status.workers = &workers
+		}
+	}
+
+	if isV5(cluster) {
+		nodePoolsResponse, err := clientWrapper.GetNodePools(cluster.ID, auxParams)
+		if err != nil {
+			status.errors = append(status.errors, microerror.Mask(err))
+		} else {
+			var workers, nodesReady int64
+			for _, np := range nodePoolsResponse.Payload {
+				if np.Status != nil {
+					workers += np.Status.Nodes
+					nodesReady += np.Status.NodesReady
+				}
+			}
+			nodePools := int64(len(nodePoolsResponse.Payload))
+
+			status.workers = &workers
+			status.nodesReady = &nodesReady
+			status.nodePools = &nodePools
+		}
+	}
+
+	return status
+}
+
+// latestCondition returns the type of the most recent condition
+// with status 'True', e. g. 'Created' or 'Updating'.
+func latestCondition(status *client.ClusterStatus) string {
+	conditions := status.Cluster.Conditions
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].LastTransitionTime.After(conditions[j].LastTransitionTime.Time)
+	})
+
+	for _, condition := range conditions {
+		if condition.Status == "True" {
+			return condition.Type
+		}
+	}
+
+	return ""
+}
+
+// formatCount returns a number as string, or a placeholder if unknown.
+func formatCount(n *int64) string {
+	if n == nil {
+		return "n/a"
+	}
+
+	return strconv.FormatInt(*n, 10)
+}
+
+// fields returns the table fields for the status columns.
+func (s *clusterStatus) fields() []string {
+	kubernetesVersion := "n/a"
+	if s.kubernetesVersion != "" {
+		kubernetesVersion = s.kubernetesVersion
+	}
+
+	eol := "n/a"
+	if s.kubernetesEOL != nil {
+		eol = "no"
+		if *s.kubernetesEOL {
+			eol = "yes"
+		}
+	}
+
+	condition := "n/a"
+	if s.condition != "" {
+		condition = s.condition
+	}
+
+	return []string{
+		formatCount(s.workers),
+		formatCount(s.nodesReady),
+		formatCount(s.nodePools),
+		kubernetesVersion,
+		eol,
+		condition,
+	}
+}
+
+// jsonFields returns the status details to add to a cluster's JSON output,
+// keyed by JSON field name. Unknown details are nil.
+func (s *clusterStatus) jsonFields() map[string]interface{} {
+	m := map[string]interface{}{
+		"workers":                nil,
+		"nodes_ready":            nil,
+		"node_pools":             nil,
+		"kubernetes_version":     nil,
+		"kubernetes_version_eol": nil,
+		"condition":              nil,
+	}
+	if s.workers != nil {
+		m["workers"] = *s.workers
+	}
+	if s.nodesReady != nil {
+		m["nodes_ready"] = *s.nodesReady
+	}
+	if s.nodePools != nil {
+		m["node_pools"] = *s.nodePools
+	}
+	if s.kubernetesVersion != "" {
+		m["kubernetes_version"] = s.kubernetesVersion
+	}
+	if s.kubernetesEOL != nil {
+		m["kubernetes_version_eol"] = *s.kubernetesEOL
+	}
+	if s.condition != "" {
+		m["condition"] = s.condition
+	}
+
+	return m
+}
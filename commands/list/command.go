@@ -8,15 +8,17 @@ import (
 	"github.com/giantswarm/gsctl/commands/list/endpoints"
 	"github.com/giantswarm/gsctl/commands/list/keypairs"
 	"github.com/giantswarm/gsctl/commands/list/nodepools"
+	"github.com/giantswarm/gsctl/commands/list/nodes"
 	"github.com/giantswarm/gsctl/commands/list/organizations"
 	"github.com/giantswarm/gsctl/commands/list/releases"
+	"github.com/giantswarm/gsctl/commands/list/templates"
 )
 
 var (
 	// Command is the command to list things.
 	Command = &cobra.Command{
 		Use:   "list",
-		Short: "List clusters, endpoints, key pairs, node pools, organizations, releases",
+		Short: "List clusters, endpoints, key pairs, node pools, nodes, organizations, releases, templates",
 		Long:  `Prints a list of the things you have access to.`,
 	}
 )
@@ -26,6 +28,8 @@ func init() {
 	Command.AddCommand(endpoints.Command)
 	Command.AddCommand(keypairs.Command)
 	Command.AddCommand(nodepools.Command)
+	Command.AddCommand(nodes.Command)
 	Command.AddCommand(organizations.Command)
 	Command.AddCommand(releases.Command)
+	Command.AddCommand(templates.Command)
 }
@@ -3,15 +3,19 @@ package endpoints
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/giantswarm/columnize"
 	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
 )
 
 var (
@@ -20,17 +24,57 @@ var (
 		Use:     "endpoints",
 		Aliases: []string{"endpoint"},
 		Short:   "List API endpoints",
-		Long:    `Prints a list of API endpoints you have used so far`,
-		Run:     listEndpoints,
+		Long: `Prints a list of API endpoints you have used so far
+
+Endpoints are sorted by alias, then by URL, unless the --sort flag is given.
+
+Examples:
+
+  gsctl list endpoints --filter 'logged-in=yes'
+
+  gsctl list endpoints --sort email
+`,
+		Run: listEndpoints,
 	}
+
+	cmdFilter string
+
+	cmdSort string
 )
 
+const (
+	tableColAlias    = "alias"
+	tableColEndpoint = "endpoint"
+	tableColEmail    = "email"
+	tableColSelected = "selected"
+	tableColLoggedIn = "logged-in"
+)
+
+var tableCols = [...]string{
+	tableColAlias,
+	tableColEndpoint,
+	tableColEmail,
+	tableColSelected,
+	tableColLoggedIn,
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], "", "endpoints", "logged-in=yes")
+}
+
 // Arguments are the arguments we pass to the actual functions
 // listing endpoints and printing endpoints lists
 // TODO: apiEndpoint is the only argument used. The rest can be removed.
 type Arguments struct {
 	apiEndpoint string
+	filter      string
 	scheme      string
+	sortBy      string
 	token       string
 }
 
@@ -42,25 +86,93 @@ func collectArguments() Arguments {
 	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
 	return Arguments{
 		apiEndpoint: endpoint,
+		filter:      cmdFilter,
 		token:       token,
 		scheme:      scheme,
+		sortBy:      cmdSort,
 	}
 }
 
 // listEndpoints prints a table with all endpoint URLs the user has used
 func listEndpoints(cmd *cobra.Command, args []string) {
 	myArgs := collectArguments()
-	output := endpointsTable(myArgs)
+	output, err := endpointsTable(myArgs)
+	if err != nil {
+		handleError(myArgs, err)
+		os.Exit(1)
+	}
 	if output != "" {
 		fmt.Println(output)
 	}
 }
 
+func handleError(args Arguments, err error) {
+	switch {
+	case table.IsOptionsError(err):
+		headline, subtext := table.OptionsErrorMessage(err, args.sortBy, tableCols[:])
+		fmt.Println(color.RedString(headline))
+		fmt.Println(subtext)
+	default:
+		fmt.Println(color.RedString(err.Error()))
+	}
+}
+
+// createTable returns the table used to resolve the attributes
+// endpoints can be sorted and filtered by.
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:     tableColAlias,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColEndpoint,
+			Aliases:  []string{"url"},
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColEmail,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColSelected,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColLoggedIn,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+	})
+
+	return &t
+}
+
 // endpointsTable returns a table of clusters the user has access to
-func endpointsTable(args Arguments) string {
+func endpointsTable(args Arguments) (string, error) {
+	eTable := createTable()
+
+	filter, err := eTable.ParseFilter(args.filter)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var sortByColumn table.Column
+	if args.sortBy != "" {
+		colName, err := eTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		_, sortByColumn, err = eTable.GetColumnByName(colName)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+	}
+
 	if len(config.Config.Endpoints()) == 0 {
 		return fmt.Sprintf("No endpoints configured.\n\nTo add an endpoint and authenticate for it, use\n\n\t%s\n",
-			color.YellowString("gsctl login <email> -e <endpoint>"))
+			color.YellowString("gsctl login <email> -e <endpoint>")), nil
 	}
 
 	// get keys (URLs) and sort by them
@@ -94,6 +206,27 @@ func endpointsTable(args Arguments) string {
 		return aliasi < aliasj
 	})
 
+	// apply the filter and sort order given by the user
+	{
+		filtered := make([]string, 0, len(endpointURLs))
+		for _, endpoint := range endpointURLs {
+			if filter.Matches(func(colName string) string { return columnValue(endpoint, colName, args) }) {
+				filtered = append(filtered, endpoint)
+			}
+		}
+		endpointURLs = filtered
+
+		if len(endpointURLs) == 0 {
+			return color.YellowString("No endpoints matching the filter"), nil
+		}
+
+		if sortByColumn.Name != "" {
+			table.SortSliceUsingColumnData(endpointURLs, sortByColumn, sortable.ASC, func(i int, colName string) string {
+				return columnValue(endpointURLs[i], colName, args)
+			})
+		}
+	}
+
 	// table headers
 	output := []string{}
 	headers := []string{}
@@ -153,5 +286,35 @@ func endpointsTable(args Arguments) string {
 		output = append(output, strings.Join(columns, "|"))
 	}
 
-	return columnize.SimpleFormat(output)
+	return columnize.SimpleFormat(output), nil
+}
+
+// columnValue returns the value of an endpoint for the given column.
+func columnValue(endpoint string, colName string, args Arguments) string {
+	endpointConfig := config.Config.EndpointConfig(endpoint)
+
+	switch colName {
+	case tableColAlias:
+		if endpointConfig.Alias != "" {
+			return endpointConfig.Alias
+		}
+	case tableColEndpoint:
+		return endpoint
+	case tableColEmail:
+		if endpointConfig.Email != "" {
+			return endpointConfig.Email
+		}
+	case tableColSelected:
+		if endpoint == args.apiEndpoint {
+			return "yes"
+		}
+		return "no"
+	case tableColLoggedIn:
+		if endpointConfig.Token != "" {
+			return "yes"
+		}
+		return "no"
+	}
+
+	return "n/a"
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/giantswarm/gscliauth/config"
 	"github.com/spf13/afero"
 
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -37,7 +38,10 @@ selected_endpoint: https://my.second.endpoint
 		apiEndpoint: config.Config.ChooseEndpoint(""),
 	}
 
-	table := endpointsTable(args)
+	table, err := endpointsTable(args)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if table == "" {
 		t.Error("Got no output where I expected a table")
 	}
@@ -48,3 +52,49 @@ selected_endpoint: https://my.second.endpoint
 		t.Errorf("Table does not contain expected row '%s'", testString)
 	}
 }
+
+// Test_ListEndpointsFilterSort tests filtering and sorting endpoints.
+func Test_ListEndpointsFilterSort(t *testing.T) {
+	yamlText := `last_version_check: 0001-01-01T00:00:00Z
+updated: 2017-09-29T11:23:15+02:00
+endpoints:
+  https://my.first.endpoint:
+    email: zoe@example.com
+    token: some-token
+    alias: first
+  https://my.second.endpoint:
+    email: adam@example.com
+    token: some-other-token
+  https://my.third.endpoint:
+    email: bob@example.com
+selected_endpoint: https://my.second.endpoint
+`
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, yamlText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{
+		apiEndpoint: config.Config.ChooseEndpoint(""),
+		filter:      "logged-in=yes",
+		sortBy:      "email",
+	}
+
+	output, err := endpointsTable(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(output, "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "my.second.endpoint") || !strings.Contains(lines[2], "my.first.endpoint") {
+		t.Errorf("Unexpected output:\n%s", output)
+	}
+
+	args = Arguments{sortBy: "e"}
+	_, err = endpointsTable(args)
+	if !table.IsMultipleFieldsMatchingError(err) {
+		t.Errorf("Expected multipleFieldsMatchingError, got %v", err)
+	}
+}
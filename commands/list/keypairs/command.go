@@ -22,22 +22,55 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
 	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/util"
 )
 
-const listKeypairsActivityName = "list-keypairs"
+const (
+	listKeypairsActivityName = "list-keypairs"
+
+	tableColCreated       = "created"
+	tableColExpires       = "expires"
+	tableColID            = "id"
+	tableColDescription   = "description"
+	tableColCommonName    = "common-name"
+	tableColOrganizations = "organizations"
+)
+
+var tableCols = [...]string{
+	tableColCreated,
+	tableColExpires,
+	tableColID,
+	tableColDescription,
+	tableColCommonName,
+	tableColOrganizations,
+}
 
 var (
 
 	// Command performs the "list keypairs" function
 	Command = &cobra.Command{
-		Use:    "keypairs",
-		Short:  "List key pairs for a cluster",
-		Long:   `Prints a list of key pairs for a cluster`,
+		Use:   "keypairs",
+		Short: "List key pairs for a cluster",
+		Long: `Prints a list of key pairs for a cluster
+
+Key pairs are sorted by creation date, unless the --sort flag is given.
+
+Examples:
+
+  gsctl list keypairs --cluster f01r4 --filter 'expires<2020-12-31'
+
+  gsctl list keypairs --cluster f01r4 --sort description
+`,
 		PreRun: printValidation,
 		Run:    printResult,
 	}
 
+	cmdFilter string
+
+	cmdSort string
+
 	arguments Arguments
 )
 
@@ -46,8 +79,10 @@ var (
 type Arguments struct {
 	apiEndpoint       string
 	clusterNameOrID   string
+	filter            string
 	full              bool
 	outputFormat      string
+	sortBy            string
 	token             string
 	userProvidedToken string
 	scheme            string
@@ -63,8 +98,10 @@ func collectArguments() Arguments {
 	return Arguments{
 		apiEndpoint:       endpoint,
 		clusterNameOrID:   flags.ClusterID,
+		filter:            cmdFilter,
 		full:              flags.Full,
 		outputFormat:      flags.OutputFormat,
+		sortBy:            cmdSort,
 		token:             token,
 		userProvidedToken: flags.Token,
 		scheme:            scheme,
@@ -86,6 +123,7 @@ func initFlags() {
 	Command.Flags().StringVarP(&flags.ClusterID, "cluster", "c", "", "Name/ID of the cluster to list key pairs for")
 	Command.Flags().BoolVarP(&flags.Full, "full", "", false, "Enables output of full, untruncated values")
 	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], tableColCreated, "key pairs", "expires<2020-12-31")
 
 	Command.MarkFlagRequired("cluster")
 }
@@ -99,6 +137,11 @@ func printValidation(cmd *cobra.Command, extraArgs []string) {
 		client.HandleErrors(err)
 		errors.HandleCommonErrors(err)
 
+		if table.IsOptionsError(err) {
+			handleError(err)
+			os.Exit(1)
+		}
+
 		fmt.Println(color.RedString(err.Error()))
 		os.Exit(1)
 	}
@@ -120,6 +163,11 @@ func listKeypairsValidate(args *Arguments) error {
 		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
 	}
 
+	err := createTable().ValidateOptions(args.sortBy, args.filter)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
 	if err != nil {
 		return microerror.Mask(err)
@@ -145,24 +193,7 @@ func printResult(cmd *cobra.Command, extraArgs []string) {
 
 	// error output
 	if err != nil {
-		client.HandleErrors(err)
-		errors.HandleCommonErrors(err)
-
-		var headline string
-		var subtext string
-
-		switch {
-		case errors.IsClusterNotFoundError(err):
-			headline = "The cluster does not exist."
-			subtext = fmt.Sprintf("We couldn't find the cluster '%s' via API endpoint %s.", arguments.clusterNameOrID, arguments.apiEndpoint)
-		default:
-			headline = err.Error()
-		}
-
-		fmt.Println(color.RedString(headline))
-		if subtext != "" {
-			fmt.Println(subtext)
-		}
+		handleError(err)
 		os.Exit(1)
 	}
 
@@ -177,7 +208,9 @@ func printResult(cmd *cobra.Command, extraArgs []string) {
 		fmt.Println(string(outputBytes))
 	} else {
 		// success output
-		if len(result.keypairs) == 0 {
+		if len(result.keypairs) == 0 && arguments.filter != "" {
+			fmt.Println(color.YellowString("No key pairs matching the filter."))
+		} else if len(result.keypairs) == 0 {
 			fmt.Println(color.YellowString("No key pairs available for this cluster."))
 			fmt.Println("You can create a new key pair using the 'gsctl create kubeconfig' or 'gsctl create keypair' command.")
 		} else {
@@ -252,14 +285,123 @@ func listKeypairs(args Arguments) (listKeypairsResult, error) {
 		return result, microerror.Mask(err)
 	}
 
-	// sort key pairs by create date (descending)
+	// sort key pairs by create date (ascending)
 	if len(response.Payload) > 1 {
 		sort.Slice(response.Payload[:], func(i, j int) bool {
 			return response.Payload[i].CreateDate < response.Payload[j].CreateDate
 		})
 	}
 
-	result.keypairs = response.Payload
+	kpTable := createTable()
+
+	filter, err := kpTable.ParseFilter(args.filter)
+	if err != nil {
+		return result, microerror.Mask(err)
+	}
+
+	result.keypairs = make([]*models.V4GetKeyPairsResponseItems, 0, len(response.Payload))
+	for _, keypair := range response.Payload {
+		if filter.Matches(func(colName string) string { return columnValue(keypair, colName) }) {
+			result.keypairs = append(result.keypairs, keypair)
+		}
+	}
+
+	if args.sortBy != "" {
+		colName, err := kpTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return result, microerror.Mask(err)
+		}
+		_, column, err := kpTable.GetColumnByName(colName)
+		if err != nil {
+			return result, microerror.Mask(err)
+		}
+
+		table.SortSliceUsingColumnData(result.keypairs, column, sortable.ASC, func(i int, colName string) string {
+			return columnValue(result.keypairs[i], colName)
+		})
+	}
 
 	return result, nil
 }
+
+// createTable returns the table used to resolve the attributes
+// key pairs can be sorted and filtered by.
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:     tableColCreated,
+			Sortable: sortable.Sortable{SortType: sortable.Date},
+		},
+		{
+			Name:     tableColExpires,
+			Sortable: sortable.Sortable{SortType: sortable.Date},
+		},
+		{
+			Name:     tableColID,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColDescription,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColCommonName,
+			Aliases:  []string{"cn"},
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColOrganizations,
+			Aliases:  []string{"o"},
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+	})
+
+	return &t
+}
+
+// columnValue returns the value of a key pair for the given column.
+func columnValue(keypair *models.V4GetKeyPairsResponseItems, colName string) string {
+	switch colName {
+	case tableColCreated:
+		return keypair.CreateDate
+	case tableColExpires:
+		createdTime := util.ParseDate(keypair.CreateDate)
+		expiryTime := createdTime.Add(time.Duration(keypair.TTLHours) * time.Hour)
+		return expiryTime.UTC().Format(time.RFC3339)
+	case tableColID:
+		return formatting.CleanKeypairID(keypair.ID)
+	case tableColDescription:
+		return keypair.Description
+	case tableColCommonName:
+		return keypair.CommonName
+	case tableColOrganizations:
+		return keypair.CertificateOrganizations
+	}
+
+	return "n/a"
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	var headline string
+	var subtext string
+
+	switch {
+	case errors.IsClusterNotFoundError(err):
+		headline = "The cluster does not exist."
+		subtext = fmt.Sprintf("We couldn't find the cluster '%s' via API endpoint %s.", arguments.clusterNameOrID, arguments.apiEndpoint)
+	case table.IsOptionsError(err):
+		headline, subtext = table.OptionsErrorMessage(err, arguments.sortBy, tableCols[:])
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -284,3 +285,109 @@ selected_endpoint: ` + mockServer.URL
 		})
 	}
 }
+
+// Test_ListKeyPairsFilterSort tests filtering and sorting key pairs.
+func Test_ListKeyPairsFilterSort(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPairsMockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if r.Method == "GET" && r.URL.Path == "/v4/clusters/" {
+			w.Write([]byte(`[{"id": "my-cluster", "name": "Name of the cluster", "owner": "acme"}]`))
+		} else {
+			w.Write([]byte(`[
+			{
+				"create_date": "2017-01-23T13:57:57.755631763Z",
+				"description": "Web UI",
+				"id": "74:2d:de",
+				"ttl_hours": 720
+			},
+			{
+				"create_date": "2017-03-17T12:41:23.053271166Z",
+				"description": "CI pipeline",
+				"id": "52:64:7d",
+				"ttl_hours": 24
+			},
+			{
+				"create_date": "2017-02-01T08:00:00.000000000Z",
+				"description": "Admin",
+				"id": "11:22:33",
+				"ttl_hours": 8760
+			}
+		]`))
+		}
+	}))
+	defer keyPairsMockServer.Close()
+
+	testCases := []struct {
+		filter      string
+		sortBy      string
+		expectedIDs []string
+	}{
+		{
+			expectedIDs: []string{"74:2d:de", "11:22:33", "52:64:7d"},
+		},
+		{
+			sortBy:      "desc",
+			expectedIDs: []string{"11:22:33", "52:64:7d", "74:2d:de"},
+		},
+		{
+			filter:      "expires<2017-06-01",
+			sortBy:      "expires",
+			expectedIDs: []string{"74:2d:de", "52:64:7d"},
+		},
+		{
+			filter:      "created>=2017-02-01,description!=admin",
+			expectedIDs: []string{"52:64:7d"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			args := Arguments{
+				apiEndpoint:     keyPairsMockServer.URL,
+				clusterNameOrID: "my-cluster",
+				filter:          tc.filter,
+				outputFormat:    "json",
+				sortBy:          tc.sortBy,
+				token:           "my-token",
+			}
+
+			err := listKeypairsValidate(&args)
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+
+			result, err := listKeypairs(args)
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+
+			ids := []string{}
+			for _, kp := range result.keypairs {
+				ids = append(ids, kp.ID)
+			}
+			if diff := cmp.Diff(tc.expectedIDs, ids); diff != "" {
+				t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
+			}
+		})
+	}
+
+	args := Arguments{
+		apiEndpoint:     keyPairsMockServer.URL,
+		clusterNameOrID: "my-cluster",
+		outputFormat:    "json",
+		sortBy:          "serial",
+		token:           "my-token",
+	}
+	err = listKeypairsValidate(&args)
+	if !table.IsFieldNotFoundError(err) {
+		t.Errorf("Expected fieldNotFoundError, got %v", err)
+	}
+}
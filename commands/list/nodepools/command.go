@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/giantswarm/columnize"
@@ -23,6 +24,10 @@ import (
 	"github.com/giantswarm/gsctl/flags"
 	"github.com/giantswarm/gsctl/formatting"
 	"github.com/giantswarm/gsctl/nodespec"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
+	"github.com/giantswarm/gsctl/pkg/watch"
 )
 
 var (
@@ -31,8 +36,9 @@ var (
 		Use:     "nodepools <cluster-name/cluster-id>",
 		Aliases: []string{"nps", "np"},
 
-		// Args: cobra.ExactArgs(1) guarantees that cobra will fail if no positional argument is given.
-		Args:  cobra.ExactArgs(1),
+		// Args: cobra.MaximumNArgs(1) lets cobra fail if more than one positional argument
+		// is given. Without an argument, the user can pick a cluster interactively.
+		Args:  cobra.MaximumNArgs(1),
 		Short: "List node pools",
 		Long: `Prints a list of the node pools of a cluster.
 
@@ -54,17 +60,56 @@ columns:
 	CPUS:                  Sum of CPU cores in nodes that are in state Ready
 	RAM (GB):              Sum of memory in GB of all nodes that are in state Ready
 
+Node pools are sorted by ID, unless the --sort flag is given. To only list
+some node pools, use the --filter flag with comma-separated conditions, for example:
+
+	gsctl list nodepools f01r4 --filter 'instance-type=m5.xlarge,nodes-ready<3' --sort name
+
+To follow scaling or an upgrade, use the --watch flag. The list is then refreshed
+periodically until interrupted. In a terminal, the table is redrawn in place and
+changed cells are highlighted. Otherwise, only added, modified and deleted node
+pools are printed, or JSON events with --output json:
+
+	gsctl list nodepools f01r4 --watch --interval 30s
+
 To see all available details for a cluster, use 'gsctl show nodepool <cluster-id>/<nodepool-id>'.
 
-To list all clusters you have access to, use 'gsctl list clusters'.`,
+To list all clusters you have access to, use 'gsctl list clusters'. When used in a
+terminal without a cluster argument, you can select the cluster from a list.`,
 		PreRun: printValidation,
 		Run:    printResult,
 	}
 
+	cmdFilter string
+
+	cmdSort string
+
 	arguments Arguments
 )
 
-const activityName = "list-nodepools"
+const (
+	activityName = "list-nodepools"
+
+	tableColID           = "id"
+	tableColName         = "name"
+	tableColAZ           = "az"
+	tableColInstanceType = "instance-type"
+	tableColNodesMin     = "nodes-min"
+	tableColNodesMax     = "nodes-max"
+	tableColNodesDesired = "nodes-desired"
+	tableColNodesReady   = "nodes-ready"
+)
+
+var tableCols = [...]string{
+	tableColID,
+	tableColName,
+	tableColAZ,
+	tableColInstanceType,
+	tableColNodesMin,
+	tableColNodesMax,
+	tableColNodesDesired,
+	tableColNodesReady,
+}
 
 func init() {
 	initFlags()
@@ -72,16 +117,23 @@ func init() {
 
 func initFlags() {
 	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], tableColID, "node pools", "nodes-ready<3")
+	Command.Flags().BoolVarP(&flags.Watch, "watch", "w", false, "Refresh the list periodically, until interrupted.")
+	Command.Flags().DurationVarP(&flags.WatchInterval, "interval", "", watch.DefaultInterval, "Time between two refreshes, with --watch.")
 }
 
 type Arguments struct {
 	apiEndpoint       string
 	authToken         string
 	clusterNameOrID   string
+	filter            string
 	outputFormat      string
 	scheme            string
+	sortBy            string
 	userProvidedToken string
 	verbose           bool
+	watch             bool
+	watchInterval     time.Duration
 }
 
 // collectArguments creates arguments based on command line flags and config.
@@ -90,14 +142,23 @@ func collectArguments(cmdLineArgs []string) Arguments {
 	token := config.Config.ChooseToken(endpoint, flags.Token)
 	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
 
+	clusterNameOrID := ""
+	if len(cmdLineArgs) > 0 {
+		clusterNameOrID = cmdLineArgs[0]
+	}
+
 	return Arguments{
 		apiEndpoint:       endpoint,
 		authToken:         token,
-		clusterNameOrID:   cmdLineArgs[0],
+		clusterNameOrID:   clusterNameOrID,
+		filter:            cmdFilter,
 		outputFormat:      flags.OutputFormat,
 		scheme:            scheme,
+		sortBy:            cmdSort,
 		userProvidedToken: flags.Token,
 		verbose:           flags.Verbose,
+		watch:             flags.Watch,
+		watchInterval:     flags.WatchInterval,
 	}
 }
 
@@ -108,16 +169,37 @@ func verifyPreconditions(args Arguments, positionalArgs []string) error {
 	if config.Config.Token == "" && args.authToken == "" {
 		return microerror.Mask(errors.NotLoggedInError)
 	}
+	if args.clusterNameOrID == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	}
 	if args.outputFormat != formatting.OutputFormatJSON && args.outputFormat != formatting.OutputFormatTable {
 		return microerror.Maskf(errors.OutputFormatInvalidError, "Output format '%s' is unknown", args.outputFormat)
 	}
 
+	err := createTable().ValidateOptions(args.sortBy, args.filter)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if args.watch {
+		err = watch.ValidateInterval(args.watchInterval)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	return nil
 }
 
 func printValidation(cmd *cobra.Command, positionalArgs []string) {
 	arguments = collectArguments(positionalArgs)
 	err := verifyPreconditions(arguments, positionalArgs)
+	if errors.IsClusterNameOrIDMissingError(err) {
+		// In interactive sessions, let the user pick a cluster.
+		if clusterID := picker.MissingCluster(arguments.apiEndpoint, arguments.userProvidedToken); clusterID != "" {
+			arguments.clusterNameOrID = clusterID
+			err = verifyPreconditions(arguments, positionalArgs)
+		}
+	}
 	if err != nil {
 		handleError(err)
 		os.Exit(1)
@@ -162,10 +244,34 @@ func fetchNodePools(args Arguments) ([]*models.V5GetNodePoolsResponseItems, erro
 		return response.Payload[i].ID < response.Payload[j].ID
 	})
 
+	nodePoolIDs := make([]string, 0, len(response.Payload))
+	for _, np := range response.Payload {
+		nodePoolIDs = append(nodePoolIDs, np.ID)
+	}
+	clustercache.CacheNodePoolIDs(args.apiEndpoint, clusterID, nodePoolIDs)
+
 	return response.Payload, nil
 }
 
 func printResult(cmd *cobra.Command, positionalArgs []string) {
+	if arguments.watch {
+		err := watch.Run(watch.Config{
+			Fetch: func() (*watch.Frame, error) {
+				return getFrame(arguments)
+			},
+			Interval:    arguments.watchInterval,
+			Interactive: watch.IsTerminal(),
+			JSON:        arguments.outputFormat == formatting.OutputFormatJSON,
+			Title:       cmd.CommandPath() + " " + arguments.clusterNameOrID,
+		})
+		if err != nil {
+			handleError(err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	nodePools, err := fetchNodePools(arguments)
 	if err != nil {
 		handleError(err)
@@ -177,6 +283,17 @@ func printResult(cmd *cobra.Command, positionalArgs []string) {
 		return
 	}
 
+	nodePools, err = selectNodePools(nodePools, arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	if len(nodePools) == 0 && arguments.outputFormat == formatting.OutputFormatTable {
+		fmt.Println(color.YellowString("No node pools matching the filter"))
+		return
+	}
+
 	output, err := getOutput(nodePools, arguments.outputFormat)
 	if err != nil {
 		handleError(err)
@@ -186,6 +303,167 @@ func printResult(cmd *cobra.Command, positionalArgs []string) {
 	fmt.Println(output)
 }
 
+// getFrame returns the current state of the node pools for watch mode.
+func getFrame(args Arguments) (*watch.Frame, error) {
+	nodePools, err := fetchNodePools(args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	numNodePools := len(nodePools)
+
+	nodePools, err = selectNodePools(nodePools, args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if args.outputFormat == formatting.OutputFormatJSON {
+		frame := &watch.Frame{Objects: map[string]interface{}{}}
+		for _, np := range nodePools {
+			frame.Objects[np.ID] = np
+		}
+
+		return frame, nil
+	}
+
+	if numNodePools == 0 {
+		return &watch.Frame{Footer: color.YellowString("This cluster has no node pools")}, nil
+	}
+	if len(nodePools) == 0 {
+		return &watch.Frame{Footer: color.YellowString("No node pools matching the filter")}, nil
+	}
+
+	rows, colConfig, err := getTable(nodePools)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	frame := watch.NewFrame(rows, true)
+	frame.Format = func(rows []string) string {
+		return columnize.Format(rows, colConfig)
+	}
+
+	return frame, nil
+}
+
+// createTable returns the table used to resolve the attributes
+// node pools can be sorted and filtered by.
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:     tableColID,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColName,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColAZ,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColInstanceType,
+			Aliases:  []string{"vm-size"},
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColNodesMin,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColNodesMax,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColNodesDesired,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColNodesReady,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+	})
+
+	return &t
+}
+
+// columnValue returns the value of a node pool for the given column.
+func columnValue(np *models.V5GetNodePoolsResponseItems, colName string) string {
+	switch colName {
+	case tableColID:
+		return np.ID
+	case tableColName:
+		return np.Name
+	case tableColAZ:
+		return formatting.AvailabilityZonesList(np.AvailabilityZones)
+	case tableColInstanceType:
+		if np.Status != nil && len(np.Status.InstanceTypes) > 0 {
+			return strings.Join(np.Status.InstanceTypes, ",")
+		}
+		if np.NodeSpec != nil && np.NodeSpec.Aws != nil {
+			return np.NodeSpec.Aws.InstanceType
+		}
+		if np.NodeSpec != nil && np.NodeSpec.Azure != nil {
+			return np.NodeSpec.Azure.VMSize
+		}
+	case tableColNodesMin:
+		if np.Scaling != nil && np.Scaling.Min != nil {
+			return strconv.FormatInt(*np.Scaling.Min, 10)
+		}
+		return "0"
+	case tableColNodesMax:
+		if np.Scaling != nil {
+			return strconv.FormatInt(np.Scaling.Max, 10)
+		}
+	case tableColNodesDesired:
+		if np.Status != nil {
+			return strconv.FormatInt(np.Status.Nodes, 10)
+		}
+	case tableColNodesReady:
+		if np.Status != nil {
+			return strconv.FormatInt(np.Status.NodesReady, 10)
+		}
+	}
+
+	return "n/a"
+}
+
+// selectNodePools applies the filter and sort order given by the user.
+func selectNodePools(nps []*models.V5GetNodePoolsResponseItems, args Arguments) ([]*models.V5GetNodePoolsResponseItems, error) {
+	npTable := createTable()
+
+	filter, err := npTable.ParseFilter(args.filter)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	result := make([]*models.V5GetNodePoolsResponseItems, 0, len(nps))
+	for _, np := range nps {
+		if filter.Matches(func(colName string) string { return columnValue(np, colName) }) {
+			result = append(result, np)
+		}
+	}
+
+	if args.sortBy != "" {
+		colName, err := npTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		_, column, err := npTable.GetColumnByName(colName)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		table.SortSliceUsingColumnData(result, column, sortable.ASC, func(i int, colName string) string {
+			return columnValue(result[i], colName)
+		})
+	}
+
+	return result, nil
+}
+
 func formatNodesReady(nodes, nodesReady int64) string {
 	if nodes == nodesReady {
 		return strconv.FormatInt(nodesReady, 10)
@@ -208,31 +486,32 @@ func getOutput(nps []*models.V5GetNodePoolsResponseItems, outputFormat string) (
 		return string(outputBytes), nil
 	}
 
-	var output string
-	var err error
+	rows, colConfig, err := getTable(nps)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return columnize.Format(rows, colConfig), nil
+}
+
+// getTable returns the table rows including the header, with cells separated
+// by '|', and the column configuration for the provider of the node pools.
+func getTable(nps []*models.V5GetNodePoolsResponseItems) ([]string, *columnize.Config, error) {
 	np := nps[0]
 
 	if np.NodeSpec.Aws != nil && np.NodeSpec.Azure == nil {
-		output, err = getOutputAWS(nps)
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
+		return getTableAWS(nps)
 	} else if np.NodeSpec.Azure != nil && np.NodeSpec.Aws == nil {
-		output, err = getOutputAzure(nps)
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
-	} else {
-		return "", microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
+		return getTableAzure(nps)
 	}
 
-	return output, nil
+	return nil, nil, microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
 }
 
-func getOutputAWS(nps []*models.V5GetNodePoolsResponseItems) (string, error) {
+func getTableAWS(nps []*models.V5GetNodePoolsResponseItems) ([]string, *columnize.Config, error) {
 	awsInfo, err := nodespec.NewAWS()
 	if err != nil {
-		return "", microerror.Mask(err)
+		return nil, nil, microerror.Mask(err)
 	}
 
 	headers := []string{
@@ -259,7 +538,7 @@ func getOutputAWS(nps []*models.V5GetNodePoolsResponseItems) (string, error) {
 		if nodespec.IsInstanceTypeNotFoundErr(err) {
 			// We deliberately ignore "instance type not found", but respect all other errors.
 		} else if err != nil {
-			return "", microerror.Mask(err)
+			return nil, nil, microerror.Mask(err)
 		}
 
 		var sumCPUs string
@@ -330,13 +609,13 @@ func getOutputAWS(nps []*models.V5GetNodePoolsResponseItems) (string, error) {
 		&columnize.ColumnSpecification{Alignment: columnize.AlignRight},
 	}
 
-	return columnize.Format(table, colConfig), nil
+	return table, colConfig, nil
 }
 
-func getOutputAzure(nps []*models.V5GetNodePoolsResponseItems) (string, error) {
+func getTableAzure(nps []*models.V5GetNodePoolsResponseItems) ([]string, *columnize.Config, error) {
 	azureInfo, err := nodespec.NewAzureProvider()
 	if err != nil {
-		return "", microerror.Mask(err)
+		return nil, nil, microerror.Mask(err)
 	}
 
 	headers := []string{
@@ -360,7 +639,7 @@ func getOutputAzure(nps []*models.V5GetNodePoolsResponseItems) (string, error) {
 		if nodespec.IsVMSizeNotFoundErr(err) {
 			// We deliberately ignore "vm size not found", but respect all other errors.
 		} else if err != nil {
-			return "", microerror.Mask(err)
+			return nil, nil, microerror.Mask(err)
 		}
 
 		var sumCPUs string
@@ -432,7 +711,7 @@ func getOutputAzure(nps []*models.V5GetNodePoolsResponseItems) (string, error) {
 		&columnize.ColumnSpecification{Alignment: columnize.AlignRight},
 	}
 
-	return columnize.Format(table, colConfig), nil
+	return table, colConfig, nil
 }
 
 func handleError(err error) {
@@ -446,6 +725,8 @@ func handleError(err error) {
 	case errors.IsClusterDoesNotSupportNodePools(err):
 		headline = "This cluster does not support node pools."
 		subtext = "Node pools cannot be listed for this cluster. Please use 'gsctl show cluster' to get information on worker nodes."
+	case table.IsOptionsError(err):
+		headline, subtext = table.OptionsErrorMessage(err, arguments.sortBy, tableCols[:])
 	default:
 		headline = err.Error()
 	}
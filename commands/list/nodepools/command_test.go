@@ -1,15 +1,18 @@
 package nodepools
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
 
 	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
 
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -242,3 +245,131 @@ np002-3  np002-3  2    Standard_D4s_v3           3/10              0
 		})
 	}
 }
+
+// Test_selectNodePools tests filtering and sorting node pools.
+func Test_selectNodePools(t *testing.T) {
+	var nodePools []*models.V5GetNodePoolsResponseItems
+	err := json.Unmarshal([]byte(`[
+		{"id": "6feel", "name": "Application servers", "availability_zones": ["eu-west-1a", "eu-west-1b"], "scaling": {"min": 3, "max": 15}, "node_spec": {"aws": {"instance_type": "p3.2xlarge"}}, "status": {"nodes": 10, "nodes_ready": 9}},
+		{"id": "a6bf4", "name": "New node pool", "availability_zones": ["eu-west-1c"], "scaling": {"min": 3, "max": 3}, "node_spec": {"aws": {"instance_type": "m5.2xlarge"}}, "status": {"nodes": 0, "nodes_ready": 0}},
+		{"id": "a7rc4", "name": "Batch number crunching", "availability_zones": ["eu-west-1d"], "scaling": {"min": 2, "max": 5}, "node_spec": {"aws": {"instance_type": "p3.8xlarge"}}, "status": {"nodes": 4, "nodes_ready": 4}}
+	]`), &nodePools)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		args         Arguments
+		expectedIDs  []string
+		errorMatcher func(error) bool
+	}{
+		{
+			args:        Arguments{sortBy: "id"},
+			expectedIDs: []string{"6feel", "a6bf4", "a7rc4"},
+		},
+		{
+			args:        Arguments{sortBy: "nodes-r"},
+			expectedIDs: []string{"a6bf4", "a7rc4", "6feel"},
+		},
+		{
+			args:        Arguments{sortBy: "name", filter: "nodes-ready>=4"},
+			expectedIDs: []string{"6feel", "a7rc4"},
+		},
+		{
+			args:        Arguments{filter: "vm-size!=m5.2xlarge,nodes-max<10"},
+			expectedIDs: []string{"a7rc4"},
+		},
+		{
+			args:         Arguments{sortBy: "nodes"},
+			errorMatcher: table.IsMultipleFieldsMatchingError,
+		},
+		{
+			args:         Arguments{filter: "cpus>4"},
+			errorMatcher: table.IsInvalidFilterError,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			result, err := selectNodePools(nodePools, tc.args)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Errorf("Case %d - Unexpected error: %v", i, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+
+			ids := []string{}
+			for _, np := range result {
+				ids = append(ids, np.ID)
+			}
+			if diff := cmp.Diff(tc.expectedIDs, ids); diff != "" {
+				t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
+			}
+		})
+	}
+}
+
+// Test_getFrame tests the node pool state used in watch mode.
+func Test_getFrame(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v5/clusters/cluster-id/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "a7rc4", "name": "Batch number crunching", "availability_zones": ["eu-west-1d"], "scaling": {"min": 2, "max": 5}, "node_spec": {"aws": {"instance_type": "p3.8xlarge", "instance_distribution": {"on_demand_base_capacity": 0, "on_demand_percentage_above_base_capacity": 0}}}, "status": {"nodes": 4, "nodes_ready": 4}},
+				{"id": "6feel", "name": "Application servers", "availability_zones": ["eu-west-1a"], "scaling": {"min": 3, "max": 15}, "node_spec": {"aws": {"instance_type": "p3.2xlarge", "instance_distribution": {"on_demand_base_capacity": 0, "on_demand_percentage_above_base_capacity": 0}}}, "status": {"nodes": 10, "nodes_ready": 9}}
+			]`))
+		case "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "cluster-id", "name": "Name of the cluster", "owner": "acme"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	configDir := testutils.TempDir(fs)
+	config.Initialize(fs, configDir)
+
+	args := Arguments{
+		clusterNameOrID: "cluster-id",
+		apiEndpoint:     mockServer.URL,
+		authToken:       "my-token",
+		outputFormat:    "table",
+		sortBy:          "id",
+	}
+
+	frame, err := getFrame(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(frame.Header) != 13 || len(frame.Rows) != 2 || frame.Rows[0][0] != "6feel" || frame.Rows[1][0] != "a7rc4" {
+		t.Errorf("Unexpected frame: %#v", frame)
+	}
+
+	args.filter = "nodes-ready>10"
+	frame, err = getFrame(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(frame.Rows) != 0 || frame.Footer == "" {
+		t.Errorf("Expected empty frame with footer, got %#v", frame)
+	}
+
+	args.filter = ""
+	args.outputFormat = "json"
+	frame, err = getFrame(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, ok := frame.Objects["a7rc4"]; !ok || len(frame.Objects) != 2 {
+		t.Errorf("Unexpected frame objects: %#v", frame.Objects)
+	}
+}
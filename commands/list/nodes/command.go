@@ -0,0 +1,530 @@
+// Package nodes implements the 'list nodes' sub-command.
+package nodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/apiextensions/v2/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/util"
+)
+
+var (
+	// Command performs the "list nodes" function
+	Command = &cobra.Command{
+		Use:     "nodes <cluster-name/cluster-id>",
+		Aliases: []string{"node"},
+
+		// Args: cobra.MaximumNArgs(1) lets cobra fail if more than one positional argument
+		// is given. Without an argument, the user can pick a cluster interactively.
+		Args:  cobra.MaximumNArgs(1),
+		Short: "List nodes",
+		Long: `Prints a list of the nodes of a cluster, as reported in the cluster status.
+
+The result will be a table with the following details in columns:
+
+	NAME:              Kubernetes node name
+	ROLE:              Whether the node is a master or a worker
+	NODE POOL:         ID of the node pool the node belongs to, if any
+	INSTANCE TYPE:     EC2 instance type or Azure VM size of the node
+	KUBERNETES:        Kubernetes version of the cluster's release. During an
+	                   upgrade, nodes with an older operator version still run
+	                   the Kubernetes version of the previous release.
+	OPERATOR VERSION:  Version of the provider operator that rolled out the node.
+	                   During an upgrade, nodes still showing the old version
+	                   have not been replaced yet.
+	LAST TRANSITION:   Time of the node's last status transition
+
+The cluster status provided by the API does not contain node readiness. Nodes
+are listed once they have joined the cluster, whether they are Ready or not.
+Use 'kubectl get nodes' with a kubeconfig for the cluster to check readiness.
+
+Examples:
+
+  gsctl list nodes f01r4
+
+  gsctl list nodes "Cluster name" --nodepool a7k9m
+
+  gsctl list nodes f01r4 --sort operator-version --output json
+
+  gsctl list nodes f01r4 --filter 'role=worker,operator-version<2.3.0'
+
+When used in a terminal without a cluster argument, you can select the cluster
+from a list.`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	cmdNodePoolID string
+
+	cmdSort string
+
+	cmdFilter string
+
+	arguments Arguments
+)
+
+const (
+	activityName = "list-nodes"
+
+	tableColName            = "name"
+	tableColRole            = "role"
+	tableColNodePool        = "nodepool"
+	tableColInstanceType    = "instance-type"
+	tableColKubernetes      = "kubernetes"
+	tableColOperatorVersion = "operator-version"
+	tableColLastTransition  = "last-transition"
+
+	roleMaster = "master"
+	roleWorker = "worker"
+)
+
+var tableCols = [...]string{
+	tableColName,
+	tableColRole,
+	tableColNodePool,
+	tableColInstanceType,
+	tableColKubernetes,
+	tableColOperatorVersion,
+	tableColLastTransition,
+}
+
+// Node labels we take node details from, in order of preference.
+var (
+	nodePoolLabels     = []string{"giantswarm.io/machine-deployment", "giantswarm.io/machine-pool"}
+	instanceTypeLabels = []string{"node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type"}
+)
+
+// Node is the representation of a node in our output.
+type Node struct {
+	Name              string            `json:"name"`
+	Role              string            `json:"role"`
+	NodePoolID        string            `json:"nodepool_id,omitempty"`
+	InstanceType      string            `json:"instance_type,omitempty"`
+	KubernetesVersion string            `json:"kubernetes_version,omitempty"`
+	OperatorVersion   string            `json:"operator_version,omitempty"`
+	LastTransition    string            `json:"last_transition,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+	Command.Flags().StringVarP(&cmdNodePoolID, "nodepool", "", "", "Only list the nodes of the node pool with this ID.")
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], tableColName, "nodes", "role=worker,operator-version<2.3.0")
+}
+
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	clusterNameOrID   string
+	filter            string
+	nodePoolID        string
+	outputFormat      string
+	sortBy            string
+	userProvidedToken string
+}
+
+// collectArguments creates arguments based on command line flags and config.
+func collectArguments(cmdLineArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	clusterNameOrID := ""
+	if len(cmdLineArgs) > 0 {
+		clusterNameOrID = cmdLineArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		clusterNameOrID:   clusterNameOrID,
+		filter:            cmdFilter,
+		nodePoolID:        cmdNodePoolID,
+		outputFormat:      flags.OutputFormat,
+		sortBy:            cmdSort,
+		userProvidedToken: flags.Token,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.clusterNameOrID == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	}
+	if args.outputFormat != formatting.OutputFormatJSON && args.outputFormat != formatting.OutputFormatTable {
+		return microerror.Maskf(errors.OutputFormatInvalidError, "Output format '%s' is unknown", args.outputFormat)
+	}
+	err := createTable().ValidateOptions(args.sortBy, args.filter)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+	if errors.IsClusterNameOrIDMissingError(err) {
+		// In interactive sessions, let the user pick a cluster.
+		if clusterID := picker.MissingCluster(arguments.apiEndpoint, arguments.userProvidedToken); clusterID != "" {
+			arguments.clusterNameOrID = clusterID
+			err = verifyPreconditions(arguments)
+		}
+	}
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+}
+
+// fetchNodes gets the nodes of a cluster from the cluster status.
+func fetchNodes(args Arguments) ([]Node, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	clusterID, err := clustercache.GetID(args.apiEndpoint, args.clusterNameOrID, clientWrapper)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	status, err := clientWrapper.GetClusterStatus(clusterID, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if status.Cluster == nil {
+		return []Node{}, nil
+	}
+
+	// The Kubernetes version is not essential, so we go on without it
+	// if it can't be found out.
+	kubernetesVersion, _ := fetchKubernetesVersion(clusterID, clientWrapper)
+
+	nodes := make([]Node, 0, len(status.Cluster.Nodes))
+	for _, statusNode := range status.Cluster.Nodes {
+		node := nodeFromStatus(statusNode)
+		node.KubernetesVersion = kubernetesVersion
+		if args.nodePoolID != "" && node.NodePoolID != args.nodePoolID {
+			continue
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// fetchKubernetesVersion returns the version of the kubernetes component
+// of the cluster's release.
+func fetchKubernetesVersion(clusterID string, clientWrapper *client.Wrapper) (string, error) {
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	clustersResponse, err := clientWrapper.GetClusters(auxParams)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	releaseVersion := ""
+	for _, cluster := range clustersResponse.Payload {
+		if cluster.ID == clusterID {
+			releaseVersion = cluster.ReleaseVersion
+			break
+		}
+	}
+	if releaseVersion == "" {
+		return "", nil
+	}
+
+	releasesResponse, err := clientWrapper.GetReleases(auxParams)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	for _, release := range releasesResponse.Payload {
+		if release.Version == nil || *release.Version != releaseVersion {
+			continue
+		}
+		for _, component := range release.Components {
+			if component.Name != nil && *component.Name == "kubernetes" && component.Version != nil {
+				return *component.Version, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// nodeFromStatus extracts the details we display from a cluster status node.
+func nodeFromStatus(statusNode v1alpha1.StatusClusterNode) Node {
+	node := Node{
+		Name:            statusNode.Name,
+		Role:            nodeRole(statusNode.Labels),
+		NodePoolID:      firstLabelValue(statusNode.Labels, nodePoolLabels),
+		InstanceType:    firstLabelValue(statusNode.Labels, instanceTypeLabels),
+		OperatorVersion: statusNode.Version,
+		Labels:          statusNode.Labels,
+	}
+
+	if !statusNode.LastTransitionTime.IsZero() {
+		node.LastTransition = util.ShortDate(statusNode.LastTransitionTime.UTC())
+	}
+
+	return node
+}
+
+// nodeRole returns 'master' for nodes labelled as such, 'worker' otherwise.
+func nodeRole(labels map[string]string) string {
+	if labels["role"] == roleMaster || labels["kubernetes.io/role"] == roleMaster {
+		return roleMaster
+	}
+	if _, ok := labels["node-role.kubernetes.io/master"]; ok {
+		return roleMaster
+	}
+
+	return roleWorker
+}
+
+func firstLabelValue(labels map[string]string, keys []string) string {
+	for _, key := range keys {
+		if val, ok := labels[key]; ok && val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	nodes, err := fetchNodes(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	if len(nodes) == 0 && arguments.outputFormat == formatting.OutputFormatTable {
+		if arguments.nodePoolID != "" {
+			fmt.Println(color.YellowString("No nodes found for node pool %s", arguments.nodePoolID))
+		} else {
+			fmt.Println(color.YellowString("No nodes found"))
+		}
+		return
+	}
+
+	output, err := getOutput(nodes, arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+func getOutput(nodes []Node, args Arguments) (string, error) {
+	nTable := createTable()
+
+	filter, err := nTable.ParseFilter(args.filter)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	sortByColName := tableColName
+	if args.sortBy != "" {
+		var err error
+		sortByColName, err = nTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+	}
+
+	if args.outputFormat == formatting.OutputFormatJSON {
+		return getJSONOutput(nodes, nTable, filter, sortByColName)
+	}
+
+	rows := make([][]string, 0, len(nodes))
+	for _, node := range nodes {
+		rows = append(rows, []string{
+			node.Name,
+			formatRole(node.Role),
+			valueOrPlaceholder(node.NodePoolID),
+			valueOrPlaceholder(node.InstanceType),
+			valueOrPlaceholder(node.KubernetesVersion),
+			valueOrPlaceholder(node.OperatorVersion),
+			valueOrPlaceholder(node.LastTransition),
+		})
+	}
+	nTable.SetRows(rows)
+	nTable.FilterRows(filter)
+
+	if nTable.NumRows() == 0 {
+		return color.YellowString("No nodes matching the filter"), nil
+	}
+
+	err = nTable.SortByColumnName(sortByColName, sortable.ASC)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return nTable.String(), nil
+}
+
+func getJSONOutput(nodes []Node, nTable *table.Table, filter table.Filter, sortByColName string) (string, error) {
+	if len(nodes) == 0 {
+		return "[]", nil
+	}
+
+	_, sortByColumn, err := nTable.GetColumnByName(sortByColName)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	// The table column names, mapped to the json field names in the node data structure.
+	fieldMapping := map[string]string{
+		tableColName:            "name",
+		tableColRole:            "role",
+		tableColNodePool:        "nodepool_id",
+		tableColInstanceType:    "instance_type",
+		tableColKubernetes:      "kubernetes_version",
+		tableColOperatorVersion: "operator_version",
+		tableColLastTransition:  "last_transition",
+	}
+
+	// Convert node list to map, with the json field names as keys,
+	// to be able to use same sorting logic as in the table.
+	var nodesAsMapList []map[string]interface{}
+	{
+		j, err := json.Marshal(nodes)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		err = json.Unmarshal(j, &nodesAsMapList)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+	}
+
+	nodesAsMapList = table.FilterMapSliceUsingColumnData(nodesAsMapList, filter, fieldMapping)
+	if len(nodesAsMapList) == 0 {
+		return "[]", nil
+	}
+
+	table.SortMapSliceUsingColumnData(nodesAsMapList, sortByColumn, fieldMapping)
+
+	output, err := json.MarshalIndent(nodesAsMapList, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return string(output), nil
+}
+
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:        tableColName,
+			DisplayName: "NAME",
+			Sortable:    sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:        tableColRole,
+			DisplayName: "ROLE",
+			Sortable:    sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:        tableColNodePool,
+			DisplayName: "NODE POOL",
+			Sortable:    sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:        tableColInstanceType,
+			DisplayName: "INSTANCE TYPE",
+			Sortable:    sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:        tableColKubernetes,
+			DisplayName: "KUBERNETES",
+			Sortable:    sortable.Sortable{SortType: sortable.Semver},
+		},
+		{
+			Name:        tableColOperatorVersion,
+			DisplayName: "OPERATOR VERSION",
+			Sortable:    sortable.Sortable{SortType: sortable.Semver},
+		},
+		{
+			Name:        tableColLastTransition,
+			DisplayName: "LAST TRANSITION",
+			Sortable:    sortable.Sortable{SortType: sortable.Date},
+		},
+	})
+
+	return &t
+}
+
+func formatRole(role string) string {
+	if role == roleMaster {
+		return color.YellowString(role)
+	}
+
+	return role
+}
+
+func valueOrPlaceholder(s string) string {
+	if s == "" {
+		return "n/a"
+	}
+
+	return s
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case table.IsOptionsError(err):
+		headline, subtext = table.OptionsErrorMessage(err, arguments.sortBy, tableCols[:])
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
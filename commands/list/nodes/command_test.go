@@ -0,0 +1,156 @@
+package nodes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/pkg/table"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+const statusResponse = `{
+	"cluster": {
+		"nodes": [
+			{"name": "ip-10-1-1-1", "version": "8.7.0", "lastTransitionTime": "2020-06-01T10:00:00Z", "labels": {"role": "master", "node.kubernetes.io/instance-type": "m5.xlarge"}},
+			{"name": "ip-10-1-2-2", "version": "8.6.1", "lastTransitionTime": "2020-05-01T10:00:00Z", "labels": {"role": "worker", "giantswarm.io/machine-deployment": "a7k9m", "beta.kubernetes.io/instance-type": "m5.2xlarge"}},
+			{"name": "ip-10-1-3-3", "version": "8.7.0", "labels": {"role": "worker", "giantswarm.io/machine-deployment": "b8l0n", "node.kubernetes.io/instance-type": "p3.2xlarge"}}
+		]
+	}
+}`
+
+func newMockServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "f01r4", "name": "Production", "owner": "acme", "create_date": "2020-01-01T00:00:00Z", "release_version": "11.0.0"}]`))
+		case "/v4/releases/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"timestamp": "2020-04-15T12:00:00Z", "version": "11.0.0", "active": true, "changelog": [], "components": [{"name": "kubernetes", "version": "1.16.8"}]}]`))
+		case "/v4/clusters/f01r4/status/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(statusResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+}
+
+// Test_fetchNodes tests extracting node details from the cluster status.
+func Test_fetchNodes(t *testing.T) {
+	mockServer := newMockServer()
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, err := fetchNodes(Arguments{apiEndpoint: mockServer.URL, authToken: "token", clusterNameOrID: "Production"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	got := [][]string{}
+	for _, n := range nodes {
+		got = append(got, []string{n.Name, n.Role, n.NodePoolID, n.InstanceType, n.KubernetesVersion, n.OperatorVersion, n.LastTransition})
+	}
+	expected := [][]string{
+		{"ip-10-1-1-1", "master", "", "m5.xlarge", "1.16.8", "8.7.0", "2020 Jun 01, 10:00 UTC"},
+		{"ip-10-1-2-2", "worker", "a7k9m", "m5.2xlarge", "1.16.8", "8.6.1", "2020 May 01, 10:00 UTC"},
+		{"ip-10-1-3-3", "worker", "b8l0n", "p3.2xlarge", "1.16.8", "8.7.0", ""},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Nodes not as expected (-want +got):\n%s", diff)
+	}
+
+	nodes, err = fetchNodes(Arguments{apiEndpoint: mockServer.URL, authToken: "token", clusterNameOrID: "f01r4", nodePoolID: "b8l0n"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "ip-10-1-3-3" {
+		t.Errorf("Expected only node ip-10-1-3-3, got %#v", nodes)
+	}
+}
+
+// Test_getOutput tests sorting in table and JSON output.
+func Test_getOutput(t *testing.T) {
+	nodes := []Node{
+		{Name: "c", Role: "worker", OperatorVersion: "8.7.0"},
+		{Name: "a", Role: "master", OperatorVersion: "8.7.0"},
+		{Name: "b", Role: "worker", OperatorVersion: "8.6.1"},
+	}
+
+	output, err := getOutput(nodes, Arguments{outputFormat: "table", sortBy: "o"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	lines := strings.Split(table.RemoveColors(output), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "NAME") || !strings.HasPrefix(lines[1], "b ") {
+		t.Errorf("Unexpected table output:\n%s", output)
+	}
+
+	output, err = getOutput(nodes, Arguments{outputFormat: "json", sortBy: "name"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var decoded []Node
+	err = json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		t.Fatalf("Output is not valid JSON: %s", err)
+	}
+	if decoded[0].Name != "a" || decoded[2].Name != "c" {
+		t.Errorf("JSON output not sorted by name:\n%s", output)
+	}
+
+	_, err = getOutput(nodes, Arguments{outputFormat: "table", sortBy: "unknown"})
+	if !table.IsFieldNotFoundError(err) {
+		t.Errorf("Expected fieldNotFoundError, got %v", err)
+	}
+}
+
+// Test_getOutputFiltered tests filtering in table and JSON output.
+func Test_getOutputFiltered(t *testing.T) {
+	nodes := []Node{
+		{Name: "c", Role: "worker", OperatorVersion: "8.7.0"},
+		{Name: "a", Role: "master", OperatorVersion: "8.7.0"},
+		{Name: "b", Role: "worker", OperatorVersion: "8.6.1"},
+	}
+
+	output, err := getOutput(nodes, Arguments{outputFormat: "table", filter: "role=worker,operator-version>=8.7.0"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	lines := strings.Split(table.RemoveColors(output), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "c ") {
+		t.Errorf("Unexpected table output:\n%s", output)
+	}
+
+	output, err = getOutput(nodes, Arguments{outputFormat: "json", filter: "operator-version<8.7"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var decoded []Node
+	err = json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		t.Fatalf("Output is not valid JSON: %s", err)
+	}
+	if len(decoded) != 1 || decoded[0].Name != "b" {
+		t.Errorf("JSON output not filtered by version:\n%s", output)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://foo", authToken: "token", clusterNameOrID: "f01r4", outputFormat: "table", filter: "role~worker"})
+	if !table.IsInvalidFilterError(err) {
+		t.Errorf("Expected invalidFilterError, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 
@@ -15,6 +16,8 @@ import (
 	"github.com/giantswarm/gsctl/client/clienterror"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
 )
 
 var (
@@ -23,22 +26,50 @@ var (
 		Use:     "organizations",
 		Aliases: []string{"orgs", "organisations"},
 		Short:   "List organizations",
-		Long:    `Prints a list of the organizations you are a member of`,
-		PreRun:  printValidation,
-		Run:     printResult,
+		Long: `Prints a list of the organizations you are a member of
+
+Examples:
+
+  gsctl list organizations
+
+  gsctl list organizations --filter 'organization!=giantswarm'
+`,
+		PreRun: printValidation,
+		Run:    printResult,
 	}
 
+	cmdFilter string
+
+	cmdSort string
+
 	arguments Arguments
 )
 
 const (
 	listOrgsActivityName = "list-organizations"
+
+	tableColOrganization = "organization"
 )
 
+var tableCols = [...]string{
+	tableColOrganization,
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], tableColOrganization, "organizations", "organization!=giantswarm")
+}
+
 type Arguments struct {
 	apiEndpoint       string
 	authToken         string
+	filter            string
 	scheme            string
+	sortBy            string
 	userProvidedToken string
 }
 
@@ -51,7 +82,9 @@ func collectArguments() Arguments {
 	return Arguments{
 		apiEndpoint:       endpoint,
 		authToken:         token,
+		filter:            cmdFilter,
 		scheme:            scheme,
+		sortBy:            cmdSort,
 		userProvidedToken: flags.Token,
 	}
 }
@@ -65,6 +98,8 @@ func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
 
 	client.HandleErrors(err)
 	errors.HandleCommonErrors(err)
+	handleError(err)
+	os.Exit(1)
 }
 
 func verifyListOrgsPreconditions(args Arguments) error {
@@ -74,6 +109,12 @@ func verifyListOrgsPreconditions(args Arguments) error {
 	if config.Config.Token == "" && args.authToken == "" {
 		return microerror.Mask(errors.NotLoggedInError)
 	}
+
+	err := createTable().ValidateOptions(args.sortBy, args.filter)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	return nil
 }
 
@@ -85,9 +126,23 @@ func verifyListOrgsPreconditions(args Arguments) error {
 func printResult(cmd *cobra.Command, extraArgs []string) {
 	output, err := orgsTable(arguments)
 	if err != nil {
-		client.HandleErrors(err)
-		errors.HandleCommonErrors(err)
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Print(output)
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
 
+	switch {
+	case table.IsOptionsError(err):
+		headline, subtext := table.OptionsErrorMessage(err, arguments.sortBy, tableCols[:])
+		fmt.Println(color.RedString(headline))
+		fmt.Println(subtext)
+	default:
 		if clientErr, ok := err.(*clienterror.APIError); ok {
 			fmt.Println(color.RedString(clientErr.ErrorMessage))
 			if clientErr.ErrorDetails != "" {
@@ -96,10 +151,7 @@ func printResult(cmd *cobra.Command, extraArgs []string) {
 		} else {
 			fmt.Println(color.RedString("Error: %s", err.Error()))
 		}
-		os.Exit(1)
 	}
-
-	fmt.Print(output)
 }
 
 // orgsTable fetches the organizations the user is a member of
@@ -128,18 +180,67 @@ func orgsTable(args Arguments) (string, error) {
 
 	var output string
 	if len(response.Payload) == 0 {
-		output = color.YellowString("No organizations available\n")
-	} else {
-		// sort orgs by Id
-		sort.Slice(response.Payload[:], func(i, j int) bool {
-			return response.Payload[i].ID < response.Payload[j].ID
-		})
+		return color.YellowString("No organizations available\n"), nil
+	}
+
+	// sort orgs by Id
+	sort.Slice(response.Payload[:], func(i, j int) bool {
+		return response.Payload[i].ID < response.Payload[j].ID
+	})
+
+	oTable := createTable()
+
+	filter, err := oTable.ParseFilter(args.filter)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	orgs := make([]*models.V4OrganizationListItem, 0, len(response.Payload))
+	for _, org := range response.Payload {
+		if filter.Matches(func(colName string) string { return org.ID }) {
+			orgs = append(orgs, org)
+		}
+	}
+
+	if len(orgs) == 0 {
+		return color.YellowString("No organizations matching the filter\n"), nil
+	}
 
-		output = color.CyanString("ORGANIZATION") + "\n"
-		for _, org := range response.Payload {
-			output = output + org.ID + "\n"
+	if args.sortBy != "" {
+		colName, err := oTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return "", microerror.Mask(err)
 		}
+		_, column, err := oTable.GetColumnByName(colName)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		table.SortSliceUsingColumnData(orgs, column, sortable.ASC, func(i int, colName string) string {
+			return orgs[i].ID
+		})
+	}
+
+	output = color.CyanString("ORGANIZATION") + "\n"
+	for _, org := range orgs {
+		output = output + org.ID + "\n"
 	}
 
 	return output, nil
 }
+
+// createTable returns the table used to resolve the attributes
+// organizations can be sorted and filtered by.
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:     tableColOrganization,
+			Aliases:  []string{"id"},
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+	})
+
+	return &t
+}
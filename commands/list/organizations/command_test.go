@@ -3,7 +3,12 @@ package organizations
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/gsctl/pkg/table"
 )
 
 // Test_ListOrganizationsSuccess tests the command with inputs that should succeed.
@@ -52,3 +57,41 @@ func Test_ListOrganizationsSuccess(t *testing.T) {
 
 	}
 }
+
+// Test_ListOrganizationsFiltered tests the --filter flag.
+func Test_ListOrganizationsFiltered(t *testing.T) {
+	orgsMockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": "giantswarm"}, {"id": "acme"}, {"id": "foo"}]`))
+	}))
+	defer orgsMockServer.Close()
+
+	args := Arguments{
+		authToken:   "some-token",
+		apiEndpoint: orgsMockServer.URL,
+		filter:      "organization!=giantswarm",
+		sortBy:      "org",
+	}
+
+	err := verifyListOrgsPreconditions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error in verifyListOrgsPreconditions: %s", err)
+	}
+
+	output, err := orgsTable(args)
+	if err != nil {
+		t.Fatalf("Unexpected error in orgsTable: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(table.RemoveColors(output)), "\n")
+	if diff := cmp.Diff([]string{"ORGANIZATION", "acme", "foo"}, lines); diff != "" {
+		t.Errorf("Output did not match.\nOutput: %s", diff)
+	}
+
+	args.filter = "members>2"
+	err = verifyListOrgsPreconditions(args)
+	if !table.IsInvalidFilterError(err) {
+		t.Errorf("Expected invalidFilterError, got %v", err)
+	}
+}
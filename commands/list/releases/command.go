@@ -22,10 +22,32 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
 	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/util"
 )
 
-const listReleasesActivityName = "list-releases"
+const (
+	listReleasesActivityName = "list-releases"
+
+	tableColVersion        = "version"
+	tableColStatus         = "status"
+	tableColCreated        = "created"
+	tableColKubernetes     = "kubernetes"
+	tableColContainerLinux = "containerlinux"
+	tableColCoreDNS        = "coredns"
+	tableColCalico         = "calico"
+)
+
+var tableCols = [...]string{
+	tableColVersion,
+	tableColStatus,
+	tableColCreated,
+	tableColKubernetes,
+	tableColContainerLinux,
+	tableColCoreDNS,
+	tableColCalico,
+}
 
 var (
 	// Command performs the "list releases" function
@@ -61,11 +83,24 @@ Output
 - COREDNS: The CodeDNS version provided.
 
 - CALICO: The Project Calico version provided.
+
+Releases are sorted by version, unless the --sort flag is given. Use --filter
+to only list releases matching all of the given comma-separated conditions.
+
+Examples:
+
+  gsctl list releases --filter 'status=active,kubernetes>=1.17.0'
+
+  gsctl list releases --sort created
 `,
 		PreRun: printValidation,
 		Run:    printResult,
 	}
 
+	cmdFilter string
+
+	cmdSort string
+
 	arguments Arguments
 )
 
@@ -77,14 +112,17 @@ func initFlags() {
 	Command.ResetFlags()
 
 	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], tableColVersion, "releases", "status=active,kubernetes>=1.17.0")
 }
 
 // Arguments are the actual arguments used to call the
 // listReleases() function.
 type Arguments struct {
 	apiEndpoint       string
+	filter            string
 	outputFormat      string
 	scheme            string
+	sortBy            string
 	token             string
 	userProvidedToken string
 }
@@ -98,7 +136,9 @@ func collectArguments() Arguments {
 
 	return Arguments{
 		apiEndpoint:       endpoint,
+		filter:            cmdFilter,
 		outputFormat:      flags.OutputFormat,
+		sortBy:            cmdSort,
 		token:             token,
 		scheme:            scheme,
 		userProvidedToken: flags.Token,
@@ -118,6 +158,11 @@ func printValidation(cmd *cobra.Command, extraArgs []string) {
 	client.HandleErrors(err)
 	errors.HandleCommonErrors(err)
 
+	if table.IsOptionsError(err) {
+		handleError(err)
+		os.Exit(1)
+	}
+
 	fmt.Println(color.RedString(err.Error()))
 	os.Exit(1)
 }
@@ -135,6 +180,11 @@ func listReleasesPreconditions(args *Arguments) error {
 		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
 	}
 
+	err := createTable().ValidateOptions(args.sortBy, args.filter)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	return nil
 }
 
@@ -175,6 +225,10 @@ func printResult(cmd *cobra.Command, extraArgs []string) {
 	}
 
 	// success
+	if len(releases) == 0 && arguments.filter != "" {
+		fmt.Println(color.YellowString("No releases matching the filter"))
+		return
+	}
 	if len(releases) == 0 {
 		fmt.Println(color.RedString("No releases available."))
 		fmt.Println("We cannot find any releases. Please contact the Giant Swarm support team to find out if there is a problem to be solved.")
@@ -277,7 +331,102 @@ func listReleases(clientWrapper *client.Wrapper, args Arguments) ([]*models.V4Re
 		return vj.GreaterThan(vi)
 	})
 
-	return response.Payload, nil
+	rTable := createTable()
+
+	filter, err := rTable.ParseFilter(args.filter)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	releases := make([]*models.V4ReleaseListItem, 0, len(response.Payload))
+	for _, release := range response.Payload {
+		if filter.Matches(func(colName string) string { return columnValue(release, colName) }) {
+			releases = append(releases, release)
+		}
+	}
+
+	if args.sortBy != "" {
+		colName, err := rTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		_, column, err := rTable.GetColumnByName(colName)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		table.SortSliceUsingColumnData(releases, column, sortable.ASC, func(i int, colName string) string {
+			return columnValue(releases[i], colName)
+		})
+	}
+
+	return releases, nil
+}
+
+// createTable returns the table used to resolve the attributes
+// releases can be sorted and filtered by.
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:     tableColVersion,
+			Sortable: sortable.Sortable{SortType: sortable.Semver},
+		},
+		{
+			Name:     tableColStatus,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColCreated,
+			Sortable: sortable.Sortable{SortType: sortable.Date},
+		},
+		{
+			Name:     tableColKubernetes,
+			Sortable: sortable.Sortable{SortType: sortable.Semver},
+		},
+		{
+			Name:     tableColContainerLinux,
+			Sortable: sortable.Sortable{SortType: sortable.Semver},
+		},
+		{
+			Name:     tableColCoreDNS,
+			Sortable: sortable.Sortable{SortType: sortable.Semver},
+		},
+		{
+			Name:     tableColCalico,
+			Sortable: sortable.Sortable{SortType: sortable.Semver},
+		},
+	})
+
+	return &t
+}
+
+// columnValue returns the value of a release for the given column.
+func columnValue(release *models.V4ReleaseListItem, colName string) string {
+	switch colName {
+	case tableColVersion:
+		if release.Version != nil {
+			return *release.Version
+		}
+	case tableColStatus:
+		if release.Active {
+			return "active"
+		}
+		return "inactive"
+	case tableColCreated:
+		if release.Timestamp != nil {
+			return *release.Timestamp
+		}
+	default:
+		for _, component := range release.Components {
+			if component.Name != nil && component.Version != nil && *component.Name == colName {
+				return *component.Version
+			}
+		}
+	}
+
+	return "n/a"
 }
 
 func formatKubernetesVersion(releaseInfo *releaseinfo.ReleaseInfo, version string) string {
@@ -305,5 +454,13 @@ func handleError(err error) {
 		return
 	}
 
+	switch {
+	case table.IsOptionsError(err):
+		headline, subtext := table.OptionsErrorMessage(err, arguments.sortBy, tableCols[:])
+		fmt.Println(color.RedString(headline))
+		fmt.Println(subtext)
+		return
+	}
+
 	fmt.Println(color.RedString("Error: %s", err.Error()))
 }
@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/giantswarm/gsctl/client"
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
 
+	"github.com/giantswarm/gsctl/pkg/table"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -224,3 +226,99 @@ func Test_ListReleases_Nonempty(t *testing.T) {
 		t.Error("Releases returned were not in the expected order.")
 	}
 }
+
+// Test_ListReleases_FilterSort tests filtering and sorting releases.
+func Test_ListReleases_FilterSort(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releasesMockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{
+				"timestamp": "2020-03-01T12:00:00Z",
+				"version": "11.2.0",
+				"active": true,
+				"components": [{"name": "kubernetes", "version": "1.16.7"}]
+			},
+			{
+				"timestamp": "2020-01-01T12:00:00Z",
+				"version": "9.0.0",
+				"active": false,
+				"components": [{"name": "kubernetes", "version": "1.15.5"}]
+			},
+			{
+				"timestamp": "2020-02-01T12:00:00Z",
+				"version": "12.0.0",
+				"active": true,
+				"components": [{"name": "kubernetes", "version": "1.17.2"}]
+			}
+		]`))
+	}))
+	defer releasesMockServer.Close()
+
+	testCases := []struct {
+		filter           string
+		sortBy           string
+		expectedVersions []string
+	}{
+		{
+			expectedVersions: []string{"9.0.0", "11.2.0", "12.0.0"},
+		},
+		{
+			sortBy:           "cr",
+			expectedVersions: []string{"9.0.0", "12.0.0", "11.2.0"},
+		},
+		{
+			filter:           "status=active",
+			sortBy:           "kubernetes",
+			expectedVersions: []string{"11.2.0", "12.0.0"},
+		},
+		{
+			filter:           "kubernetes<1.17.0,created>=2020-02-01",
+			expectedVersions: []string{"11.2.0"},
+		},
+	}
+
+	for i, tc := range testCases {
+		args := Arguments{
+			apiEndpoint:  releasesMockServer.URL,
+			filter:       tc.filter,
+			outputFormat: "table",
+			sortBy:       tc.sortBy,
+			token:        "my-token",
+		}
+
+		err = listReleasesPreconditions(&args)
+		if err != nil {
+			t.Fatalf("Case %d - Unexpected error: %s", i, err)
+		}
+
+		clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		releases, err := listReleases(clientWrapper, args)
+		if err != nil {
+			t.Fatalf("Case %d - Unexpected error: %s", i, err)
+		}
+
+		versions := []string{}
+		for _, r := range releases {
+			versions = append(versions, *r.Version)
+		}
+		if diff := cmp.Diff(tc.expectedVersions, versions); diff != "" {
+			t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
+		}
+	}
+
+	err = listReleasesPreconditions(&Arguments{apiEndpoint: releasesMockServer.URL, token: "my-token", outputFormat: "table", filter: "kernel>5"})
+	if !table.IsInvalidFilterError(err) {
+		t.Errorf("Expected invalidFilterError, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+// Package templates implements the 'list templates' sub-command.
+package templates
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/sortable"
+	"github.com/giantswarm/gsctl/pkg/table"
+)
+
+var (
+	// Command performs the "list templates" function
+	Command = &cobra.Command{
+		Use:     "templates",
+		Aliases: []string{"template"},
+		Short:   "List cluster definition templates",
+		Long: `Prints a list of the stored cluster definition templates, with the values
+each template references.
+
+Templates are stored using 'gsctl create template' and used with
+'gsctl create cluster --template <name>'.
+
+Examples:
+
+  gsctl list templates --filter 'name>=prod'`,
+		Run: printResult,
+	}
+
+	cmdFilter string
+
+	cmdSort string
+)
+
+const (
+	tableColName       = "name"
+	tableColParameters = "parameters"
+)
+
+var tableCols = [...]string{
+	tableColName,
+	tableColParameters,
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	table.AddFlags(Command.Flags(), &cmdSort, &cmdFilter, tableCols[:], tableColName, "templates", "name!=dev")
+}
+
+// Arguments represents all the ways the user can influence the command.
+type Arguments struct {
+	filter string
+	sortBy string
+}
+
+func collectArguments() Arguments {
+	return Arguments{
+		filter: cmdFilter,
+		sortBy: cmdSort,
+	}
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	args := collectArguments()
+
+	output, err := templatesTable(config.FileSystem, args)
+	if err != nil {
+		errors.HandleCommonErrors(err)
+
+		switch {
+		case table.IsOptionsError(err):
+			headline, subtext := table.OptionsErrorMessage(err, args.sortBy, tableCols[:])
+			fmt.Println(color.RedString(headline))
+			fmt.Println(subtext)
+		default:
+			fmt.Println(color.RedString("Could not list templates"))
+			fmt.Println(err.Error())
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+// createTable returns the table used to resolve the attributes
+// templates can be sorted and filtered by.
+func createTable() *table.Table {
+	t := table.New()
+
+	t.SetColumns([]table.Column{
+		{
+			Name:     tableColName,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+		{
+			Name:     tableColParameters,
+			Sortable: sortable.Sortable{SortType: sortable.String},
+		},
+	})
+
+	return &t
+}
+
+// columnValue returns the value of a template for the given column.
+func columnValue(t clustertemplate.Template, colName string) string {
+	switch colName {
+	case tableColName:
+		return t.Name
+	case tableColParameters:
+		if len(t.Parameters) > 0 {
+			return strings.Join(t.Parameters, ", ")
+		}
+	}
+
+	return "n/a"
+}
+
+// templatesTable returns a table of all stored templates
+// matching the filter given by the user.
+func templatesTable(fs afero.Fs, args Arguments) (string, error) {
+	tTable := createTable()
+
+	filter, err := tTable.ParseFilter(args.filter)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var sortByColumn table.Column
+	if args.sortBy != "" {
+		colName, err := tTable.GetColumnNameFromInitials(args.sortBy)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		_, sortByColumn, err = tTable.GetColumnByName(colName)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+	}
+
+	allTemplates, err := clustertemplate.List(fs)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if len(allTemplates) == 0 {
+		return fmt.Sprintf("No templates stored.\n\nTo store a cluster definition template, use\n\n\t%s\n",
+			color.YellowString("gsctl create template <name> --file <path>")), nil
+	}
+
+	templates := make([]clustertemplate.Template, 0, len(allTemplates))
+	for _, t := range allTemplates {
+		if filter.Matches(func(colName string) string { return columnValue(t, colName) }) {
+			templates = append(templates, t)
+		}
+	}
+
+	if len(templates) == 0 {
+		return color.YellowString("No templates matching the filter"), nil
+	}
+
+	if sortByColumn.Name != "" {
+		table.SortSliceUsingColumnData(templates, sortByColumn, sortable.ASC, func(i int, colName string) string {
+			return columnValue(templates[i], colName)
+		})
+	}
+
+	rows := []string{strings.Join([]string{
+		color.CyanString("NAME"),
+		color.CyanString("PARAMETERS"),
+	}, "|")}
+
+	for _, t := range templates {
+		rows = append(rows, strings.Join([]string{
+			columnValue(t, tableColName),
+			columnValue(t, tableColParameters),
+		}, "|"))
+	}
+
+	return columnize.SimpleFormat(rows), nil
+}
@@ -0,0 +1,85 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/clustertemplate"
+	"github.com/giantswarm/gsctl/pkg/table"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_templatesTable tests the table of stored templates.
+func Test_templatesTable(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := templatesTable(fs, Arguments{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "No templates stored.") {
+		t.Errorf("Expected hint on empty list, got %q", output)
+	}
+
+	err = clustertemplate.Store(fs, "prod", []byte("owner: acme\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = clustertemplate.Store(fs, "dev", []byte("owner: {{ .owner }}\nname: {{ .name }}\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err = templatesTable(fs, Arguments{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(output, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", output)
+	}
+	if !strings.HasPrefix(lines[1], "dev") || !strings.Contains(lines[1], "name, owner") {
+		t.Errorf("Unexpected line %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "prod") || !strings.Contains(lines[2], "n/a") {
+		t.Errorf("Unexpected line %q", lines[2])
+	}
+}
+
+// Test_templatesTableFilterSort tests filtering and sorting templates.
+func Test_templatesTableFilterSort(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"prod", "dev", "staging"} {
+		err = clustertemplate.Store(fs, name, []byte("owner: acme\n"), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := templatesTable(fs, Arguments{filter: "name!=dev", sortBy: "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(output, "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "prod") || !strings.HasPrefix(lines[2], "staging") {
+		t.Errorf("Unexpected output %q", output)
+	}
+
+	_, err = templatesTable(fs, Arguments{filter: "size>3"})
+	if !table.IsInvalidFilterError(err) {
+		t.Errorf("Expected invalidFilterError, got %v", err)
+	}
+}
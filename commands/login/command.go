@@ -16,6 +16,7 @@ import (
 	"github.com/giantswarm/gsctl/client"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/deviceauth"
 )
 
 const (
@@ -32,6 +33,9 @@ var (
 	// cmdSSO is the bool that triggers login via SSO.
 	cmdSSO bool
 
+	// cmdDeviceCode triggers login via SSO using the device authorization flow.
+	cmdDeviceCode bool
+
 	// Command is the "login" CLI command
 	Command = &cobra.Command{
 		Use:   "login <email> [-e|--endpoint <endpoint>]",
@@ -42,7 +46,12 @@ This will select the given endpoint for subsequent commands.
 
 The password has to be entered interactively or given as -p / --password flag.
 
-The -e or --endpoint argument can be omitted if an endpoint is already selected.`,
+The -e or --endpoint argument can be omitted if an endpoint is already selected.
+
+On machines without a web browser, e. g. when connected via SSH, use
+--device-code to sign in via Single Sign On. gsctl then prints a URL and a
+code to enter in a web browser on any other device, and waits until the
+sign in has been completed there. No email address is required.`,
 		Example: "  gsctl login user@example.com --endpoint api.example.com",
 		PreRun:  loginPreRunOutput,
 		Run:     loginRunOutput,
@@ -55,6 +64,7 @@ func init() {
 	Command.Flags().StringVarP(&cmdPassword, "password", "p", "", "Password. If not given, will be prompted interactively.")
 	Command.Flags().BoolVarP(&cmdSSO, "sso", "", false, "Authenticate using Single Sign On through our identity provider.")
 	Command.Flags().MarkHidden("sso")
+	Command.Flags().BoolVarP(&cmdDeviceCode, "device-code", "", false, "Authenticate using Single Sign On by entering a code in a web browser on another device.")
 }
 
 // Arguments is the argument struct for the business function.
@@ -62,6 +72,7 @@ func init() {
 // is by design.
 type Arguments struct {
 	apiEndpoint string
+	deviceAuth  deviceauth.Config
 	email       string
 	password    string
 	verbose     bool
@@ -76,6 +87,7 @@ func collectArguments(positionalArgs []string) Arguments {
 
 	return Arguments{
 		apiEndpoint: endpoint,
+		deviceAuth:  deviceauth.Config{Audience: endpoint},
 		email:       cmdEmail,
 		password:    cmdPassword,
 		verbose:     flags.Verbose,
@@ -122,7 +134,7 @@ func loginPreRunOutput(cmd *cobra.Command, positionalArgs []string) {
 	case errors.IsTokenArgumentNotApplicableError(err):
 		headline = "The '--auth-token' flag cannot be used with the 'gsctl login' command."
 	case errors.IsPasswordArgumentNotApplicableError(err):
-		headline = "The '--password' flag cannot be used with the 'gsctl login --sso' command."
+		headline = "The '--password' flag cannot be used with the 'gsctl login --sso' or 'gsctl login --device-code' command."
 	case errors.IsEmptyPasswordError(err):
 		headline = "The password cannot be empty."
 		subtext = "Please call the command again and enter a non-empty password. See 'gsctl login --help' for details."
@@ -147,7 +159,7 @@ func verifyLoginPreconditions(positionalArgs []string) error {
 		return microerror.Mask(errors.TokenArgumentNotApplicableError)
 	}
 
-	if cmdSSO {
+	if cmdSSO || cmdDeviceCode {
 		if cmdPassword != "" {
 			return microerror.Mask(errors.PasswordArgumentNotApplicableError)
 		}
@@ -176,9 +188,12 @@ func verifyLoginPreconditions(positionalArgs []string) error {
 func login(loginArgs Arguments) (loginResult, error) {
 	var result loginResult
 	var err error
-	if cmdSSO {
+	switch {
+	case cmdDeviceCode:
+		result, err = loginDeviceCode(loginArgs)
+	case cmdSSO:
 		result, err = loginSSO(loginArgs)
-	} else {
+	default:
 		result, err = loginGiantSwarm(loginArgs)
 	}
 
@@ -212,11 +227,21 @@ func loginRunOutput(cmd *cobra.Command, positionalArgs []string) {
 		case config.IsAliasMustBeUniqueError(err):
 			headline = "Alias is already in use for a different endpoint"
 			subtext = fmt.Sprintf("The alias '%s' is already used for an endpoint in your configuration.\n", result.alias)
-			subtext += "Please edit your configuration file manually to delete the alias or endpoint."
+			subtext += "Please use 'gsctl config rename-endpoint-alias' to rename the existing alias first."
 		case oidc.IsTokenIssuedAtError(err):
 			headline = "Token created in the future?"
 			subtext = "It appears as if your system time is behind the actual time. Please adjust the time and make sure\n"
 			subtext += "that it is automatically synchronized with a time service. Otherwise SSO login does not work."
+		case deviceauth.IsAccessDenied(err):
+			headline = "Sign in has been denied"
+			subtext = "The sign in request was denied in the web browser. Please try again."
+		case deviceauth.IsExpired(err):
+			headline = "The code has expired"
+			subtext = "The sign in has not been completed in time. Please run the command again."
+		case deviceauth.IsAuthorization(err):
+			headline = "Something went wrong during SSO"
+			subtext = err.Error()
+			subtext += "\nPlease contact the Giant Swarm support team or try the command again later."
 		case errors.IsSSOError(err):
 			headline = "Something went wrong during SSO"
 			subtext = err.Error()
@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gscliauth/oidc"
 	"github.com/giantswarm/gsclientgen/v2/client/auth_tokens"
 	"github.com/spf13/afero"
 
 	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/pkg/deviceauth"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -213,3 +217,72 @@ func Test_LoginInactiveAccount(t *testing.T) {
 		t.Errorf("Expected 'ACCOUNT_EXPIRED', got %#v", origErr.Payload.Code)
 	}
 }
+
+// Test_LoginDeviceCode simulates an SSO login via the device authorization
+// flow against a fake identity provider.
+func Test_LoginDeviceCode(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	idp := testutils.NewFakeIdP("some-access-token", "some-refresh-token", "some-id-token")
+	idp.Errors = []string{"authorization_pending"}
+	defer idp.Close()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer some-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code": "PERMISSION_DENIED", "message": "Unauthorized"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(regularInfoResponse)
+	}))
+	defer mockServer.Close()
+
+	parseIDToken = func(tokenString string) (*oidc.IDToken, error) {
+		if tokenString != "some-id-token" {
+			t.Errorf("Unexpected ID token %q", tokenString)
+		}
+		return &oidc.IDToken{Email: "email@example.com"}, nil
+	}
+	defer func() { parseIDToken = oidc.ParseIDToken }()
+
+	cmdDeviceCode = true
+	defer func() { cmdDeviceCode = false }()
+
+	args := Arguments{
+		apiEndpoint: mockServer.URL,
+		deviceAuth: deviceauth.Config{
+			IssuerURL:    idp.URL,
+			Audience:     mockServer.URL,
+			PollInterval: time.Millisecond,
+		},
+	}
+
+	var result loginResult
+	output := testutils.CaptureOutput(func() {
+		result, err = login(args)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %#v", err)
+	}
+	if !strings.Contains(output, testutils.FakeIdPUserCode) || !strings.Contains(output, idp.URL+"/activate") {
+		t.Errorf("Expected verification URL and user code in output, got:\n%s", output)
+	}
+	if result.email != "email@example.com" || result.alias != "codename" {
+		t.Errorf("Unexpected result %#v", result)
+	}
+
+	// Tokens are stored the same way as in the browser based SSO flow.
+	ec := config.Config.EndpointConfig(mockServer.URL)
+	if ec == nil || ec.Token != "some-access-token" || ec.RefreshToken != "some-refresh-token" || ec.Scheme != "Bearer" {
+		t.Errorf("Unexpected endpoint configuration %#v", ec)
+	}
+	if config.Config.SelectedEndpoint != mockServer.URL {
+		t.Errorf("Expected endpoint %s to be selected, got %s", mockServer.URL, config.Config.SelectedEndpoint)
+	}
+}
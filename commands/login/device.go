@@ -0,0 +1,43 @@
+package login
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/gsctl/pkg/deviceauth"
+)
+
+// loginDeviceCode logs in via SSO using the OAuth device authorization
+// grant. Instead of opening a browser and waiting for a local callback,
+// it prints a URL and a code to enter on any other device.
+func loginDeviceCode(args Arguments) (loginResult, error) {
+	numEndpointsBefore := config.Config.NumEndpoints()
+
+	flow := deviceauth.New(args.deviceAuth)
+
+	code, err := flow.RequestDeviceCode()
+	if err != nil {
+		if args.verbose {
+			fmt.Println(color.WhiteString("Attempt to request a device code from the identity provider failed."))
+		}
+		return loginResult{}, microerror.Mask(err)
+	}
+
+	fmt.Println(color.YellowString("\nTo log in, open this URL in a web browser on any device:"))
+	fmt.Printf("\n    %s\n\n", code.VerificationURI)
+	fmt.Printf("and enter the code %s\n", color.CyanString(code.UserCode))
+	if code.VerificationURIComplete != "" {
+		fmt.Printf("\nAlternatively, open this URL which already contains the code:\n\n    %s\n", code.VerificationURIComplete)
+	}
+	fmt.Println("\nWaiting for the login to be completed...")
+
+	token, err := flow.PollToken(code)
+	if err != nil {
+		return loginResult{}, microerror.Mask(err)
+	}
+
+	return storeSSOLogin(args, numEndpointsBefore, token.AccessToken, token.RefreshToken, token.IDToken)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 )
 
 // loginGiantSwarm executes the authentication logic.
@@ -73,10 +74,14 @@ func loginGiantSwarm(args Arguments) (loginResult, error) {
 	result.alias = installationInfo.InstallationName
 	result.provider = installationInfo.Provider
 
-	if err := config.Config.StoreEndpointAuth(args.apiEndpoint, result.alias, result.provider, args.email, "giantswarm", result.token, ""); err != nil {
-		return result, microerror.Mask(err)
-	}
-	if err := config.Config.SelectEndpoint(args.apiEndpoint); err != nil {
+	err = ssotoken.UpdateConfig(func() error {
+		if err := config.Config.StoreEndpointAuth(args.apiEndpoint, result.alias, result.provider, args.email, "giantswarm", result.token, ""); err != nil {
+			return microerror.Mask(err)
+		}
+
+		return config.Config.SelectEndpoint(args.apiEndpoint)
+	})
+	if err != nil {
 		return result, microerror.Mask(err)
 	}
 
@@ -12,8 +12,14 @@ import (
 
 	"github.com/giantswarm/gsctl/client/clienterror"
 	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 )
 
+// parseIDToken extracts the claims we need from the ID token.
+// It is replaced in tests, as it verifies the token signature
+// against Giant Swarm's identity provider.
+var parseIDToken = oidc.ParseIDToken
+
 func init() {
 	rand.Seed(time.Now().UTC().UnixNano())
 }
@@ -29,14 +35,21 @@ func loginSSO(args Arguments) (loginResult, error) {
 		return loginResult{}, microerror.Maskf(errors.SSOError, pkceResponse.ErrorDescription)
 	}
 
+	return storeSSOLogin(args, numEndpointsBefore, pkceResponse.AccessToken, pkceResponse.RefreshToken, pkceResponse.IDToken)
+}
+
+// storeSSOLogin verifies the tokens received via SSO against the API and
+// stores them with the endpoint in the configuration. The refresh token
+// is used by gscliauth to renew the access token when it expires.
+func storeSSOLogin(args Arguments, numEndpointsBefore int, accessToken, refreshToken, rawIDToken string) (loginResult, error) {
 	// Try to parse the ID Token.
-	idToken, err := oidc.ParseIDToken(pkceResponse.IDToken)
+	idToken, err := parseIDToken(rawIDToken)
 	if err != nil {
 		return loginResult{}, microerror.Mask(err)
 	}
 
 	// Check if the access token works by fetching the installation's name.
-	installationInfo, err := getInstallationInfo(args.apiEndpoint, "Bearer", pkceResponse.AccessToken)
+	installationInfo, err := getInstallationInfo(args.apiEndpoint, "Bearer", accessToken)
 	if err != nil {
 		if args.verbose {
 			fmt.Println(color.WhiteString("Attempt to use new token against the API failed."))
@@ -59,14 +72,18 @@ func loginSSO(args Arguments) (loginResult, error) {
 	}
 
 	// Store the token in the config file.
-	if err := config.Config.StoreEndpointAuth(args.apiEndpoint, installationInfo.InstallationName, installationInfo.Provider, idToken.Email, "Bearer", pkceResponse.AccessToken, pkceResponse.RefreshToken); err != nil {
-		if args.verbose {
-			fmt.Println(color.WhiteString("Attempt to store our authentication data with the endpoint in the configuration failed."))
-			fmt.Println(color.WhiteString("Error details: %s", err.Error()))
+	err = ssotoken.UpdateConfig(func() error {
+		if err := config.Config.StoreEndpointAuth(args.apiEndpoint, installationInfo.InstallationName, installationInfo.Provider, idToken.Email, "Bearer", accessToken, refreshToken); err != nil {
+			if args.verbose {
+				fmt.Println(color.WhiteString("Attempt to store our authentication data with the endpoint in the configuration failed."))
+				fmt.Println(color.WhiteString("Error details: %s", err.Error()))
+			}
+			return microerror.Maskf(errors.SSOError, "Error while attempting to store the token in the config file")
 		}
-		return loginResult{}, microerror.Maskf(errors.SSOError, "Error while attempting to store the token in the config file")
-	}
-	if err := config.Config.SelectEndpoint(args.apiEndpoint); err != nil {
+
+		return config.Config.SelectEndpoint(args.apiEndpoint)
+	})
+	if err != nil {
 		return loginResult{}, microerror.Mask(err)
 	}
 
@@ -77,7 +94,7 @@ func loginSSO(args Arguments) (loginResult, error) {
 		loggedOutBefore:    false,
 		alias:              installationInfo.InstallationName,
 		provider:           installationInfo.Provider,
-		token:              pkceResponse.AccessToken,
+		token:              accessToken,
 		numEndpointsBefore: numEndpointsBefore,
 		numEndpointsAfter:  config.Config.NumEndpoints(),
 	}
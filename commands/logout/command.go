@@ -15,6 +15,7 @@ import (
 	"github.com/giantswarm/gsctl/client/clienterror"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 )
 
 const (
@@ -94,7 +95,10 @@ func printResult(cmd *cobra.Command, extraArgs []string) {
 // The email and token are erased from the local config file.
 func logout(args Arguments) error {
 	// erase local credentials, no matter what the result on the API side is
-	defer config.Config.Logout(args.apiEndpoint)
+	defer ssotoken.UpdateConfig(func() error {
+		config.Config.Logout(args.apiEndpoint)
+		return nil
+	})
 
 	if args.scheme == "Bearer" {
 		return nil
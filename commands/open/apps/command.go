@@ -0,0 +1,110 @@
+// Package apps implements the 'open apps' command.
+package apps
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+var (
+	// Command is the cobra command for 'gsctl open apps'
+	Command = &cobra.Command{
+		Use:   "apps",
+		Short: "Open the app catalog in the web UI",
+		Long: `Open the app catalog page of the web UI.
+
+Examples:
+
+  gsctl open apps
+  gsctl open apps --print
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments holds all arguments that can influence our business function.
+type Arguments struct {
+	apiEndpoint string
+	printOnly   bool
+}
+
+func collectArguments() Arguments {
+	return Arguments{
+		apiEndpoint: config.Config.ChooseEndpoint(flags.APIEndpoint),
+		printOnly:   flags.PrintOnly,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	url, err := webui.AppCatalogURL(arguments.apiEndpoint)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	err = webui.Launch(url, arguments.printOnly)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case webui.IsUnsupportedHostName(err):
+		headline = "Cannot determine the web UI URL for this endpoint"
+		subtext = "Please configure the web UI URL for the endpoint. See 'gsctl open --help' for details."
+	case webui.IsLaunchFailed(err):
+		headline = "Could not open the web browser"
+		subtext = "Please open the URL manually, or use --print to only print it."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
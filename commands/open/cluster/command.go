@@ -0,0 +1,171 @@
+// Package cluster implements the 'open cluster' command.
+package cluster
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+var (
+	// Command is the cobra command for 'gsctl open cluster'
+	Command = &cobra.Command{
+		Use:   "cluster <cluster-name-or-id>",
+		Short: "Open the cluster details page in the web UI",
+		Long: `Open the details page of a cluster in the web UI.
+
+When used in a terminal without a cluster argument, you can select the cluster
+from a list.
+
+Examples:
+
+  gsctl open cluster f01r4
+  gsctl open cluster "Cluster name" --print
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments holds all arguments that can influence our business function.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	clusterNameOrID   string
+	printOnly         bool
+	userProvidedToken string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	clusterNameOrID := ""
+	if len(positionalArgs) > 0 {
+		clusterNameOrID = positionalArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		clusterNameOrID:   clusterNameOrID,
+		printOnly:         flags.PrintOnly,
+		userProvidedToken: flags.Token,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.clusterNameOrID == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+
+	if errors.IsClusterNameOrIDMissingError(err) {
+		if clusterID := picker.MissingCluster(arguments.apiEndpoint, arguments.userProvidedToken); clusterID != "" {
+			arguments.clusterNameOrID = clusterID
+			err = verifyPreconditions(arguments)
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	url, err := getURL(arguments)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	err = webui.Launch(url, arguments.printOnly)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+}
+
+// getURL resolves the cluster and returns its web UI URL.
+func getURL(args Arguments) (string, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	clusterID, err := clustercache.GetID(args.apiEndpoint, args.clusterNameOrID, clientWrapper)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	owner, err := clustercache.GetOwner(args.apiEndpoint, clusterID, clientWrapper)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	url, err := webui.ClusterDetailsURL(args.apiEndpoint, clusterID, owner)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return url, nil
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsClusterNotFoundError(err):
+		headline = "Cluster not found"
+		subtext = "The cluster you tried to open could not be found. Please check the name or ID using 'gsctl list clusters'."
+	case webui.IsUnsupportedHostName(err):
+		headline = "Cannot determine the web UI URL for this endpoint"
+		subtext = "Please configure the web UI URL for the endpoint. See 'gsctl open --help' for details."
+	case webui.IsLaunchFailed(err):
+		headline = "Could not open the web browser"
+		subtext = "Please open the URL manually, or use --print to only print it."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_getURL tests resolving a cluster name to its web UI URL.
+func Test_getURL(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": "f01r4", "name": "Production", "owner": "acme", "create_date": "2020-01-01T00:00:00Z"}]`))
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{apiEndpoint: mockServer.URL, authToken: "token", clusterNameOrID: "Production"}
+
+	// The mock server's host name doesn't allow deriving the web UI URL.
+	_, err = getURL(args)
+	if !webui.IsUnsupportedHostName(err) {
+		t.Errorf("Expected unsupportedHostNameError, got %v", err)
+	}
+
+	err = webui.StoreBaseURL(mockServer.URL, "https://console.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := getURL(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if url != "https://console.example.com/organizations/acme/clusters/f01r4" {
+		t.Errorf("Unexpected URL %q", url)
+	}
+
+	args.clusterNameOrID = "unknown"
+	_, err = getURL(args)
+	if !errors.IsClusterNotFoundError(err) {
+		t.Errorf("Expected ClusterNotFoundError, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Package open holds the 'open *' sub-commands.
+package open
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/open/apps"
+	"github.com/giantswarm/gsctl/commands/open/cluster"
+	"github.com/giantswarm/gsctl/commands/open/nodepool"
+	"github.com/giantswarm/gsctl/commands/open/organization"
+	"github.com/giantswarm/gsctl/commands/open/release"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+var (
+	// Command is the command to open web UI pages.
+	Command = &cobra.Command{
+		Use:   "open",
+		Short: "Open clusters, node pools, organizations, releases or apps in the web UI",
+		Long: `Open a page of the web UI in your web browser.
+
+The web UI URL is derived from the API endpoint, by replacing the leading 'api'
+of the host name with 'happa'. For installations with a different host name
+scheme, configure the web UI URL per endpoint:
+
+  gsctl config set endpoints.https://api.example.com.webui_url https://console.example.com
+
+Use --print to only print the URL, e. g. in a terminal without a web browser.`,
+	}
+)
+
+func init() {
+	Command.PersistentFlags().BoolVarP(&flags.PrintOnly, "print", "", false, "Only print the URL instead of opening it in the web browser")
+
+	Command.AddCommand(apps.Command)
+	Command.AddCommand(cluster.Command)
+	Command.AddCommand(nodepool.Command)
+	Command.AddCommand(organization.Command)
+	Command.AddCommand(release.Command)
+}
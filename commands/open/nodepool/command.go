@@ -0,0 +1,187 @@
+// Package nodepool implements the 'open nodepool' command.
+package nodepool
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+var (
+	// Command is the cobra command for 'gsctl open nodepool'
+	Command = &cobra.Command{
+		DisableFlagsInUseLine: true,
+		Use:                   "nodepool <cluster-name/cluster-id>/<nodepool-id>",
+		Aliases:               []string{"np"},
+		// Args: cobra.ExactArgs(1) guarantees that cobra will fail if no positional argument is given.
+		Args:  cobra.ExactArgs(1),
+		Short: "Open the node pool details in the web UI",
+		Long: `Open the details of a node pool in the web UI.
+
+When used in a terminal with only the cluster given, you can select the node
+pool from a list.
+
+Examples:
+
+  gsctl open nodepool f01r4/75rh1
+  gsctl open nodepool "Cluster name"/75rh1 --print
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments *Arguments
+)
+
+// Arguments holds all arguments that can influence our business function.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	clusterNameOrID   string
+	nodePoolID        string
+	printOnly         bool
+	userProvidedToken string
+}
+
+func collectArguments(positionalArgs []string) (*Arguments, error) {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	parts := strings.Split(positionalArgs[0], "/")
+
+	if len(parts) < 2 {
+		return nil, microerror.Maskf(errors.InvalidNodePoolIDArgumentError, "Please specify the node pool as <cluster-name/cluster-id>/<nodepool-id>. Use --help for details.")
+	}
+
+	return &Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		clusterNameOrID:   parts[0],
+		nodePoolID:        parts[1],
+		printOnly:         flags.PrintOnly,
+		userProvidedToken: flags.Token,
+	}, nil
+}
+
+func verifyPreconditions(args *Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.clusterNameOrID == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	}
+	if args.nodePoolID == "" {
+		return microerror.Mask(errors.NodePoolIDMissingError)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	var err error
+	arguments, err = collectArguments(positionalArgs)
+	if errors.IsInvalidNodePoolIDArgument(err) && !strings.Contains(positionalArgs[0], "/") {
+		// Only a cluster is given. In interactive sessions, let the user pick a node pool.
+		endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+		if nodePoolID := picker.MissingNodePool(endpoint, flags.Token, positionalArgs[0]); nodePoolID != "" {
+			arguments, err = collectArguments([]string{positionalArgs[0] + "/" + nodePoolID})
+		}
+	}
+	if err == nil {
+		err = verifyPreconditions(arguments)
+		if err == nil {
+			return
+		}
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	url, err := getURL(arguments)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	err = webui.Launch(url, arguments.printOnly)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+}
+
+// getURL resolves the cluster and returns the web UI URL of the node pool.
+func getURL(args *Arguments) (string, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	clusterID, err := clustercache.GetID(args.apiEndpoint, args.clusterNameOrID, clientWrapper)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	owner, err := clustercache.GetOwner(args.apiEndpoint, clusterID, clientWrapper)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	url, err := webui.NodePoolDetailsURL(args.apiEndpoint, clusterID, args.nodePoolID, owner)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return url, nil
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsInvalidNodePoolIDArgument(err):
+		headline = "Invalid argument syntax"
+		subtext = "Please give the cluster name or ID, followed by /, followed by the node pool ID."
+	case errors.IsClusterNotFoundError(err):
+		headline = "Cluster not found"
+		subtext = "The cluster could not be found. Please check the name or ID using 'gsctl list clusters'."
+	case webui.IsUnsupportedHostName(err):
+		headline = "Cannot determine the web UI URL for this endpoint"
+		subtext = "Please configure the web UI URL for the endpoint. See 'gsctl open --help' for details."
+	case webui.IsLaunchFailed(err):
+		headline = "Could not open the web browser"
+		subtext = "Please open the URL manually, or use --print to only print it."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
@@ -0,0 +1,57 @@
+package nodepool
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+// Test_collectArguments tests parsing the positional argument.
+func Test_collectArguments(t *testing.T) {
+	args, err := collectArguments([]string{"Cluster name/a7k9m"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if args.clusterNameOrID != "Cluster name" || args.nodePoolID != "a7k9m" {
+		t.Errorf("Unexpected arguments %#v", args)
+	}
+
+	_, err = collectArguments([]string{"f01r4"})
+	if !errors.IsInvalidNodePoolIDArgument(err) {
+		t.Errorf("Expected InvalidNodePoolIDArgumentError, got %v", err)
+	}
+}
+
+// Test_getURL tests resolving the node pool web UI URL.
+func Test_getURL(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": "f01r4", "name": "Production", "owner": "acme", "create_date": "2020-01-01T00:00:00Z"}]`))
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = webui.StoreBaseURL(mockServer.URL, "https://console.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := getURL(&Arguments{apiEndpoint: mockServer.URL, authToken: "token", clusterNameOrID: "f01r4", nodePoolID: "a7k9m"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if url != "https://console.example.com/organizations/acme/clusters/f01r4/nodepools/a7k9m" {
+		t.Errorf("Unexpected URL %q", url)
+	}
+}
@@ -0,0 +1,140 @@
+// Package organization implements the 'open organization' command.
+package organization
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+var (
+	// Command is the cobra command for 'gsctl open organization'
+	Command = &cobra.Command{
+		Use:     "organization <organization-id>",
+		Aliases: []string{"org"},
+		Short:   "Open the organization details page in the web UI",
+		Long: `Open the details page of an organization in the web UI.
+
+When used in a terminal without an organization argument, you can select the
+organization from a list.
+
+Examples:
+
+  gsctl open organization acme
+  gsctl open org acme --print
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments holds all arguments that can influence our business function.
+type Arguments struct {
+	apiEndpoint       string
+	organizationID    string
+	printOnly         bool
+	userProvidedToken string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+
+	organizationID := ""
+	if len(positionalArgs) > 0 {
+		organizationID = positionalArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		organizationID:    organizationID,
+		printOnly:         flags.PrintOnly,
+		userProvidedToken: flags.Token,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.organizationID == "" {
+		return microerror.Mask(errors.OrganizationNotSpecifiedError)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+
+	if errors.IsOrganizationNotSpecifiedError(err) {
+		if organizationID := picker.MissingOrganization(arguments.apiEndpoint, arguments.userProvidedToken); organizationID != "" {
+			arguments.organizationID = organizationID
+			err = verifyPreconditions(arguments)
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	url, err := webui.OrganizationDetailsURL(arguments.apiEndpoint, arguments.organizationID)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	err = webui.Launch(url, arguments.printOnly)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotSpecifiedError(err):
+		headline = "No organization ID given"
+		subtext = "Please specify the organization to open as a positional argument. See --help for details."
+	case webui.IsUnsupportedHostName(err):
+		headline = "Cannot determine the web UI URL for this endpoint"
+		subtext = "Please configure the web UI URL for the endpoint. See 'gsctl open --help' for details."
+	case webui.IsLaunchFailed(err):
+		headline = "Could not open the web browser"
+		subtext = "Please open the URL manually, or use --print to only print it."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
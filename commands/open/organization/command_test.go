@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests the argument validation.
+func Test_verifyPreconditions(t *testing.T) {
+	err := verifyPreconditions(Arguments{apiEndpoint: "https://api.g8s.example.com"})
+	if !errors.IsOrganizationNotSpecifiedError(err) {
+		t.Errorf("Expected OrganizationNotSpecifiedError, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{organizationID: "acme"})
+	if !errors.IsEndpointMissingError(err) {
+		t.Errorf("Expected EndpointMissingError, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://api.g8s.example.com", organizationID: "acme"})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
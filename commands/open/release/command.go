@@ -0,0 +1,138 @@
+// Package release implements the 'open release' command.
+package release
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+var (
+	// Command is the cobra command for 'gsctl open release'
+	Command = &cobra.Command{
+		Use:   "release <version>",
+		Short: "Open the release details page in the web UI",
+		Long: `Open the details page of a workload cluster release in the web UI.
+
+When used in a terminal without a version argument, you can select the release
+from a list.
+
+Examples:
+
+  gsctl open release 14.0.0
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments holds all arguments that can influence our business function.
+type Arguments struct {
+	apiEndpoint       string
+	printOnly         bool
+	releaseVersion    string
+	userProvidedToken string
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+
+	releaseVersion := ""
+	if len(positionalArgs) > 0 {
+		releaseVersion = positionalArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		printOnly:         flags.PrintOnly,
+		releaseVersion:    releaseVersion,
+		userProvidedToken: flags.Token,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.releaseVersion == "" {
+		return microerror.Mask(errors.ReleaseVersionMissingError)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+
+	if errors.IsReleaseVersionMissingError(err) {
+		if version := picker.MissingRelease(arguments.apiEndpoint, arguments.userProvidedToken); version != "" {
+			arguments.releaseVersion = version
+			err = verifyPreconditions(arguments)
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	url, err := webui.ReleaseDetailsURL(arguments.apiEndpoint, arguments.releaseVersion)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	err = webui.Launch(url, arguments.printOnly)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsReleaseVersionMissingError(err):
+		headline = "No release version specified"
+		subtext = "Please specify the release version to open as a positional argument. See --help for details."
+	case webui.IsUnsupportedHostName(err):
+		headline = "Cannot determine the web UI URL for this endpoint"
+		subtext = "Please configure the web UI URL for the endpoint. See 'gsctl open --help' for details."
+	case webui.IsLaunchFailed(err):
+		headline = "Could not open the web browser"
+		subtext = "Please open the URL manually, or use --print to only print it."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
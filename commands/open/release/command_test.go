@@ -0,0 +1,25 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+)
+
+// Test_verifyPreconditions tests the argument validation.
+func Test_verifyPreconditions(t *testing.T) {
+	err := verifyPreconditions(Arguments{releaseVersion: "14.0.0"})
+	if !errors.IsEndpointMissingError(err) {
+		t.Errorf("Expected EndpointMissingError, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://api.g8s.example.com"})
+	if !errors.IsReleaseVersionMissingError(err) {
+		t.Errorf("Expected ReleaseVersionMissingError, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://api.g8s.example.com", releaseVersion: "14.0.0"})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
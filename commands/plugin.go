@@ -0,0 +1,112 @@
+package commands
+
+// Plugin dispatch is defined on the top level of the commands package, as it
+// has to know the root command and its persistent flags.
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/pflag"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/plugin"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
+)
+
+// Execute runs gsctl with the command line arguments. If the first argument
+// is not a built-in command, but a plugin executable gsctl-<argument> exists
+// in the PATH, the plugin is run instead and gsctl exits with its exit code.
+func Execute() {
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") && !plugin.IsBuiltin(RootCommand, args[0]) {
+		if p, ok := plugin.Find(os.Getenv("PATH"), args[0]); ok {
+			os.Exit(runPlugin(p, args[1:]))
+		}
+	}
+
+	RootCommand.Execute()
+}
+
+// runPlugin executes the plugin with the given arguments and returns its
+// exit code. The arguments are passed on unchanged. Global flags among them
+// are evaluated as well, to provide the plugin with the endpoint, auth header,
+// config dir, and output format via environment variables.
+func runPlugin(p plugin.Plugin, args []string) int {
+	env, err := pluginEnv(args)
+	if err != nil {
+		errors.HandleCommonErrors(err)
+
+		fmt.Println(color.RedString("Could not prepare running plugin %s", p.Name))
+		fmt.Printf("Details: %s\n", err.Error())
+		return 1
+	}
+
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Later entries take precedence over values inherited from our environment.
+	cmd.Env = append(os.Environ(), env...)
+
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	} else if err != nil {
+		fmt.Println(color.RedString("Could not run plugin %s", p.Name))
+		fmt.Printf("Details: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// pluginEnv returns the environment variables to set for a plugin, based on
+// the global flags found in args and the configuration.
+func pluginEnv(args []string) ([]string, error) {
+	fs := pflag.NewFlagSet(config.ProgramName, pflag.ContinueOnError)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	fs.AddFlagSet(RootCommand.PersistentFlags())
+	fs.StringVarP(&flags.OutputFormat, "output", "o", "table", "")
+	fs.BoolP("help", "h", false, "")
+	fs.SetOutput(&strings.Builder{})
+
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	err = initConfig(RootCommand, args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	env := []string{
+		plugin.EnvConfigDir + "=" + config.ConfigDirPath,
+		plugin.EnvOutput + "=" + flags.OutputFormat,
+	}
+
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	if endpoint == "" {
+		return env, nil
+	}
+	env = append(env, plugin.EnvEndpoint+"="+endpoint)
+
+	if config.Config.ChooseToken(endpoint, flags.Token) == "" {
+		return env, nil
+	}
+
+	authHeader, err := ssotoken.AuthHeaderGetter(endpoint, flags.Token)()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	env = append(env, plugin.EnvAuthHeader+"="+authHeader)
+
+	return env, nil
+}
@@ -0,0 +1,40 @@
+// Package plugin holds the 'plugin *' sub-commands.
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/plugin/list"
+	pluginpkg "github.com/giantswarm/gsctl/pkg/plugin"
+)
+
+var (
+	// Command is the command to manage plugins.
+	Command = &cobra.Command{
+		Use:     "plugin",
+		Aliases: []string{"plugins"},
+		Short:   "Manage plugins",
+		Long: `Plugins extend gsctl with additional commands.
+
+A plugin is an executable named ` + pluginpkg.Prefix + `<name> anywhere in your PATH. It
+is run as 'gsctl <name>', with all further arguments passed to it unchanged.
+Built-in commands always take precedence over plugins with the same name. If
+several executables with the same name exist, the first one in the PATH is used.
+
+The plugin name has to be the first argument, so global flags like --endpoint
+have to follow it. gsctl evaluates them and passes these environment variables
+to the plugin:
+
+  ` + pluginpkg.EnvEndpoint + `      The API endpoint URL to use, if any.
+  ` + pluginpkg.EnvAuthHeader + `   The Authorization header value for the endpoint,
+                     if logged in.
+  ` + pluginpkg.EnvConfigDir + `    The gsctl configuration directory.
+  ` + pluginpkg.EnvOutput + `        The output format requested via --output/-o.
+                     Defaults to 'table'.
+`,
+	}
+)
+
+func init() {
+	Command.AddCommand(list.Command)
+}
@@ -0,0 +1,82 @@
+// Package list implements the 'plugin list' sub-command.
+package list
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/pkg/plugin"
+)
+
+var (
+	// Command performs the "plugin list" function
+	Command = &cobra.Command{
+		Use:   "list",
+		Short: "List plugins found in the PATH",
+		Long: `Prints a list of the plugin executables found in your PATH.
+
+Plugins named like a built-in command can't be run and are marked as
+conflicting. Executables hidden by another one with the same name earlier in
+the PATH are listed as shadowed.
+`,
+		Run: printResult,
+	}
+)
+
+// Arguments specifies all the arguments to be used for our business function.
+type Arguments struct {
+	// pathList is the PATH to search for plugins.
+	pathList string
+	// isBuiltin returns true if a plugin name is taken by a built-in command.
+	isBuiltin func(name string) bool
+}
+
+// collectArguments fills arguments from the environment and the command tree.
+func collectArguments(cmd *cobra.Command) Arguments {
+	root := cmd.Root()
+
+	return Arguments{
+		pathList: os.Getenv("PATH"),
+		isBuiltin: func(name string) bool {
+			return plugin.IsBuiltin(root, name)
+		},
+	}
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	fmt.Println(pluginsTable(collectArguments(cmd)))
+}
+
+// pluginsTable returns the table of plugins, or a hint if there are none.
+func pluginsTable(args Arguments) string {
+	plugins := plugin.Discover(args.pathList)
+	if len(plugins) == 0 {
+		return fmt.Sprintf("No plugins found.\n\nTo add one, place an executable named %s in your PATH.",
+			color.YellowString(plugin.Prefix+"<name>"))
+	}
+
+	rows := []string{color.CyanString("NAME") + "|" + color.CyanString("PATH") + "|" + color.CyanString("NOTE")}
+	for _, p := range plugins {
+		notes := []string{}
+		if args.isBuiltin(p.Name) {
+			notes = append(notes, color.RedString("conflicts with a built-in command and is never run"))
+		}
+		if len(p.ShadowedPaths) > 0 {
+			notes = append(notes, color.YellowString("shadows %s", strings.Join(p.ShadowedPaths, ", ")))
+		}
+
+		note := "n/a"
+		if len(notes) > 0 {
+			note = strings.Join(notes, "; ")
+		}
+
+		rows = append(rows, strings.Join([]string{p.Name, p.Path, note}, "|"))
+	}
+
+	return columnize.SimpleFormat(rows)
+}
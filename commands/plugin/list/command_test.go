@@ -0,0 +1,58 @@
+package list
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp executes the command with --help.
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_pluginsTable tests listing plugins, including conflicts.
+func Test_pluginsTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"gsctl-foo", "gsctl-list"} {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	args := Arguments{
+		pathList:  dir,
+		isBuiltin: func(name string) bool { return name == "list" },
+	}
+
+	output := pluginsTable(args)
+	lines := strings.Split(output, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", output)
+	}
+	if !strings.Contains(lines[1], filepath.Join(dir, "gsctl-foo")) || strings.Contains(lines[1], "conflicts") {
+		t.Errorf("Unexpected line %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "conflicts with a built-in command") {
+		t.Errorf("Expected conflict in line %q", lines[2])
+	}
+
+	args.pathList = ""
+	output = pluginsTable(args)
+	if !strings.Contains(output, "No plugins found") {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
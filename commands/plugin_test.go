@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/plugin"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_runPlugin runs a plugin script and checks the environment
+// and arguments it gets, as well as the exit code.
+func Test_runPlugin(t *testing.T) {
+	configYAML := `endpoints:
+  https://foo:
+    email: email@example.com
+    token: some-token
+selected_endpoint: https://foo
+`
+	configDir, err := testutils.TempConfig(afero.NewOsFs(), configYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(configDir)
+
+	dir, err := ioutil.TempDir("", "plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	outFile := filepath.Join(dir, "out")
+	script := `#!/bin/sh
+echo "$@" > ` + outFile + `
+echo "$GSCTL_ENDPOINT" >> ` + outFile + `
+echo "$GSCTL_AUTH_HEADER" >> ` + outFile + `
+echo "$GSCTL_CONFIG_DIR" >> ` + outFile + `
+echo "$GSCTL_OUTPUT" >> ` + outFile + `
+exit 3
+`
+	err = ioutil.WriteFile(filepath.Join(dir, "gsctl-foo"), []byte(script), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, ok := plugin.Find(dir, "foo")
+	if !ok {
+		t.Fatal("Plugin not found")
+	}
+
+	args := []string{"bar", "--config-dir", configDir, "-o", "json", "--unknown", "value"}
+	defer func() {
+		flags.ConfigDirPath = ""
+		flags.OutputFormat = ""
+	}()
+	exitCode := runPlugin(p, args)
+
+	if exitCode != 3 {
+		t.Errorf("Expected exit code 3, got %d", exitCode)
+	}
+
+	content, err := ioutil.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		strings.Join(args, " "),
+		"https://foo",
+		"giantswarm some-token",
+		configDir,
+		"json",
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("Unexpected plugin output %q", content)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], lines[i])
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package replace
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/replace/nodepool"
+)
+
+var (
+	// Command is the command to replace things
+	Command = &cobra.Command{
+		Use:   "replace",
+		Short: "Replace node pools",
+		Long:  `Lets you replace a node pool with a new one using a different instance type or VM size.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(nodepool.Command)
+}
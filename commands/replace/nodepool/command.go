@@ -0,0 +1,507 @@
+// Package nodepool implements the "replace nodepool" command.
+package nodepool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/nodepoolspec"
+)
+
+var (
+	// Command is the cobra command for 'gsctl replace nodepool'
+	Command = &cobra.Command{
+		Use:     "nodepool <cluster-name/cluster-id>/<nodepool-id>",
+		Aliases: []string{"np"},
+		// Args: cobra.ExactArgs(1) guarantees that cobra will fail if no positional argument is given.
+		Args:  cobra.ExactArgs(1),
+		Short: "Replace a node pool with one using a different instance type",
+		Long: `Replace a node pool with a new one using a different instance type (AWS)
+or VM size (Azure).
+
+As the instance type of a node pool cannot be changed, this performs a
+blue/green replacement:
+
+1. A new node pool is created, with the same name, availability zones,
+   spot instance settings and scaling as the existing one, but with the
+   new instance type or VM size.
+2. gsctl waits until the new node pool has as many Ready nodes as the
+   existing one currently has, but at least its scaling minimum and at
+   least one. Readiness is taken from the node pool status reported by
+   the API.
+3. The existing node pool is scaled down to zero. Once its nodes are gone
+   from the cluster status, it is deleted.
+
+If the new node pool doesn't get ready within the time given via --timeout,
+it is deleted again and the existing node pool is left untouched.
+
+If the nodes of the existing node pool are not gone within --timeout after
+scaling it down, it is not deleted. Check the cluster and delete it using
+'gsctl delete nodepool' afterwards.
+
+Examples:
+
+  gsctl replace nodepool f01r4/75rh1 --aws-instance-type m5.2xlarge
+
+  gsctl replace nodepool "Cluster name"/75rh1 --azure-vm-size Standard_D8s_v3
+
+  gsctl replace nodepool f01r4/75rh1 --aws-instance-type m5.2xlarge --timeout 1h --force
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	cmdTimeout time.Duration
+
+	// pollInterval is the time between two cluster status checks.
+	pollInterval = 30 * time.Second
+
+	arguments Arguments
+)
+
+const (
+	activityName = "replace-nodepool"
+
+	defaultTimeout = 30 * time.Minute
+)
+
+// nodePoolLabels are the node labels carrying the node pool ID, on AWS and Azure.
+var nodePoolLabels = []string{"giantswarm.io/machine-deployment", "giantswarm.io/machine-pool"}
+
+func init() {
+	initFlags()
+}
+
+// initFlags initializes flags in a re-usable way, so we can call it from multiple tests.
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().StringVarP(&flags.WorkerAwsEc2InstanceType, "aws-instance-type", "", "", "AWS EC2 instance type to use for the new node pool, e. g. 'm5.2xlarge'")
+	Command.Flags().StringVarP(&flags.WorkerAzureVMSize, "azure-vm-size", "", "", "Azure VM Size to use for the new node pool, e. g. 'Standard_D4s_v3'")
+	Command.Flags().DurationVarP(&cmdTimeout, "timeout", "", defaultTimeout, "How long to wait for the nodes of the new node pool, before rolling back.")
+	Command.Flags().BoolVarP(&flags.Force, "force", "", false, "If set, no interactive confirmation will be required.")
+}
+
+// Arguments represents all the ways the user can influence the command.
+type Arguments struct {
+	APIEndpoint       string
+	AuthToken         string
+	ClusterNameOrID   string
+	Force             bool
+	InstanceType      string
+	NodePoolID        string
+	Timeout           time.Duration
+	UserProvidedToken string
+	Verbose           bool
+	VMSize            string
+}
+
+// result represents the outcome of a node pool replacement.
+type result struct {
+	clusterID     string
+	oldNodePoolID string
+	newNodePoolID string
+	// oldNodePoolDrained is false if the old node pool's nodes were
+	// still present when the timeout was reached. The old node pool
+	// is not deleted in this case.
+	oldNodePoolDrained bool
+}
+
+func collectArguments(positionalArgs []string) (Arguments, error) {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	parts := strings.Split(positionalArgs[0], "/")
+	if len(parts) != 2 {
+		return Arguments{}, microerror.Mask(errors.NodePoolIDMalformedError)
+	}
+
+	return Arguments{
+		APIEndpoint:       endpoint,
+		AuthToken:         token,
+		ClusterNameOrID:   strings.TrimSpace(parts[0]),
+		Force:             flags.Force,
+		InstanceType:      flags.WorkerAwsEc2InstanceType,
+		NodePoolID:        strings.TrimSpace(parts[1]),
+		Timeout:           cmdTimeout,
+		UserProvidedToken: flags.Token,
+		Verbose:           flags.Verbose,
+		VMSize:            flags.WorkerAzureVMSize,
+	}, nil
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.APIEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.AuthToken == "" && args.UserProvidedToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	} else if args.ClusterNameOrID == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	} else if args.NodePoolID == "" {
+		return microerror.Mask(errors.NodePoolIDMissingError)
+	}
+
+	if args.InstanceType != "" && args.VMSize != "" {
+		return microerror.Maskf(errors.ConflictingFlagsError, "the flags --aws-instance-type and --azure-vm-size cannot be combined.")
+	}
+	if args.InstanceType == "" && args.VMSize == "" {
+		return microerror.Maskf(errors.NoOpError, "Please specify the new instance type using --aws-instance-type or --azure-vm-size.")
+	}
+	if args.Timeout <= 0 {
+		return microerror.Maskf(errors.NoOpError, "The --timeout must be positive.")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	var err error
+	arguments, err = collectArguments(positionalArgs)
+
+	if err == nil {
+		err = verifyPreconditions(arguments)
+	}
+
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	clientWrapper, err := client.NewWithConfig(arguments.APIEndpoint, arguments.UserProvidedToken)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	clusterID, err := clustercache.GetID(arguments.APIEndpoint, arguments.ClusterNameOrID, clientWrapper)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	oldNodePool, err := fetchNodePool(arguments, clusterID, clientWrapper)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	if !arguments.Force {
+		confirmed := confirm.Ask(fmt.Sprintf("Do you want to replace node pool '%s' (%s) in cluster '%s' with a new node pool using %s? The existing node pool will be deleted.",
+			oldNodePool.ID, machineType(oldNodePool), clusterID, newMachineType(arguments)))
+		if !confirmed {
+			if arguments.Verbose {
+				fmt.Println(color.GreenString("Aborted."))
+			}
+			return
+		}
+	}
+
+	r, err := replaceNodePool(arguments, clusterID, oldNodePool, clientWrapper)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	if !r.oldNodePoolDrained {
+		fmt.Println(color.YellowString("Node pool '%s' has been scaled down to zero, but not all of its nodes were gone within the timeout.", r.oldNodePoolID))
+		fmt.Println("The node pool has not been deleted. Once its nodes are gone, delete it using this command:")
+		fmt.Println(color.YellowString("\n    gsctl delete nodepool %s/%s\n", r.clusterID, r.oldNodePoolID))
+		fmt.Printf("New node pool '%s' is ready. Use this command to inspect its details:\n\n", r.newNodePoolID)
+		fmt.Println(color.YellowString("    gsctl show nodepool %s/%s", r.clusterID, r.newNodePoolID))
+		fmt.Printf("\n")
+		return
+	}
+
+	fmt.Println(color.GreenString("Node pool '%s' in cluster '%s' has been replaced by node pool '%s'.", r.oldNodePoolID, r.clusterID, r.newNodePoolID))
+	fmt.Printf("Use this command to inspect details for the new node pool:\n\n")
+	fmt.Println(color.YellowString("    gsctl show nodepool %s/%s", r.clusterID, r.newNodePoolID))
+	fmt.Printf("\n")
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsNodePoolIDMalformedError(err):
+		headline = "Bad format for Cluster name/ID or Node Pool ID argument"
+		subtext = "Please provide cluster name/ID and node pool ID separated by a slash. See --help for examples."
+	case errors.IsConflictingFlagsError(err):
+		headline = "Conflicting flags used"
+		subtext = strings.Replace(err.Error(), "conflicting flags error: t", "T", 1)
+	case errors.IsNoOpError(err):
+		headline = microerror.Pretty(err, false)
+	case errors.IsNodePoolNotFound(err):
+		headline = "Node pool not found"
+		subtext = "Please check the node pool ID. Use 'gsctl list nodepools' to list all node pools of a cluster."
+	case IsProviderMismatch(err):
+		headline = "Wrong flag for this provider"
+		subtext = "Use --aws-instance-type for node pools on AWS, and --azure-vm-size for node pools on Azure."
+	case IsNodePoolNotReady(err):
+		headline = "The new node pool did not get ready in time"
+		subtext = microerror.Pretty(err, false) + "\nThe existing node pool has not been changed. You might want to try again with a longer --timeout."
+	case IsRollbackFailed(err):
+		headline = "The new node pool did not get ready in time and could not be removed"
+		subtext = microerror.Pretty(err, false) + "\nThe existing node pool has not been changed. Please delete the new node pool using 'gsctl delete nodepool'."
+	default:
+		headline = err.Error()
+	}
+
+	// print output
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// fetchNodePool fetches the node pool to replace and checks
+// whether the requested change fits.
+func fetchNodePool(args Arguments, clusterID string, clientWrapper *client.Wrapper) (*models.V5GetNodePoolResponse, error) {
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	response, err := clientWrapper.GetNodePool(clusterID, args.NodePoolID, auxParams)
+	if err != nil {
+		if clienterror.IsNotFoundError(err) {
+			return nil, microerror.Mask(errors.NodePoolNotFoundError)
+		}
+
+		return nil, microerror.Mask(err)
+	}
+
+	np := response.Payload
+	if np.NodeSpec == nil {
+		return nil, microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
+	}
+	if args.InstanceType != "" && np.NodeSpec.Aws == nil {
+		return nil, microerror.Maskf(providerMismatchError, "node pool '%s' is not an AWS node pool", np.ID)
+	}
+	if args.VMSize != "" && np.NodeSpec.Azure == nil {
+		return nil, microerror.Maskf(providerMismatchError, "node pool '%s' is not an Azure node pool", np.ID)
+	}
+	if machineType(np) == newMachineType(args) {
+		return nil, microerror.Maskf(errors.NoOpError, "The node pool already uses %s.", machineType(np))
+	}
+
+	return np, nil
+}
+
+// replaceNodePool creates the new node pool, waits for its nodes, and
+// then removes the old node pool. If the new node pool doesn't get ready,
+// it gets deleted again.
+func replaceNodePool(args Arguments, clusterID string, oldNodePool *models.V5GetNodePoolResponse, clientWrapper *client.Wrapper) (*result, error) {
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	requestBody := nodepoolspec.AddRequestFromNodePool(oldNodePool)
+	if args.InstanceType != "" {
+		requestBody.NodeSpec.Aws.InstanceType = args.InstanceType
+	}
+	if args.VMSize != "" {
+		requestBody.NodeSpec.Azure.VMSize = args.VMSize
+	}
+
+	if args.Verbose {
+		fmt.Println(color.WhiteString("Submitting node pool creation request"))
+		bodyJSON, _ := json.Marshal(requestBody)
+		fmt.Println(color.WhiteString("Request body: ") + string(bodyJSON))
+	}
+
+	response, err := clientWrapper.CreateNodePool(clusterID, requestBody, auxParams)
+	if err != nil {
+		if clienterror.IsBadRequestError(err) {
+			return nil, microerror.Maskf(errors.BadRequestError, err.Error())
+		}
+
+		return nil, microerror.Mask(err)
+	}
+
+	// The cached node pool IDs are outdated now.
+	clustercache.InvalidateNodePools(args.APIEndpoint, clusterID)
+
+	r := &result{
+		clusterID:     clusterID,
+		oldNodePoolID: oldNodePool.ID,
+		newNodePoolID: response.Payload.ID,
+	}
+
+	minNodes := requiredNodes(oldNodePool)
+
+	fmt.Println(color.WhiteString("Created node pool '%s', waiting for %d of its nodes to be ready", r.newNodePoolID, minNodes))
+
+	ready, err := waitForReadyNodes(clusterID, r.newNodePoolID, minNodes, args.Timeout, clientWrapper, auxParams)
+	if err != nil || !ready {
+		fmt.Println(color.WhiteString("Rolling back, deleting node pool '%s'", r.newNodePoolID))
+
+		_, deleteErr := clientWrapper.DeleteNodePool(clusterID, r.newNodePoolID, auxParams)
+		if deleteErr != nil {
+			return nil, microerror.Maskf(rollbackFailedError, "node pool '%s': %s", r.newNodePoolID, deleteErr.Error())
+		}
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		return nil, microerror.Maskf(nodePoolNotReadyError, "Fewer than %d nodes of node pool '%s' were ready within %s.", minNodes, r.newNodePoolID, args.Timeout)
+	}
+
+	fmt.Println(color.WhiteString("Scaling down node pool '%s'", r.oldNodePoolID))
+
+	err = clientWrapper.ScaleNodePool(clusterID, r.oldNodePoolID, 0, 0, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	r.oldNodePoolDrained, err = waitForNodeCount(clusterID, r.oldNodePoolID, func(count int64) bool { return count == 0 }, args.Timeout, clientWrapper, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if !r.oldNodePoolDrained {
+		// Deleting the node pool now could remove nodes still running workloads.
+		return r, nil
+	}
+
+	fmt.Println(color.WhiteString("Deleting node pool '%s'", r.oldNodePoolID))
+
+	_, err = clientWrapper.DeleteNodePool(clusterID, r.oldNodePoolID, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return r, nil
+}
+
+// requiredNodes returns the number of ready nodes the new node pool needs
+// before the old one is removed: the old node pool's current node count,
+// but at least its scaling minimum and at least one.
+func requiredNodes(oldNodePool *models.V5GetNodePoolResponse) int64 {
+	required := int64(1)
+	if oldNodePool.Scaling != nil && oldNodePool.Scaling.Min != nil && *oldNodePool.Scaling.Min > required {
+		required = *oldNodePool.Scaling.Min
+	}
+	if oldNodePool.Status != nil && oldNodePool.Status.Nodes > required {
+		required = oldNodePool.Status.Nodes
+	}
+
+	return required
+}
+
+// waitForReadyNodes polls the node pool status until at least the given
+// number of its nodes are ready. It returns false if the timeout is
+// reached before.
+func waitForReadyNodes(clusterID, nodePoolID string, required int64, timeout time.Duration, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) (bool, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		response, err := clientWrapper.GetNodePool(clusterID, nodePoolID, auxParams)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		var ready int64
+		if response.Payload.Status != nil {
+			ready = response.Payload.Status.NodesReady
+		}
+		if ready >= required {
+			return true, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return false, nil
+		}
+
+		fmt.Println(color.WhiteString("Node pool '%s' has %d of %d nodes ready, checking again in %s", nodePoolID, ready, required, pollInterval))
+		time.Sleep(pollInterval)
+	}
+}
+
+// waitForNodeCount polls the cluster status until the number of nodes
+// belonging to the node pool fulfills the condition. It returns false
+// if the timeout is reached before.
+func waitForNodeCount(clusterID, nodePoolID string, condition func(int64) bool, timeout time.Duration, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) (bool, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		status, err := clientWrapper.GetClusterStatus(clusterID, auxParams)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		count := countNodes(status, nodePoolID)
+		if condition(count) {
+			return true, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return false, nil
+		}
+
+		fmt.Println(color.WhiteString("Node pool '%s' has %d nodes, checking again in %s", nodePoolID, count, pollInterval))
+		time.Sleep(pollInterval)
+	}
+}
+
+// countNodes returns the number of nodes in the cluster status belonging to the node pool.
+func countNodes(status *client.ClusterStatus, nodePoolID string) int64 {
+	if status == nil || status.Cluster == nil {
+		return 0
+	}
+
+	var count int64
+	for _, node := range status.Cluster.Nodes {
+		for _, label := range nodePoolLabels {
+			if node.Labels[label] == nodePoolID {
+				count++
+				break
+			}
+		}
+	}
+
+	return count
+}
+
+// machineType returns the instance type or VM size of a node pool.
+func machineType(np *models.V5GetNodePoolResponse) string {
+	if np.NodeSpec.Aws != nil {
+		return np.NodeSpec.Aws.InstanceType
+	}
+	if np.NodeSpec.Azure != nil {
+		return np.NodeSpec.Azure.VMSize
+	}
+
+	return ""
+}
+
+// newMachineType returns the instance type or VM size requested.
+func newMachineType(args Arguments) string {
+	if args.InstanceType != "" {
+		return args.InstanceType
+	}
+
+	return args.VMSize
+}
@@ -0,0 +1,330 @@
+package nodepool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/go-openapi/swag"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+const oldNodePoolResponse = `{
+	"id": "a7k",
+	"name": "General purpose",
+	"availability_zones": ["eu-central-1a"],
+	"node_spec": {
+		"aws": {
+			"instance_type": "m5.xlarge",
+			"use_alike_instance_types": false,
+			"instance_distribution": {"on_demand_base_capacity": 0, "on_demand_percentage_above_base_capacity": 100}
+		}
+	},
+	"scaling": {"min": 2, "max": 5},
+	"status": {"nodes": 3, "nodes_ready": 3}
+}`
+
+// replaceMockServer simulates the API during a node pool replacement. If
+// newNodesJoin is false, nodes of the new node pool never get ready. If
+// oldNodesStay is true, nodes of the old node pool don't go away after scaling.
+type replaceMockServer struct {
+	newNodesJoin bool
+	oldNodesStay bool
+	requests     []string
+	createBody   map[string]interface{}
+	oldScaledTo  string
+}
+
+func (m *replaceMockServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		m.requests = append(m.requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/f01r4/nodepools/a7k/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(oldNodePoolResponse))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/f01r4/nodepools/b8l/":
+			nodesReady := 0
+			if m.newNodesJoin {
+				nodesReady = 3
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fmt.Sprintf(`{"id": "b8l", "status": {"nodes": 3, "nodes_ready": %d}}`, nodesReady)))
+		case r.Method == http.MethodPost && r.URL.Path == "/v5/clusters/f01r4/nodepools/":
+			body, _ := ioutil.ReadAll(r.Body)
+			err := json.Unmarshal(body, &m.createBody)
+			if err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "b8l", "name": "General purpose"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v5/clusters/f01r4/nodepools/a7k/":
+			body, _ := ioutil.ReadAll(r.Body)
+			m.oldScaledTo = string(body)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "a7k"}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v5/clusters/f01r4/nodepools/"):
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code": "RESOURCE_DELETION_STARTED", "message": "Deletion started"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/clusters/f01r4/status/":
+			nodes := []string{}
+			if m.oldScaledTo == "" || m.oldNodesStay {
+				nodes = append(nodes,
+					`{"name": "old-1", "labels": {"giantswarm.io/machine-deployment": "a7k"}}`,
+					`{"name": "old-2", "labels": {"giantswarm.io/machine-deployment": "a7k"}}`)
+			}
+			if m.newNodesJoin && m.createBody != nil {
+				nodes = append(nodes,
+					`{"name": "new-1", "labels": {"giantswarm.io/machine-deployment": "b8l"}}`,
+					`{"name": "new-2", "labels": {"giantswarm.io/machine-deployment": "b8l"}}`)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fmt.Sprintf(`{"cluster": {"nodes": [%s]}}`, strings.Join(nodes, ","))))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}
+}
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests the flag combinations.
+func Test_verifyPreconditions(t *testing.T) {
+	testCases := []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", ClusterNameOrID: "f01r4", NodePoolID: "a7k", InstanceType: "m5.2xlarge", Timeout: time.Minute},
+			errorMatcher: nil,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", ClusterNameOrID: "f01r4", NodePoolID: "a7k", InstanceType: "m5.2xlarge", VMSize: "Standard_D8s_v3", Timeout: time.Minute},
+			errorMatcher: errors.IsConflictingFlagsError,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", ClusterNameOrID: "f01r4", NodePoolID: "a7k", Timeout: time.Minute},
+			errorMatcher: errors.IsNoOpError,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", ClusterNameOrID: "f01r4", InstanceType: "m5.2xlarge", Timeout: time.Minute},
+			errorMatcher: errors.IsNodePoolIDMissingError,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := verifyPreconditions(tc.args)
+		if tc.errorMatcher == nil && err != nil {
+			t.Errorf("Case %d - Unexpected error: %s", i, err)
+		} else if tc.errorMatcher != nil && !tc.errorMatcher(err) {
+			t.Errorf("Case %d - Error not matching expectation, got %v", i, err)
+		}
+	}
+}
+
+// Test_replaceNodePool tests a successful replacement.
+func Test_replaceNodePool(t *testing.T) {
+	pollInterval = time.Millisecond
+
+	m := &replaceMockServer{newNodesJoin: true}
+	mockServer := httptest.NewServer(m.handler(t))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{APIEndpoint: mockServer.URL, NodePoolID: "a7k", InstanceType: "m5.2xlarge", Timeout: time.Second}
+
+	oldNodePool, err := fetchNodePool(args, "f01r4", clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	r, err := replaceNodePool(args, "f01r4", oldNodePool, clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if r.newNodePoolID != "b8l" || !r.oldNodePoolDrained {
+		t.Errorf("Unexpected result %#v", r)
+	}
+
+	nodeSpec := m.createBody["node_spec"].(map[string]interface{})["aws"].(map[string]interface{})
+	if nodeSpec["instance_type"] != "m5.2xlarge" {
+		t.Errorf("Expected new instance type, got %v", nodeSpec["instance_type"])
+	}
+	scaling := m.createBody["scaling"].(map[string]interface{})
+	if scaling["min"] != 2.0 || scaling["max"] != 5.0 {
+		t.Errorf("Expected scaling to be copied, got %v", scaling)
+	}
+	if m.oldScaledTo != `{"scaling":{"max":0,"min":0}}` {
+		t.Errorf("Expected old node pool to be scaled to zero, got %s", m.oldScaledTo)
+	}
+	if m.requests[len(m.requests)-1] != "DELETE /v5/clusters/f01r4/nodepools/a7k/" {
+		t.Errorf("Expected old node pool to be deleted last, got %s", m.requests[len(m.requests)-1])
+	}
+}
+
+// Test_replaceNodePoolRollback tests that the new node pool gets removed
+// if its nodes don't join in time, leaving the old node pool alone.
+func Test_replaceNodePoolRollback(t *testing.T) {
+	pollInterval = time.Millisecond
+
+	m := &replaceMockServer{newNodesJoin: false}
+	mockServer := httptest.NewServer(m.handler(t))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{APIEndpoint: mockServer.URL, NodePoolID: "a7k", InstanceType: "m5.2xlarge", Timeout: 20 * time.Millisecond}
+
+	oldNodePool, err := fetchNodePool(args, "f01r4", clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	_, err = replaceNodePool(args, "f01r4", oldNodePool, clientWrapper)
+	if !IsNodePoolNotReady(err) {
+		t.Fatalf("Expected nodePoolNotReadyError, got %v", err)
+	}
+
+	if m.oldScaledTo != "" {
+		t.Errorf("Old node pool must not be scaled, got %s", m.oldScaledTo)
+	}
+	if m.requests[len(m.requests)-1] != "DELETE /v5/clusters/f01r4/nodepools/b8l/" {
+		t.Errorf("Expected new node pool to be deleted, got %s", m.requests[len(m.requests)-1])
+	}
+}
+
+// Test_fetchNodePoolProviderMismatch tests using the Azure flag on an AWS node pool.
+func Test_fetchNodePoolProviderMismatch(t *testing.T) {
+	m := &replaceMockServer{}
+	mockServer := httptest.NewServer(m.handler(t))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fetchNodePool(Arguments{NodePoolID: "a7k", VMSize: "Standard_D8s_v3"}, "f01r4", clientWrapper)
+	if !IsProviderMismatch(err) {
+		t.Errorf("Expected providerMismatchError, got %v", err)
+	}
+
+	_, err = fetchNodePool(Arguments{NodePoolID: "a7k", InstanceType: "m5.xlarge"}, "f01r4", clientWrapper)
+	if !errors.IsNoOpError(err) {
+		t.Errorf("Expected NoOpError, got %v", err)
+	}
+}
+
+// Test_replaceNodePoolNotDrained tests that the old node pool is not deleted
+// if its nodes are still there after scaling it down.
+func Test_replaceNodePoolNotDrained(t *testing.T) {
+	pollInterval = time.Millisecond
+
+	m := &replaceMockServer{newNodesJoin: true, oldNodesStay: true}
+	mockServer := httptest.NewServer(m.handler(t))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{APIEndpoint: mockServer.URL, NodePoolID: "a7k", InstanceType: "m5.2xlarge", Timeout: 20 * time.Millisecond}
+
+	oldNodePool, err := fetchNodePool(args, "f01r4", clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	r, err := replaceNodePool(args, "f01r4", oldNodePool, clientWrapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if r.oldNodePoolDrained {
+		t.Errorf("Expected old node pool not to be drained")
+	}
+	for _, request := range m.requests {
+		if request == "DELETE /v5/clusters/f01r4/nodepools/a7k/" {
+			t.Errorf("Old node pool must not be deleted")
+		}
+	}
+}
+
+// Test_requiredNodes tests the number of ready nodes required before
+// the old node pool gets removed.
+func Test_requiredNodes(t *testing.T) {
+	var testCases = []struct {
+		min      *int64
+		nodes    int64
+		expected int64
+	}{
+		{nil, 0, 1},
+		{swag.Int64(0), 0, 1},
+		{swag.Int64(0), 4, 4},
+		{swag.Int64(3), 2, 3},
+		{swag.Int64(2), 5, 5},
+	}
+
+	for i, tc := range testCases {
+		np := &models.V5GetNodePoolResponse{
+			Scaling: &models.V5GetNodePoolResponseScaling{Min: tc.min},
+			Status:  &models.V5GetNodePoolResponseStatus{Nodes: tc.nodes},
+		}
+		if got := requiredNodes(np); got != tc.expected {
+			t.Errorf("Case %d - expected %d, got %d", i, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package nodepool
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var providerMismatchError = &microerror.Error{
+	Kind: "providerMismatchError",
+}
+
+// IsProviderMismatch asserts providerMismatchError.
+func IsProviderMismatch(err error) bool {
+	return microerror.Cause(err) == providerMismatchError
+}
+
+var nodePoolNotReadyError = &microerror.Error{
+	Kind: "nodePoolNotReadyError",
+}
+
+// IsNodePoolNotReady asserts nodePoolNotReadyError.
+func IsNodePoolNotReady(err error) bool {
+	return microerror.Cause(err) == nodePoolNotReadyError
+}
+
+var rollbackFailedError = &microerror.Error{
+	Kind: "rollbackFailedError",
+}
+
+// IsRollbackFailed asserts rollbackFailedError.
+func IsRollbackFailed(err error) bool {
+	return microerror.Cause(err) == rollbackFailedError
+}
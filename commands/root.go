@@ -5,26 +5,43 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/giantswarm/gscliauth/config"
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
+	"github.com/giantswarm/gsctl/buildinfo"
+	"github.com/giantswarm/gsctl/commands/cache"
+	configcmd "github.com/giantswarm/gsctl/commands/config"
+	copycmd "github.com/giantswarm/gsctl/commands/copy"
 	"github.com/giantswarm/gsctl/commands/create"
 	deletecmd "github.com/giantswarm/gsctl/commands/delete"
+	"github.com/giantswarm/gsctl/commands/doctor"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/commands/hibernate"
 	"github.com/giantswarm/gsctl/commands/info"
+	"github.com/giantswarm/gsctl/commands/label"
 	"github.com/giantswarm/gsctl/commands/list"
 	"github.com/giantswarm/gsctl/commands/login"
 	"github.com/giantswarm/gsctl/commands/logout"
+	"github.com/giantswarm/gsctl/commands/open"
 	"github.com/giantswarm/gsctl/commands/ping"
+	plugincmd "github.com/giantswarm/gsctl/commands/plugin"
+	"github.com/giantswarm/gsctl/commands/replace"
 	"github.com/giantswarm/gsctl/commands/scale"
+	"github.com/giantswarm/gsctl/commands/schema"
 	selectcmd "github.com/giantswarm/gsctl/commands/select"
 	"github.com/giantswarm/gsctl/commands/show"
 	"github.com/giantswarm/gsctl/commands/update"
 	"github.com/giantswarm/gsctl/commands/upgrade"
+	"github.com/giantswarm/gsctl/commands/validate"
 	"github.com/giantswarm/gsctl/commands/version"
+	"github.com/giantswarm/gsctl/commands/wake"
+	"github.com/giantswarm/gsctl/commands/whoami"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 	"github.com/giantswarm/gsctl/util"
 )
 
@@ -39,8 +56,52 @@ const (
         fi
     fi
 	`
+
+	getClustersFunc = `
+	local gsctl_out
+    if gsctl_out=$(gsctl cache show 2>/dev/null); then
+        if [[ $(echo "${gsctl_out}") != *"No clusters cached"* ]]; then
+            gsctl_out=$(echo "${gsctl_out}" | awk 'FNR > 1 {print $1}')
+
+            COMPREPLY=( $( compgen -W "${gsctl_out}" -- "${cur}" ) )
+        fi
+    fi
+	`
+
+	// clusterArgCompletionFn completes cluster IDs from the local cluster cache
+	// for commands taking a cluster name or ID as their first argument.
+	clusterArgCompletionFn = `
+case ${last_command} in
+	gsctl_delete_cluster|gsctl_hibernate_cluster|gsctl_list_keypairs|gsctl_list_nodepools|gsctl_list_nodes|gsctl_open_cluster|gsctl_scale_cluster|gsctl_show_cluster|gsctl_update_cluster|gsctl_upgrade_cluster|gsctl_wake_cluster)
+		__gsctl_get_clusters;
+		;;
+esac
+`
 )
 
+// mutatingCommands are the commands changing resources via the API. Before
+// these start, we make sure that an SSO token won't expire while they run.
+var mutatingCommands = []string{
+	"copy nodepools",
+	"create cluster",
+	"create keypair",
+	"create kubeconfig",
+	"create nodepool",
+	"create organization",
+	"delete cluster",
+	"delete nodepool",
+	"delete organization",
+	"hibernate cluster",
+	"label cluster",
+	"replace nodepool",
+	"scale cluster",
+	"update cluster",
+	"update nodepool",
+	"update organization",
+	"upgrade cluster",
+	"wake cluster",
+}
+
 // RootCommand is the main command of the CLI
 var RootCommand = &cobra.Command{
 	Use: config.ProgramName,
@@ -50,6 +111,11 @@ var RootCommand = &cobra.Command{
 }
 
 func init() {
+	// The version is used in the User-Agent header and for update checks.
+	if buildinfo.Version != buildinfo.VersionPlaceholder {
+		config.Version = buildinfo.Version
+	}
+
 	RootCommand.PersistentFlags().StringVarP(&flags.APIEndpoint, "endpoint", "e", "", "The API endpoint to use")
 
 	// Use the auth token defined as an environmental variable,
@@ -63,20 +129,33 @@ func init() {
 	RootCommand.Flags().Bool("version", false, version.Command.Short)
 
 	// add subcommands
+	RootCommand.AddCommand(cache.Command)
 	RootCommand.AddCommand(CompletionCommand)
+	RootCommand.AddCommand(configcmd.Command)
+	RootCommand.AddCommand(copycmd.Command)
 	RootCommand.AddCommand(create.Command)
 	RootCommand.AddCommand(deletecmd.Command)
+	RootCommand.AddCommand(doctor.Command)
+	RootCommand.AddCommand(hibernate.Command)
 	RootCommand.AddCommand(info.Command)
+	RootCommand.AddCommand(label.Command)
 	RootCommand.AddCommand(list.Command)
 	RootCommand.AddCommand(login.Command)
 	RootCommand.AddCommand(logout.Command)
+	RootCommand.AddCommand(open.Command)
 	RootCommand.AddCommand(ping.Command)
+	RootCommand.AddCommand(plugincmd.Command)
+	RootCommand.AddCommand(replace.Command)
 	RootCommand.AddCommand(scale.Command)
+	RootCommand.AddCommand(schema.Command)
 	RootCommand.AddCommand(selectcmd.Command)
 	RootCommand.AddCommand(show.Command)
 	RootCommand.AddCommand(update.Command)
 	RootCommand.AddCommand(upgrade.Command)
+	RootCommand.AddCommand(validate.Command)
 	RootCommand.AddCommand(version.Command)
+	RootCommand.AddCommand(wake.Command)
+	RootCommand.AddCommand(whoami.Command)
 
 	// Custom auto-completion
 	util.SetFlagBashCompletionFn(&util.BashCompletionFunc{
@@ -86,6 +165,10 @@ func init() {
 		FnName:   "__gsctl_get_endpoints",
 		FnBody:   getEndpointsFunc,
 	})
+	util.RegisterBashCompletionFn(RootCommand, "__gsctl_get_clusters", getClustersFunc)
+	util.SetCommandBashCompletion(&util.BashCompletionFunc{
+		FnBody: clusterArgCompletionFn,
+	})
 	util.RegisterBashCompletionFn(RootCommand, "__gsctl_custom_func", util.GetCustomCommandCompletionFnBody())
 }
 
@@ -109,9 +192,31 @@ func initConfig(cmd *cobra.Command, args []string) error {
 		return microerror.Mask(err)
 	}
 
+	if isMutating(cmd) {
+		endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+		err = ssotoken.EnsureValid(endpoint, flags.Token)
+		if err != nil {
+			errors.HandleCommonErrors(err)
+			return microerror.Mask(err)
+		}
+	}
+
 	return nil
 }
 
+// isMutating returns true if cmd is one of the mutatingCommands
+// or one of their sub-commands.
+func isMutating(cmd *cobra.Command) bool {
+	path := strings.TrimPrefix(cmd.CommandPath(), config.ProgramName+" ") + " "
+	for _, c := range mutatingCommands {
+		if strings.HasPrefix(path, c+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func printResult(cmd *cobra.Command, args []string) {
 	isVersion, _ := cmd.Flags().GetBool("version")
 	if isVersion {
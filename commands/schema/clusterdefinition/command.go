@@ -0,0 +1,53 @@
+// Package clusterdefinition implements the 'schema cluster-definition' command.
+package clusterdefinition
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
+)
+
+var (
+	// Command is the cobra command for 'gsctl schema cluster-definition'
+	Command = &cobra.Command{
+		Use:     "cluster-definition",
+		Aliases: []string{"clusterdefinition"},
+		Short:   "Print the JSON schema for cluster definition files",
+		Long: `Prints a JSON Schema (draft-07) for cluster definition YAML files as used
+with 'gsctl create cluster --file'.
+
+The schema covers both the v4 format and the v5 format with node pools,
+including provider specific node specs, spot instance settings and master
+node high availability.
+
+Examples:
+
+  gsctl schema cluster-definition > cluster-definition.schema.json
+
+To have the definition validated in Visual Studio Code with the YAML
+extension, add this comment at the top of the definition file:
+
+  # yaml-language-server: $schema=./cluster-definition.schema.json
+
+Note that the schema only checks the structure of a definition. Use
+'gsctl validate' to check a definition against the installation.
+`,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+)
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	schema, err := clusterdefinition.Schema()
+	if err != nil {
+		fmt.Println(color.RedString(err.Error()))
+		os.Exit(1)
+	}
+
+	fmt.Println(string(schema))
+}
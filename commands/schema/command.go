@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/schema/clusterdefinition"
+)
+
+var (
+	// Command is the command to print schemas
+	Command = &cobra.Command{
+		Use:   "schema",
+		Short: "Print JSON schemas",
+		Long:  `Prints JSON schemas for files used with gsctl, so that editors and linters can validate them.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(clusterdefinition.Command)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 	"github.com/giantswarm/gsctl/util"
 )
 
@@ -82,7 +83,9 @@ func verifySelectEndpointPreconditions(cmdLineArgs []string) error {
 // selectEndpointRunOutput calls the actual function and
 // shows the result of the command execution
 func selectEndpointRunOutput(cmd *cobra.Command, cmdLineArgs []string) {
-	err := config.Config.SelectEndpoint(cmdLineArgs[0])
+	err := ssotoken.UpdateConfig(func() error {
+		return config.Config.SelectEndpoint(cmdLineArgs[0])
+	})
 	if err != nil {
 		if config.IsEndpointNotDefinedError(err) {
 			fmt.Println(color.RedString("The endpoint given is not defined."))
@@ -13,6 +13,7 @@ import (
 	"github.com/giantswarm/columnize"
 	"github.com/giantswarm/gscliauth/config"
 	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/gsctl/pkg/credentials"
 	"github.com/giantswarm/gsctl/pkg/releaseinfo"
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
@@ -24,6 +25,8 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
 	"github.com/giantswarm/gsctl/nodespec"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/pkg/watch"
 	"github.com/giantswarm/gsctl/util"
 	"github.com/giantswarm/gsctl/webui"
 )
@@ -36,10 +39,20 @@ var (
 		Short: "Show cluster details",
 		Long: `Display details of a cluster
 
+When used in a terminal without a cluster argument, you can select the cluster
+from a list.
+
 Examples:
 
   gsctl show cluster c7t2o
   gsctl show cluster "Cluster name"
+
+To follow an upgrade or scaling, use the --watch flag. The details are then
+refreshed periodically until interrupted. In a terminal, they are redrawn in
+place with changed values highlighted. Otherwise, only changed values are
+printed.
+
+  gsctl show cluster c7t2o --watch --interval 30s
 `,
 
 		// PreRun checks a few general things, like authentication.
@@ -55,6 +68,15 @@ Examples:
 	arguments Arguments
 )
 
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	ShowClusterCommand.Flags().BoolVarP(&flags.Watch, "watch", "w", false, "Refresh the details periodically, until interrupted.")
+	ShowClusterCommand.Flags().DurationVarP(&flags.WatchInterval, "interval", "", watch.DefaultInterval, "Time between two refreshes, with --watch.")
+}
+
 const (
 	activityName = "show-cluster"
 
@@ -70,6 +92,8 @@ type Arguments struct {
 	clusterNameOrID   string
 	userProvidedToken string
 	verbose           bool
+	watch             bool
+	watchInterval     time.Duration
 }
 
 // collectArguments fills arguments from user input, config, and environment.
@@ -85,6 +109,8 @@ func collectArguments() Arguments {
 		clusterNameOrID:   "",
 		userProvidedToken: flags.Token,
 		verbose:           flags.Verbose,
+		watch:             flags.Watch,
+		watchInterval:     flags.WatchInterval,
 	}
 }
 
@@ -92,6 +118,14 @@ func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
 	arguments = collectArguments()
 	err := verifyPreconditions(arguments, cmdLineArgs)
 
+	if errors.IsClusterNameOrIDMissingError(err) {
+		// In interactive sessions, let the user pick a cluster.
+		if clusterID := picker.MissingCluster(arguments.apiEndpoint, arguments.userProvidedToken); clusterID != "" {
+			arguments.clusterNameOrID = clusterID
+			err = nil
+		}
+	}
+
 	if err == nil {
 		return
 	}
@@ -111,6 +145,12 @@ func verifyPreconditions(args Arguments, cmdLineArgs []string) error {
 	if config.Config.Token == "" && args.authToken == "" {
 		return microerror.Mask(errors.NotLoggedInError)
 	}
+	if args.watch {
+		err := watch.ValidateInterval(args.watchInterval)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
 	if len(cmdLineArgs) == 0 {
 		return microerror.Mask(errors.ClusterNameOrIDMissingError)
 	}
@@ -348,7 +388,9 @@ func sumWorkerMemory(numWorkers int, workerDetails []*models.V4ClusterDetailsRes
 // printResult fetches cluster info from the API, which involves
 // several API calls, and prints the output.
 func printResult(cmd *cobra.Command, cmdLineArgs []string) {
-	arguments.clusterNameOrID = cmdLineArgs[0]
+	if len(cmdLineArgs) > 0 {
+		arguments.clusterNameOrID = cmdLineArgs[0]
+	}
 
 	if arguments.verbose {
 		fmt.Println(color.WhiteString("Fetching details for cluster %s.", arguments.clusterNameOrID))
@@ -360,6 +402,16 @@ func printResult(cmd *cobra.Command, cmdLineArgs []string) {
 		os.Exit(1)
 	}
 
+	if arguments.watch {
+		err = watchCluster(cmd, clientWrapper, arguments)
+		if err != nil {
+			handleError(microerror.Mask(err))
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	clusterDetailsV4, clusterDetailsV5, nodePools, clusterStatus, credentialDetails, err := getClusterDetails(clientWrapper, arguments)
 
 	var capabilitiesService *capabilities.Service
@@ -387,6 +439,50 @@ func printResult(cmd *cobra.Command, cmdLineArgs []string) {
 	}
 }
 
+// watchCluster displays the cluster details repeatedly, until interrupted.
+func watchCluster(cmd *cobra.Command, clientWrapper *client.Wrapper, args Arguments) error {
+	capabilitiesService, err := getCapabilitiesService(args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	releaseInfo, err := releaseinfo.New(releaseinfo.Config{ClientWrapper: clientWrapper})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = watch.Run(watch.Config{
+		Fetch: func() (*watch.Frame, error) {
+			return getFrame(clientWrapper, capabilitiesService, releaseInfo, args)
+		},
+		Interval:    args.watchInterval,
+		Interactive: watch.IsTerminal(),
+		Title:       cmd.CommandPath() + " " + args.clusterNameOrID,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getFrame returns the current cluster details for watch mode.
+func getFrame(clientWrapper *client.Wrapper, capabilitiesService *capabilities.Service, releaseInfo *releaseinfo.ReleaseInfo, args Arguments) (*watch.Frame, error) {
+	clusterDetailsV4, clusterDetailsV5, nodePools, clusterStatus, credentialDetails, err := getClusterDetails(clientWrapper, args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var rows []string
+	if clusterDetailsV4 != nil {
+		rows = getV4Rows(args, clusterDetailsV4, clusterStatus, credentialDetails, releaseInfo)
+	} else if clusterDetailsV5 != nil {
+		rows = getV5Rows(args, clusterDetailsV5, credentialDetails, nodePools, capabilitiesService, releaseInfo)
+	}
+
+	return watch.NewFrame(rows, false), nil
+}
+
 // printV4Result prints the detils for a V4 cluster.
 func printV4Result(
 	args Arguments,
@@ -395,6 +491,17 @@ func printV4Result(
 	credentialDetails *models.V4GetCredentialResponse,
 	releaseInfo *releaseinfo.ReleaseInfo,
 ) {
+	fmt.Println(columnize.SimpleFormat(getV4Rows(args, clusterDetails, clusterStatus, credentialDetails, releaseInfo)))
+}
+
+// getV4Rows returns the rows of the details table for a V4 cluster.
+func getV4Rows(
+	args Arguments,
+	clusterDetails *models.V4ClusterDetailsResponse,
+	clusterStatus *client.ClusterStatus,
+	credentialDetails *models.V4GetCredentialResponse,
+	releaseInfo *releaseinfo.ReleaseInfo,
+) []string {
 	// Calculate worker node count.
 	numWorkers := 0
 	if clusterStatus != nil && clusterStatus.Cluster.Nodes != nil {
@@ -489,7 +596,7 @@ func printV4Result(
 		output = append(output, color.YellowString("Web UI:")+"|"+webUIURL)
 	}
 
-	fmt.Println(columnize.SimpleFormat(output))
+	return output
 }
 
 // printV5Result prints details for a v5 clsuter.
@@ -501,6 +608,30 @@ func printV5Result(
 	capabilitiesService *capabilities.Service,
 	releaseInfo *releaseinfo.ReleaseInfo,
 ) {
+	fmt.Println(columnize.SimpleFormat(getV5Rows(args, details, credentialDetails, nodePools, capabilitiesService, releaseInfo)))
+
+	if nodePools != nil && len(*nodePools) > 0 {
+		fmt.Println()
+		fmt.Printf("This cluster has node pools. For details, use\n\n")
+		fmt.Printf("    %s\n\n", color.YellowString("gsctl list nodepools %s", details.ID))
+		fmt.Printf("For details on a specific node pool, use\n\n")
+		fmt.Printf("    %s\n\n", color.YellowString("gsctl show nodepool %s/<nodepool-id>", details.ID))
+	} else {
+		fmt.Println()
+		fmt.Print("This cluster has no node pools. Find out how to add a node pool using\n\n")
+		fmt.Printf("    %s\n\n", color.YellowString("gsctl create nodepool --help"))
+	}
+}
+
+// getV5Rows returns the rows of the details table for a V5 cluster.
+func getV5Rows(
+	args Arguments,
+	details *models.V5ClusterDetailsResponse,
+	credentialDetails *models.V4GetCredentialResponse,
+	nodePools *models.V5GetNodePoolsResponse,
+	capabilitiesService *capabilities.Service,
+	releaseInfo *releaseinfo.ReleaseInfo,
+) []string {
 
 	webUIURL, _ := webui.ClusterDetailsURL(args.apiEndpoint, details.ID, details.Owner)
 
@@ -554,25 +685,11 @@ func printV5Result(
 	// once KVM is supported in V5.
 
 	// Aggregate of node pools.
-	if nodePools != nil {
-		if len(*nodePools) > 0 {
-			clusterTable = append(clusterTable, formatNodePoolDetails(nodePools)...)
-
-			fmt.Println(columnize.SimpleFormat(clusterTable))
-
-			fmt.Println()
-			fmt.Printf("This cluster has node pools. For details, use\n\n")
-			fmt.Printf("    %s\n\n", color.YellowString("gsctl list nodepools %s", details.ID))
-			fmt.Printf("For details on a specific node pool, use\n\n")
-			fmt.Printf("    %s\n\n", color.YellowString("gsctl show nodepool %s/<nodepool-id>", details.ID))
-		} else {
-			fmt.Println(columnize.SimpleFormat(clusterTable))
-
-			fmt.Println()
-			fmt.Print("This cluster has no node pools. Find out how to add a node pool using\n\n")
-			fmt.Printf("    %s\n\n", color.YellowString("gsctl create nodepool --help"))
-		}
+	if nodePools != nil && len(*nodePools) > 0 {
+		clusterTable = append(clusterTable, formatNodePoolDetails(nodePools)...)
 	}
+
+	return clusterTable
 }
 
 // formatDate takes a date/time string from the API and returns a formated version.
@@ -596,12 +713,8 @@ func formatCredentialDetails(credentialDetails *models.V4GetCredentialResponse)
 	rows := []string{}
 
 	if credentialDetails.Aws != nil {
-		parts := strings.Split(credentialDetails.Aws.Roles.Awsoperator, ":")
-		if len(parts) > 3 {
-			rows = append(rows, color.YellowString("AWS account:")+"|"+parts[4])
-		} else {
-			rows = append(rows, color.YellowString("AWS account:")+"|n/a")
-		}
+		accountID := credentials.AWSAccountID(credentialDetails.Aws.Roles.Awsoperator)
+		rows = append(rows, color.YellowString("AWS account:")+"|"+stringOrPlaceholder(accountID))
 	} else if credentialDetails.Azure != nil {
 		rows = append(rows, color.YellowString("Azure subscription:")+"|"+credentialDetails.Azure.Credential.SubscriptionID)
 		rows = append(rows, color.YellowString("Azure tenant:")+"|"+credentialDetails.Azure.Credential.TenantID)
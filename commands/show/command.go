@@ -5,6 +5,7 @@ import (
 
 	"github.com/giantswarm/gsctl/commands/show/cluster"
 	"github.com/giantswarm/gsctl/commands/show/nodepool"
+	"github.com/giantswarm/gsctl/commands/show/organization"
 	"github.com/giantswarm/gsctl/commands/show/release"
 )
 
@@ -12,8 +13,8 @@ var (
 	// Command is the command to display single items
 	Command = &cobra.Command{
 		Use:   "show",
-		Short: "Show clusters, node pools, releases",
-		Long:  `Print details of a cluster or a release`,
+		Short: "Show clusters, node pools, organizations, releases",
+		Long:  `Print details of a cluster, a node pool, an organization or a release`,
 	}
 )
 
@@ -21,4 +22,5 @@ func init() {
 	Command.AddCommand(cluster.ShowClusterCommand)
 	Command.AddCommand(nodepool.ShowNodepoolCommand)
 	Command.AddCommand(release.ShowReleaseCommand)
+	Command.AddCommand(organization.Command)
 }
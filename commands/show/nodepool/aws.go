@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
-	"github.com/giantswarm/columnize"
 	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
 
@@ -13,17 +12,18 @@ import (
 	"github.com/giantswarm/gsctl/nodespec"
 )
 
-func getOutputAWS(nodePool *models.V5GetNodePoolResponse) (string, error) {
+// getRowsAWS returns the rows of the details table, with cells separated by '|'.
+func getRowsAWS(nodePool *models.V5GetNodePoolResponse) ([]string, error) {
 	awsInfo, err := nodespec.NewAWS()
 	if err != nil {
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
 	instanceTypeDetails, err := awsInfo.GetInstanceTypeDetails(nodePool.NodeSpec.Aws.InstanceType)
 	if nodespec.IsInstanceTypeNotFoundErr(err) {
 		// We deliberately ignore "instance type not found", but respect all other errors.
 	} else if err != nil {
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
 	var instanceTypes string
@@ -52,7 +52,7 @@ func getOutputAWS(nodePool *models.V5GetNodePoolResponse) (string, error) {
 		table = append(table, color.YellowString("RAM:")+"|"+formatRAMAWS(nodePool.Status.NodesReady, instanceTypeDetails))
 	}
 
-	return columnize.SimpleFormat(table), nil
+	return table, nil
 }
 
 func formatInstanceTypeAWS(instanceTypeName string, details *nodespec.InstanceType) string {
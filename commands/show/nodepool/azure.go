@@ -6,24 +6,24 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
-	"github.com/giantswarm/columnize"
 	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/gsctl/nodespec"
 )
 
-func getOutputAzure(nodePool *models.V5GetNodePoolResponse) (string, error) {
+// getRowsAzure returns the rows of the details table, with cells separated by '|'.
+func getRowsAzure(nodePool *models.V5GetNodePoolResponse) ([]string, error) {
 	azureInfo, err := nodespec.NewAzureProvider()
 	if err != nil {
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
 	vmSizeDetails, err := azureInfo.GetVMSizeDetails(nodePool.NodeSpec.Azure.VMSize)
 	if nodespec.IsVMSizeNotFoundErr(err) {
 		// We deliberately ignore "vm size not found", but respect all other errors.
 	} else if err != nil {
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
 	var vmSizes string
@@ -56,7 +56,7 @@ func getOutputAzure(nodePool *models.V5GetNodePoolResponse) (string, error) {
 		table = append(table, color.YellowString("RAM:")+"|"+formatRAMAzure(nodePool.Status.NodesReady, vmSizeDetails))
 	}
 
-	return columnize.SimpleFormat(table), nil
+	return table, nil
 }
 
 func formatVMSizeAzure(vmSize string, details *nodespec.VMSize) string {
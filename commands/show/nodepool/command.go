@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
 	"github.com/giantswarm/gscliauth/config"
 	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/microerror"
@@ -18,6 +20,8 @@ import (
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
 	"github.com/giantswarm/gsctl/nodespec"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/pkg/watch"
 )
 
 var (
@@ -31,10 +35,19 @@ var (
 		Short: "Show node pool details",
 		Long: `Display details of a node pool.
 
+When used in a terminal with only the cluster given, you can select the node
+pool from a list.
+
 Examples:
 
   gsctl show nodepool f01r4/75rh1
   gsctl show nodepool "Cluster name"/75rh1
+
+To follow scaling, use the --watch flag. The details are then refreshed
+periodically until interrupted. In a terminal, they are redrawn in place with
+changed values highlighted. Otherwise, only changed values are printed.
+
+  gsctl show nodepool f01r4/75rh1 --watch --interval 30s
 `,
 
 		// PreRun checks a few general things, like authentication.
@@ -43,18 +56,31 @@ Examples:
 		// Run calls the business function and prints results and errors.
 		Run: printResult,
 	}
+
+	arguments *Arguments
 )
 
 const (
 	activityName = "show-nodepool"
 )
 
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	ShowNodepoolCommand.Flags().BoolVarP(&flags.Watch, "watch", "w", false, "Refresh the details periodically, until interrupted.")
+	ShowNodepoolCommand.Flags().DurationVarP(&flags.WatchInterval, "interval", "", watch.DefaultInterval, "Time between two refreshes, with --watch.")
+}
+
 type Arguments struct {
 	apiEndpoint       string
 	authToken         string
 	clusterNameOrID   string
 	nodePoolID        string
 	userProvidedToken string
+	watch             bool
+	watchInterval     time.Duration
 }
 
 // result represents all information we want to collect about one node pool.
@@ -83,6 +109,8 @@ func collectArguments(positionalArgs []string) (*Arguments, error) {
 		clusterNameOrID:   parts[0],
 		nodePoolID:        parts[1],
 		userProvidedToken: flags.Token,
+		watch:             flags.Watch,
+		watchInterval:     flags.WatchInterval,
 	}, nil
 }
 
@@ -100,14 +128,28 @@ func verifyPreconditions(args *Arguments) error {
 	if args.nodePoolID == "" {
 		return microerror.Mask(errors.NodePoolIDMissingError)
 	}
+	if args.watch {
+		err := watch.ValidateInterval(args.watchInterval)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
 
 	return nil
 }
 
 func printValidation(cmd *cobra.Command, positionalArgs []string) {
-	args, err := collectArguments(positionalArgs)
+	var err error
+	arguments, err = collectArguments(positionalArgs)
+	if errors.IsInvalidNodePoolIDArgument(err) && !strings.Contains(positionalArgs[0], "/") {
+		// Only a cluster is given. In interactive sessions, let the user pick a node pool.
+		endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+		if nodePoolID := picker.MissingNodePool(endpoint, flags.Token, positionalArgs[0]); nodePoolID != "" {
+			arguments, err = collectArguments([]string{positionalArgs[0] + "/" + nodePoolID})
+		}
+	}
 	if err == nil {
-		err = verifyPreconditions(args)
+		err = verifyPreconditions(arguments)
 		if err == nil {
 			return
 		}
@@ -118,7 +160,34 @@ func printValidation(cmd *cobra.Command, positionalArgs []string) {
 }
 
 func printResult(cmd *cobra.Command, positionalArgs []string) {
-	output, err := getOutput(positionalArgs)
+	args, err := collectArguments(positionalArgs)
+	if err != nil && arguments != nil {
+		// The node pool has been picked interactively in printValidation.
+		args, err = arguments, nil
+	}
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	if args.watch {
+		err = watch.Run(watch.Config{
+			Fetch: func() (*watch.Frame, error) {
+				return getFrame(args)
+			},
+			Interval:    args.watchInterval,
+			Interactive: watch.IsTerminal(),
+			Title:       cmd.CommandPath() + " " + args.clusterNameOrID + "/" + args.nodePoolID,
+		})
+		if err != nil {
+			handleError(microerror.Mask(err))
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	output, err := getOutput(args)
 	if err != nil {
 		handleError(microerror.Mask(err))
 		os.Exit(1)
@@ -180,36 +249,44 @@ func fetchNodePool(args *Arguments) (*models.V5GetNodePoolResponse, error) {
 	return response.Payload, nil
 }
 
-func getOutput(positionalArgs []string) (string, error) {
-	args, err := collectArguments(positionalArgs)
+func getOutput(args *Arguments) (string, error) {
+	nodePool, err := fetchNodePool(args)
 	if err != nil {
 		return "", microerror.Mask(err)
 	}
 
-	nodePool, err := fetchNodePool(args)
+	rows, err := getRows(nodePool)
 	if err != nil {
 		return "", microerror.Mask(err)
 	}
 
-	var output string
-	{
-		switch {
-		case nodePool.NodeSpec.Aws != nil:
-			output, err = getOutputAWS(nodePool)
-			if err != nil {
-				return "", microerror.Mask(err)
-			}
-
-		case nodePool.NodeSpec.Azure != nil:
-			output, err = getOutputAzure(nodePool)
-			if err != nil {
-				return "", microerror.Mask(err)
-			}
+	return columnize.SimpleFormat(rows), nil
+}
 
-		default:
-			return "", microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
-		}
+// getFrame returns the current node pool details for watch mode.
+func getFrame(args *Arguments) (*watch.Frame, error) {
+	nodePool, err := fetchNodePool(args)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	rows, err := getRows(nodePool)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return watch.NewFrame(rows, false), nil
+}
+
+// getRows returns the rows of the details table for the provider of the node pool.
+func getRows(nodePool *models.V5GetNodePoolResponse) ([]string, error) {
+	switch {
+	case nodePool.NodeSpec.Aws != nil:
+		return getRowsAWS(nodePool)
+
+	case nodePool.NodeSpec.Azure != nil:
+		return getRowsAzure(nodePool)
 	}
 
-	return output, nil
+	return nil, microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
 }
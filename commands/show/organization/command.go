@@ -0,0 +1,326 @@
+// Package organization implements the 'show organization' sub-command.
+package organization
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/commands/show/organization/credentials"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/picker"
+)
+
+const (
+	showOrganizationActivityName = "show-organization"
+
+	naString = "n/a"
+)
+
+var (
+	// Command is the cobra command for 'gsctl show organization'
+	Command = &cobra.Command{
+		Use:     "organization <organization-id>",
+		Aliases: []string{"org", "organisation"},
+		Short:   "Show organization details",
+		Long: `Display the members, credentials and number of clusters of an organization.
+
+Credentials are listed by ID and provider only. Use
+'gsctl show organization credentials' for details.
+
+When used in a terminal without an organization argument, you can select the
+organization from a list.
+
+Examples:
+
+  gsctl show organization acme
+
+  gsctl show organization acme --output json
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for showing an organization.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	organizationID    string
+	outputFormat      string
+	scheme            string
+	userProvidedToken string
+	verbose           bool
+}
+
+// OrganizationDetails is the result of this command, also used for JSON output.
+type OrganizationDetails struct {
+	ID           string              `json:"id"`
+	Members      []string            `json:"members"`
+	Credentials  []CredentialDetails `json:"credentials"`
+	ClusterCount int                 `json:"cluster_count"`
+}
+
+// CredentialDetails identifies a credential set of an organization,
+// without exposing any details of it.
+type CredentialDetails struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+}
+
+func init() {
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+
+	Command.AddCommand(credentials.Command)
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	organizationID := ""
+	if len(positionalArgs) > 0 {
+		organizationID = positionalArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		organizationID:    organizationID,
+		outputFormat:      flags.OutputFormat,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments(cmdLineArgs)
+	err := verifyPreconditions(arguments)
+
+	if errors.IsOrganizationNotSpecifiedError(err) {
+		if organizationID := picker.MissingOrganization(arguments.apiEndpoint, arguments.userProvidedToken); organizationID != "" {
+			arguments.organizationID = organizationID
+			err = verifyPreconditions(arguments)
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotSpecifiedError(err):
+		headline = "No organization ID given"
+		subtext = "Please specify the organization to show as a positional argument. See --help for details."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	os.Exit(1)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.organizationID == "" {
+		return microerror.Mask(errors.OrganizationNotSpecifiedError)
+	}
+	if args.outputFormat != formatting.OutputFormatJSON && args.outputFormat != formatting.OutputFormatTable {
+		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	details, err := getOrganizationDetails(arguments)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	output, err := getOutput(details, arguments.outputFormat)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotFoundError(err):
+		headline = fmt.Sprintf("Organization '%s' not found", arguments.organizationID)
+		subtext = "The specified organization does not exist, or you are not a member. Please check the exact upper/lower case spelling."
+		subtext += "\nUse 'gsctl list organizations' to list all organizations."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// getOrganizationDetails fetches the organization, its credentials and
+// clusters and puts together the details we show.
+func getOrganizationDetails(args Arguments) (*OrganizationDetails, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = showOrganizationActivityName
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching organization details"))
+	}
+
+	orgResponse, err := clientWrapper.GetOrganization(args.organizationID, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(mapClientError(err))
+	}
+
+	details := &OrganizationDetails{
+		ID:          orgResponse.Payload.ID,
+		Members:     []string{},
+		Credentials: []CredentialDetails{},
+	}
+	for _, member := range orgResponse.Payload.Members {
+		details.Members = append(details.Members, member.Email)
+	}
+	sort.Strings(details.Members)
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching organization credentials"))
+	}
+
+	credentialsResponse, err := clientWrapper.GetCredentials(args.organizationID, auxParams)
+	if err != nil && !clienterror.IsNotFoundError(err) {
+		return nil, microerror.Mask(mapClientError(err))
+	} else if err == nil {
+		for _, credential := range credentialsResponse.Payload {
+			details.Credentials = append(details.Credentials, CredentialDetails{
+				ID:       credential.ID,
+				Provider: credential.Provider,
+			})
+		}
+	}
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching clusters"))
+	}
+
+	clustersResponse, err := clientWrapper.GetClusters(auxParams)
+	if err != nil {
+		return nil, microerror.Mask(mapClientError(err))
+	}
+	for _, cluster := range clustersResponse.Payload {
+		if cluster.Owner == details.ID && cluster.DeleteDate == nil {
+			details.ClusterCount++
+		}
+	}
+
+	return details, nil
+}
+
+// mapClientError turns client errors into the errors we handle in this command.
+func mapClientError(err error) error {
+	switch {
+	case clienterror.IsUnauthorizedError(err):
+		return errors.NotAuthorizedError
+	case clienterror.IsAccessForbiddenError(err):
+		return errors.AccessForbiddenError
+	case clienterror.IsNotFoundError(err):
+		return errors.OrganizationNotFoundError
+	}
+
+	return err
+}
+
+// getOutput renders the organization details as a table or JSON.
+func getOutput(details *OrganizationDetails, outputFormat string) (string, error) {
+	if outputFormat == formatting.OutputFormatJSON {
+		output, err := json.MarshalIndent(details, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		return string(output), nil
+	}
+
+	table := []string{}
+	table = append(table, color.YellowString("ID:")+"|"+details.ID)
+	table = append(table, formatList("Members:", details.Members)...)
+
+	credentials := []string{}
+	for _, credential := range details.Credentials {
+		credentials = append(credentials, fmt.Sprintf("%s (%s)", credential.ID, credential.Provider))
+	}
+	table = append(table, formatList("Credentials:", credentials)...)
+
+	table = append(table, color.YellowString("Clusters:")+"|"+fmt.Sprintf("%d", details.ClusterCount))
+
+	return columnize.SimpleFormat(table), nil
+}
+
+// formatList returns table rows with one item per row, labelled on the first row.
+func formatList(label string, items []string) []string {
+	if len(items) == 0 {
+		return []string{color.YellowString(label) + "|" + naString}
+	}
+
+	rows := []string{}
+	for i, item := range items {
+		if i == 0 {
+			rows = append(rows, color.YellowString(label)+"|"+item)
+		} else {
+			rows = append(rows, "|"+item)
+		}
+	}
+
+	return rows
+}
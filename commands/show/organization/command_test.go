@@ -0,0 +1,95 @@
+package organization
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_getOrganizationDetails tests fetching and combining organization details.
+func Test_getOrganizationDetails(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "acme", "members": [{"email": "zoe@acme.com"}, {"email": "adam@acme.com"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{
+				"id": "a1b2c3",
+				"provider": "aws",
+				"aws": {"roles": {"admin": "arn:aws:iam::123456789012:role/GiantSwarmAdmin", "awsoperator": "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"}}
+			}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/clusters/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "f01r4", "name": "Production", "owner": "acme"},
+				{"id": "g02s5", "name": "Staging", "owner": "acme"},
+				{"id": "h03t6", "name": "Gone", "owner": "acme", "delete_date": "2020-01-01T00:00:00Z"},
+				{"id": "i04u7", "name": "Other", "owner": "other"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := getOrganizationDetails(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := &OrganizationDetails{
+		ID:           "acme",
+		Members:      []string{"adam@acme.com", "zoe@acme.com"},
+		Credentials:  []CredentialDetails{{ID: "a1b2c3", Provider: "aws"}},
+		ClusterCount: 2,
+	}
+	if diff := cmp.Diff(expected, details); diff != "" {
+		t.Errorf("Details not as expected (-want +got):\n%s", diff)
+	}
+
+	output, err := getOutput(details, "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if strings.Contains(output, "arn:aws") {
+		t.Errorf("Output must not contain credential details, got:\n%s", output)
+	}
+	var decoded OrganizationDetails
+	err = json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		t.Errorf("Output is not valid JSON: %s", err)
+	}
+
+	_, err = getOrganizationDetails(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "unknown"})
+	if !errors.IsOrganizationNotFoundError(err) {
+		t.Errorf("Expected OrganizationNotFoundError, got '%v'", err)
+	}
+}
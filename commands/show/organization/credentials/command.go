@@ -0,0 +1,328 @@
+// Package credentials implements the 'show organization credentials' sub-command.
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/pkg/credentials"
+	"github.com/giantswarm/gsctl/pkg/provider"
+)
+
+const (
+	showCredentialsActivityName = "show-org-credentials"
+
+	naString = "n/a"
+)
+
+var (
+	// Command is the cobra command for 'gsctl show organization credentials'
+	Command = &cobra.Command{
+		Use:   "credentials <organization-id>",
+		Short: "Show credentials of an organization",
+		Long: `Display the cloud provider credentials set for an organization.
+
+For AWS, the account ID and the ARNs of the admin and operator roles are shown.
+For Azure, the subscription, tenant and client IDs are shown. Secrets are
+never displayed.
+
+When used in a terminal without an organization argument, you can select the
+organization from a list.
+
+Examples:
+
+  gsctl show organization credentials acme
+
+  gsctl show organization credentials acme --output json
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for showing organization credentials.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	organizationID    string
+	outputFormat      string
+	scheme            string
+	userProvidedToken string
+	verbose           bool
+}
+
+// CredentialDetails holds the non-secret details of one credential set,
+// also used for JSON output.
+type CredentialDetails struct {
+	ID                  string `json:"id"`
+	Provider            string `json:"provider"`
+	AWSAccountID        string `json:"aws_account_id,omitempty"`
+	AWSAdminRoleARN     string `json:"aws_admin_role_arn,omitempty"`
+	AWSOperatorRoleARN  string `json:"aws_operator_role_arn,omitempty"`
+	AzureSubscriptionID string `json:"azure_subscription_id,omitempty"`
+	AzureTenantID       string `json:"azure_tenant_id,omitempty"`
+	AzureClientID       string `json:"azure_client_id,omitempty"`
+}
+
+func init() {
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' for JSON output. Defaults to human-friendly table output.", formatting.OutputFormatJSON))
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	organizationID := ""
+	if len(positionalArgs) > 0 {
+		organizationID = positionalArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		organizationID:    organizationID,
+		outputFormat:      flags.OutputFormat,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments(cmdLineArgs)
+	err := verifyPreconditions(arguments)
+
+	if errors.IsOrganizationNotSpecifiedError(err) {
+		if organizationID := picker.MissingOrganization(arguments.apiEndpoint, arguments.userProvidedToken); organizationID != "" {
+			arguments.organizationID = organizationID
+			err = verifyPreconditions(arguments)
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotSpecifiedError(err):
+		headline = "No organization ID given"
+		subtext = "Please specify the organization as a positional argument. See --help for details."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	os.Exit(1)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.organizationID == "" {
+		return microerror.Mask(errors.OrganizationNotSpecifiedError)
+	}
+	if args.outputFormat != formatting.OutputFormatJSON && args.outputFormat != formatting.OutputFormatTable {
+		return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	details, err := getCredentials(arguments)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	output, err := getOutput(arguments.organizationID, details, arguments.outputFormat)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotFoundError(err):
+		headline = fmt.Sprintf("Organization '%s' not found", arguments.organizationID)
+		subtext = "The specified organization does not exist, or you are not a member. Please check the exact upper/lower case spelling."
+		subtext += "\nUse 'gsctl list organizations' to list all organizations."
+	case errors.IsCredentialNotFoundError(err):
+		headline = "Credentials not found"
+		subtext = "The credentials of this organization could not be fetched. Please try again in a moment."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// getCredentials fetches the credentials of an organization, one by one,
+// and returns their non-secret details.
+func getCredentials(args Arguments) ([]CredentialDetails, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = showCredentialsActivityName
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching credentials of organization %s", args.organizationID))
+	}
+
+	listResponse, err := clientWrapper.GetCredentials(args.organizationID, auxParams)
+	if err != nil {
+		if clienterror.IsUnauthorizedError(err) {
+			return nil, microerror.Mask(errors.NotAuthorizedError)
+		}
+		if clienterror.IsAccessForbiddenError(err) {
+			return nil, microerror.Mask(errors.AccessForbiddenError)
+		}
+		if clienterror.IsNotFoundError(err) {
+			return nil, microerror.Mask(errors.OrganizationNotFoundError)
+		}
+
+		return nil, microerror.Mask(err)
+	}
+
+	details := []CredentialDetails{}
+
+	for _, item := range listResponse.Payload {
+		response, err := clientWrapper.GetCredential(args.organizationID, item.ID, auxParams)
+		if err != nil {
+			if clienterror.IsUnauthorizedError(err) {
+				return nil, microerror.Mask(errors.NotAuthorizedError)
+			}
+			if clienterror.IsAccessForbiddenError(err) {
+				return nil, microerror.Mask(errors.AccessForbiddenError)
+			}
+			if clienterror.IsNotFoundError(err) {
+				return nil, microerror.Mask(errors.CredentialNotFoundError)
+			}
+
+			return nil, microerror.Mask(err)
+		}
+
+		credential := response.Payload
+		d := CredentialDetails{
+			ID:       credential.ID,
+			Provider: credential.Provider,
+		}
+		if credential.Aws != nil && credential.Aws.Roles != nil {
+			d.AWSAdminRoleARN = credential.Aws.Roles.Admin
+			d.AWSOperatorRoleARN = credential.Aws.Roles.Awsoperator
+			d.AWSAccountID = credentials.AWSAccountID(credential.Aws.Roles.Awsoperator)
+		}
+		if credential.Azure != nil && credential.Azure.Credential != nil {
+			d.AzureSubscriptionID = credential.Azure.Credential.SubscriptionID
+			d.AzureTenantID = credential.Azure.Credential.TenantID
+			d.AzureClientID = credential.Azure.Credential.ClientID
+		}
+
+		details = append(details, d)
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+
+	return details, nil
+}
+
+// getOutput renders the credential details as a table or JSON.
+func getOutput(organizationID string, details []CredentialDetails, outputFormat string) (string, error) {
+	if outputFormat == formatting.OutputFormatJSON {
+		output, err := json.MarshalIndent(details, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		return string(output), nil
+	}
+
+	if len(details) == 0 {
+		return color.YellowString("Organization '%s' has no credentials set.", organizationID), nil
+	}
+
+	tables := []string{}
+	for _, d := range details {
+		table := []string{
+			color.YellowString("ID:") + "|" + d.ID,
+			color.YellowString("Provider:") + "|" + d.Provider,
+		}
+
+		switch d.Provider {
+		case provider.AWS:
+			table = append(table, color.YellowString("AWS account:")+"|"+stringOrPlaceholder(d.AWSAccountID))
+			table = append(table, color.YellowString("Admin role ARN:")+"|"+stringOrPlaceholder(d.AWSAdminRoleARN))
+			table = append(table, color.YellowString("Operator role ARN:")+"|"+stringOrPlaceholder(d.AWSOperatorRoleARN))
+		case provider.Azure:
+			table = append(table, color.YellowString("Azure subscription:")+"|"+stringOrPlaceholder(d.AzureSubscriptionID))
+			table = append(table, color.YellowString("Azure tenant:")+"|"+stringOrPlaceholder(d.AzureTenantID))
+			table = append(table, color.YellowString("Azure client:")+"|"+stringOrPlaceholder(d.AzureClientID))
+		}
+
+		tables = append(tables, columnize.SimpleFormat(table))
+	}
+
+	return strings.Join(tables, "\n\n"), nil
+}
+
+// stringOrPlaceholder returns the string or, if it is empty, the "n/a" placeholder.
+func stringOrPlaceholder(s string) string {
+	if s == "" {
+		return naString
+	}
+
+	return s
+}
@@ -0,0 +1,133 @@
+package credentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_getCredentials tests fetching credential details for AWS and Azure.
+func Test_getCredentials(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "a1b2c3", "provider": "aws"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/credentials/a1b2c3/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "a1b2c3",
+				"provider": "aws",
+				"aws": {"roles": {"admin": "arn:aws:iam::123456789012:role/GiantSwarmAdmin", "awsoperator": "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"}}
+			}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/contoso/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "d4e5f6", "provider": "azure"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/contoso/credentials/d4e5f6/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "d4e5f6",
+				"provider": "azure",
+				"azure": {"credential": {"subscription_id": "sub-id", "tenant_id": "tenant-id", "client_id": "client-id"}}
+			}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/empty/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		organizationID string
+		expected       []CredentialDetails
+		errorMatcher   func(error) bool
+	}{
+		{
+			organizationID: "acme",
+			expected: []CredentialDetails{
+				{
+					ID:                 "a1b2c3",
+					Provider:           "aws",
+					AWSAccountID:       "123456789012",
+					AWSAdminRoleARN:    "arn:aws:iam::123456789012:role/GiantSwarmAdmin",
+					AWSOperatorRoleARN: "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator",
+				},
+			},
+		},
+		{
+			organizationID: "contoso",
+			expected: []CredentialDetails{
+				{
+					ID:                  "d4e5f6",
+					Provider:            "azure",
+					AzureSubscriptionID: "sub-id",
+					AzureTenantID:       "tenant-id",
+					AzureClientID:       "client-id",
+				},
+			},
+		},
+		{
+			organizationID: "empty",
+			expected:       []CredentialDetails{},
+		},
+		{
+			organizationID: "unknown",
+			errorMatcher:   errors.IsOrganizationNotFoundError,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.organizationID, func(t *testing.T) {
+			details, err := getCredentials(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: tc.organizationID})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Errorf("Case %d - Error did not match expected type. Got '%v'", i, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+			if diff := cmp.Diff(tc.expected, details); diff != "" {
+				t.Errorf("Case %d - Details not as expected (-want +got):\n%s", i, diff)
+			}
+
+			output, err := getOutput(tc.organizationID, details, "table")
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+			for _, d := range tc.expected {
+				if !strings.Contains(output, d.ID) {
+					t.Errorf("Case %d - Expected '%s' in output:\n%s", i, d.ID, output)
+				}
+			}
+		})
+	}
+}
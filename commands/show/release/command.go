@@ -16,6 +16,7 @@ import (
 	"github.com/giantswarm/gsctl/client/clienterror"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/picker"
 	"github.com/giantswarm/gsctl/util"
 )
 
@@ -26,6 +27,9 @@ var (
 		Short: "Show release details",
 		Long: `Display details of a workload cluster release
 
+When used in a terminal without a version argument, you can select the release
+from a list.
+
 Examples:
 
   gsctl show release 14.0.0
@@ -73,6 +77,14 @@ func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
 	arguments = collectArguments()
 	err := verifyShowReleasePreconditions(arguments, cmdLineArgs)
 
+	if errors.IsReleaseVersionMissingError(err) {
+		// In interactive sessions, let the user pick a release.
+		if version := picker.MissingRelease(arguments.apiEndpoint, arguments.userProvidedToken); version != "" {
+			arguments.releaseVersion = version
+			err = nil
+		}
+	}
+
 	if err == nil {
 		return
 	}
@@ -128,7 +140,9 @@ func getReleaseDetails(clientWrapper *client.Wrapper, args Arguments) (*models.V
 
 // printResult prints the release information on stdout
 func printResult(cmd *cobra.Command, cmdLineArgs []string) {
-	arguments.releaseVersion = cmdLineArgs[0]
+	if len(cmdLineArgs) > 0 {
+		arguments.releaseVersion = cmdLineArgs[0]
+	}
 
 	clientWrapper, err := client.NewWithConfig(arguments.apiEndpoint, arguments.userProvidedToken)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/giantswarm/gsctl/client"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/labels"
 )
 
 var (
@@ -227,6 +228,11 @@ func updateClusterSpec(args Arguments) (*result, error) {
 			return nil, microerror.Mask(err)
 		}
 
+		if args.Name != "" {
+			// The cached cluster name is outdated now.
+			clustercache.Invalidate(args.APIEndpoint)
+		}
+
 		r := &result{}
 		{
 			if args.Name != "" {
@@ -263,6 +269,11 @@ func updateClusterSpec(args Arguments) (*result, error) {
 			return nil, microerror.Mask(err)
 		}
 
+		if args.Name != "" {
+			// The cached cluster name is outdated now.
+			clustercache.Invalidate(args.APIEndpoint)
+		}
+
 		r := &result{
 			ClusterName: response.Payload.Name,
 		}
@@ -347,6 +358,9 @@ func printResult(cmd *cobra.Command, positionalArgs []string) {
 
 		case errors.IsNoOpError(err):
 			headline = "No flags specified"
+		case labels.IsInvalidLabel(err):
+			headline = "Invalid label"
+			subtext = err.Error()
 
 		// If there are specific errors to handle, add them here.
 		default:
@@ -7,12 +7,13 @@ import (
 
 	"github.com/giantswarm/gsclientgen/v2/models"
 	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/labels"
 )
 
-func modifyClusterLabelsRequestFromArguments(labels []string) (*models.V5SetClusterLabelsRequest, error) {
+func modifyClusterLabelsRequestFromArguments(labelChanges []string) (*models.V5SetClusterLabelsRequest, error) {
 	request := &models.V5SetClusterLabelsRequest{Labels: map[string]*string{}}
 
-	for _, label := range labels {
+	for _, label := range labelChanges {
 		labelParts := strings.Split(strings.TrimSpace(label), "=")
 		if len(labelParts) != 2 {
 			return request, microerror.Maskf(errors.NoOpError, "malformed label change '%s' (single = required)", label)
@@ -20,6 +21,14 @@ func modifyClusterLabelsRequestFromArguments(labels []string) (*models.V5SetClus
 		if labelParts[0] == "" {
 			return request, microerror.Maskf(errors.NoOpError, "malformed label change '%s' (empty key)", label)
 		}
+		err := labels.ValidateKey(labelParts[0])
+		if err != nil {
+			return request, microerror.Mask(err)
+		}
+		err = labels.ValidateValue(labelParts[1])
+		if err != nil {
+			return request, microerror.Mask(err)
+		}
 		if labelParts[1] == "" {
 			request.Labels[labelParts[0]] = nil
 		} else {
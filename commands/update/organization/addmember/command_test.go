@@ -0,0 +1,81 @@
+package addmember
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func Test_addMember(t *testing.T) {
+	var submitted *models.ModifyOrganizationParamsBody
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "acme", "members": [{"email": "jane@acme.com"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v4/organizations/acme/":
+			body, _ := ioutil.ReadAll(r.Body)
+			submitted = &models.ModifyOrganizationParamsBody{}
+			json.Unmarshal(body, submitted)
+			w.WriteHeader(http.StatusOK)
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = addMember(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme", email: "john@acme.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if submitted == nil || len(submitted.Members) != 2 || submitted.Members[0].Email != "jane@acme.com" || submitted.Members[1].Email != "john@acme.com" {
+		t.Errorf("Unexpected members submitted: %#v", submitted)
+	}
+
+	err = addMember(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme", email: "Jane@acme.com"})
+	if !errors.IsOrganizationMemberAlreadyExistsError(err) {
+		t.Errorf("Expected OrganizationMemberAlreadyExistsError, got '%v'", err)
+	}
+
+	err = addMember(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "unknown", email: "john@acme.com"})
+	if !errors.IsOrganizationNotFoundError(err) {
+		t.Errorf("Expected OrganizationNotFoundError, got '%v'", err)
+	}
+}
+
+func Test_verifyPreconditions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://api.example.com", authToken: "token", organizationID: "acme"})
+	if !errors.IsNoEmailArgumentGivenError(err) {
+		t.Errorf("Expected NoEmailArgumentGivenError, got '%v'", err)
+	}
+
+	err = verifyPreconditions(Arguments{apiEndpoint: "https://api.example.com", authToken: "token", email: "john@acme.com"})
+	if !errors.IsOrganizationNotSpecifiedError(err) {
+		t.Errorf("Expected OrganizationNotSpecifiedError, got '%v'", err)
+	}
+}
@@ -3,6 +3,8 @@ package organization
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/giantswarm/gsctl/commands/update/organization/addmember"
+	"github.com/giantswarm/gsctl/commands/update/organization/removemember"
 	"github.com/giantswarm/gsctl/commands/update/organization/setcredentials"
 	"github.com/giantswarm/gsctl/flags"
 )
@@ -18,6 +20,12 @@ var (
 Examples:
 
   gsctl update organization set-credentials -o acme ...
+
+  gsctl update organization rotate-credentials -o acme ...
+
+  gsctl update organization add-member -o acme --email jane@example.com
+
+  gsctl update organization remove-member -o acme --email jane@example.com
 `,
 	}
 )
@@ -26,4 +34,7 @@ func init() {
 	Command.Flags().StringVarP(&flags.OrganizationID, "organization", "o", "", "ID of the organization to modify")
 
 	Command.AddCommand(setcredentials.Command)
+	Command.AddCommand(setcredentials.RotateCommand)
+	Command.AddCommand(addmember.Command)
+	Command.AddCommand(removemember.Command)
 }
@@ -0,0 +1,221 @@
+// Package removemember implements the 'update organization remove-member' sub-command.
+package removemember
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+)
+
+const (
+	activityName = "remove-org-member"
+)
+
+var (
+	// Command performs the "update organization remove-member" function
+	Command = &cobra.Command{
+		Use:   "remove-member",
+		Short: "Remove a member from an organization",
+		Long: `Remove a user from the members of an organization.
+
+The user is identified by their email address.
+`,
+		Example: `
+  gsctl update organization remove-member -o acme --email jane@example.com
+`,
+
+		// PreRun checks a few general things, like authentication and flags.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	cmdEmail string
+
+	arguments Arguments
+)
+
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	email             string
+	organizationID    string
+	scheme            string
+	userProvidedToken string
+	verbose           bool
+}
+
+func init() {
+	Command.Flags().StringVarP(&flags.OrganizationID, "organization", "o", "", "ID of the organization to remove the member from")
+	Command.Flags().StringVarP(&cmdEmail, "email", "", "", "Email address of the user to remove")
+}
+
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		email:             strings.TrimSpace(cmdEmail),
+		organizationID:    flags.OrganizationID,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsOrganizationNotSpecifiedError(err):
+		headline = "No organization given"
+		subtext = "Please specify the organization to remove the member from using the -o|--organization flag."
+	case errors.IsNoEmailArgumentGivenError(err):
+		headline = "No email given"
+		subtext = "Please specify the email address of the user to remove using the --email flag."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	os.Exit(1)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.organizationID == "" {
+		return microerror.Mask(errors.OrganizationNotSpecifiedError)
+	}
+	if args.email == "" {
+		return microerror.Mask(errors.NoEmailArgumentGivenError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+
+	return nil
+}
+
+// printResult calls the business function and produces
+// meaningful terminal output.
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	err := removeMember(arguments)
+
+	if err != nil {
+		client.HandleErrors(err)
+		errors.HandleCommonErrors(err)
+
+		headline := ""
+		subtext := ""
+
+		switch {
+		case errors.IsOrganizationNotFoundError(err):
+			headline = fmt.Sprintf("Organization '%s' not found", arguments.organizationID)
+			subtext = "The specified organization does not exist, or you are not a member. Please check the exact upper/lower case spelling."
+			subtext += "\nUse 'gsctl list organizations' to list all organizations."
+		case errors.IsOrganizationMemberNotFoundError(err):
+			headline = "Not a member"
+			subtext = fmt.Sprintf("'%s' is not a member of organization '%s'.", arguments.email, arguments.organizationID)
+		default:
+			headline = err.Error()
+		}
+
+		fmt.Println(color.RedString(headline))
+		if subtext != "" {
+			fmt.Println(subtext)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println(color.GreenString("'%s' has been removed from organization '%s'.", arguments.email, arguments.organizationID))
+}
+
+// removeMember fetches the current members of the organization and
+// submits the list without the given member.
+func removeMember(args Arguments) error {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = activityName
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching current organization members"))
+	}
+
+	response, err := clientWrapper.GetOrganization(args.organizationID, auxParams)
+	if err != nil {
+		return microerror.Mask(mapClientError(err))
+	}
+
+	found := false
+	members := []*models.V4OrganizationMember{}
+	for _, member := range response.Payload.Members {
+		if strings.EqualFold(member.Email, args.email) {
+			found = true
+			continue
+		}
+		members = append(members, &models.V4OrganizationMember{Email: member.Email})
+	}
+	if !found {
+		return microerror.Mask(errors.OrganizationMemberNotFoundError)
+	}
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Sending API request to modify organization members"))
+	}
+
+	_, err = clientWrapper.ModifyOrganization(args.organizationID, &models.ModifyOrganizationParamsBody{Members: members}, auxParams)
+	if err != nil {
+		return microerror.Mask(mapClientError(err))
+	}
+
+	return nil
+}
+
+// mapClientError turns client errors into the errors we handle in this command.
+func mapClientError(err error) error {
+	switch {
+	case clienterror.IsUnauthorizedError(err):
+		return errors.NotAuthorizedError
+	case clienterror.IsAccessForbiddenError(err):
+		return errors.AccessForbiddenError
+	case clienterror.IsNotFoundError(err):
+		return errors.OrganizationNotFoundError
+	}
+
+	return err
+}
@@ -0,0 +1,63 @@
+package removemember
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func Test_removeMember(t *testing.T) {
+	var submitted *models.ModifyOrganizationParamsBody
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "acme", "members": [{"email": "jane@acme.com"}, {"email": "john@acme.com"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v4/organizations/acme/":
+			body, _ := ioutil.ReadAll(r.Body)
+			submitted = &models.ModifyOrganizationParamsBody{}
+			json.Unmarshal(body, submitted)
+			w.WriteHeader(http.StatusOK)
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = removeMember(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme", email: "John@acme.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if submitted == nil || len(submitted.Members) != 1 || submitted.Members[0].Email != "jane@acme.com" {
+		t.Errorf("Unexpected members submitted: %#v", submitted)
+	}
+
+	err = removeMember(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "acme", email: "nobody@acme.com"})
+	if !errors.IsOrganizationMemberNotFoundError(err) {
+		t.Errorf("Expected OrganizationMemberNotFoundError, got '%v'", err)
+	}
+
+	err = removeMember(Arguments{apiEndpoint: mockServer.URL, authToken: "token", organizationID: "unknown", email: "john@acme.com"})
+	if !errors.IsOrganizationNotFoundError(err) {
+		t.Errorf("Expected OrganizationNotFoundError, got '%v'", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/giantswarm/gsctl/client/clienterror"
 	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/credentials"
 )
 
 const (
@@ -84,6 +85,7 @@ type Arguments struct {
 	azureSecretKey      string
 	azureSubscriptionID string
 	azureTenantID       string
+	force               bool
 	organizationID      string
 	scheme              string
 	userProvidedToken   string
@@ -118,6 +120,7 @@ func collectArguments() Arguments {
 		azureSecretKey:      cmdAzureSecretKey,
 		azureSubscriptionID: cmdAzureSubscriptionID,
 		azureTenantID:       cmdAzureTenantID,
+		force:               flags.Force,
 		organizationID:      flags.OrganizationID,
 		scheme:              scheme,
 		userProvidedToken:   flags.Token,
@@ -150,6 +153,9 @@ func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
 	case errors.IsRequiredFlagMissingError(err):
 		headline = "Missing flag: " + err.Error()
 		subtext = "Please use --help to see details regarding the command's usage."
+	case errors.IsCredentialsFormatInvalidError(err):
+		headline = "Invalid flag value: " + err.Error()
+		subtext = fmt.Sprintf("AWS roles must be given as ARNs like '%s', Azure IDs as GUIDs like '%s'.", credentials.AWSRoleARNFormat, credentials.AzureGUIDFormat)
 	case errors.IsConflictingFlagsError(err):
 		headline = "Conflicting flags"
 		subtext = "Please use only AWS or Azure related flags with this installation. See --help for details."
@@ -225,6 +231,14 @@ func verifyPreconditions(args Arguments) error {
 			if args.azureClientID != "" || args.azureSecretKey != "" || args.azureSubscriptionID != "" || args.azureTenantID != "" {
 				return microerror.Maskf(errors.ConflictingFlagsError, "Azure-related flags not allowed here")
 			}
+
+			// formats
+			if !credentials.IsAWSRoleARN(args.awsAdminRole) {
+				return microerror.Maskf(errors.CredentialsFormatInvalidError, "--aws-admin-role")
+			}
+			if !credentials.IsAWSRoleARN(args.awsOperatorRole) {
+				return microerror.Maskf(errors.CredentialsFormatInvalidError, "--aws-operator-role")
+			}
 		}
 		if provider == "azure" {
 			if args.azureClientID == "" {
@@ -244,6 +258,17 @@ func verifyPreconditions(args Arguments) error {
 			if args.awsAdminRole != "" || args.awsOperatorRole != "" {
 				return microerror.Maskf(errors.ConflictingFlagsError, "AWS-related flags not allowed here")
 			}
+
+			// formats
+			if !credentials.IsAzureGUID(args.azureClientID) {
+				return microerror.Maskf(errors.CredentialsFormatInvalidError, "--azure-client-id")
+			}
+			if !credentials.IsAzureGUID(args.azureSubscriptionID) {
+				return microerror.Maskf(errors.CredentialsFormatInvalidError, "--azure-subscription-id")
+			}
+			if !credentials.IsAzureGUID(args.azureTenantID) {
+				return microerror.Maskf(errors.CredentialsFormatInvalidError, "--azure-tenant-id")
+			}
 		}
 	}
 
@@ -294,7 +319,7 @@ func printResult(cmd *cobra.Command, cmdLineArgs []string) {
 		switch {
 		case errors.IsCredentialsAlreadySetError(err):
 			headline = "Credentials already set"
-			subtext = fmt.Sprintf("Organization '%s' has credentials already. These cannot be overwritten.", arguments.organizationID)
+			subtext = fmt.Sprintf("Organization '%s' has credentials already. Use 'gsctl update organization rotate-credentials' to replace them.", arguments.organizationID)
 		default:
 			headline = err.Error()
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/afero"
 
+	"github.com/giantswarm/gsctl/commands/errors"
 	"github.com/giantswarm/gsctl/testutils"
 )
 
@@ -69,8 +70,8 @@ selected_endpoint: ` + mockServer.URL
 		apiEndpoint:     mockServer.URL,
 		authToken:       "some-token",
 		organizationID:  "acme",
-		awsAdminRole:    "test-admin-role",
-		awsOperatorRole: "test-operator-role",
+		awsAdminRole:    "arn:aws:iam::123456789012:role/GiantSwarmAdmin",
+		awsOperatorRole: "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator",
 	}
 
 	err = verifyPreconditions(args)
@@ -87,3 +88,36 @@ selected_endpoint: ` + mockServer.URL
 		t.Errorf("Expected credential ID 'test', got %q", result.credentialID)
 	}
 }
+
+func Test_UpdateOrgSetCredentials_InvalidFormat(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "GET" && r.URL.String() == "/v4/info/" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"general": {"installation_name": "shire", "provider": "azure", "datacenter": "westeurope"}}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	args := Arguments{
+		apiEndpoint:         mockServer.URL,
+		authToken:           "some-token",
+		organizationID:      "acme",
+		azureClientID:       "b5e4f0b4-7d3c-4a7e-9a8b-2c1d0e9f8a7b",
+		azureSecretKey:      "secret",
+		azureSubscriptionID: "my-subscription",
+		azureTenantID:       "b5e4f0b4-7d3c-4a7e-9a8b-2c1d0e9f8a7c",
+	}
+
+	err = verifyPreconditions(args)
+	if !errors.IsCredentialsFormatInvalidError(err) {
+		t.Errorf("Expected CredentialsFormatInvalidError, got %v", err)
+	}
+}
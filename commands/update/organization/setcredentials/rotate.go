@@ -0,0 +1,171 @@
+package setcredentials
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/credentials"
+)
+
+const (
+	rotateActivityName = "rotate-org-credentials"
+)
+
+var (
+	// RotateCommand performs the "update organization rotate-credentials" function
+	RotateCommand = &cobra.Command{
+		Use:     "rotate-credentials",
+		Aliases: []string{"rc"},
+		Short:   "Replace credentials of an organization",
+		Long: `Replace the credentials used to create and operate the clusters of an organization.
+
+The new credentials are validated before submitting them. Existing clusters
+keep using the credentials they were created with.
+
+Replacing credentials is only possible if the installation's API allows it.
+Otherwise the command fails and the existing credentials remain in place.
+`,
+		Example: `
+  gsctl update organization rotate-credentials -o acme \
+    --aws-operator-role arn:aws:iam::<AWS-ACCOUNT-ID>:role/GiantSwarmAWSOperator \
+    --aws-admin-role arn:aws:iam::<AWS-ACCOUNT-ID>:role/GiantSwarmAdmin
+
+  gsctl update organization rotate-credentials -o acme \
+    --azure-subscription-id <AZURE-SUBSCRIPTION-ID> \
+    --azure-tenant-id <AZURE-TENANT-ID> \
+    --azure-client-id <AZURE-CLIENT-ID> \
+    --azure-secret-key <AZURE-SECRET-KEY>
+`,
+
+		// PreRun checks the same things as for setting credentials.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printRotateResult,
+	}
+)
+
+func init() {
+	RotateCommand.Flags().StringVarP(&flags.OrganizationID, "organization", "o", "", "ID of the organization to replace credentials for")
+	RotateCommand.Flags().StringVarP(&cmdAWSOperatorRoleARN, "aws-operator-role", "", "", "AWS ARN of the role to use for operating clusters")
+	RotateCommand.Flags().StringVarP(&cmdAWSAdminRoleARN, "aws-admin-role", "", "", "AWS ARN of the role to be used by Giant Swarm staff")
+	RotateCommand.Flags().StringVarP(&cmdAzureSubscriptionID, "azure-subscription-id", "", "", "ID of the Azure subscription to run clusters in")
+	RotateCommand.Flags().StringVarP(&cmdAzureTenantID, "azure-tenant-id", "", "", "ID of the Azure tenant to run clusters in")
+	RotateCommand.Flags().StringVarP(&cmdAzureClientID, "azure-client-id", "", "", "ID of the Azure service principal to use for operating clusters")
+	RotateCommand.Flags().StringVarP(&cmdAzureSecretKey, "azure-secret-key", "", "", "Secret key for the Azure service principal to use for operating clusters")
+	RotateCommand.Flags().BoolVarP(&flags.Force, "force", "", false, "If set, no interactive confirmation will be required.")
+}
+
+// printRotateResult calls the business function and produces
+// meaningful terminal output.
+func printRotateResult(cmd *cobra.Command, cmdLineArgs []string) {
+	result, err := rotateOrgCredentials(arguments)
+
+	if err != nil {
+		client.HandleErrors(err)
+		errors.HandleCommonErrors(err)
+
+		headline := ""
+		subtext := ""
+
+		switch {
+		case errors.IsCredentialsNotSetError(err):
+			headline = "No credentials set"
+			subtext = fmt.Sprintf("Organization '%s' has no credentials to replace. Use 'gsctl update organization set-credentials' instead.", arguments.organizationID)
+		case errors.IsDesiredEqualsCurrentStateError(err):
+			headline = "Nothing to change"
+			subtext = fmt.Sprintf("Organization '%s' uses these credentials already.", arguments.organizationID)
+		case errors.IsCredentialsRotationNotSupportedError(err):
+			headline = "Credentials cannot be replaced"
+			subtext = "The API of this installation does not allow replacing credentials. The existing credentials remain in place."
+			subtext += "\nPlease contact the Giant Swarm support team to have them replaced."
+		default:
+			headline = err.Error()
+		}
+
+		fmt.Println(color.RedString(headline))
+		if subtext != "" {
+			fmt.Println(subtext)
+		}
+		os.Exit(1)
+	}
+
+	if result == nil {
+		if arguments.verbose {
+			fmt.Println(color.GreenString("Aborted."))
+		}
+		return
+	}
+
+	fmt.Println(color.GreenString("Credentials replaced successfully"))
+	fmt.Printf("The new credentials are stored with the unique ID '%s'.\n", result.credentialID)
+}
+
+// rotateOrgCredentials checks the current credentials of the organization,
+// asks for confirmation and submits the new credentials. A nil result
+// without error means that the user didn't confirm.
+func rotateOrgCredentials(args Arguments) (*setOrgCredentialsResult, error) {
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = rotateActivityName
+
+	if args.verbose {
+		fmt.Println(color.WhiteString("Fetching current credentials"))
+	}
+
+	response, err := clientWrapper.GetCredentials(args.organizationID, auxParams)
+	if err != nil {
+		if clienterror.IsNotFoundError(err) {
+			return nil, microerror.Mask(errors.OrganizationNotFoundError)
+		}
+
+		return nil, microerror.Mask(err)
+	}
+	if len(response.Payload) == 0 {
+		return nil, microerror.Mask(errors.CredentialsNotSetError)
+	}
+
+	current := response.Payload[0]
+	currentDescription := current.ID
+	if current.Aws != nil && current.Aws.Roles != nil {
+		if current.Aws.Roles.Admin == args.awsAdminRole && current.Aws.Roles.Awsoperator == args.awsOperatorRole {
+			return nil, microerror.Mask(errors.DesiredEqualsCurrentStateError)
+		}
+		currentDescription = fmt.Sprintf("%s (AWS account %s)", current.ID, credentials.AWSAccountID(current.Aws.Roles.Awsoperator))
+	} else if current.Azure != nil && current.Azure.Credential != nil {
+		// The secret key is not returned by the API, so we cannot tell
+		// whether it is the same. Submitting identical IDs is fine.
+		currentDescription = fmt.Sprintf("%s (Azure subscription %s)", current.ID, current.Azure.Credential.SubscriptionID)
+	}
+
+	if !args.force {
+		confirmed := confirm.Ask(fmt.Sprintf("Do you really want to replace credentials %s of organization '%s'?", currentDescription, args.organizationID))
+		if !confirmed {
+			return nil, nil
+		}
+	}
+
+	result, err := setOrgCredentials(args)
+	if err != nil {
+		if errors.IsCredentialsAlreadySetError(err) {
+			return nil, microerror.Mask(errors.CredentialsRotationNotSupportedError)
+		}
+
+		return nil, microerror.Mask(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package setcredentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func Test_rotateOrgCredentials(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/acme/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{
+				"id": "old",
+				"provider": "aws",
+				"aws": {"roles": {"admin": "arn:aws:iam::123456789012:role/GiantSwarmAdmin", "awsoperator": "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"}}
+			}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v4/organizations/acme/credentials/":
+			w.Header().Set("Location", "/v4/organizations/acme/credentials/new/")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"code": "RESOURCE_CREATED", "message": "A new set of credentials has been created with ID 'new'"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/locked/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "old", "provider": "aws", "aws": {"roles": {"admin": "a", "awsoperator": "b"}}}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v4/organizations/locked/credentials/":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"code": "RESOURCE_ALREADY_EXISTS", "message": "Credentials already set"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/organizations/empty/credentials/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider = "aws"
+	args := Arguments{
+		apiEndpoint:     mockServer.URL,
+		authToken:       "some-token",
+		awsAdminRole:    "arn:aws:iam::210987654321:role/GiantSwarmAdmin",
+		awsOperatorRole: "arn:aws:iam::210987654321:role/GiantSwarmAWSOperator",
+		force:           true,
+	}
+
+	args.organizationID = "acme"
+	result, err := rotateOrgCredentials(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result.credentialID != "new" {
+		t.Errorf("Expected credential ID 'new', got %q", result.credentialID)
+	}
+
+	args.organizationID = "locked"
+	_, err = rotateOrgCredentials(args)
+	if !errors.IsCredentialsRotationNotSupportedError(err) {
+		t.Errorf("Expected CredentialsRotationNotSupportedError, got %v", err)
+	}
+
+	args.organizationID = "empty"
+	_, err = rotateOrgCredentials(args)
+	if !errors.IsCredentialsNotSetError(err) {
+		t.Errorf("Expected CredentialsNotSetError, got %v", err)
+	}
+
+	args.organizationID = "acme"
+	args.awsAdminRole = "arn:aws:iam::123456789012:role/GiantSwarmAdmin"
+	args.awsOperatorRole = "arn:aws:iam::123456789012:role/GiantSwarmAWSOperator"
+	_, err = rotateOrgCredentials(args)
+	if !errors.IsDesiredEqualsCurrentStateError(err) {
+		t.Errorf("Expected DesiredEqualsCurrentStateError, got %v", err)
+	}
+}
@@ -4,17 +4,19 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/gsctl/commands/upgrade/cluster"
+	"github.com/giantswarm/gsctl/commands/upgrade/self"
 )
 
 var (
 	// Command is the command to upgrade things
 	Command = &cobra.Command{
 		Use:   "upgrade",
-		Short: "Upgrade clusters",
-		Long:  `Lets you upgrade a cluster`,
+		Short: "Upgrade clusters or gsctl itself",
+		Long:  `Lets you upgrade a cluster or the gsctl binary`,
 	}
 )
 
 func init() {
 	Command.AddCommand(cluster.Command)
+	Command.AddCommand(self.Command)
 }
@@ -0,0 +1,287 @@
+// Package self implements the 'upgrade self' command.
+package self
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/buildinfo"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/commands/version"
+	"github.com/giantswarm/gsctl/pkg/selfupdate"
+)
+
+const (
+	// baseURLEnvVar is the environment variable to set a default for --base-url.
+	baseURLEnvVar = "GSCTL_UPDATE_BASE_URL"
+)
+
+var (
+	// Command performs the "upgrade self" function
+	Command = &cobra.Command{
+		Use:   "self",
+		Short: "Upgrade gsctl to the latest or a specific version",
+		Long: `Downloads a gsctl release for your platform and replaces the gsctl binary
+you are running with it.
+
+The checksum of the downloaded archive and the signature of the release's
+checksums file are verified before the binary gets replaced. A copy of the
+previous binary is kept next to it, with the suffix ` + selfupdate.BackupSuffix + `, so you can
+go back using --rollback.
+
+With --skip-signature-verification, only the checksum is verified. As the
+checksums file comes from the same location as the archive, this detects
+broken downloads, but not tampered releases. No authenticity check is left.
+
+Releases are downloaded from
+
+    ` + selfupdate.DefaultBaseURL + `/<version>/
+
+To use a mirror, set --base-url or the ` + baseURLEnvVar + ` environment
+variable. A mirror has to provide the release files with the same names. To
+find out the latest version, <base URL>/latest has to redirect to a URL
+ending in the version number, unless --version is given.
+
+Examples:
+
+  gsctl upgrade self
+
+  gsctl upgrade self --version 1.2.3
+
+  gsctl upgrade self --base-url https://mirror.example.com/gsctl
+
+  gsctl upgrade self --rollback
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	cmdBaseURL       string
+	cmdForce         bool
+	cmdRollback      bool
+	cmdSkipSignature bool
+	cmdVersion       string
+
+	arguments Arguments
+)
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+
+	defaultBaseURL := os.Getenv(baseURLEnvVar)
+	if defaultBaseURL == "" {
+		defaultBaseURL = selfupdate.DefaultBaseURL
+	}
+
+	Command.Flags().StringVarP(&cmdVersion, "version", "", "", "Version to install. Defaults to the latest version.")
+	Command.Flags().StringVarP(&cmdBaseURL, "base-url", "", defaultBaseURL, fmt.Sprintf("URL to download releases from. Can also be set via %s.", baseURLEnvVar))
+	Command.Flags().BoolVarP(&cmdForce, "force", "", false, "Install the version even if it is the one running already")
+	Command.Flags().BoolVarP(&cmdRollback, "rollback", "", false, "Restore the binary replaced by the last upgrade")
+	Command.Flags().BoolVarP(&cmdSkipSignature, "skip-signature-verification", "", false, "Don't verify the signature of the release. This removes all authenticity checks, as the checksums are downloaded from the same location as the release.")
+}
+
+// Arguments specifies all the arguments to be used for our business function.
+type Arguments struct {
+	arch           string
+	baseURL        string
+	currentVersion string
+	executablePath string
+	force          bool
+	os             string
+	publicKey      string
+	rollback       bool
+	skipSignature  bool
+	version        string
+	versionURL     string
+}
+
+// collectArguments fills arguments from user input, config, and environment.
+func collectArguments() (Arguments, error) {
+	executablePath, err := selfupdate.ExecutablePath()
+	if err != nil {
+		return Arguments{}, microerror.Mask(err)
+	}
+
+	baseURL := strings.TrimSuffix(cmdBaseURL, "/")
+
+	// GitHub redirects from this URL to the latest release.
+	versionURL := config.VersionCheckURL
+	if baseURL != selfupdate.DefaultBaseURL {
+		versionURL = baseURL + "/latest"
+	}
+
+	return Arguments{
+		arch:           runtime.GOARCH,
+		baseURL:        baseURL,
+		currentVersion: config.Version,
+		executablePath: executablePath,
+		force:          cmdForce,
+		os:             runtime.GOOS,
+		publicKey:      buildinfo.ReleaseSigningKey,
+		rollback:       cmdRollback,
+		skipSignature:  cmdSkipSignature,
+		version:        strings.TrimPrefix(cmdVersion, "v"),
+		versionURL:     versionURL,
+	}, nil
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.rollback && (args.version != "" || args.force || args.skipSignature) {
+		return microerror.Maskf(errors.ConflictingFlagsError, "--rollback cannot be combined with other flags")
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	var err error
+	arguments, err = collectArguments()
+	if err == nil {
+		err = verifyPreconditions(arguments)
+	}
+
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+}
+
+// result is what upgradeSelf returns.
+type result struct {
+	previousVersion string
+	version         string
+	// upToDate is true if nothing was installed, as the version is running already.
+	upToDate   bool
+	backupPath string
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	if arguments.rollback {
+		err := selfupdate.Rollback(arguments.executablePath)
+		if err != nil {
+			handleError(err)
+			os.Exit(1)
+		}
+
+		fmt.Println(color.GreenString("The previous %s binary has been restored at %s.", config.ProgramName, arguments.executablePath))
+		return
+	}
+
+	r, err := upgradeSelf(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	if r.upToDate {
+		fmt.Println(color.GreenString("%s %s is installed already.", config.ProgramName, r.version))
+		fmt.Println("Use --force to install it again.")
+		return
+	}
+
+	fmt.Println(color.GreenString("%s has been upgraded to version %s.", config.ProgramName, r.version))
+	fmt.Printf("The previous binary has been saved as %s.\n", r.backupPath)
+	fmt.Printf("Use '%s upgrade self --rollback' to restore it.\n", config.ProgramName)
+}
+
+// upgradeSelf determines the version to install, downloads and verifies it,
+// and replaces the binary.
+func upgradeSelf(args Arguments) (result, error) {
+	r := result{
+		previousVersion: args.currentVersion,
+		version:         args.version,
+	}
+
+	if r.version == "" {
+		latest, _, err := version.CheckUpdate(args.versionURL, args.currentVersion)
+		if err != nil {
+			return r, microerror.Mask(err)
+		}
+		r.version = strings.TrimPrefix(latest, "v")
+	}
+
+	if r.version == args.currentVersion && !args.force {
+		r.upToDate = true
+		return r, nil
+	}
+
+	updater, err := selfupdate.New(selfupdate.Config{
+		BaseURL:       args.baseURL,
+		OS:            args.os,
+		Arch:          args.arch,
+		PublicKey:     args.publicKey,
+		SkipSignature: args.skipSignature,
+	})
+	if err != nil {
+		return r, microerror.Mask(err)
+	}
+
+	binary, err := updater.Download(r.version)
+	if err != nil {
+		return r, microerror.Mask(err)
+	}
+
+	err = selfupdate.Replace(args.executablePath, binary)
+	if err != nil {
+		return r, microerror.Mask(err)
+	}
+
+	r.backupPath = args.executablePath + selfupdate.BackupSuffix
+
+	return r, nil
+}
+
+func handleError(err error) {
+	errors.HandleCommonErrors(err)
+
+	var headline = ""
+	var subtext = ""
+
+	switch {
+	case errors.IsConflictingFlagsError(err):
+		headline = "Conflicting flags used"
+		subtext = "--rollback cannot be combined with other flags."
+	case errors.IsUpdateCheckFailed(err):
+		headline = "Could not find out the latest version"
+		subtext = "Please specify the version to install using --version."
+	case selfupdate.IsDownloadFailed(err):
+		headline = "Download failed"
+		subtext = fmt.Sprintf("Details: %s\nPlease check that the version exists for your platform (%s/%s).", err.Error(), runtime.GOOS, runtime.GOARCH)
+	case selfupdate.IsChecksumMismatch(err):
+		headline = "Checksum verification failed"
+		subtext = fmt.Sprintf("Details: %s\nThe binary has not been replaced.", err.Error())
+	case selfupdate.IsInvalidSignature(err):
+		headline = "Signature verification failed"
+		subtext = "The release checksums are not signed by Giant Swarm. The binary has not been replaced."
+	case selfupdate.IsNoSigningKey(err):
+		headline = "Cannot verify the release signature"
+		subtext = "This gsctl build has no release signing key. Please download the release manually and verify it before installing it."
+	case selfupdate.IsBinaryNotFound(err):
+		headline = "Invalid release archive"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case selfupdate.IsNoBackup(err):
+		headline = "Nothing to roll back"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case os.IsPermission(microerror.Cause(err)):
+		headline = "Permission denied"
+		subtext = fmt.Sprintf("Details: %s\nYou might have to run the command with elevated permissions.", err.Error())
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
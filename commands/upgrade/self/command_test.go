@@ -0,0 +1,117 @@
+package self
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp executes the command with --help.
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+// Test_upgradeSelf tests upgrading to the latest and a pinned version
+// from a local mirror.
+func Test_upgradeSelf(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := testutils.NewFakeRelease("1.2.3", []string{"linux-amd64"}, []byte("gsctl 1.2.3"), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release.Close()
+
+	var testCases = []struct {
+		currentVersion string
+		version        string
+		force          bool
+		expectUpToDate bool
+	}{
+		{currentVersion: "1.0.0"},
+		{currentVersion: "1.0.0", version: "1.2.3"},
+		{currentVersion: "1.2.3", expectUpToDate: true},
+		{currentVersion: "1.2.3", force: true},
+	}
+
+	for i, tc := range testCases {
+		dir, err := ioutil.TempDir("", "upgrade-self")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		executablePath := path.Join(dir, "gsctl")
+		err = ioutil.WriteFile(executablePath, []byte("gsctl "+tc.currentVersion), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		args := Arguments{
+			arch:           "amd64",
+			baseURL:        release.URL,
+			currentVersion: tc.currentVersion,
+			executablePath: executablePath,
+			force:          tc.force,
+			os:             "linux",
+			publicKey:      base64.StdEncoding.EncodeToString(publicKey),
+			version:        tc.version,
+			versionURL:     release.URL + "/latest",
+		}
+
+		err = verifyPreconditions(args)
+		if err != nil {
+			t.Fatalf("Case %d - unexpected error %s", i, err)
+		}
+
+		r, err := upgradeSelf(args)
+		if err != nil {
+			t.Fatalf("Case %d - unexpected error %s", i, err)
+		}
+
+		if r.version != "1.2.3" || r.upToDate != tc.expectUpToDate {
+			t.Errorf("Case %d - unexpected result %#v", i, r)
+		}
+
+		content, _ := ioutil.ReadFile(executablePath)
+		if tc.expectUpToDate {
+			if string(content) != "gsctl "+tc.currentVersion {
+				t.Errorf("Case %d - binary should not have been replaced", i)
+			}
+			continue
+		}
+
+		if string(content) != "gsctl 1.2.3" {
+			t.Errorf("Case %d - unexpected binary %q", i, content)
+		}
+		content, _ = ioutil.ReadFile(r.backupPath)
+		if string(content) != "gsctl "+tc.currentVersion {
+			t.Errorf("Case %d - unexpected backup %q", i, content)
+		}
+	}
+}
+
+func Test_verifyPreconditions(t *testing.T) {
+	err := verifyPreconditions(Arguments{rollback: true, version: "1.2.3"})
+	if !errors.IsConflictingFlagsError(err) {
+		t.Errorf("Expected conflicting flags error, got %v", err)
+	}
+
+	err = verifyPreconditions(Arguments{rollback: true})
+	if err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+}
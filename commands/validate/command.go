@@ -0,0 +1,280 @@
+// Package validate implements the 'validate' command.
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
+)
+
+const (
+	validateActivityName = "validate"
+
+	standardInputSpecialPath = "-"
+)
+
+var (
+	// Command is the cobra command for 'gsctl validate'
+	Command = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a cluster definition file",
+		Long: `Checks a cluster definition YAML file as used with 'gsctl create cluster --file'.
+
+The file is decoded strictly, so that unknown keys (e. g. typos) and values of
+the wrong type are reported with their line and column. Keys which are only
+valid in v4 or only in v5 definitions are detected as well.
+
+In addition, the definition is checked against the installation: availability
+zones, scaling limits, instance types or VM sizes and the release version.
+Use --offline to skip these checks, e. g. when not logged in.
+
+Instance types and VM sizes unknown to gsctl are reported as warnings. Only
+errors make the command fail.
+
+A file can contain several cluster definitions, either as YAML documents
+separated by '---' or as a list. Problems are then reported with the number
+of the definition, e. g. 'clusters.yaml[2]:4:3: error: ...'.
+
+The same validation is performed by 'gsctl create cluster' before creating
+a cluster.
+
+Examples:
+
+  gsctl validate -f cluster.yaml
+
+  gsctl validate -f cluster.yaml --offline
+
+  gsctl create cluster --template dev --set owner=acme --render-only | gsctl validate -f -
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for validating a definition.
+type Arguments struct {
+	APIEndpoint       string
+	AuthToken         string
+	FileSystem        afero.Fs
+	InputYAMLFile     string
+	Offline           bool
+	UserProvidedToken string
+	Verbose           bool
+}
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().StringVarP(&flags.InputYAMLFile, "file", "f", "", "Path to the cluster definition YAML file. Use '-' to read from STDIN.")
+	Command.Flags().BoolVarP(&flags.Offline, "offline", "", false, "If set, the definition is not checked against the installation.")
+}
+
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+
+	return Arguments{
+		APIEndpoint:       endpoint,
+		AuthToken:         token,
+		FileSystem:        config.FileSystem,
+		InputYAMLFile:     flags.InputYAMLFile,
+		Offline:           flags.Offline,
+		UserProvidedToken: flags.Token,
+		Verbose:           flags.Verbose,
+	}
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.InputYAMLFile == "" {
+		return microerror.Maskf(errors.RequiredFlagMissingError, "--file")
+	}
+	if args.Offline {
+		return nil
+	}
+	if args.APIEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.AuthToken == "" && args.UserProvidedToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+
+	return nil
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	results, err := validate(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fileName := arguments.InputYAMLFile
+	if fileName == standardInputSpecialPath {
+		fileName = "<stdin>"
+	}
+
+	numErrors := 0
+	numWarnings := 0
+	for i, result := range results {
+		prefix := fileName
+		if len(results) > 1 {
+			prefix = fmt.Sprintf("%s[%d]", fileName, i+1)
+		}
+
+		for _, p := range result.Problems {
+			line := fmt.Sprintf("%s:%s", prefix, p.String())
+			if p.Severity == clusterdefinition.SeverityError {
+				fmt.Println(color.RedString(line))
+			} else {
+				fmt.Println(color.YellowString(line))
+			}
+		}
+
+		numErrors += len(result.Errors())
+		numWarnings += len(result.Warnings())
+	}
+
+	if numErrors > 0 {
+		fmt.Println(color.RedString("\nFound %d error(s) and %d warning(s).", numErrors, numWarnings))
+		os.Exit(1)
+	}
+
+	if numWarnings > 0 {
+		fmt.Println("")
+	}
+	subject := "The cluster definition is"
+	if len(results) > 1 {
+		subject = fmt.Sprintf("All %d cluster definitions are", len(results))
+	}
+	if arguments.Offline {
+		fmt.Println(color.GreenString("%s valid. Checks against the installation have been skipped.", subject))
+	} else {
+		fmt.Println(color.GreenString("%s valid.", subject))
+	}
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsRequiredFlagMissingError(err):
+		headline = "No definition file given"
+		subtext = "Please specify the cluster definition file via --file. See --help for details."
+	case errors.IsYAMLFileNotReadable(err):
+		headline = "Could not read the definition"
+		subtext = fmt.Sprintf("Details: %s", err.Error())
+	case clusterdefinition.IsInvalidDefinition(err):
+		headline = "Invalid list of cluster definitions"
+		subtext = strings.TrimPrefix(err.Error(), "invalid definition error: ")
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// validate reads the definitions and validates them, against the
+// installation if not offline. There is one result per definition.
+func validate(args Arguments) ([]*clusterdefinition.Result, error) {
+	var data []byte
+	var err error
+
+	if args.InputYAMLFile == standardInputSpecialPath {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = afero.ReadFile(args.FileSystem, args.InputYAMLFile)
+	}
+	if err != nil {
+		return nil, microerror.Maskf(errors.YAMLFileNotReadableError, err.Error())
+	}
+
+	documents, err := clusterdefinition.Split(data)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if len(documents) == 0 {
+		// Let linting report the empty definition.
+		documents = []clusterdefinition.Document{{Line: 1, Data: data}}
+	}
+
+	results := []*clusterdefinition.Result{}
+	decoded := false
+	for _, d := range documents {
+		result := clusterdefinition.Lint(d.Data)
+		results = append(results, result)
+		decoded = decoded || result.Definition != nil
+	}
+
+	if args.Offline || !decoded {
+		return results, nil
+	}
+
+	clientWrapper, err := client.NewWithConfig(args.APIEndpoint, args.UserProvidedToken)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = validateActivityName
+
+	if args.Verbose {
+		fmt.Println(color.WhiteString("Fetching installation information"))
+	}
+
+	info, err := clientWrapper.GetInfo(auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	releases, err := clientWrapper.GetReleases(auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	installation := clusterdefinition.NewInstallation(info.Payload, releases.Payload)
+	for _, result := range results {
+		result.Check(installation)
+	}
+
+	return results, nil
+}
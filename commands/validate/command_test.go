@@ -0,0 +1,151 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/clusterdefinition"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+const definition = `api_version: v5
+owner: acme
+release_version: 11.0.0
+master_nodes:
+  availability_zones: [eu-central-1z]
+nodepools:
+- name: General purpose
+  node_spec:
+    aws:
+      instance_type: m5.xlarge
+`
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_verifyPreconditions tests the flag combinations.
+func Test_verifyPreconditions(t *testing.T) {
+	testCases := []struct {
+		args         Arguments
+		errorMatcher func(error) bool
+	}{
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token", InputYAMLFile: "cluster.yaml"},
+			errorMatcher: nil,
+		},
+		{
+			args:         Arguments{InputYAMLFile: "cluster.yaml", Offline: true},
+			errorMatcher: nil,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", AuthToken: "token"},
+			errorMatcher: errors.IsRequiredFlagMissingError,
+		},
+		{
+			args:         Arguments{InputYAMLFile: "cluster.yaml"},
+			errorMatcher: errors.IsEndpointMissingError,
+		},
+		{
+			args:         Arguments{APIEndpoint: "https://foo", InputYAMLFile: "cluster.yaml"},
+			errorMatcher: errors.IsNotLoggedInError,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := verifyPreconditions(tc.args)
+		if tc.errorMatcher == nil && err != nil {
+			t.Errorf("Case %d - Unexpected error: %s", i, err)
+		} else if tc.errorMatcher != nil && !tc.errorMatcher(err) {
+			t.Errorf("Case %d - Error not matching expectation, got %v", i, err)
+		}
+	}
+}
+
+// Test_validate tests validation against the installation and offline.
+func Test_validate(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v4/info/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"general": {"provider": "aws", "availability_zones": {"max": 3, "default": 1, "zones": ["eu-central-1a", "eu-central-1b"]}},
+				"workers": {"count_per_cluster": {"max": 20, "default": 3}, "instance_type": {"options": ["m5.xlarge"], "default": "m5.xlarge"}}
+			}`))
+		case "/v4/releases/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"version": "11.0.0", "timestamp": "2020-01-01T12:00:00.000Z", "active": true, "changelog": [], "components": []}]`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = afero.WriteFile(fs, "/cluster.yaml", []byte(definition), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := Arguments{APIEndpoint: mockServer.URL, AuthToken: "token", FileSystem: fs, InputYAMLFile: "/cluster.yaml"}
+
+	results, err := validate(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected one result, got %d", len(results))
+	}
+	result := results[0]
+	if len(result.Errors()) != 1 {
+		t.Fatalf("Expected one error, got %v", result.Problems)
+	}
+	if p := result.Errors()[0]; p.Severity != clusterdefinition.SeverityError || p.Path != "master_nodes.availability_zones" {
+		t.Errorf("Unexpected problem %s", p.String())
+	}
+
+	args.Offline = true
+	results, err = validate(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(results[0].Problems) != 0 {
+		t.Errorf("Expected no problems offline, got %v", results[0].Problems)
+	}
+
+	err = afero.WriteFile(fs, "/clusters.yaml", []byte("- owner: acme\n  name: Dev\n- owner: acme\n  nmae: Prod\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args.InputYAMLFile = "/clusters.yaml"
+	results, err = validate(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(results) != 2 || len(results[0].Problems) != 0 || len(results[1].Errors()) != 1 {
+		t.Errorf("Expected an error in the second definition only, got %v", results)
+	} else if results[1].Errors()[0].Line != 4 {
+		t.Errorf("Expected the error in line 4, got %s", results[1].Errors()[0].String())
+	}
+
+	args.InputYAMLFile = "/missing.yaml"
+	_, err = validate(args)
+	if !errors.IsYAMLFileNotReadable(err) {
+		t.Errorf("Expected YAMLFileNotReadableError, got %v", err)
+	}
+}
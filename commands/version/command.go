@@ -14,6 +14,7 @@ import (
 
 	"github.com/giantswarm/gsctl/buildinfo"
 	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
 	"github.com/giantswarm/gsctl/util"
 )
 
@@ -75,8 +76,10 @@ func printVersion(cmd *cobra.Command, args []string) {
 		// we are ignoring any errors from failed versionchecks
 		// as we don't want to get into the way. And we only print this for
 		// a properly built gsctl binary.
-		config.Config.LastVersionCheck = time.Now()
-		config.WriteToFile()
+		ssotoken.UpdateConfig(func() error {
+			config.Config.LastVersionCheck = time.Now()
+			return nil
+		})
 		if info.updateAvailable {
 			fmt.Println()
 			fmt.Println(formatUpdateInfo(info))
@@ -148,6 +151,26 @@ func checkUpdateAvailable(url string) (updateAvailabilityInfo, error) {
 	return info, nil
 }
 
+// CheckUpdate returns the latest version available at the given URL and
+// whether it is newer than the given current version. An empty current
+// version, as in development builds, is older than any release.
+func CheckUpdate(url, current string) (string, bool, error) {
+	latest, err := latestVersion(url)
+	if err != nil {
+		return "", false, microerror.Mask(err)
+	}
+
+	if current == "" {
+		current = "0.0.0"
+	}
+	comp, err := util.CompareVersions(latest, strings.Replace(current, "+git", "", 1))
+	if err != nil {
+		return "", false, microerror.Mask(err)
+	}
+
+	return latest, comp > 0, nil
+}
+
 // timeSinceLastVersionCheck returns the time sine the last update check
 func timeSinceLastVersionCheck() time.Duration {
 	return time.Since(config.Config.LastVersionCheck)
@@ -0,0 +1,360 @@
+// Package cluster implements the 'wake cluster' sub-command.
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/hibernation"
+	"github.com/giantswarm/gsctl/picker"
+	"github.com/giantswarm/gsctl/pkg/labels"
+)
+
+const (
+	wakeClusterActivityName = "wake-cluster"
+)
+
+var (
+	// Command is the cobra command for 'gsctl wake cluster'
+	Command = &cobra.Command{
+		Use:   "cluster <cluster-name-or-id>",
+		Short: "Restore the node pool scaling of a hibernated cluster",
+		Long: `Restores the node pool scaling of one or several clusters hibernated
+using 'gsctl hibernate cluster'.
+
+Each node pool gets the exact minimum and maximum scaling recorded when the
+cluster was hibernated. Node pools deleted in the meantime are skipped. Once
+all node pools are restored, the record is removed.
+
+Examples:
+
+  gsctl wake cluster "Development cluster"
+
+  gsctl wake cluster f01r4 --dry-run
+
+  gsctl wake cluster --selector environment=dev --force
+`,
+
+		// PreRun checks a few general things, like authentication.
+		PreRun: printValidation,
+
+		// Run calls the business function and prints results and errors.
+		Run: printResult,
+	}
+
+	arguments Arguments
+)
+
+// Arguments contains all possible input parameter needed
+// (and optionally available) for waking clusters.
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	clusterNameOrID   string
+	dryRun            bool
+	force             bool
+	scheme            string
+	selector          string
+	userProvidedToken string
+	verbose           bool
+}
+
+// plan is what we are going to do with one cluster.
+type plan struct {
+	target hibernation.Target
+	// nodePools is the scaling recorded for each node pool.
+	nodePools []hibernation.NodePoolScaling
+	// current is the current scaling of the node pools, by ID.
+	current map[string]hibernation.NodePoolScaling
+	// skipReason is set if the cluster is not going to be woken up.
+	skipReason string
+}
+
+func init() {
+	Command.Flags().StringVarP(&flags.Selector, "selector", "l", "", "Label selector query to select the clusters to wake, instead of a single cluster.")
+	Command.Flags().BoolVarP(&flags.DryRun, "dry-run", "", false, "If set, only print what would be done, without changing anything.")
+	Command.Flags().BoolVarP(&flags.Force, "force", "", false, "If set, no interactive confirmation will be required.")
+}
+
+func collectArguments(positionalArgs []string) Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	clusterNameOrID := ""
+	if len(positionalArgs) > 0 {
+		clusterNameOrID = positionalArgs[0]
+	}
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		clusterNameOrID:   clusterNameOrID,
+		dryRun:            flags.DryRun,
+		force:             flags.Force,
+		scheme:            scheme,
+		selector:          flags.Selector,
+		userProvidedToken: flags.Token,
+		verbose:           flags.Verbose,
+	}
+}
+
+func printValidation(cmd *cobra.Command, positionalArgs []string) {
+	arguments = collectArguments(positionalArgs)
+	err := verifyPreconditions(arguments)
+
+	if errors.IsClusterNameOrIDMissingError(err) {
+		if clusterID := picker.MissingCluster(arguments.apiEndpoint, arguments.userProvidedToken); clusterID != "" {
+			arguments.clusterNameOrID = clusterID
+			err = verifyPreconditions(arguments)
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsClusterNameOrIDMissingError(err):
+		headline = "No cluster name or ID specified"
+		subtext = "Please specify the cluster as a positional argument, or use --selector. See --help for details."
+	case errors.IsConflictingFlagsError(err):
+		headline = "Conflicting flags/arguments"
+		subtext = "Please specify either a cluster as a positional argument, or a --selector, but not both."
+	case labels.IsInvalidSelector(err):
+		headline = "Invalid label selector"
+		subtext = err.Error()
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+	os.Exit(1)
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if config.Config.Token == "" && args.authToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+	if args.clusterNameOrID != "" && args.selector != "" {
+		return microerror.Mask(errors.ConflictingFlagsError)
+	}
+	if args.clusterNameOrID == "" && args.selector == "" {
+		return microerror.Mask(errors.ClusterNameOrIDMissingError)
+	}
+	if args.selector != "" {
+		_, err := labels.ParseSelector(args.selector)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func printResult(cmd *cobra.Command, positionalArgs []string) {
+	clientWrapper, err := client.NewWithConfig(arguments.apiEndpoint, arguments.userProvidedToken)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = wakeClusterActivityName
+
+	plans, err := getPlans(arguments, clientWrapper, auxParams)
+	if err != nil {
+		handleError(microerror.Mask(err))
+		os.Exit(1)
+	}
+
+	fmt.Println(formatPlans(plans))
+
+	if countActionable(plans) == 0 {
+		fmt.Println(color.YellowString("Nothing to wake up."))
+		return
+	}
+
+	if arguments.dryRun {
+		fmt.Println(color.YellowString("Dry run, no changes made."))
+		return
+	}
+
+	if !arguments.force {
+		confirmed := confirm.Ask(fmt.Sprintf("Do you want to restore the node pool scaling listed above, in %d cluster(s)?", countActionable(plans)))
+		if !confirmed {
+			if arguments.verbose {
+				fmt.Println(color.GreenString("Aborted."))
+			}
+			return
+		}
+	}
+
+	failed := false
+	for _, p := range plans {
+		if p.skipReason != "" {
+			continue
+		}
+
+		_, err = hibernation.Wake(arguments.apiEndpoint, p.target.ID, clientWrapper, auxParams)
+		if err != nil {
+			failed = true
+			fmt.Println(color.RedString("Cluster '%s' could not be woken up completely: %s", p.target.ID, err.Error()))
+			continue
+		}
+
+		fmt.Println(color.GreenString("Cluster '%s' is waking up.", p.target.ID))
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	headline := ""
+	subtext := ""
+
+	switch {
+	case errors.IsClusterNotFoundError(err):
+		headline = "Cluster not found"
+		subtext = "Please check the cluster name or ID. Use 'gsctl list clusters' to list all clusters."
+	case errors.IsClusterDoesNotSupportNodePools(err):
+		headline = "This cluster does not support node pools"
+		subtext = "Only clusters with node pools can be hibernated and woken up."
+	case hibernation.IsNoMatchingClusters(err):
+		headline = "No clusters match the selector"
+		subtext = "Use 'gsctl list clusters --selector' to check which clusters a selector matches."
+	default:
+		headline = err.Error()
+	}
+
+	fmt.Println(color.RedString(headline))
+	if subtext != "" {
+		fmt.Println(subtext)
+	}
+}
+
+// getPlans determines the clusters to wake up and the scaling to restore.
+func getPlans(args Arguments, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]plan, error) {
+	targets, err := hibernation.FindTargets(args.apiEndpoint, args.clusterNameOrID, args.selector, clientWrapper, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	plans := []plan{}
+	for _, target := range targets {
+		p := plan{target: target, current: map[string]hibernation.NodePoolScaling{}}
+
+		state, ok := hibernation.Get(args.apiEndpoint, target.ID)
+		if !ok {
+			p.skipReason = "not hibernated"
+			plans = append(plans, p)
+			continue
+		}
+		p.nodePools = state.NodePools
+
+		if args.verbose {
+			fmt.Println(color.WhiteString("Fetching node pools of cluster '%s'", target.ID))
+		}
+
+		current, err := hibernation.CurrentScaling(target.ID, clientWrapper, auxParams)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		for _, np := range current {
+			p.current[np.ID] = np
+		}
+
+		plans = append(plans, p)
+	}
+
+	return plans, nil
+}
+
+// countActionable returns the number of clusters which are not skipped.
+func countActionable(plans []plan) int {
+	count := 0
+	for _, p := range plans {
+		if p.skipReason == "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+// formatPlans renders the plans as a table, showing the scaling
+// each node pool currently has and will have after waking up.
+func formatPlans(plans []plan) string {
+	rows := []string{strings.Join([]string{
+		color.CyanString("CLUSTER ID"),
+		color.CyanString("CLUSTER NAME"),
+		color.CyanString("NODE POOL ID"),
+		color.CyanString("NODE POOL NAME"),
+		color.CyanString("CURRENT MIN/MAX"),
+		color.CyanString("NEW MIN/MAX"),
+	}, "|")}
+
+	for _, p := range plans {
+		if p.skipReason != "" {
+			rows = append(rows, strings.Join([]string{
+				p.target.ID,
+				p.target.Name,
+				color.YellowString("skipped: " + p.skipReason),
+			}, "|"))
+			continue
+		}
+
+		for _, np := range p.nodePools {
+			current, ok := p.current[np.ID]
+			if !ok {
+				rows = append(rows, strings.Join([]string{
+					p.target.ID,
+					p.target.Name,
+					np.ID,
+					np.Name,
+					color.YellowString("skipped: node pool deleted"),
+				}, "|"))
+				continue
+			}
+
+			rows = append(rows, strings.Join([]string{
+				p.target.ID,
+				p.target.Name,
+				np.ID,
+				np.Name,
+				fmt.Sprintf("%d/%d", current.Min, current.Max),
+				fmt.Sprintf("%d/%d", np.Min, np.Max),
+			}, "|"))
+		}
+	}
+
+	return columnize.SimpleFormat(rows)
+}
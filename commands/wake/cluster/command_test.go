@@ -0,0 +1,88 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/hibernation"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp tests the help output.
+func TestCommandExecutionHelp(t *testing.T) {
+	Command.SetArgs([]string{"--help"})
+	err := Command.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_getPlans tests planning to wake up a cluster.
+func Test_getPlans(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v5/clusters/by_label/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[
+				{"id": "f01r4", "name": "Dev 1", "path": "/v5/clusters/f01r4/"},
+				{"id": "g02s5", "name": "Dev 2", "path": "/v5/clusters/g02s5/"}
+			]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/f01r4/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "a1b", "name": "Default", "scaling": {"min": 0, "max": 0}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = hibernation.Store(mockServer.URL, "f01r4", []hibernation.NodePoolScaling{
+		{ID: "a1b", Name: "Default", Min: 3, Max: 10},
+		{ID: "b2c", Name: "GPU", Min: 0, Max: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plans, err := getPlans(Arguments{apiEndpoint: mockServer.URL, selector: "env=dev"}, clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(plans) != 2 {
+		t.Fatalf("Expected 2 plans, got %d", len(plans))
+	}
+	if plans[1].skipReason != "not hibernated" {
+		t.Errorf("Unexpected skip reason for %s: %q", plans[1].target.ID, plans[1].skipReason)
+	}
+	if countActionable(plans) != 1 {
+		t.Errorf("Expected 1 actionable plan, got %d", countActionable(plans))
+	}
+
+	output := formatPlans(plans)
+	if !strings.Contains(output, "0/0") || !strings.Contains(output, "3/10") {
+		t.Errorf("Output does not show the scaling change:\n%s", output)
+	}
+	if !strings.Contains(output, "node pool deleted") {
+		t.Errorf("Output does not mark the deleted node pool:\n%s", output)
+	}
+}
@@ -0,0 +1,20 @@
+package wake
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/gsctl/commands/wake/cluster"
+)
+
+var (
+	// Command is the command to wake things up
+	Command = &cobra.Command{
+		Use:   "wake",
+		Short: "Wake up hibernated clusters",
+		Long:  `Lets you restore the node pool scaling of clusters hibernated using 'gsctl hibernate'.`,
+	}
+)
+
+func init() {
+	Command.AddCommand(cluster.Command)
+}
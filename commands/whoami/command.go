@@ -0,0 +1,269 @@
+// Package whoami implements the 'whoami' command.
+package whoami
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/flags"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
+)
+
+const (
+	whoamiActivityName = "whoami"
+)
+
+var (
+	// Command is the "whoami" go command
+	Command = &cobra.Command{
+		Use:   "whoami",
+		Short: "Show who you are logged in as",
+		Long: `Shows the identity you are authenticated with at the API endpoint,
+the organizations you can access and whether you have admin permissions.
+
+For SSO logins, the subject, email and groups are decoded from the token.
+
+Examples:
+
+  gsctl whoami
+
+  gsctl whoami --output json
+`,
+		PreRun: printValidation,
+		Run:    printResult,
+	}
+
+	arguments Arguments
+)
+
+func init() {
+	initFlags()
+}
+
+func initFlags() {
+	Command.ResetFlags()
+	Command.Flags().StringVarP(&flags.OutputFormat, "output", "o", formatting.OutputFormatTable, fmt.Sprintf("Use '%s' or '%s' for machine-readable output. Defaults to human-friendly table output.", formatting.OutputFormatJSON, formatting.OutputFormatYAML))
+}
+
+// Arguments represents the arguments we can make use of in this command
+type Arguments struct {
+	apiEndpoint       string
+	authToken         string
+	outputFormat      string
+	scheme            string
+	userProvidedToken string
+}
+
+// collectArguments returns an Arguments object populated by the user's
+// command line arguments and/or config.
+func collectArguments() Arguments {
+	endpoint := config.Config.ChooseEndpoint(flags.APIEndpoint)
+	token := config.Config.ChooseToken(endpoint, flags.Token)
+	scheme := config.Config.ChooseScheme(endpoint, flags.Token)
+
+	return Arguments{
+		apiEndpoint:       endpoint,
+		authToken:         token,
+		outputFormat:      flags.OutputFormat,
+		scheme:            scheme,
+		userProvidedToken: flags.Token,
+	}
+}
+
+// Output is the structure we render for machine-readable output.
+type Output struct {
+	APIEndpoint      string   `json:"api_endpoint"`
+	APIEndpointAlias string   `json:"api_endpoint_alias,omitempty"`
+	Email            string   `json:"email,omitempty"`
+	Subject          string   `json:"subject,omitempty"`
+	Groups           []string `json:"groups,omitempty"`
+	AuthScheme       string   `json:"auth_scheme"`
+	TokenExpiry      string   `json:"token_expiry,omitempty"`
+	Organizations    []string `json:"organizations"`
+	Admin            bool     `json:"admin"`
+}
+
+func verifyPreconditions(args Arguments) error {
+	if args.apiEndpoint == "" {
+		return microerror.Mask(errors.EndpointMissingError)
+	}
+	if args.authToken == "" && args.userProvidedToken == "" {
+		return microerror.Mask(errors.NotLoggedInError)
+	}
+
+	switch args.outputFormat {
+	case formatting.OutputFormatTable, formatting.OutputFormatJSON, formatting.OutputFormatYAML:
+		return nil
+	}
+
+	return microerror.Maskf(errors.OutputFormatInvalidError, fmt.Sprintf("Output format '%s' is unknown", args.outputFormat))
+}
+
+func printValidation(cmd *cobra.Command, cmdLineArgs []string) {
+	arguments = collectArguments()
+	err := verifyPreconditions(arguments)
+	if err == nil {
+		return
+	}
+
+	handleError(err)
+	os.Exit(1)
+}
+
+func printResult(cmd *cobra.Command, cmdLineArgs []string) {
+	result, err := whoami(arguments)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	output, err := render(result, arguments.outputFormat)
+	if err != nil {
+		handleError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(output)
+}
+
+func handleError(err error) {
+	client.HandleErrors(err)
+	errors.HandleCommonErrors(err)
+
+	fmt.Println(color.RedString(err.Error()))
+}
+
+// whoami collects the identity and permissions of the user.
+func whoami(args Arguments) (Output, error) {
+	out := Output{
+		APIEndpoint:   args.apiEndpoint,
+		AuthScheme:    args.scheme,
+		Organizations: []string{},
+	}
+
+	if ec := config.Config.EndpointConfig(args.apiEndpoint); ec != nil {
+		out.APIEndpointAlias = ec.Alias
+		if args.userProvidedToken == "" {
+			out.Email = ec.Email
+		}
+	}
+
+	clientWrapper, err := client.NewWithConfig(args.apiEndpoint, args.userProvidedToken)
+	if err != nil {
+		return out, microerror.Mask(err)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = whoamiActivityName
+
+	orgsResponse, err := clientWrapper.GetOrganizations(auxParams)
+	if err != nil {
+		return out, microerror.Mask(err)
+	}
+	for _, org := range orgsResponse.Payload {
+		out.Organizations = append(out.Organizations, org.ID)
+	}
+	sort.Strings(out.Organizations)
+
+	// Listing all users is only permitted for admins. The API responds with
+	// 401 to other users. As listing organizations has succeeded, this is no
+	// sign of invalid credentials here.
+	_, err = clientWrapper.GetUsers(auxParams)
+	if clienterror.IsUnauthorizedError(err) || clienterror.IsAccessForbiddenError(err) {
+		out.Admin = false
+	} else if err != nil {
+		return out, microerror.Mask(err)
+	} else {
+		out.Admin = true
+	}
+
+	// The API calls above might have refreshed the SSO token,
+	// so we decode the one currently in use.
+	if args.scheme == ssotoken.Scheme {
+		token := config.Config.ChooseToken(args.apiEndpoint, args.userProvidedToken)
+		claims, _ := ssotoken.ParseClaims(token)
+		out.Subject = claims.Subject
+		out.Groups = claims.Groups
+		if claims.Email != "" {
+			out.Email = claims.Email
+		}
+		if !claims.Expiry.IsZero() {
+			out.TokenExpiry = claims.Expiry.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return out, nil
+}
+
+// render returns the output in the given format.
+func render(out Output, outputFormat string) (string, error) {
+	switch outputFormat {
+	case formatting.OutputFormatJSON:
+		data, err := json.MarshalIndent(out, formatting.OutputJSONPrefix, formatting.OutputJSONIndent)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		return string(data), nil
+	case formatting.OutputFormatYAML:
+		// Going through JSON first lets YAML output use the same keys as JSON.
+		data, err := json.Marshal(out)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		var generic interface{}
+		err = yaml.Unmarshal(data, &generic)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		data, err = yaml.Marshal(generic)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		return strings.TrimSuffix(string(data), "\n"), nil
+	}
+
+	table := []string{}
+	table = append(table, color.YellowString("API endpoint:")+"|"+color.CyanString(out.APIEndpoint))
+	if out.APIEndpointAlias != "" {
+		table = append(table, color.YellowString("API endpoint alias:")+"|"+color.CyanString(out.APIEndpointAlias))
+	}
+	table = append(table, color.YellowString("Email:")+"|"+valueOrNA(out.Email))
+	if out.AuthScheme == ssotoken.Scheme {
+		table = append(table, color.YellowString("Subject:")+"|"+valueOrNA(out.Subject))
+		table = append(table, color.YellowString("Groups:")+"|"+valueOrNA(strings.Join(out.Groups, ", ")))
+	}
+	table = append(table, color.YellowString("Auth scheme:")+"|"+color.CyanString(out.AuthScheme))
+	if out.AuthScheme == ssotoken.Scheme {
+		table = append(table, color.YellowString("Token expiry:")+"|"+valueOrNA(out.TokenExpiry))
+	}
+	table = append(table, color.YellowString("Organizations:")+"|"+valueOrNA(strings.Join(out.Organizations, ", ")))
+	if out.Admin {
+		table = append(table, color.YellowString("Admin:")+"|"+color.CyanString("yes"))
+	} else {
+		table = append(table, color.YellowString("Admin:")+"|"+color.CyanString("no"))
+	}
+
+	return columnize.SimpleFormat(table), nil
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return "n/a"
+	}
+	return color.CyanString(value)
+}
@@ -0,0 +1,171 @@
+package whoami
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/formatting"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestCommandExecutionHelp executes the command with --help.
+func TestCommandExecutionHelp(t *testing.T) {
+	testutils.CaptureOutput(func() {
+		Command.SetArgs([]string{"--help"})
+		Command.Execute()
+	})
+}
+
+func mockServer(t *testing.T, usersStatus int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.String() {
+		case "/v4/organizations/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id": "giantswarm"}, {"id": "acme"}]`))
+		case "/v4/users/":
+			w.WriteHeader(usersStatus)
+			if usersStatus == http.StatusOK {
+				w.Write([]byte(`[{"email": "user@example.com"}]`))
+			} else {
+				w.Write([]byte(fmt.Sprintf(`{"code": "PERMISSION_DENIED", "message": "%s"}`, http.StatusText(usersStatus))))
+			}
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.String())
+		}
+	}))
+}
+
+// Test_Whoami tests the result for token and SSO logins.
+func Test_Whoami(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	enc := base64.RawURLEncoding
+	ssoToken := enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." +
+		enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"auth0|123","email":"sso@example.com","exp":%d,"https://giantswarm.io/groups":["ops"]}`, expiry.Unix()))) +
+		".signature"
+
+	var testCases = []struct {
+		scheme      string
+		token       string
+		usersStatus int
+		expected    Output
+	}{
+		{
+			scheme:      "giantswarm",
+			token:       "some-token",
+			usersStatus: http.StatusForbidden,
+			expected: Output{
+				APIEndpointAlias: "myalias",
+				Email:            "email@example.com",
+				AuthScheme:       "giantswarm",
+				Organizations:    []string{"acme", "giantswarm"},
+			},
+		},
+		{
+			scheme:      "giantswarm",
+			token:       "some-token",
+			usersStatus: http.StatusUnauthorized,
+			expected: Output{
+				APIEndpointAlias: "myalias",
+				Email:            "email@example.com",
+				AuthScheme:       "giantswarm",
+				Organizations:    []string{"acme", "giantswarm"},
+			},
+		},
+		{
+			scheme:      "Bearer",
+			token:       ssoToken,
+			usersStatus: http.StatusOK,
+			expected: Output{
+				APIEndpointAlias: "myalias",
+				Email:            "sso@example.com",
+				Subject:          "auth0|123",
+				Groups:           []string{"ops"},
+				AuthScheme:       "Bearer",
+				TokenExpiry:      expiry.Format(time.RFC3339),
+				Organizations:    []string{"acme", "giantswarm"},
+				Admin:            true,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			server := mockServer(t, tc.usersStatus)
+			defer server.Close()
+
+			fs := afero.NewMemMapFs()
+			_, err := testutils.TempConfig(fs, fmt.Sprintf(`endpoints:
+  %s:
+    email: email@example.com
+    token: %s
+    auth_scheme: %s
+    alias: myalias
+selected_endpoint: %s
+`, server.URL, tc.token, tc.scheme, server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			args := collectArguments()
+			args.outputFormat = formatting.OutputFormatJSON
+			err = verifyPreconditions(args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			out, err := whoami(args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			tc.expected.APIEndpoint = server.URL
+			if diff := cmp.Diff(tc.expected, out); diff != "" {
+				t.Errorf("Output not as expected (-want +got):\n%s", diff)
+			}
+
+			rendered, err := render(out, args.outputFormat)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			var parsed Output
+			err = json.Unmarshal([]byte(rendered), &parsed)
+			if err != nil {
+				t.Fatalf("Could not parse JSON output: %s", err)
+			}
+			if diff := cmp.Diff(out, parsed); diff != "" {
+				t.Errorf("JSON output not as expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// Test_WhoamiNotLoggedIn tests the preconditions without a token.
+func Test_WhoamiNotLoggedIn(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, `endpoints:
+  https://api.example.com:
+    email: ""
+    token: ""
+selected_endpoint: https://api.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := collectArguments()
+	args.outputFormat = formatting.OutputFormatTable
+	err = verifyPreconditions(args)
+	if !errors.IsNotLoggedInError(err) {
+		t.Errorf("Expected not logged in error, got %v", err)
+	}
+}
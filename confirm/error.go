@@ -0,0 +1,35 @@
+package confirm
+
+import "github.com/giantswarm/microerror"
+
+var abortedError = &microerror.Error{
+	Kind: "abortedError",
+	Desc: "The selection has been aborted",
+}
+
+// IsAborted asserts abortedError.
+func IsAborted(err error) bool {
+	return microerror.Cause(err) == abortedError
+}
+
+// NotInteractiveError means that the user can't be prompted, as standard
+// input or output is not a terminal. It is shared with the picker package.
+var NotInteractiveError = &microerror.Error{
+	Kind: "notInteractiveError",
+	Desc: "Standard input or output is not a terminal",
+}
+
+// IsNotInteractive asserts NotInteractiveError.
+func IsNotInteractive(err error) bool {
+	return microerror.Cause(err) == NotInteractiveError
+}
+
+var noOptionsError = &microerror.Error{
+	Kind: "noOptionsError",
+	Desc: "There is nothing to pick from",
+}
+
+// IsNoOptions asserts noOptionsError.
+func IsNoOptions(err error) bool {
+	return microerror.Cause(err) == noOptionsError
+}
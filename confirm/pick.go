@@ -0,0 +1,242 @@
+package confirm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	// maxVisibleOptions is the number of options shown at once.
+	maxVisibleOptions = 10
+
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyLF        = 10
+	keyCR        = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// Option is one item the user can pick.
+type Option struct {
+	// Value is what gets returned when the option is picked.
+	Value string
+	// Label is what gets displayed and matched against the filter.
+	Label string
+}
+
+// IsInteractive returns true if both standard input and standard output
+// are connected to a terminal, so that we can ask the user to pick something.
+func IsInteractive() bool {
+	return terminal.IsTerminal(int(os.Stdin.Fd())) && terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Pick shows a filterable list of options in the terminal and lets the user
+// select one using the arrow keys and enter. Typing narrows the list down.
+// The value of the picked option is returned.
+func Pick(prompt string, options []Option) (string, error) {
+	if !IsInteractive() {
+		return "", microerror.Mask(NotInteractiveError)
+	}
+	if len(options) == 0 {
+		return "", microerror.Mask(noOptionsError)
+	}
+
+	fd := int(os.Stdin.Fd())
+	oldState, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	defer terminal.Restore(fd, oldState)
+
+	value, err := pick(os.Stdin, os.Stdout, prompt, options)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return value, nil
+}
+
+// picker holds the state of one interactive selection.
+type picker struct {
+	prompt  string
+	options []Option
+	filter  []rune
+	// cursor is the index of the highlighted option within matches().
+	cursor int
+	// offset is the index of the first visible option within matches().
+	offset int
+	// renderedLines is the number of lines printed by the last render call.
+	renderedLines int
+}
+
+// pick runs the selection on the given reader and writer, which are expected
+// to be a terminal in raw mode.
+func pick(in io.Reader, out io.Writer, prompt string, options []Option) (string, error) {
+	p := &picker{
+		prompt:  prompt,
+		options: options,
+	}
+	reader := bufio.NewReader(in)
+
+	for {
+		p.render(out)
+
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			p.clear(out)
+			return "", microerror.Mask(abortedError)
+		}
+
+		switch r {
+		case keyCtrlC, keyCtrlD:
+			p.clear(out)
+			return "", microerror.Mask(abortedError)
+
+		case keyEscape:
+			// Arrow keys are sent as escape sequences, a lone escape aborts.
+			if reader.Buffered() == 0 {
+				p.clear(out)
+				return "", microerror.Mask(abortedError)
+			}
+			next, _, _ := reader.ReadRune()
+			if next != '[' && next != 'O' {
+				continue
+			}
+			code, _, _ := reader.ReadRune()
+			switch code {
+			case 'A':
+				p.move(-1)
+			case 'B':
+				p.move(1)
+			}
+
+		case keyCtrlP:
+			p.move(-1)
+
+		case keyCtrlN:
+			p.move(1)
+
+		case keyCR, keyLF:
+			matches := p.matches()
+			if len(matches) == 0 {
+				continue
+			}
+			p.clear(out)
+			fmt.Fprintf(out, "%s: %s\r\n", color.YellowString(p.prompt), matches[p.cursor].Value)
+			return matches[p.cursor].Value, nil
+
+		case keyBackspace, keyDelete:
+			if len(p.filter) > 0 {
+				p.filter = p.filter[:len(p.filter)-1]
+				p.cursor = 0
+				p.offset = 0
+			}
+
+		default:
+			if r >= 32 {
+				p.filter = append(p.filter, r)
+				p.cursor = 0
+				p.offset = 0
+			}
+		}
+	}
+}
+
+// matches returns the options matching the current filter,
+// compared case-insensitively.
+func (p *picker) matches() []Option {
+	if len(p.filter) == 0 {
+		return p.options
+	}
+
+	filter := strings.ToLower(string(p.filter))
+
+	var matches []Option
+	for _, o := range p.options {
+		if strings.Contains(strings.ToLower(o.Label), filter) || strings.Contains(strings.ToLower(o.Value), filter) {
+			matches = append(matches, o)
+		}
+	}
+
+	return matches
+}
+
+// move moves the cursor by delta, keeping it within the matching options
+// and scrolling the visible window as needed.
+func (p *picker) move(delta int) {
+	numMatches := len(p.matches())
+	if numMatches == 0 {
+		return
+	}
+
+	p.cursor += delta
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	if p.cursor >= numMatches {
+		p.cursor = numMatches - 1
+	}
+
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+maxVisibleOptions {
+		p.offset = p.cursor - maxVisibleOptions + 1
+	}
+}
+
+// render prints the prompt, filter and visible options,
+// replacing what has been printed before.
+func (p *picker) render(out io.Writer) {
+	p.clear(out)
+
+	lines := []string{
+		fmt.Sprintf("%s %s", color.YellowString(p.prompt), color.WhiteString("(type to filter, up/down to move, enter to select, esc to abort)")),
+		fmt.Sprintf("> %s", string(p.filter)),
+	}
+
+	matches := p.matches()
+	if len(matches) == 0 {
+		lines = append(lines, color.YellowString("  No matches"))
+	}
+
+	end := p.offset + maxVisibleOptions
+	if end > len(matches) {
+		end = len(matches)
+	}
+	for i := p.offset; i < end; i++ {
+		if i == p.cursor {
+			lines = append(lines, color.CyanString("> %s", matches[i].Label))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %s", matches[i].Label))
+		}
+	}
+	if len(matches) > end {
+		lines = append(lines, color.WhiteString("  ... %d more", len(matches)-end))
+	}
+
+	// In raw mode, a line feed doesn't return the carriage.
+	fmt.Fprint(out, strings.Join(lines, "\r\n")+"\r\n")
+	p.renderedLines = len(lines)
+}
+
+// clear removes the lines printed by the last render call.
+func (p *picker) clear(out io.Writer) {
+	if p.renderedLines == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "\x1b[%dA\r\x1b[J", p.renderedLines)
+	p.renderedLines = 0
+}
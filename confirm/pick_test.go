@@ -0,0 +1,92 @@
+package confirm
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var testOptions = []Option{
+	{Value: "fow72", Label: "fow72  My dearest production cluster  acme"},
+	{Value: "2sg4i", Label: "2sg4i  Abandoned cluster  some_org"},
+	{Value: "7ste0", Label: "7ste0  A fairly recent test cluster  acme"},
+}
+
+// Test_pick tests key handling of the interactive picker.
+func Test_pick(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expectedValue string
+		errorMatcher  func(error) bool
+	}{
+		// Enter picks the first option.
+		{"\r", "fow72", nil},
+		// Arrow down moves to the next option.
+		{"\x1b[B\r", "2sg4i", nil},
+		// Arrow keys stop at the ends of the list.
+		{"\x1b[A\x1b[B\x1b[B\x1b[B\x1b[B\r", "7ste0", nil},
+		// Typing filters, case-insensitively.
+		{"TEST\r", "7ste0", nil},
+		// Filter matches the value, too.
+		{"2sg\r", "2sg4i", nil},
+		// Backspace removes from the filter.
+		{"testx\x7f\x7f\x7f\x7f\x7f\x1b[B\r", "2sg4i", nil},
+		// Enter without matches does nothing.
+		{"nothing\r\x7f\x7f\x7f\x7f\x7f\x7f\x7f\r", "fow72", nil},
+		// Ctrl-C aborts.
+		{"\x03", "", IsAborted},
+		// A lone escape aborts.
+		{"\x1b", "", IsAborted},
+		// End of input aborts.
+		{"acme", "", IsAborted},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			value, err := pick(strings.NewReader(tc.input), out, "Pick a cluster", testOptions)
+
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Errorf("Case %d - Error did not match expected type. Got '%v'", i, err)
+				}
+			} else if err != nil {
+				t.Errorf("Case %d - Unexpected error: %s", i, err)
+			}
+
+			if value != tc.expectedValue {
+				t.Errorf("Case %d - Expected %q, got %q", i, tc.expectedValue, value)
+			}
+		})
+	}
+}
+
+// Test_pickerScrolling tests that the visible window follows the cursor.
+func Test_pickerScrolling(t *testing.T) {
+	var options []Option
+	for i := 0; i < 25; i++ {
+		options = append(options, Option{Value: strconv.Itoa(i), Label: "option " + strconv.Itoa(i)})
+	}
+
+	p := &picker{options: options}
+	for i := 0; i < 15; i++ {
+		p.move(1)
+	}
+
+	if p.cursor != 15 {
+		t.Errorf("Expected cursor 15, got %d", p.cursor)
+	}
+	if p.offset != 6 {
+		t.Errorf("Expected offset 6, got %d", p.offset)
+	}
+
+	out := &bytes.Buffer{}
+	p.render(out)
+	if !strings.Contains(out.String(), "option 15") || strings.Contains(out.String(), "option 5\r") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "9 more") {
+		t.Errorf("Expected a hint about more options, got:\n%s", out.String())
+	}
+}
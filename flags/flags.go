@@ -1,5 +1,7 @@
 package flags
 
+import "time"
+
 var (
 	// APIEndpoint represents the API endpoint URL flag.
 	APIEndpoint string
@@ -47,6 +49,9 @@ var (
 	// CNPrefix represents the CN prefix passed as a flag.
 	CNPrefix string
 
+	// Concurrency is the maximum number of operations to run in parallel.
+	Concurrency int
+
 	// CreateDefaultNodePool defines whether a default node pool should be created
 	// in the case that none was defined in the cluster definition.
 	CreateDefaultNodePool bool
@@ -54,6 +59,9 @@ var (
 	// Description represents the description passed as a flag.
 	Description string
 
+	// DryRun makes commands print what they would do, without changing anything.
+	DryRun bool
+
 	// Use spot instances for a node pool
 	EnableSpotInstances bool
 
@@ -79,24 +87,45 @@ var (
 	// NumWorkers is the number of workers required via flag on execution.
 	NumWorkers int
 
+	// Offline makes commands skip all checks requiring the API.
+	Offline bool
+
 	// OrganizationID represents an organization ID, passed as a flag.
 	OrganizationID string
 
 	// OutputFormat is the output format (table or json) of a commands output, passed as a flag.
 	OutputFormat string
 
+	// Overwrite allows replacing existing values.
+	Overwrite bool
+
 	// Owner is the owner organization of the cluster as set via flag on execution.
 	Owner string
 
+	// PrintOnly makes commands print a URL instead of opening it in the web browser.
+	PrintOnly bool
+
+	// RenderOnly makes 'create cluster' print the rendered cluster definition instead of creating a cluster.
+	RenderOnly bool
+
+	// Selector is a label selector query to select clusters with.
+	Selector string
+
 	// Release sets a release to use, provided as a command line flag.
 	Release string
 
+	// Set contains key=value pairs to render a cluster definition template with.
+	Set []string
+
 	// SilenceHTTPEndpointWarning represents
 	SilenceHTTPEndpointWarning bool
 
 	// MasterHA enables or disabled master node high availability.
 	MasterHA bool
 
+	// Template is the name of a stored cluster definition template.
+	Template string
+
 	// TenantInternal represents the type of Kubernetes API endpoints
 	// used to generate kubeconfig
 	TenantInternal bool
@@ -107,6 +136,15 @@ var (
 	// TTL represents a TTL (time to live) value passed as a flag.
 	TTL string
 
+	// ValuesFile is the path to a YAML file with values to render a cluster definition template with.
+	ValuesFile string
+
+	// Watch makes commands refresh their output periodically, until interrupted.
+	Watch bool
+
+	// WatchInterval is the time between two refreshes in watch mode.
+	WatchInterval time.Duration
+
 	// WorkerAwsEc2InstanceType is the instance type name for nodes in AWS.
 	WorkerAwsEc2InstanceType string
 
@@ -5,6 +5,8 @@ const (
 	OutputFormatJSON = "json"
 	// OutputFormatTable contains the string value to enable table formatted output
 	OutputFormatTable = "table"
+	// OutputFormatYAML contains the string value to enable YAML formatted output
+	OutputFormatYAML = "yaml"
 
 	// OutputJSONPrefix is the prefix for json formatted output
 	OutputJSONPrefix = ""
@@ -0,0 +1,42 @@
+package hibernation
+
+import "github.com/giantswarm/microerror"
+
+var missingArgumentError = &microerror.Error{
+	Kind: "missingArgumentError",
+}
+
+// IsMissingArgument asserts missingArgumentError.
+func IsMissingArgument(err error) bool {
+	return microerror.Cause(err) == missingArgumentError
+}
+
+var alreadyHibernatedError = &microerror.Error{
+	Kind: "alreadyHibernatedError",
+	Desc: "The cluster is already hibernated",
+}
+
+// IsAlreadyHibernated asserts alreadyHibernatedError.
+func IsAlreadyHibernated(err error) bool {
+	return microerror.Cause(err) == alreadyHibernatedError
+}
+
+var notHibernatedError = &microerror.Error{
+	Kind: "notHibernatedError",
+	Desc: "There is no hibernation state recorded for the cluster",
+}
+
+// IsNotHibernated asserts notHibernatedError.
+func IsNotHibernated(err error) bool {
+	return microerror.Cause(err) == notHibernatedError
+}
+
+var noMatchingClustersError = &microerror.Error{
+	Kind: "noMatchingClustersError",
+	Desc: "No clusters match the label selector",
+}
+
+// IsNoMatchingClusters asserts noMatchingClustersError.
+func IsNoMatchingClusters(err error) bool {
+	return microerror.Cause(err) == noMatchingClustersError
+}
@@ -0,0 +1,202 @@
+// Package hibernation scales all node pools of a cluster down to zero
+// and back up again, recording the original scaling in a local state file.
+package hibernation
+
+import (
+	"sort"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/client/clienterror"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/pkg/labels"
+)
+
+// Target is a cluster to hibernate or wake.
+type Target struct {
+	ID   string
+	Name string
+}
+
+// FindTargets returns the clusters to act on. This is either the single
+// cluster given by name or ID, or all clusters matching the label selector.
+func FindTargets(endpoint, clusterNameOrID, selector string, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]Target, error) {
+	if selector == "" {
+		clusterID, err := clustercache.GetID(endpoint, clusterNameOrID, clientWrapper)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		target := Target{ID: clusterID}
+		for _, entry := range clustercache.List(endpoint) {
+			if entry.ID == clusterID {
+				target.Name = entry.Name
+			}
+		}
+
+		return []Target{target}, nil
+	}
+
+	parsed, err := labels.ParseSelector(selector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	selector = parsed.String()
+
+	response, err := clientWrapper.GetClustersByLabel(&models.V5ListClustersByLabelRequest{Labels: &selector}, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(mapClientError(err))
+	}
+
+	targets := []Target{}
+	for _, cluster := range response.Payload {
+		if cluster.DeleteDate != nil {
+			continue
+		}
+		targets = append(targets, Target{ID: cluster.ID, Name: cluster.Name})
+	}
+
+	if len(targets) == 0 {
+		return nil, microerror.Maskf(noMatchingClustersError, "selector '%s'", selector)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].ID < targets[j].ID
+	})
+
+	return targets, nil
+}
+
+// CurrentScaling returns the current scaling of all node pools of a cluster,
+// sorted by node pool ID.
+func CurrentScaling(clusterID string, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]NodePoolScaling, error) {
+	response, err := clientWrapper.GetNodePools(clusterID, auxParams)
+	if err != nil {
+		if clienterror.IsNotFoundError(err) {
+			// Provide a specific error in case this is a v4 cluster.
+			_, v4Err := clientWrapper.GetClusterV4(clusterID, auxParams)
+			if v4Err == nil {
+				return nil, microerror.Mask(errors.ClusterDoesNotSupportNodePoolsError)
+			}
+
+			return nil, microerror.Mask(errors.ClusterNotFoundError)
+		}
+
+		return nil, microerror.Mask(mapClientError(err))
+	}
+
+	nodePools := []NodePoolScaling{}
+	for _, np := range response.Payload {
+		scaling := NodePoolScaling{ID: np.ID, Name: np.Name}
+		if np.Scaling != nil {
+			scaling.Max = np.Scaling.Max
+			if np.Scaling.Min != nil {
+				scaling.Min = *np.Scaling.Min
+			}
+		}
+		nodePools = append(nodePools, scaling)
+	}
+
+	sort.Slice(nodePools, func(i, j int) bool {
+		return nodePools[i].ID < nodePools[j].ID
+	})
+
+	return nodePools, nil
+}
+
+// Hibernate records the given node pool scaling of a cluster and then
+// scales all these node pools down to zero nodes.
+//
+// The state is recorded first, so that a cluster can be woken up even
+// if scaling down fails for some of the node pools.
+func Hibernate(endpoint, clusterID string, nodePools []NodePoolScaling, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) error {
+	if _, ok := Get(endpoint, clusterID); ok {
+		return microerror.Maskf(alreadyHibernatedError, "cluster '%s'", clusterID)
+	}
+
+	err := Store(endpoint, clusterID, nodePools)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, np := range nodePools {
+		err = clientWrapper.ScaleNodePool(clusterID, np.ID, 0, 0, auxParams)
+		if err != nil {
+			return microerror.Mask(mapNodePoolError(err))
+		}
+	}
+
+	return nil
+}
+
+// Restorable returns the node pools recorded in the state which still exist
+// according to the current node pool list.
+func Restorable(state ClusterState, current []NodePoolScaling) []NodePoolScaling {
+	existing := map[string]bool{}
+	for _, np := range current {
+		existing[np.ID] = true
+	}
+
+	restorable := []NodePoolScaling{}
+	for _, np := range state.NodePools {
+		if existing[np.ID] {
+			restorable = append(restorable, np)
+		}
+	}
+
+	return restorable
+}
+
+// Wake restores the node pool scaling recorded when hibernating a cluster and
+// returns the node pools restored. Node pools deleted in the meantime are
+// skipped. The recorded state is removed once all node pools are restored.
+func Wake(endpoint, clusterID string, clientWrapper *client.Wrapper, auxParams *client.AuxiliaryParams) ([]NodePoolScaling, error) {
+	state, ok := Get(endpoint, clusterID)
+	if !ok {
+		return nil, microerror.Maskf(notHibernatedError, "cluster '%s'", clusterID)
+	}
+
+	current, err := CurrentScaling(clusterID, clientWrapper, auxParams)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	restorable := Restorable(state, current)
+	for _, np := range restorable {
+		err = clientWrapper.ScaleNodePool(clusterID, np.ID, np.Min, np.Max, auxParams)
+		if err != nil {
+			return nil, microerror.Mask(mapNodePoolError(err))
+		}
+	}
+
+	err = Remove(endpoint, clusterID)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return restorable, nil
+}
+
+// mapClientError turns client errors into the errors we handle in commands.
+func mapClientError(err error) error {
+	switch {
+	case clienterror.IsUnauthorizedError(err):
+		return errors.NotAuthorizedError
+	case clienterror.IsAccessForbiddenError(err):
+		return errors.AccessForbiddenError
+	}
+
+	return err
+}
+
+// mapNodePoolError is like mapClientError for requests on a single node pool.
+func mapNodePoolError(err error) error {
+	if clienterror.IsNotFoundError(err) {
+		return errors.NodePoolNotFoundError
+	}
+
+	return mapClientError(err)
+}
@@ -0,0 +1,116 @@
+package hibernation
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/commands/errors"
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_HibernateAndWake tests a full hibernation cycle against a mock API.
+func Test_HibernateAndWake(t *testing.T) {
+	patches := map[string]string{}
+	nodePoolsResponse := `[
+		{"id": "b2c", "name": "GPU", "scaling": {"min": 0, "max": 2}},
+		{"id": "a1b", "name": "Default", "scaling": {"min": 3, "max": 10}}
+	]`
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/clusters/f01r4/nodepools/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(nodePoolsResponse))
+		case r.Method == http.MethodPatch && (r.URL.Path == "/v5/clusters/f01r4/nodepools/a1b/" || r.URL.Path == "/v5/clusters/f01r4/nodepools/b2c/"):
+			body, _ := ioutil.ReadAll(r.Body)
+			patches[r.URL.Path] = string(body)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/clusters/v4cls/":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "v4cls"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND", "message": "Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientWrapper, err := client.New(&client.Configuration{Endpoint: mockServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := CurrentScaling("f01r4", clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []NodePoolScaling{
+		{ID: "a1b", Name: "Default", Min: 3, Max: 10},
+		{ID: "b2c", Name: "GPU", Min: 0, Max: 2},
+	}
+	if diff := cmp.Diff(expected, current); diff != "" {
+		t.Fatalf("Scaling not as expected (-want +got):\n%s", diff)
+	}
+
+	err = Hibernate(mockServer.URL, "f01r4", current, clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, path := range []string{"/v5/clusters/f01r4/nodepools/a1b/", "/v5/clusters/f01r4/nodepools/b2c/"} {
+		if patches[path] != `{"scaling":{"max":0,"min":0}}` {
+			t.Errorf("Unexpected request body for %s: %s", path, patches[path])
+		}
+	}
+
+	err = Hibernate(mockServer.URL, "f01r4", current, clientWrapper, nil)
+	if !IsAlreadyHibernated(err) {
+		t.Errorf("Expected alreadyHibernatedError, got %v", err)
+	}
+
+	// Node pool b2c has been deleted while the cluster was hibernated.
+	nodePoolsResponse = `[{"id": "a1b", "name": "Default", "scaling": {"min": 0, "max": 0}}]`
+	patches = map[string]string{}
+
+	restored, err := Wake(mockServer.URL, "f01r4", clientWrapper, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(expected[:1], restored); diff != "" {
+		t.Errorf("Restored node pools not as expected (-want +got):\n%s", diff)
+	}
+	if patches["/v5/clusters/f01r4/nodepools/a1b/"] != `{"scaling":{"max":10,"min":3}}` {
+		t.Errorf("Unexpected request body: %s", patches["/v5/clusters/f01r4/nodepools/a1b/"])
+	}
+	if len(patches) != 1 {
+		t.Errorf("Expected one request, got %d", len(patches))
+	}
+
+	if _, ok := Get(mockServer.URL, "f01r4"); ok {
+		t.Error("Expected state to be removed after waking the cluster")
+	}
+
+	_, err = Wake(mockServer.URL, "f01r4", clientWrapper, nil)
+	if !IsNotHibernated(err) {
+		t.Errorf("Expected notHibernatedError, got %v", err)
+	}
+
+	_, err = CurrentScaling("v4cls", clientWrapper, nil)
+	if !errors.IsClusterDoesNotSupportNodePools(err) {
+		t.Errorf("Expected ClusterDoesNotSupportNodePoolsError, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package hibernation
+
+import (
+	"path"
+	"time"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	stateFileName = "hibernation.yaml"
+
+	timeLayout = time.RFC3339
+)
+
+// NodePoolScaling is the scaling configuration of a node pool
+// before it got hibernated.
+type NodePoolScaling struct {
+	ID   string `yaml:"id" json:"id"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	Min  int64  `yaml:"min" json:"min"`
+	Max  int64  `yaml:"max" json:"max"`
+}
+
+// ClusterState is what we record for a hibernated cluster.
+type ClusterState struct {
+	NodePools []NodePoolScaling `yaml:"nodepools"`
+	// Hibernated is the time the cluster has been hibernated, in RFC3339 format.
+	Hibernated string `yaml:"hibernated"`
+}
+
+// State is the file structure of the hibernation state file. It maps API
+// endpoint URLs to cluster IDs to the state recorded for the cluster.
+type State struct {
+	Endpoints map[string]map[string]ClusterState `yaml:"endpoints"`
+}
+
+// Get returns the recorded state of a hibernated cluster. The second return
+// value is false if the cluster is not hibernated.
+func Get(endpoint string, clusterID string) (ClusterState, bool) {
+	state, err := read(config.FileSystem)
+	if err != nil {
+		return ClusterState{}, false
+	}
+
+	clusterState, ok := state.Endpoints[endpoint][clusterID]
+
+	return clusterState, ok
+}
+
+// Store records the node pool scaling of a cluster about to be hibernated.
+func Store(endpoint string, clusterID string, nodePools []NodePoolScaling) error {
+	if endpoint == "" || clusterID == "" {
+		return microerror.Maskf(missingArgumentError, "endpoint and cluster ID must be given")
+	}
+
+	fs := config.FileSystem
+
+	state, err := read(fs)
+	if err != nil {
+		state = &State{Endpoints: map[string]map[string]ClusterState{}}
+	}
+
+	if state.Endpoints[endpoint] == nil {
+		state.Endpoints[endpoint] = map[string]ClusterState{}
+	}
+	state.Endpoints[endpoint][clusterID] = ClusterState{
+		NodePools:  nodePools,
+		Hibernated: time.Now().UTC().Format(timeLayout),
+	}
+
+	err = write(fs, state)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Remove deletes the recorded state of a cluster, e. g. after waking it up.
+func Remove(endpoint string, clusterID string) error {
+	fs := config.FileSystem
+
+	state, err := read(fs)
+	if err != nil {
+		// Nothing to remove.
+		return nil
+	}
+
+	delete(state.Endpoints[endpoint], clusterID)
+	if len(state.Endpoints[endpoint]) == 0 {
+		delete(state.Endpoints, endpoint)
+	}
+
+	err = write(fs, state)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func read(fs afero.Fs) (*State, error) {
+	if fs == nil {
+		return nil, microerror.Mask(missingArgumentError)
+	}
+
+	filePath := path.Join(config.ConfigDirPath, stateFileName)
+	yamlBytes, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{}
+	err = yaml.Unmarshal(yamlBytes, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Endpoints == nil {
+		state.Endpoints = map[string]map[string]ClusterState{}
+	}
+
+	return state, nil
+}
+
+func write(fs afero.Fs, state *State) error {
+	filePath := path.Join(config.ConfigDirPath, stateFileName)
+	output, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fs, filePath, output, config.ConfigFilePermission)
+}
@@ -21,5 +21,5 @@ func init() {
 }
 
 func main() {
-	commands.RootCommand.Execute()
+	commands.Execute()
 }
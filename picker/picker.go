@@ -0,0 +1,283 @@
+// Package picker lets the user interactively select clusters, node pools,
+// releases and organizations in a terminal session, for when a command
+// argument is missing or ambiguous.
+package picker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/gsctl/client"
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/confirm"
+	"github.com/giantswarm/gsctl/util"
+)
+
+const (
+	listNodePoolsActivityName     = "list-nodepools"
+	listOrganizationsActivityName = "list-organizations"
+	listReleasesActivityName      = "list-releases"
+)
+
+// Cluster lets the user pick a cluster and returns its ID. Cached cluster
+// details are used if available, otherwise clusters are fetched from the API.
+func Cluster(endpoint string, clientWrapper *client.Wrapper) (string, error) {
+	if !confirm.IsInteractive() {
+		return "", microerror.Mask(confirm.NotInteractiveError)
+	}
+
+	entries := validEntries(clustercache.List(endpoint))
+	if len(entries) == 0 {
+		var err error
+		entries, err = clustercache.Refresh(endpoint, clientWrapper)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+	}
+
+	id, err := confirm.Pick("Select a cluster", clusterOptions(entries))
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return id, nil
+}
+
+// clusterOptions turns cached cluster entries into options to pick from.
+func clusterOptions(entries []clustercache.Entry) []confirm.Option {
+	options := make([]confirm.Option, 0, len(entries))
+	for _, entry := range entries {
+		label := entry.ID
+		if entry.Name != "" {
+			label += fmt.Sprintf("  %s", entry.Name)
+		}
+		if entry.Owner != "" {
+			label += fmt.Sprintf("  (%s)", entry.Owner)
+		}
+		if entry.Release != "" {
+			label += fmt.Sprintf("  %s", entry.Release)
+		}
+		if entry.CreateDate != "" {
+			label += fmt.Sprintf("  created %s", util.ShortDate(util.ParseDate(entry.CreateDate)))
+		}
+
+		options = append(options, confirm.Option{Value: entry.ID, Label: label})
+	}
+
+	return options
+}
+
+// NodePool lets the user pick one of the node pools of a cluster
+// and returns its ID.
+func NodePool(clusterID string, clientWrapper *client.Wrapper) (string, error) {
+	if !confirm.IsInteractive() {
+		return "", microerror.Mask(confirm.NotInteractiveError)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = listNodePoolsActivityName
+
+	response, err := clientWrapper.GetNodePools(clusterID, auxParams)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	sort.Slice(response.Payload, func(i, j int) bool {
+		return response.Payload[i].ID < response.Payload[j].ID
+	})
+
+	options := make([]confirm.Option, 0, len(response.Payload))
+	for _, np := range response.Payload {
+		options = append(options, confirm.Option{
+			Value: np.ID,
+			Label: fmt.Sprintf("%s  %s", np.ID, np.Name),
+		})
+	}
+
+	id, err := confirm.Pick("Select a node pool", options)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return id, nil
+}
+
+// Release lets the user pick one of the active releases and returns its version.
+// The latest release comes first.
+func Release(clientWrapper *client.Wrapper) (string, error) {
+	if !confirm.IsInteractive() {
+		return "", microerror.Mask(confirm.NotInteractiveError)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = listReleasesActivityName
+
+	response, err := clientWrapper.GetReleases(auxParams)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var versions []*semver.Version
+	for _, release := range response.Payload {
+		if !release.Active || release.Version == nil {
+			continue
+		}
+
+		v, err := semver.NewVersion(*release.Version)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+
+	options := make([]confirm.Option, 0, len(versions))
+	for _, v := range versions {
+		options = append(options, confirm.Option{Value: v.Original(), Label: v.Original()})
+	}
+
+	version, err := confirm.Pick("Select a release", options)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return version, nil
+}
+
+// Organization lets the user pick one of the organizations
+// they are a member of and returns its ID.
+func Organization(clientWrapper *client.Wrapper) (string, error) {
+	if !confirm.IsInteractive() {
+		return "", microerror.Mask(confirm.NotInteractiveError)
+	}
+
+	auxParams := clientWrapper.DefaultAuxiliaryParams()
+	auxParams.ActivityName = listOrganizationsActivityName
+
+	response, err := clientWrapper.GetOrganizations(auxParams)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	sort.Slice(response.Payload, func(i, j int) bool {
+		return response.Payload[i].ID < response.Payload[j].ID
+	})
+
+	options := make([]confirm.Option, 0, len(response.Payload))
+	for _, org := range response.Payload {
+		options = append(options, confirm.Option{Value: org.ID, Label: org.ID})
+	}
+
+	id, err := confirm.Pick("Select an organization", options)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return id, nil
+}
+
+// validEntries filters out expired cache entries and those we only know
+// the ID of.
+func validEntries(entries []clustercache.Entry) []clustercache.Entry {
+	var valid []clustercache.Entry
+	for _, entry := range entries {
+		if entry.IsExpired() || entry.Name == "" {
+			continue
+		}
+		valid = append(valid, entry)
+	}
+
+	return valid
+}
+
+// MissingCluster lets the user pick a cluster for a command invoked without
+// a cluster argument. An empty string is returned if the session is not
+// interactive, if fetching clusters fails or if the user aborts, so that the
+// command can report the missing argument as usual.
+func MissingCluster(endpoint, userProvidedToken string) string {
+	if !confirm.IsInteractive() {
+		return ""
+	}
+
+	clientWrapper, err := client.NewWithConfig(endpoint, userProvidedToken)
+	if err != nil {
+		return ""
+	}
+
+	id, err := Cluster(endpoint, clientWrapper)
+	if err != nil {
+		return ""
+	}
+
+	return id
+}
+
+// MissingNodePool lets the user pick a node pool of the given cluster for a
+// command invoked without a node pool ID. An empty string is returned if that
+// is not possible, like MissingCluster does.
+func MissingNodePool(endpoint, userProvidedToken, clusterNameOrID string) string {
+	if !confirm.IsInteractive() {
+		return ""
+	}
+
+	clientWrapper, err := client.NewWithConfig(endpoint, userProvidedToken)
+	if err != nil {
+		return ""
+	}
+
+	clusterID, err := clustercache.GetID(endpoint, clusterNameOrID, clientWrapper)
+	if err != nil {
+		return ""
+	}
+
+	id, err := NodePool(clusterID, clientWrapper)
+	if err != nil {
+		return ""
+	}
+
+	return id
+}
+
+// MissingRelease lets the user pick a release version for a command invoked
+// without one. An empty string is returned if that is not possible.
+func MissingRelease(endpoint, userProvidedToken string) string {
+	if !confirm.IsInteractive() {
+		return ""
+	}
+
+	clientWrapper, err := client.NewWithConfig(endpoint, userProvidedToken)
+	if err != nil {
+		return ""
+	}
+
+	version, err := Release(clientWrapper)
+	if err != nil {
+		return ""
+	}
+
+	return version
+}
+
+// MissingOrganization lets the user pick an organization for a command
+// invoked without one. An empty string is returned if that is not possible.
+func MissingOrganization(endpoint, userProvidedToken string) string {
+	if !confirm.IsInteractive() {
+		return ""
+	}
+
+	clientWrapper, err := client.NewWithConfig(endpoint, userProvidedToken)
+	if err != nil {
+		return ""
+	}
+
+	id, err := Organization(clientWrapper)
+	if err != nil {
+		return ""
+	}
+
+	return id
+}
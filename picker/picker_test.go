@@ -0,0 +1,33 @@
+package picker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/gsctl/clustercache"
+	"github.com/giantswarm/gsctl/confirm"
+)
+
+// Test_clusterOptions tests which cache entries are offered and how.
+func Test_clusterOptions(t *testing.T) {
+	now := time.Now().Format(time.RFC3339)
+
+	entries := []clustercache.Entry{
+		{ID: "fow72", Name: "Production", Owner: "acme", Release: "11.0.0", CreateDate: "2017-05-16T09:30:31.192170835Z", Cached: now},
+		{ID: "2sg4i", Name: "Expired", Cached: "2010-03-03T13:17:16+01:00"},
+		{ID: "7ste0", Cached: now},
+		{ID: "9as2a", Name: "Minimal", Cached: now},
+	}
+
+	expected := []confirm.Option{
+		{Value: "fow72", Label: "fow72  Production  (acme)  11.0.0  created 2017 May 16, 09:30 UTC"},
+		{Value: "9as2a", Label: "9as2a  Minimal"},
+	}
+
+	options := clusterOptions(validEntries(entries))
+	if diff := cmp.Diff(expected, options); diff != "" {
+		t.Errorf("Options did not match expectation:\n%s", diff)
+	}
+}
@@ -0,0 +1,160 @@
+// Package clusterdefinition validates cluster definition YAML as used
+// with 'gsctl create cluster --file'.
+//
+// Validation happens in two steps. Lint decodes the YAML strictly and
+// reports unknown keys, type errors and v4/v5 mismatches with their
+// position, as well as problems which can be detected without knowing
+// the installation. Validate additionally checks the definition against
+// the capabilities of an installation.
+package clusterdefinition
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// SeverityError marks a problem which prevents creating a cluster.
+	SeverityError = "error"
+
+	// SeverityWarning marks a problem which may be fine, e. g. an
+	// instance type gsctl doesn't know about.
+	SeverityWarning = "warning"
+)
+
+// Problem is an issue found in a cluster definition.
+type Problem struct {
+	// Line and Column of the problem in the YAML data, starting at 1.
+	// Zero if the position is not known.
+	Line   int
+	Column int
+	// Path points to the attribute the problem relates to, in a notation
+	// like 'nodepools[0].scaling'. Empty for problems on the top level.
+	Path     string
+	Message  string
+	Severity string
+}
+
+// String formats the problem as 'line:column: severity: message' or
+// 'path: severity: message', depending on the information available.
+func (p Problem) String() string {
+	location := ""
+	switch {
+	case p.Line > 0 && p.Column > 0:
+		location = fmt.Sprintf("%d:%d: ", p.Line, p.Column)
+	case p.Line > 0:
+		location = fmt.Sprintf("%d: ", p.Line)
+	case p.Path != "":
+		location = p.Path + ": "
+	}
+
+	return location + p.Severity + ": " + p.Message
+}
+
+// Result is the outcome of linting or validating a definition.
+type Result struct {
+	// Definition is the decoded definition, either *types.ClusterDefinitionV4
+	// or *types.ClusterDefinitionV5. Nil if the YAML could not be decoded.
+	Definition interface{}
+	Problems   []Problem
+}
+
+// Errors returns the problems with error severity.
+func (r *Result) Errors() []Problem {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the problems with warning severity.
+func (r *Result) Warnings() []Problem {
+	return r.filter(SeverityWarning)
+}
+
+func (r *Result) filter(severity string) []Problem {
+	problems := []Problem{}
+	for _, p := range r.Problems {
+		if p.Severity == severity {
+			problems = append(problems, p)
+		}
+	}
+
+	return problems
+}
+
+// Err returns an invalidDefinitionError listing all errors,
+// or nil if there are none.
+func (r *Result) Err() error {
+	errs := r.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(errs))
+	for i, p := range errs {
+		lines[i] = p.String()
+	}
+
+	return microerror.Maskf(invalidDefinitionError, strings.Join(lines, "\n"))
+}
+
+func (r *Result) addError(path string, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, Problem{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+}
+
+func (r *Result) addWarning(path string, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, Problem{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityWarning})
+}
+
+// Installation holds what we know about the installation a cluster is going
+// to be created in. Empty fields mean that the according check is skipped.
+type Installation struct {
+	// AvailabilityZones are the zone names available for node pools.
+	AvailabilityZones []string
+	// MaxAvailabilityZones is the maximum number of zones per node pool or cluster.
+	MaxAvailabilityZones int64
+	// MaxWorkers is the maximum number of worker nodes per cluster.
+	MaxWorkers int64
+	// InstanceTypes are the AWS instance types the installation allows.
+	InstanceTypes []string
+	// VMSizes are the Azure VM sizes the installation allows.
+	VMSizes []string
+	// ActiveReleases are the release versions clusters can be created with.
+	ActiveReleases []string
+}
+
+// NewInstallation assembles installation details from the API's info and
+// releases responses. Releases can be nil to skip the release check.
+func NewInstallation(info *models.V4InfoResponse, releases []*models.V4ReleaseListItem) *Installation {
+	i := &Installation{}
+
+	if info != nil && info.General != nil && info.General.AvailabilityZones != nil {
+		i.AvailabilityZones = info.General.AvailabilityZones.Zones
+		if info.General.AvailabilityZones.Max != nil {
+			i.MaxAvailabilityZones = *info.General.AvailabilityZones.Max
+		}
+	}
+	if info != nil && info.Workers != nil {
+		if info.Workers.CountPerCluster != nil {
+			i.MaxWorkers = int64(info.Workers.CountPerCluster.Max)
+		}
+		if info.Workers.InstanceType != nil {
+			i.InstanceTypes = info.Workers.InstanceType.Options
+		}
+		if info.Workers.VMSize != nil {
+			i.VMSizes = info.Workers.VMSize.Options
+		}
+	}
+
+	if releases != nil {
+		i.ActiveReleases = []string{}
+		for _, r := range releases {
+			if r.Active && r.Version != nil {
+				i.ActiveReleases = append(i.ActiveReleases, *r.Version)
+			}
+		}
+	}
+
+	return i
+}
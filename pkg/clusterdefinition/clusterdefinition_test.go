@@ -0,0 +1,375 @@
+package clusterdefinition
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/gsctl/commands/types"
+)
+
+// Test_Lint tests strict decoding and offline checks.
+func Test_Lint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		yaml     string
+		expected []string
+	}{
+		{
+			name: "valid v5",
+			yaml: `api_version: v5
+owner: acme
+release_version: 12.0.0
+nodepools:
+- name: Default
+  scaling:
+    min: 3
+    max: 10
+  node_spec:
+    aws:
+      instance_type: m5.xlarge
+`,
+			expected: []string{},
+		},
+		{
+			name: "typo in v5 key",
+			yaml: `api_version: v5
+owner: acme
+nodepool:
+- name: Default
+`,
+			expected: []string{"3:1: error: unknown field 'nodepool', did you mean 'nodepools'?"},
+		},
+		{
+			name: "nested typo and wrong type",
+			yaml: `api_version: v5
+nodepools:
+- name: Default
+  scalling:
+    min: 3
+- name: Other
+  scaling:
+    min: three
+`,
+			expected: []string{
+				"4:3: error: unknown field 'scalling', did you mean 'scaling'?",
+				"8:10: error: invalid value 'three', expected a number",
+			},
+		},
+		{
+			name: "v5 keys without api_version",
+			yaml: `owner: acme
+nodepools:
+- name: Default
+`,
+			expected: []string{"2:1: error: 'nodepools' is only valid in v5 definitions, please add 'api_version: v5'"},
+		},
+		{
+			name: "v4 keys in v5 definition",
+			yaml: `api_version: v5
+owner: acme
+workers:
+- cpu:
+    cores: 4
+`,
+			expected: []string{"3:1: error: 'workers' is only valid in v4 definitions without api_version, define worker nodes as 'nodepools'"},
+		},
+		{
+			name: "unsupported api_version",
+			yaml: `api_version: v6
+owner: acme
+`,
+			expected: []string{"1:1: error: unsupported api_version 'v6', the only supported value is 'v5'"},
+		},
+		{
+			name: "semantic problems",
+			yaml: `api_version: v5
+release_version: latest
+master:
+  availability_zone: eu-central-1a
+master_nodes:
+  high_availability: true
+nodepools:
+- scaling:
+    min: 5
+    max: 3
+  availability_zones:
+    number: 2
+    zones: [eu-central-1a]
+  node_spec:
+    aws:
+      instance_type: m99.huge
+      instance_distribution:
+        on_demand_base_capacity: 0
+        on_demand_percentage_above_base_capacity: 120
+`,
+			expected: []string{
+				"release_version: error: 'latest' is not a valid release version",
+				"master: error: 'master' and 'master_nodes' cannot be used together, please use 'master_nodes'",
+				"nodepools[0].availability_zones: error: please specify either 'number' or 'zones', but not both",
+				"nodepools[0].scaling: error: min (5) must not be greater than max (3)",
+				"nodepools[0].node_spec.aws.instance_type: warning: unknown AWS instance type 'm99.huge'",
+				"nodepools[0].node_spec.aws.instance_distribution.on_demand_percentage_above_base_capacity: error: must be between 0 and 100",
+			},
+		},
+		{
+			name:     "syntax error",
+			yaml:     "owner: acme\n  name: foo\n",
+			expected: []string{"2:3: error: invalid YAML: mapping values are not allowed in this context"},
+		},
+		{
+			name:     "empty",
+			yaml:     "",
+			expected: []string{"error: the definition is empty"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Lint([]byte(tc.yaml))
+
+			got := []string{}
+			for _, p := range r.Problems {
+				got = append(got, p.String())
+			}
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("Problems not as expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// Test_Validate tests checks against the installation.
+func Test_Validate(t *testing.T) {
+	installation := &Installation{
+		AvailabilityZones:    []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+		MaxAvailabilityZones: 2,
+		MaxWorkers:           20,
+		InstanceTypes:        []string{"m5.xlarge", "m5.2xlarge"},
+		ActiveReleases:       []string{"12.0.0", "12.1.0"},
+	}
+
+	data := []byte(`api_version: v5
+owner: acme
+release_version: 11.0.0
+nodepools:
+- availability_zones:
+    zones: [eu-central-1a, eu-west-1b, eu-central-1c]
+  scaling:
+    max: 50
+  node_spec:
+    aws:
+      instance_type: p3.8xlarge
+`)
+
+	r := Validate(data, installation)
+
+	got := []string{}
+	for _, p := range r.Problems {
+		got = append(got, p.String())
+	}
+	expected := []string{
+		"release_version: error: release 11.0.0 is not an active release in this installation, see 'gsctl list releases'",
+		"nodepools[0].availability_zones: error: this installation supports at most 2 availability zone(s)",
+		"nodepools[0].availability_zones: error: availability zone 'eu-west-1b' is not available in this installation, available zones are [eu-central-1a eu-central-1b eu-central-1c]",
+		"nodepools[0].scaling.max: error: the maximum number of worker nodes in this installation is 20",
+		"nodepools[0].node_spec.aws.instance_type: error: instance type 'p3.8xlarge' is not available in this installation",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Problems not as expected (-want +got):\n%s", diff)
+	}
+
+	if !IsInvalidDefinition(r.Err()) {
+		t.Errorf("Expected invalidDefinitionError, got %v", r.Err())
+	}
+
+	if _, ok := r.Definition.(*types.ClusterDefinitionV5); !ok {
+		t.Errorf("Expected v5 definition, got %T", r.Definition)
+	}
+}
+
+// Test_ValidateV4 tests a valid v4 definition.
+func Test_ValidateV4(t *testing.T) {
+	data := []byte(`owner: acme
+release_version: 12.0.0
+availability_zones: 1
+scaling:
+  min: 3
+  max: 5
+workers:
+- aws:
+    instance_type: m5.xlarge
+`)
+
+	r := Validate(data, &Installation{ActiveReleases: []string{"12.0.0"}, MaxAvailabilityZones: 3})
+	if len(r.Problems) != 0 {
+		t.Errorf("Expected no problems, got %v", r.Problems)
+	}
+	if r.Err() != nil {
+		t.Errorf("Expected no error, got %v", r.Err())
+	}
+	if _, ok := r.Definition.(*types.ClusterDefinitionV4); !ok {
+		t.Errorf("Expected v4 definition, got %T", r.Definition)
+	}
+}
+
+// Test_Schema tests that the schema covers all fields of the definition types.
+func Test_Schema(t *testing.T) {
+	data, err := Schema()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	schema := map[string]interface{}{}
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		t.Fatalf("Schema is not valid JSON: %s", err)
+	}
+
+	definitions := schema["definitions"].(map[string]interface{})
+	for name, typ := range knownTypes {
+		shortName := strings.TrimPrefix(name, "types.")
+		def, ok := definitions[shortName].(map[string]interface{})
+		if !ok {
+			t.Errorf("Definition for %s missing", shortName)
+			continue
+		}
+
+		properties := def["properties"].(map[string]interface{})
+		for i := 0; i < typ.NumField(); i++ {
+			key := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+			property, ok := properties[key].(map[string]interface{})
+			if !ok {
+				t.Errorf("Property %s.%s missing", shortName, key)
+				continue
+			}
+			if _, ok := property["description"]; !ok {
+				t.Errorf("Property %s.%s has no description", shortName, key)
+			}
+		}
+	}
+
+	v5 := definitions["ClusterDefinitionV5"].(map[string]interface{})
+	if diff := cmp.Diff([]interface{}{"api_version"}, v5["required"]); diff != "" {
+		t.Errorf("Unexpected required keys for v5 (-want +got):\n%s", diff)
+	}
+
+	distribution := definitions["AWSInstanceDistribution"].(map[string]interface{})["properties"].(map[string]interface{})
+	percentage := distribution["on_demand_percentage_above_base_capacity"].(map[string]interface{})
+	if percentage["minimum"] != 0.0 || percentage["maximum"] != 100.0 {
+		t.Errorf("Unexpected percentage constraints %v", percentage)
+	}
+
+	nodeSpec := definitions["NodeSpec"].(map[string]interface{})
+	if diff := cmp.Diff(map[string]interface{}{"required": []interface{}{"aws", "azure"}}, nodeSpec["not"]); diff != "" {
+		t.Errorf("Expected aws and azure to be mutually exclusive (-want +got):\n%s", diff)
+	}
+}
+
+// Test_Split tests splitting multi-document YAML and lists of definitions.
+func Test_Split(t *testing.T) {
+	testCases := []struct {
+		name          string
+		yaml          string
+		expectedNames []string
+		expectedLines []int
+		errorMatcher  func(error) bool
+	}{
+		{
+			name:          "single definition",
+			yaml:          "owner: acme\nname: Dev\n",
+			expectedNames: []string{"Dev"},
+			expectedLines: []int{1},
+		},
+		{
+			name:          "multiple documents",
+			yaml:          "---\nowner: acme\nname: Dev\n---\n# Staging\nowner: acme\nname: Staging\n---\n",
+			expectedNames: []string{"Dev", "Staging"},
+			expectedLines: []int{2, 6},
+		},
+		{
+			name:          "list of definitions",
+			yaml:          "- owner: acme\n  name: Dev\n\n- owner: acme\n  name: Prod\n  release_version: 12.0.0\n",
+			expectedNames: []string{"Dev", "Prod"},
+			expectedLines: []int{1, 4},
+		},
+		{
+			name:          "list and document",
+			yaml:          "- name: Dev\n- name: Staging\n---\nname: Prod\n",
+			expectedNames: []string{"Dev", "Staging", "Prod"},
+			expectedLines: []int{1, 2, 4},
+		},
+		{
+			name:          "empty",
+			yaml:          "# nothing here\n",
+			expectedNames: []string{},
+			expectedLines: []int{},
+		},
+		{
+			name:         "list of strings",
+			yaml:         "- Dev\n- Prod\n",
+			errorMatcher: IsInvalidDefinition,
+		},
+		{
+			name:         "flow style list",
+			yaml:         "[{name: Dev}, {name: Prod}]\n",
+			errorMatcher: IsInvalidDefinition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			documents, err := Split([]byte(tc.yaml))
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("Error not matching expectation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			names := []string{}
+			lines := []int{}
+			for i, d := range documents {
+				if d.Index != i {
+					t.Errorf("Unexpected index %d for document %d", d.Index, i)
+				}
+				names = append(names, d.Name)
+				lines = append(lines, d.Line)
+			}
+
+			if diff := cmp.Diff(tc.expectedNames, names); diff != "" {
+				t.Errorf("Names unequal (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedLines, lines); diff != "" {
+				t.Errorf("Lines unequal (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// Test_LintSplitPositions tests that problems in split definitions
+// refer to the lines in the original file.
+func Test_LintSplitPositions(t *testing.T) {
+	documents, err := Split([]byte("- owner: acme\n  name: Dev\n- owner: acme\n  nmae: Prod\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	problems := []string{}
+	for _, d := range documents {
+		for _, p := range Lint(d.Data).Problems {
+			problems = append(problems, p.String())
+		}
+	}
+
+	expected := []string{"4:3: error: unknown field 'nmae', did you mean 'name'?"}
+	if diff := cmp.Diff(expected, problems); diff != "" {
+		t.Errorf("Problems unequal (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,13 @@
+package clusterdefinition
+
+import "github.com/giantswarm/microerror"
+
+var invalidDefinitionError = &microerror.Error{
+	Kind: "invalidDefinitionError",
+	Desc: "The cluster definition has errors",
+}
+
+// IsInvalidDefinition asserts invalidDefinitionError.
+func IsInvalidDefinition(err error) bool {
+	return microerror.Cause(err) == invalidDefinitionError
+}
@@ -0,0 +1,342 @@
+package clusterdefinition
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/commands/types"
+)
+
+const (
+	apiVersionKey = "api_version"
+	apiVersionV5  = "v5"
+)
+
+var (
+	// v4OnlyKeys are top level keys only valid in v4 definitions,
+	// with the v5 equivalent as a hint.
+	v4OnlyKeys = map[string]string{
+		"availability_zones": "define availability zones per node pool in 'nodepools'",
+		"scaling":            "define scaling per node pool in 'nodepools'",
+		"workers":            "define worker nodes as 'nodepools'",
+	}
+
+	// v5OnlyKeys are top level keys only valid in v5 definitions.
+	v5OnlyKeys = []string{"labels", "master", "master_nodes", "nodepools"}
+
+	syntaxErrorRegexp   = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	typeErrorLineRegexp = regexp.MustCompile(`^line (\d+): (.*)$`)
+	fieldNotFoundRegexp = regexp.MustCompile(`^field (\S+) not found in type (\S+)$`)
+	cannotDecodeRegexp  = regexp.MustCompile("^cannot unmarshal !!(\\w+) `(.*)` into (\\S+)$")
+	keyAlreadySetRegexp = regexp.MustCompile(`^key "(.*)" already set in map$`)
+
+	// knownTypes maps the type names in YAML decoding errors to the
+	// types, so we can suggest field names.
+	knownTypes = map[string]reflect.Type{}
+)
+
+func init() {
+	for _, v := range []interface{}{
+		types.AvailabilityZonesDefinition{},
+		types.AWSInstanceDistribution{},
+		types.AWSSpecificDefinition{},
+		types.AzureSpecificDefinition{},
+		types.AzureSpotInstances{},
+		types.ClusterDefinitionV4{},
+		types.ClusterDefinitionV5{},
+		types.CPUDefinition{},
+		types.MasterDefinition{},
+		types.MasterNodes{},
+		types.MasterNodesAzure{},
+		types.MemoryDefinition{},
+		types.NodeDefinition{},
+		types.NodePoolDefinition{},
+		types.NodeSpec{},
+		types.ScalingDefinition{},
+		types.StorageDefinition{},
+	} {
+		t := reflect.TypeOf(v)
+		knownTypes[t.String()] = t
+	}
+}
+
+// Lint decodes the definition strictly and checks everything
+// which doesn't require knowledge of the installation.
+func Lint(data []byte) *Result {
+	r := &Result{}
+	lines := strings.Split(string(data), "\n")
+
+	topLevel := yaml.MapSlice{}
+	err := yaml.Unmarshal(data, &topLevel)
+	if err != nil {
+		r.addSyntaxError(err, lines)
+		return r
+	}
+	if len(topLevel) == 0 {
+		r.addError("", "the definition is empty")
+		return r
+	}
+
+	keys := map[string]interface{}{}
+	for _, item := range topLevel {
+		keys[fmt.Sprintf("%v", item.Key)] = item.Value
+	}
+
+	// Keys we already reported as belonging to the other version,
+	// so we don't report them as unknown again.
+	mismatched := map[string]bool{}
+
+	var def interface{}
+	if apiVersion, ok := keys[apiVersionKey]; ok {
+		if fmt.Sprintf("%v", apiVersion) != apiVersionV5 {
+			line, column := keyPosition(lines, apiVersionKey)
+			r.addPositionedError(line, column, "unsupported api_version '%v', the only supported value is '%s'", apiVersion, apiVersionV5)
+		}
+
+		for key, hint := range v4OnlyKeys {
+			if _, ok := keys[key]; ok {
+				mismatched[key] = true
+				line, column := keyPosition(lines, key)
+				r.addPositionedError(line, column, "'%s' is only valid in v4 definitions without api_version, %s", key, hint)
+			}
+		}
+
+		def = &types.ClusterDefinitionV5{}
+	} else {
+		for _, key := range v5OnlyKeys {
+			if _, ok := keys[key]; ok {
+				mismatched[key] = true
+				line, column := keyPosition(lines, key)
+				r.addPositionedError(line, column, "'%s' is only valid in v5 definitions, please add 'api_version: v5'", key)
+			}
+		}
+
+		def = &types.ClusterDefinitionV4{}
+	}
+
+	err = yaml.UnmarshalStrict(data, def)
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		for _, msg := range typeErr.Errors {
+			r.addTypeError(msg, lines, mismatched)
+		}
+	} else if err != nil {
+		r.addSyntaxError(err, lines)
+		return r
+	}
+
+	r.Definition = def
+	r.sortProblems()
+
+	switch d := def.(type) {
+	case *types.ClusterDefinitionV5:
+		lintV5(r, d)
+	case *types.ClusterDefinitionV4:
+		lintV4(r, d)
+	}
+
+	return r
+}
+
+func (r *Result) addPositionedError(line, column int, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, Problem{Line: line, Column: column, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+}
+
+// addSyntaxError adds a problem for YAML which can't be parsed at all.
+func (r *Result) addSyntaxError(err error, lines []string) {
+	msg := err.Error()
+	if m := syntaxErrorRegexp.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		r.addPositionedError(line, firstNonSpaceColumn(lines, line), "invalid YAML: %s", m[2])
+		return
+	}
+
+	r.addError("", "invalid YAML: %s", strings.TrimPrefix(msg, "yaml: "))
+}
+
+// addTypeError adds a problem for one of the errors reported by strict decoding,
+// which have the form 'line N: message'.
+func (r *Result) addTypeError(msg string, lines []string, mismatched map[string]bool) {
+	m := typeErrorLineRegexp.FindStringSubmatch(msg)
+	if m == nil {
+		r.addError("", "%s", msg)
+		return
+	}
+
+	line, _ := strconv.Atoi(m[1])
+	detail := m[2]
+
+	if f := fieldNotFoundRegexp.FindStringSubmatch(detail); f != nil {
+		field, typeName := f[1], f[2]
+		if mismatched[field] && (typeName == "types.ClusterDefinitionV4" || typeName == "types.ClusterDefinitionV5") {
+			return
+		}
+
+		message := fmt.Sprintf("unknown field '%s'", field)
+		if suggestion := suggestField(field, knownTypes[typeName]); suggestion != "" {
+			message += fmt.Sprintf(", did you mean '%s'?", suggestion)
+		}
+
+		r.addPositionedError(line, keyColumn(lines, line, field), "%s", message)
+		return
+	}
+
+	if c := cannotDecodeRegexp.FindStringSubmatch(detail); c != nil {
+		column := firstNonSpaceColumn(lines, line)
+		if line > 0 && line <= len(lines) {
+			if i := strings.Index(lines[line-1], c[2]); i >= 0 {
+				column = i + 1
+			}
+		}
+
+		r.addPositionedError(line, column, "invalid value '%s', expected %s", c[2], describeType(c[3]))
+		return
+	}
+
+	if k := keyAlreadySetRegexp.FindStringSubmatch(detail); k != nil {
+		r.addPositionedError(line, keyColumn(lines, line, k[1]), "duplicate key '%s'", k[1])
+		return
+	}
+
+	r.addPositionedError(line, firstNonSpaceColumn(lines, line), "%s", detail)
+}
+
+// sortProblems orders positioned problems by position, followed by
+// problems without position.
+func (r *Result) sortProblems() {
+	sort.SliceStable(r.Problems, func(i, j int) bool {
+		pi, pj := r.Problems[i], r.Problems[j]
+		if pi.Line == 0 || pj.Line == 0 {
+			return pi.Line != 0 && pj.Line == 0
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+}
+
+// keyPosition finds the line and column of a top level key.
+func keyPosition(lines []string, key string) (int, int) {
+	// Definitions taken from a list are indented.
+	indent := 0
+	for _, l := range lines {
+		trimmed := strings.TrimLeft(l, " ")
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			indent = len(l) - len(trimmed)
+			break
+		}
+	}
+
+	prefix := regexp.MustCompile(`^ {` + strconv.Itoa(indent) + `}` + regexp.QuoteMeta(key) + `\s*:`)
+	for i, l := range lines {
+		if prefix.MatchString(l) {
+			return i + 1, indent + 1
+		}
+	}
+
+	return 0, 0
+}
+
+// keyColumn returns the column of a key in the given line.
+func keyColumn(lines []string, line int, key string) int {
+	if line < 1 || line > len(lines) {
+		return 0
+	}
+
+	re := regexp.MustCompile(`(^|[\s{,-])` + regexp.QuoteMeta(key) + `\s*:`)
+	if loc := re.FindStringSubmatchIndex(lines[line-1]); loc != nil {
+		return loc[3] + 1
+	}
+
+	return firstNonSpaceColumn(lines, line)
+}
+
+func firstNonSpaceColumn(lines []string, line int) int {
+	if line < 1 || line > len(lines) {
+		return 0
+	}
+
+	l := lines[line-1]
+	return len(l) - len(strings.TrimLeft(l, " \t-")) + 1
+}
+
+// describeType turns Go type names from decoding errors into something
+// understandable for users.
+func describeType(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "int"), strings.HasPrefix(goType, "uint"), strings.HasPrefix(goType, "float"):
+		return "a number"
+	case goType == "bool":
+		return "true or false"
+	case goType == "string":
+		return "a string"
+	case strings.HasPrefix(goType, "[]"):
+		return "a list"
+	default:
+		return "a mapping"
+	}
+}
+
+// suggestField returns the YAML field name of t closest to the given
+// unknown field, if it is similar enough.
+func suggestField(field string, t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+
+	best := ""
+	bestDistance := 3
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		d := levenshtein(field, name)
+		if d < bestDistance {
+			best = name
+			bestDistance = d
+		}
+	}
+
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,225 @@
+package clusterdefinition
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/gsctl/commands/types"
+)
+
+const (
+	schemaDraft = "http://json-schema.org/draft-07/schema#"
+
+	// semverPattern matches release versions like 11.2.0 or 11.2.0-beta1.
+	semverPattern = `^v?[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`
+)
+
+var (
+	// fieldDescriptions documents the fields of the definition types,
+	// keyed by type name and YAML key.
+	fieldDescriptions = map[string]string{
+		"ClusterDefinitionV4.name":               "Cluster name.",
+		"ClusterDefinitionV4.owner":              "Name of the organization owning the cluster.",
+		"ClusterDefinitionV4.release_version":    "Release version to use. Defaults to the latest active release.",
+		"ClusterDefinitionV4.availability_zones": "Number of availability zones to spread worker nodes across (AWS only).",
+		"ClusterDefinitionV4.scaling":            "Autoscaling limits for the worker nodes.",
+		"ClusterDefinitionV4.workers":            "Worker node specification. Only the first item is used for all workers.",
+
+		"ClusterDefinitionV5.api_version":     "Must be 'v5' for clusters with node pools.",
+		"ClusterDefinitionV5.name":            "Cluster name.",
+		"ClusterDefinitionV5.owner":           "Name of the organization owning the cluster.",
+		"ClusterDefinitionV5.release_version": "Release version to use. Defaults to the latest active release.",
+		"ClusterDefinitionV5.master":          "Single master node placement. Deprecated in favour of 'master_nodes'.",
+		"ClusterDefinitionV5.master_nodes":    "Master node configuration, including high availability.",
+		"ClusterDefinitionV5.nodepools":       "Node pools to create along with the cluster.",
+		"ClusterDefinitionV5.labels":          "Cluster labels as key-value pairs.",
+
+		"MasterDefinition.availability_zone": "Availability zone of the master node.",
+
+		"MasterNodes.high_availability":  "Whether to run three master nodes instead of one.",
+		"MasterNodes.availability_zones": "Availability zones to place master nodes in.",
+		"MasterNodes.azure":              "Azure specific master node settings.",
+
+		"MasterNodesAzure.availability_zones_unspecified": "If true, master nodes are not placed in a specific availability zone.",
+
+		"NodePoolDefinition.name":               "Node pool name.",
+		"NodePoolDefinition.availability_zones": "Availability zones of the node pool, either as a number or as a list of zone names.",
+		"NodePoolDefinition.scaling":            "Autoscaling limits of the node pool.",
+		"NodePoolDefinition.node_spec":          "Provider specific node settings.",
+
+		"AvailabilityZonesDefinition.number": "Number of availability zones to pick automatically.",
+		"AvailabilityZonesDefinition.zones":  "Names of the availability zones to use.",
+
+		"ScalingDefinition.min": "Minimum number of worker nodes.",
+		"ScalingDefinition.max": "Maximum number of worker nodes.",
+
+		"NodeSpec.aws":   "AWS specific node settings.",
+		"NodeSpec.azure": "Azure specific node settings.",
+
+		"AWSSpecificDefinition.instance_type":            "EC2 instance type of the worker nodes.",
+		"AWSSpecificDefinition.use_alike_instance_types": "Whether to also use similar instance types, e. g. for spot instances.",
+		"AWSSpecificDefinition.instance_distribution":    "Distribution between on-demand and spot instances.",
+
+		"AWSInstanceDistribution.on_demand_base_capacity":                  "Number of on-demand instances to provision before using spot instances.",
+		"AWSInstanceDistribution.on_demand_percentage_above_base_capacity": "Percentage of on-demand instances above the base capacity. 0 means only spot instances.",
+
+		"AzureSpecificDefinition.vm_size":        "Azure VM size of the worker nodes.",
+		"AzureSpecificDefinition.spot_instances": "Spot instance settings.",
+
+		"AzureSpotInstances.enabled":   "Whether to use spot instances.",
+		"AzureSpotInstances.max_price": "Maximum price per hour. -1 means the on-demand price.",
+
+		"NodeDefinition.memory":  "Memory of each worker node.",
+		"NodeDefinition.cpu":     "CPU of each worker node.",
+		"NodeDefinition.storage": "Storage of each worker node.",
+		"NodeDefinition.labels":  "Node labels.",
+		"NodeDefinition.aws":     "AWS specific worker node settings.",
+		"NodeDefinition.azure":   "Azure specific worker node settings.",
+
+		"CPUDefinition.cores":       "Number of CPU cores.",
+		"MemoryDefinition.size_gb":  "Memory size in GB.",
+		"StorageDefinition.size_gb": "Storage size in GB.",
+	}
+
+	// fieldConstraints adds JSON Schema keywords to single fields.
+	fieldConstraints = map[string]map[string]interface{}{
+		"ClusterDefinitionV5.api_version":        {"enum": []string{apiVersionV5}},
+		"ClusterDefinitionV4.release_version":    {"pattern": semverPattern},
+		"ClusterDefinitionV5.release_version":    {"pattern": semverPattern},
+		"ClusterDefinitionV4.availability_zones": {"minimum": 1},
+
+		"AvailabilityZonesDefinition.number": {"minimum": 0},
+		"AvailabilityZonesDefinition.zones":  {"uniqueItems": true},
+		"MasterNodes.availability_zones":     {"uniqueItems": true},
+
+		"ScalingDefinition.min": {"minimum": 0},
+		"ScalingDefinition.max": {"minimum": 0},
+
+		"AWSInstanceDistribution.on_demand_base_capacity":                  {"minimum": 0},
+		"AWSInstanceDistribution.on_demand_percentage_above_base_capacity": {"minimum": 0, "maximum": 100},
+
+		"CPUDefinition.cores":       {"minimum": 0},
+		"MemoryDefinition.size_gb":  {"minimum": 0},
+		"StorageDefinition.size_gb": {"minimum": 0},
+	}
+
+	// mutuallyExclusive lists fields of a type which must not be set together.
+	mutuallyExclusive = map[string][]string{
+		"ClusterDefinitionV5":         {"master", "master_nodes"},
+		"AvailabilityZonesDefinition": {"number", "zones"},
+		"NodeSpec":                    {"aws", "azure"},
+	}
+)
+
+// Schema returns a JSON Schema describing cluster definitions in both
+// the v4 and v5 format, generated from the types in commands/types.
+func Schema() ([]byte, error) {
+	g := &schemaGenerator{definitions: map[string]interface{}{}}
+
+	v4 := g.ref(reflect.TypeOf(types.ClusterDefinitionV4{}))
+	v5 := g.ref(reflect.TypeOf(types.ClusterDefinitionV5{}))
+
+	// v5 definitions are told apart by the presence of api_version.
+	v5Def := g.definitions["ClusterDefinitionV5"].(map[string]interface{})
+	v5Def["required"] = []string{apiVersionKey}
+
+	schema := map[string]interface{}{
+		"$schema":     schemaDraft,
+		"title":       "gsctl cluster definition",
+		"description": "Cluster definition as used with 'gsctl create cluster --file'. Definitions with 'api_version: v5' create clusters with node pools, definitions without it use the v4 format.",
+		"type":        "object",
+		"if":          map[string]interface{}{"required": []string{apiVersionKey}},
+		"then":        v5,
+		"else":        v4,
+		"definitions": g.definitions,
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return data, nil
+}
+
+// schemaGenerator collects the definitions of all struct types reached.
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+// ref returns a reference to the definition of the struct type t,
+// generating the definition first if needed.
+func (g *schemaGenerator) ref(t reflect.Type) map[string]interface{} {
+	name := t.Name()
+	if _, ok := g.definitions[name]; !ok {
+		// Placeholder against recursion.
+		g.definitions[name] = nil
+		g.definitions[name] = g.object(t)
+	}
+
+	return map[string]interface{}{"$ref": "#/definitions/" + name}
+}
+
+func (g *schemaGenerator) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		property := g.value(field.Type)
+		id := t.Name() + "." + key
+		if description, ok := fieldDescriptions[id]; ok {
+			property["description"] = description
+		}
+		for keyword, value := range fieldConstraints[id] {
+			property[keyword] = value
+		}
+
+		properties[key] = property
+	}
+
+	object := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if keys, ok := mutuallyExclusive[t.Name()]; ok {
+		object["not"] = map[string]interface{}{"required": keys}
+	}
+
+	return object
+}
+
+func (g *schemaGenerator) value(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.String {
+			// Used for label values, where null removes a label.
+			return map[string]interface{}{"type": []string{"string", "null"}}
+		}
+		return g.value(t.Elem())
+	case reflect.Struct:
+		// References can't have sibling keywords in draft-07,
+		// so we wrap them.
+		return map[string]interface{}{"allOf": []interface{}{g.ref(t)}}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": g.value(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.value(t.Elem())}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
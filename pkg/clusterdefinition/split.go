@@ -0,0 +1,159 @@
+package clusterdefinition
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	documentSeparatorRegexp = regexp.MustCompile(`^---(\s.*)?$`)
+	listItemRegexp          = regexp.MustCompile(`^-(\s|$)`)
+)
+
+// Document is a single cluster definition taken from a file which
+// may contain several of them.
+type Document struct {
+	// Index is the position of the definition in the file, starting at 0.
+	Index int
+	// Line is the line the definition starts at in the file, starting at 1.
+	Line int
+	// Name is the cluster name given in the definition, if any.
+	Name string
+	// Data is the YAML of this definition. Lines belonging to other
+	// definitions are blanked, so that positions in problems found
+	// refer to the original file.
+	Data []byte
+}
+
+// Split splits YAML data containing several cluster definitions, either
+// as multiple YAML documents separated by '---' or as a list of
+// definitions, into single definitions. Empty documents are skipped.
+func Split(data []byte) ([]Document, error) {
+	lines := strings.Split(string(data), "\n")
+
+	documents := []Document{}
+	start := 0
+	for i := 0; i <= len(lines); i++ {
+		if i < len(lines) && !documentSeparatorRegexp.MatchString(lines[i]) {
+			continue
+		}
+
+		docs, err := splitDocument(lines, start, i)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		for _, d := range docs {
+			d.Index = len(documents)
+			documents = append(documents, d)
+		}
+
+		start = i + 1
+	}
+
+	return documents, nil
+}
+
+// splitDocument returns the definitions in the YAML document spanning
+// the lines from start up to, but not including, end.
+func splitDocument(lines []string, start, end int) ([]Document, error) {
+	content := blankOut(lines, start, end)
+
+	var decoded interface{}
+	err := yaml.Unmarshal([]byte(content), &decoded)
+	if err != nil {
+		// Leave reporting syntax errors to Lint.
+		return []Document{newDocument(lines, start, end, content)}, nil
+	}
+
+	items, isList := decoded.([]interface{})
+	if decoded == nil {
+		return nil, nil
+	} else if !isList {
+		return []Document{newDocument(lines, start, end, content)}, nil
+	}
+
+	// Each list item starting with '- ' in the first column becomes a
+	// definition. Replacing the dash keeps the columns intact.
+	itemStarts := []int{}
+	for i := start; i < end; i++ {
+		if listItemRegexp.MatchString(lines[i]) {
+			itemStarts = append(itemStarts, i)
+		}
+	}
+	if len(itemStarts) != len(items) {
+		return nil, microerror.Maskf(invalidDefinitionError, "a list of cluster definitions must have one item per definition, starting with '- ' in the first column")
+	}
+
+	documents := []Document{}
+	for n, itemStart := range itemStarts {
+		if _, ok := items[n].(map[interface{}]interface{}); !ok {
+			return nil, microerror.Maskf(invalidDefinitionError, "%d: list item is not a cluster definition", itemStart+1)
+		}
+
+		itemEnd := end
+		if n+1 < len(itemStarts) {
+			itemEnd = itemStarts[n+1]
+		}
+
+		itemLines := make([]string, len(lines))
+		copy(itemLines, lines)
+		itemLines[itemStart] = " " + itemLines[itemStart][1:]
+
+		documents = append(documents, newDocument(itemLines, itemStart, itemEnd, blankOut(itemLines, itemStart, itemEnd)))
+	}
+
+	return documents, nil
+}
+
+// LintDocuments lints all documents and returns an invalidDefinitionError
+// listing the errors found in any of them, or nil if there are none.
+func LintDocuments(documents []Document) error {
+	lines := []string{}
+	for _, d := range documents {
+		for _, p := range Lint(d.Data).Errors() {
+			lines = append(lines, fmt.Sprintf("definition %d: %s", d.Index+1, p.String()))
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return microerror.Maskf(invalidDefinitionError, "%s", strings.Join(lines, "\n"))
+}
+
+func newDocument(lines []string, start, end int, content string) Document {
+	d := Document{Line: start + 1, Data: []byte(content)}
+
+	// Skip leading blank lines and comments.
+	for i := start; i < end; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			d.Line = i + 1
+			break
+		}
+	}
+
+	named := struct {
+		Name string `yaml:"name"`
+	}{}
+	_ = yaml.Unmarshal(d.Data, &named)
+	d.Name = named.Name
+
+	return d
+}
+
+// blankOut returns the lines, with all lines outside the range from
+// start up to end replaced by empty lines.
+func blankOut(lines []string, start, end int) string {
+	result := make([]string, len(lines))
+	for i := start; i < end && i < len(lines); i++ {
+		result[i] = lines[i]
+	}
+
+	return strings.Join(result, "\n")
+}
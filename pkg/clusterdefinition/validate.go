@@ -0,0 +1,296 @@
+package clusterdefinition
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/giantswarm/gsctl/commands/types"
+	"github.com/giantswarm/gsctl/limits"
+	"github.com/giantswarm/gsctl/nodespec"
+)
+
+// Validate lints the definition and checks it against the installation.
+// If installation is nil, only Lint checks are performed.
+func Validate(data []byte, installation *Installation) *Result {
+	r := Lint(data)
+	r.Check(installation)
+
+	return r
+}
+
+// ReleaseVersion returns the release version set in the definition, if any.
+func (r *Result) ReleaseVersion() string {
+	switch d := r.Definition.(type) {
+	case *types.ClusterDefinitionV5:
+		return d.ReleaseVersion
+	case *types.ClusterDefinitionV4:
+		return d.ReleaseVersion
+	}
+
+	return ""
+}
+
+// Check adds problems found checking the linted definition against the installation.
+func (r *Result) Check(installation *Installation) {
+	if r.Definition == nil || installation == nil {
+		return
+	}
+
+	switch d := r.Definition.(type) {
+	case *types.ClusterDefinitionV5:
+		checkRelease(r, d.ReleaseVersion, installation)
+		for i, np := range d.NodePools {
+			if np == nil {
+				continue
+			}
+			path := fmt.Sprintf("nodepools[%d]", i)
+			if np.AvailabilityZones != nil {
+				checkZones(r, path+".availability_zones", np.AvailabilityZones.Number, np.AvailabilityZones.Zones, installation)
+			}
+			if np.Scaling != nil {
+				checkMaxWorkers(r, path+".scaling", np.Scaling.Max, installation)
+			}
+			if np.NodeSpec != nil && np.NodeSpec.AWS != nil {
+				checkInstanceTypeOption(r, path+".node_spec.aws.instance_type", np.NodeSpec.AWS.InstanceType, installation)
+			}
+			if np.NodeSpec != nil && np.NodeSpec.Azure != nil {
+				checkVMSizeOption(r, path+".node_spec.azure.vm_size", np.NodeSpec.Azure.VMSize, installation)
+			}
+		}
+		if d.MasterNodes != nil && len(d.MasterNodes.AvailabilityZones) > 0 {
+			checkZones(r, "master_nodes.availability_zones", 0, d.MasterNodes.AvailabilityZones, installation)
+		}
+		if d.Master != nil && d.Master.AvailabilityZone != "" {
+			checkZones(r, "master.availability_zone", 0, []string{d.Master.AvailabilityZone}, installation)
+		}
+	case *types.ClusterDefinitionV4:
+		checkRelease(r, d.ReleaseVersion, installation)
+		checkZones(r, "availability_zones", int64(d.AvailabilityZones), nil, installation)
+		checkMaxWorkers(r, "scaling", d.Scaling.Max, installation)
+		for i, w := range d.Workers {
+			path := fmt.Sprintf("workers[%d]", i)
+			checkInstanceTypeOption(r, path+".aws.instance_type", w.AWS.InstanceType, installation)
+			checkVMSizeOption(r, path+".azure.vm_size", w.Azure.VMSize, installation)
+		}
+	}
+}
+
+// lintV5 checks a v5 definition for problems we can detect offline.
+func lintV5(r *Result, d *types.ClusterDefinitionV5) {
+	checkReleaseFormat(r, d.ReleaseVersion)
+
+	if d.Master != nil && d.MasterNodes != nil {
+		r.addError("master", "'master' and 'master_nodes' cannot be used together, please use 'master_nodes'")
+	}
+
+	for i, np := range d.NodePools {
+		path := fmt.Sprintf("nodepools[%d]", i)
+		if np == nil {
+			r.addError(path, "the node pool definition is empty")
+			continue
+		}
+
+		if np.AvailabilityZones != nil {
+			azPath := path + ".availability_zones"
+			if np.AvailabilityZones.Number != 0 && len(np.AvailabilityZones.Zones) > 0 {
+				r.addError(azPath, "please specify either 'number' or 'zones', but not both")
+			}
+			if np.AvailabilityZones.Number < 0 {
+				r.addError(azPath+".number", "the number of availability zones must not be negative")
+			}
+			checkDuplicateZones(r, azPath+".zones", np.AvailabilityZones.Zones)
+		}
+
+		if np.Scaling != nil {
+			checkScaling(r, path+".scaling", np.Scaling.Min, np.Scaling.Max)
+		}
+
+		if np.NodeSpec != nil {
+			if np.NodeSpec.AWS != nil && np.NodeSpec.Azure != nil {
+				r.addError(path+".node_spec", "please specify either 'aws' or 'azure', but not both")
+			}
+			if np.NodeSpec.AWS != nil {
+				checkInstanceType(r, path+".node_spec.aws.instance_type", np.NodeSpec.AWS.InstanceType)
+				checkInstanceDistribution(r, path+".node_spec.aws.instance_distribution", np.NodeSpec.AWS.InstanceDistribution)
+			}
+			if np.NodeSpec.Azure != nil {
+				checkVMSize(r, path+".node_spec.azure.vm_size", np.NodeSpec.Azure.VMSize)
+			}
+		}
+	}
+}
+
+// lintV4 checks a v4 definition for problems we can detect offline.
+func lintV4(r *Result, d *types.ClusterDefinitionV4) {
+	checkReleaseFormat(r, d.ReleaseVersion)
+
+	if d.AvailabilityZones < 0 {
+		r.addError("availability_zones", "the number of availability zones must not be negative")
+	}
+
+	checkScaling(r, "scaling", d.Scaling.Min, d.Scaling.Max)
+
+	for i, w := range d.Workers {
+		path := fmt.Sprintf("workers[%d]", i)
+
+		if w.CPU.Cores != 0 && w.CPU.Cores < limits.MinimumWorkerNumCPUs {
+			r.addError(path+".cpu.cores", "a worker node must have at least %d CPU core(s)", limits.MinimumWorkerNumCPUs)
+		}
+		if w.Memory.SizeGB != 0 && w.Memory.SizeGB < limits.MinimumWorkerMemorySizeGB {
+			r.addError(path+".memory.size_gb", "a worker node must have at least %v GB of memory", limits.MinimumWorkerMemorySizeGB)
+		}
+		if w.Storage.SizeGB != 0 && w.Storage.SizeGB < limits.MinimumWorkerStorageSizeGB {
+			r.addError(path+".storage.size_gb", "a worker node must have at least %v GB of storage", limits.MinimumWorkerStorageSizeGB)
+		}
+
+		checkInstanceType(r, path+".aws.instance_type", w.AWS.InstanceType)
+		checkVMSize(r, path+".azure.vm_size", w.Azure.VMSize)
+	}
+}
+
+func checkReleaseFormat(r *Result, version string) {
+	if version == "" {
+		return
+	}
+
+	_, err := semver.NewVersion(version)
+	if err != nil {
+		r.addError("release_version", "'%s' is not a valid release version", version)
+	}
+}
+
+func checkRelease(r *Result, version string, installation *Installation) {
+	if version == "" || installation.ActiveReleases == nil {
+		return
+	}
+
+	if !contains(installation.ActiveReleases, version) {
+		r.addError("release_version", "release %s is not an active release in this installation, see 'gsctl list releases'", version)
+	}
+}
+
+func checkScaling(r *Result, path string, min, max int64) {
+	if min < int64(limits.MinimumNumWorkers) {
+		r.addError(path+".min", "the minimum number of worker nodes must be at least %d", limits.MinimumNumWorkers)
+	}
+	if max < 0 {
+		r.addError(path+".max", "the maximum number of worker nodes must not be negative")
+	}
+	if max != 0 && min > max {
+		r.addError(path, "min (%d) must not be greater than max (%d)", min, max)
+	}
+}
+
+func checkMaxWorkers(r *Result, path string, max int64, installation *Installation) {
+	if installation.MaxWorkers > 0 && max > installation.MaxWorkers {
+		r.addError(path+".max", "the maximum number of worker nodes in this installation is %d", installation.MaxWorkers)
+	}
+}
+
+func checkDuplicateZones(r *Result, path string, zones []string) {
+	seen := map[string]bool{}
+	for _, z := range zones {
+		if seen[z] {
+			r.addError(path, "availability zone '%s' is listed more than once", z)
+		}
+		seen[z] = true
+	}
+}
+
+func checkZones(r *Result, path string, number int64, zones []string, installation *Installation) {
+	max := installation.MaxAvailabilityZones
+	if max > 0 && number > max {
+		r.addError(path, "this installation supports at most %d availability zone(s)", max)
+	}
+	if max > 0 && int64(len(zones)) > max {
+		r.addError(path, "this installation supports at most %d availability zone(s)", max)
+	}
+
+	if len(installation.AvailabilityZones) == 0 {
+		return
+	}
+	for _, z := range zones {
+		if !contains(installation.AvailabilityZones, z) {
+			r.addError(path, "availability zone '%s' is not available in this installation, available zones are %v", z, installation.AvailabilityZones)
+		}
+	}
+}
+
+func checkInstanceDistribution(r *Result, path string, d *types.AWSInstanceDistribution) {
+	if d == nil {
+		return
+	}
+
+	if d.OnDemandBaseCapacity < 0 {
+		r.addError(path+".on_demand_base_capacity", "must not be negative")
+	}
+	if d.OnDemandPercentageAboveBaseCapacity < 0 || d.OnDemandPercentageAboveBaseCapacity > 100 {
+		r.addError(path+".on_demand_percentage_above_base_capacity", "must be between 0 and 100")
+	}
+}
+
+// checkInstanceType warns about instance types gsctl doesn't know. This is
+// only a warning, as the list of instance types built into gsctl can be outdated.
+func checkInstanceType(r *Result, path string, instanceType string) {
+	if instanceType == "" {
+		return
+	}
+
+	awsInfo, err := nodespec.NewAWS()
+	if err != nil {
+		return
+	}
+
+	_, err = awsInfo.GetInstanceTypeDetails(instanceType)
+	if nodespec.IsInstanceTypeNotFoundErr(err) {
+		r.addWarning(path, "unknown AWS instance type '%s'", instanceType)
+	}
+}
+
+// checkVMSize warns about Azure VM sizes gsctl doesn't know.
+func checkVMSize(r *Result, path string, vmSize string) {
+	if vmSize == "" {
+		return
+	}
+
+	azureInfo, err := nodespec.NewAzureProvider()
+	if err != nil {
+		return
+	}
+
+	_, err = azureInfo.GetVMSizeDetails(vmSize)
+	if nodespec.IsVMSizeNotFoundErr(err) {
+		r.addWarning(path, "unknown Azure VM size '%s'", vmSize)
+	}
+}
+
+func checkInstanceTypeOption(r *Result, path string, instanceType string, installation *Installation) {
+	if instanceType == "" || len(installation.InstanceTypes) == 0 {
+		return
+	}
+
+	if !contains(installation.InstanceTypes, instanceType) {
+		r.addError(path, "instance type '%s' is not available in this installation", instanceType)
+	}
+}
+
+func checkVMSizeOption(r *Result, path string, vmSize string, installation *Installation) {
+	if vmSize == "" || len(installation.VMSizes) == 0 {
+		return
+	}
+
+	if !contains(installation.VMSizes, vmSize) {
+		r.addError(path, "VM size '%s' is not available in this installation", vmSize)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,456 @@
+// Package configedit reads and changes single values of the gsctl
+// configuration, and exports and imports endpoint definitions.
+//
+// Values are addressed by keys like 'selected_endpoint' or
+// 'endpoints.<alias or URL>.<field>'. All changes are validated before
+// they get written via the gscliauth config package, holding the config
+// file lock of the ssotoken package. The web UI URL is stored in the
+// settings file of the webui package.
+package configedit
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/pkg/provider"
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+// Top-level configuration keys.
+const (
+	KeySelectedEndpoint = "selected_endpoint"
+	KeyLastVersionCheck = "last_version_check"
+)
+
+// Fields of an endpoint, used as the last part of endpoint keys.
+const (
+	FieldAlias        = "alias"
+	FieldEmail        = "email"
+	FieldProvider     = "provider"
+	FieldScheme       = "auth_scheme"
+	FieldToken        = "token"
+	FieldRefreshToken = "refresh_token"
+	FieldWebUIURL     = "webui_url"
+)
+
+const (
+	// Redacted replaces secrets in all output.
+	Redacted = "REDACTED"
+
+	endpointKeyPrefix = "endpoints."
+)
+
+var aliasRegexp = regexp.MustCompile(`^[A-Za-z0-9][-A-Za-z0-9_]*$`)
+
+// keySpec defines what can be done with a key.
+type keySpec struct {
+	settable   bool
+	unsettable bool
+}
+
+var topLevelKeys = map[string]keySpec{
+	KeySelectedEndpoint: {settable: true, unsettable: true},
+	KeyLastVersionCheck: {},
+}
+
+var endpointFields = map[string]keySpec{
+	FieldAlias:        {settable: true, unsettable: true},
+	FieldEmail:        {},
+	FieldProvider:     {settable: true},
+	FieldScheme:       {},
+	FieldToken:        {},
+	FieldRefreshToken: {},
+	FieldWebUIURL:     {settable: true, unsettable: true},
+}
+
+// Key is a parsed configuration key.
+type Key struct {
+	// Endpoint is the normalized URL of the endpoint the key refers to.
+	// It is empty for top-level keys.
+	Endpoint string
+
+	// Name is the name of the top-level key or of the endpoint field.
+	Name string
+}
+
+func (k Key) spec() keySpec {
+	if k.Endpoint == "" {
+		return topLevelKeys[k.Name]
+	}
+	return endpointFields[k.Name]
+}
+
+// String returns the key in the form used on the command line.
+func (k Key) String() string {
+	if k.Endpoint == "" {
+		return k.Name
+	}
+	return endpointKeyPrefix + k.Endpoint + "." + k.Name
+}
+
+// ParseKey parses a key like 'selected_endpoint' or 'endpoints.<alias or URL>.<field>'.
+// The endpoint must be defined in the configuration.
+func ParseKey(key string) (Key, error) {
+	if _, ok := topLevelKeys[key]; ok {
+		return Key{Name: key}, nil
+	}
+
+	if !strings.HasPrefix(key, endpointKeyPrefix) {
+		return Key{}, microerror.Maskf(invalidKeyError, "unknown key %q", key)
+	}
+
+	rest := strings.TrimPrefix(key, endpointKeyPrefix)
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 {
+		return Key{}, microerror.Maskf(invalidKeyError, "key %q must have the form 'endpoints.<alias or URL>.<field>'", key)
+	}
+
+	field := rest[i+1:]
+	if _, ok := endpointFields[field]; !ok {
+		return Key{}, microerror.Maskf(invalidKeyError, "unknown endpoint field %q", field)
+	}
+
+	endpoint, err := ResolveEndpoint(rest[:i])
+	if err != nil {
+		return Key{}, microerror.Mask(err)
+	}
+
+	return Key{Endpoint: endpoint, Name: field}, nil
+}
+
+// ResolveEndpoint returns the URL of an endpoint in the configuration,
+// given either its alias or its URL.
+func ResolveEndpoint(aliasOrURL string) (string, error) {
+	if aliasOrURL == "" {
+		return "", microerror.Maskf(endpointNotFoundError, "no endpoint given")
+	}
+
+	if config.Config.HasEndpointAlias(aliasOrURL) {
+		endpoint, err := config.Config.EndpointByAlias(aliasOrURL)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+		return endpoint, nil
+	}
+
+	endpoint, err := normalizeEndpoint(aliasOrURL)
+	if err == nil && config.Config.EndpointConfig(endpoint) != nil {
+		return endpoint, nil
+	}
+
+	return "", microerror.Maskf(endpointNotFoundError, "there is no endpoint with alias or URL %q", aliasOrURL)
+}
+
+// Get returns the value of a key. Tokens are redacted.
+func Get(key string) (string, error) {
+	k, err := ParseKey(key)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if k.Endpoint == "" {
+		switch k.Name {
+		case KeySelectedEndpoint:
+			return config.Config.SelectedEndpoint, nil
+		case KeyLastVersionCheck:
+			if config.Config.LastVersionCheck.IsZero() {
+				return "", nil
+			}
+			return config.Config.LastVersionCheck.Format(time.RFC3339), nil
+		}
+	}
+
+	ec := config.Config.EndpointConfig(k.Endpoint)
+
+	switch k.Name {
+	case FieldAlias:
+		return ec.Alias, nil
+	case FieldEmail:
+		return ec.Email, nil
+	case FieldProvider:
+		return ec.Provider, nil
+	case FieldScheme:
+		return ec.Scheme, nil
+	case FieldToken:
+		return redact(ec.Token), nil
+	case FieldRefreshToken:
+		return redact(ec.RefreshToken), nil
+	case FieldWebUIURL:
+		return webui.ConfiguredBaseURL(k.Endpoint), nil
+	}
+
+	return "", nil
+}
+
+// Set validates and stores the value of a key.
+func Set(key, value string) error {
+	k, err := ParseKey(key)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if !k.spec().settable {
+		return microerror.Maskf(readOnlyKeyError, "the key %q cannot be set", key)
+	}
+	if value == "" {
+		return microerror.Maskf(invalidValueError, "the value must not be empty")
+	}
+
+	var update func() error
+
+	switch k.Name {
+	case KeySelectedEndpoint:
+		endpoint, err := ResolveEndpoint(value)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = ssotoken.UpdateConfig(func() error {
+			return config.Config.SelectEndpoint(endpoint)
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+
+	case FieldAlias:
+		err = validateAlias(k.Endpoint, value)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		update = func() error {
+			config.Config.EndpointConfig(k.Endpoint).Alias = value
+			return nil
+		}
+
+	case FieldProvider:
+		ec := config.Config.EndpointConfig(k.Endpoint)
+		err = validateProvider(ec.Provider, value)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		update = func() error {
+			ec.Provider = value
+			if k.Endpoint == config.Config.SelectedEndpoint {
+				config.Config.Provider = value
+			}
+			return nil
+		}
+
+	case FieldWebUIURL:
+		err = webui.StoreBaseURL(k.Endpoint, value)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err = ssotoken.UpdateConfig(update)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Unset removes the value of a key.
+func Unset(key string) error {
+	k, err := ParseKey(key)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if !k.spec().unsettable {
+		return microerror.Maskf(readOnlyKeyError, "the key %q cannot be unset", key)
+	}
+
+	var update func() error
+
+	switch k.Name {
+	case KeySelectedEndpoint:
+		update = func() error {
+			config.Config.SelectedEndpoint = ""
+			config.Config.Email = ""
+			config.Config.Provider = ""
+			config.Config.RefreshToken = ""
+			config.Config.Scheme = ""
+			config.Config.Token = ""
+			return nil
+		}
+
+	case FieldAlias:
+		update = func() error {
+			config.Config.EndpointConfig(k.Endpoint).Alias = ""
+			return nil
+		}
+
+	case FieldWebUIURL:
+		err = webui.StoreBaseURL(k.Endpoint, "")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err = ssotoken.UpdateConfig(update)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// RenameEndpointAlias replaces the alias of an endpoint and returns the endpoint URL.
+func RenameEndpointAlias(oldAlias, newAlias string) (string, error) {
+	if oldAlias == "" || !config.Config.HasEndpointAlias(oldAlias) {
+		return "", microerror.Maskf(endpointNotFoundError, "there is no endpoint with alias %q", oldAlias)
+	}
+
+	endpoint, err := config.Config.EndpointByAlias(oldAlias)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	err = validateAlias(endpoint, newAlias)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	err = ssotoken.UpdateConfig(func() error {
+		config.Config.EndpointConfig(endpoint).Alias = newAlias
+		return nil
+	})
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return endpoint, nil
+}
+
+// viewEndpoint is the representation of an endpoint in View.
+type viewEndpoint struct {
+	Alias        string `yaml:"alias,omitempty"`
+	Email        string `yaml:"email,omitempty"`
+	Provider     string `yaml:"provider,omitempty"`
+	Scheme       string `yaml:"auth_scheme,omitempty"`
+	Token        string `yaml:"token,omitempty"`
+	RefreshToken string `yaml:"refresh_token,omitempty"`
+	WebUIURL     string `yaml:"webui_url,omitempty"`
+}
+
+// View returns the complete configuration as YAML, with tokens redacted.
+func View() ([]byte, error) {
+	v := struct {
+		SelectedEndpoint string                  `yaml:"selected_endpoint"`
+		LastVersionCheck string                  `yaml:"last_version_check,omitempty"`
+		Updated          string                  `yaml:"updated,omitempty"`
+		Endpoints        map[string]viewEndpoint `yaml:"endpoints"`
+	}{
+		SelectedEndpoint: config.Config.SelectedEndpoint,
+		Updated:          config.Config.Updated,
+		Endpoints:        map[string]viewEndpoint{},
+	}
+
+	if !config.Config.LastVersionCheck.IsZero() {
+		v.LastVersionCheck = config.Config.LastVersionCheck.Format(time.RFC3339)
+	}
+
+	for _, endpoint := range config.Config.Endpoints() {
+		ec := config.Config.EndpointConfig(endpoint)
+		v.Endpoints[endpoint] = viewEndpoint{
+			Alias:        ec.Alias,
+			Email:        ec.Email,
+			Provider:     ec.Provider,
+			Scheme:       ec.Scheme,
+			Token:        redact(ec.Token),
+			RefreshToken: redact(ec.RefreshToken),
+			WebUIURL:     webui.ConfiguredBaseURL(endpoint),
+		}
+	}
+
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return out, nil
+}
+
+// validateAlias checks the format of an alias and that it is
+// not used for an endpoint other than the given one.
+func validateAlias(endpoint, alias string) error {
+	if !aliasRegexp.MatchString(alias) {
+		return microerror.Maskf(invalidAliasError, "alias %q must only contain letters, digits, '-' and '_', and must start with a letter or digit", alias)
+	}
+
+	if config.Config.HasEndpointAlias(alias) {
+		aliasedEndpoint, err := config.Config.EndpointByAlias(alias)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if aliasedEndpoint != endpoint {
+			return microerror.Maskf(aliasInUseError, "alias %q is already used for endpoint %s", alias, aliasedEndpoint)
+		}
+	}
+
+	return nil
+}
+
+// validateProvider checks a provider name. As in gscliauth, a provider
+// that has been set once cannot be changed.
+func validateProvider(current, value string) error {
+	switch value {
+	case provider.AWS, provider.Azure, provider.KVM:
+	default:
+		return microerror.Maskf(invalidValueError, "provider must be one of %s, got %q", strings.Join(providers(), ", "), value)
+	}
+
+	if current != "" && current != value {
+		return microerror.Maskf(providerImmutableError, "the provider is already set to %q", current)
+	}
+
+	return nil
+}
+
+func providers() []string {
+	p := []string{provider.AWS, provider.Azure, provider.KVM}
+	sort.Strings(p)
+	return p
+}
+
+// normalizeEndpoint normalizes an endpoint URL the same way gscliauth does:
+// lowercase, with https as the default scheme, without path.
+func normalizeEndpoint(endpoint string) (string, error) {
+	endpoint = strings.ToLower(endpoint)
+	if !strings.HasPrefix(endpoint, "https://") && !strings.HasPrefix(endpoint, "http://") {
+		endpoint = "https://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	if u.Host == "" {
+		return "", microerror.Maskf(invalidValueError, "%q is not a valid endpoint URL", endpoint)
+	}
+
+	return u.Scheme + "://" + u.Host, nil
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return Redacted
+}
@@ -0,0 +1,184 @@
+package configedit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+const configYAML = `last_version_check: 0001-01-01T00:00:00Z
+updated: 2020-06-01T10:00:00+02:00
+endpoints:
+  https://api.foo.example.com:
+    email: email@example.com
+    token: some-token
+    refresh_token: some-refresh-token
+    auth_scheme: Bearer
+    provider: aws
+    alias: foo
+  https://api.bar.example.com:
+    email: email@example.com
+    token: other-token
+    provider: azure
+selected_endpoint: https://api.foo.example.com
+`
+
+func tempConfig(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, configYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fs
+}
+
+// Test_ParseKey tests parsing keys with endpoint aliases and URLs.
+func Test_ParseKey(t *testing.T) {
+	tempConfig(t)
+
+	var testCases = []struct {
+		key          string
+		expected     Key
+		errorMatcher func(error) bool
+	}{
+		{key: "selected_endpoint", expected: Key{Name: KeySelectedEndpoint}},
+		{key: "endpoints.foo.alias", expected: Key{Endpoint: "https://api.foo.example.com", Name: FieldAlias}},
+		{key: "endpoints.https://api.bar.example.com.provider", expected: Key{Endpoint: "https://api.bar.example.com", Name: FieldProvider}},
+		{key: "endpoints.API.BAR.example.com.webui_url", expected: Key{Endpoint: "https://api.bar.example.com", Name: FieldWebUIURL}},
+		{key: "endpoints.foo.password", errorMatcher: IsInvalidKey},
+		{key: "endpoints.alias", errorMatcher: IsInvalidKey},
+		{key: "selected", errorMatcher: IsInvalidKey},
+		{key: "endpoints.baz.alias", errorMatcher: IsEndpointNotFound},
+	}
+
+	for i, tc := range testCases {
+		k, err := ParseKey(tc.key)
+		if tc.errorMatcher != nil {
+			if !tc.errorMatcher(err) {
+				t.Errorf("Case %d - unexpected error %v", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Case %d - unexpected error %v", i, err)
+		}
+		if k != tc.expected {
+			t.Errorf("Case %d - expected %#v, got %#v", i, tc.expected, k)
+		}
+	}
+}
+
+// Test_SetGetUnset tests changing values, including the validation.
+func Test_SetGetUnset(t *testing.T) {
+	fs := tempConfig(t)
+
+	value, err := Get("endpoints.foo.token")
+	if err != nil || value != Redacted {
+		t.Errorf("Expected redacted token, got %q, %v", value, err)
+	}
+
+	err = Set("endpoints.https://api.bar.example.com.alias", "bar")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	err = Set("selected_endpoint", "bar")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	err = Set("endpoints.bar.webui_url", "https://console.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	errorCases := []struct {
+		key          string
+		value        string
+		errorMatcher func(error) bool
+	}{
+		{"endpoints.bar.alias", "foo", IsAliasInUse},
+		{"endpoints.bar.alias", "b a r", IsInvalidAlias},
+		{"endpoints.bar.provider", "kvm", IsProviderImmutable},
+		{"endpoints.bar.provider", "gcp", IsInvalidValue},
+		{"endpoints.bar.token", "secret", IsReadOnlyKey},
+		{"endpoints.bar.webui_url", "console", webui.IsInvalidBaseURL},
+		{"selected_endpoint", "baz", IsEndpointNotFound},
+	}
+	for i, tc := range errorCases {
+		err = Set(tc.key, tc.value)
+		if !tc.errorMatcher(err) {
+			t.Errorf("Case %d - unexpected error %v", i, err)
+		}
+	}
+
+	// Changes must have been written to the config file.
+	err = config.Initialize(fs, config.ConfigDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Config.SelectedEndpoint != "https://api.bar.example.com" {
+		t.Errorf("Expected bar to be selected, got %q", config.Config.SelectedEndpoint)
+	}
+	value, _ = Get("endpoints.bar.webui_url")
+	if value != "https://console.example.com" {
+		t.Errorf("Unexpected web UI URL %q", value)
+	}
+
+	err = Unset("endpoints.bar.alias")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if config.Config.HasEndpointAlias("bar") {
+		t.Error("Expected alias to be removed")
+	}
+	err = Unset("endpoints.foo.provider")
+	if !IsReadOnlyKey(err) {
+		t.Errorf("Expected read-only key error, got %v", err)
+	}
+}
+
+// Test_RenameEndpointAlias tests renaming an alias.
+func Test_RenameEndpointAlias(t *testing.T) {
+	tempConfig(t)
+
+	endpoint, err := RenameEndpointAlias("foo", "production")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if endpoint != "https://api.foo.example.com" {
+		t.Errorf("Unexpected endpoint %q", endpoint)
+	}
+
+	value, _ := Get("endpoints.production.alias")
+	if value != "production" {
+		t.Errorf("Expected alias production, got %q", value)
+	}
+
+	_, err = RenameEndpointAlias("foo", "bar")
+	if !IsEndpointNotFound(err) {
+		t.Errorf("Expected endpoint not found error, got %v", err)
+	}
+}
+
+// Test_View tests that secrets are not part of the output.
+func Test_View(t *testing.T) {
+	tempConfig(t)
+
+	out, err := View()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	s := string(out)
+	if strings.Contains(s, "some-token") || strings.Contains(s, "other-token") || strings.Contains(s, "some-refresh-token") {
+		t.Errorf("Output contains secrets:\n%s", s)
+	}
+	if !strings.Contains(s, "refresh_token: "+Redacted) || !strings.Contains(s, "alias: foo") {
+		t.Errorf("Unexpected output:\n%s", s)
+	}
+}
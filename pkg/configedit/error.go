@@ -0,0 +1,83 @@
+package configedit
+
+import "github.com/giantswarm/microerror"
+
+var invalidKeyError = &microerror.Error{
+	Kind: "invalidKeyError",
+	Desc: "The configuration key is not valid",
+}
+
+// IsInvalidKey asserts invalidKeyError.
+func IsInvalidKey(err error) bool {
+	return microerror.Cause(err) == invalidKeyError
+}
+
+var readOnlyKeyError = &microerror.Error{
+	Kind: "readOnlyKeyError",
+	Desc: "The configuration key cannot be changed",
+}
+
+// IsReadOnlyKey asserts readOnlyKeyError.
+func IsReadOnlyKey(err error) bool {
+	return microerror.Cause(err) == readOnlyKeyError
+}
+
+var invalidValueError = &microerror.Error{
+	Kind: "invalidValueError",
+	Desc: "The configuration value is not valid",
+}
+
+// IsInvalidValue asserts invalidValueError.
+func IsInvalidValue(err error) bool {
+	return microerror.Cause(err) == invalidValueError
+}
+
+var endpointNotFoundError = &microerror.Error{
+	Kind: "endpointNotFoundError",
+	Desc: "The endpoint is not defined in the configuration",
+}
+
+// IsEndpointNotFound asserts endpointNotFoundError.
+func IsEndpointNotFound(err error) bool {
+	return microerror.Cause(err) == endpointNotFoundError
+}
+
+var invalidAliasError = &microerror.Error{
+	Kind: "invalidAliasError",
+	Desc: "The endpoint alias is not valid",
+}
+
+// IsInvalidAlias asserts invalidAliasError.
+func IsInvalidAlias(err error) bool {
+	return microerror.Cause(err) == invalidAliasError
+}
+
+var aliasInUseError = &microerror.Error{
+	Kind: "aliasInUseError",
+	Desc: "The endpoint alias is already used for a different endpoint",
+}
+
+// IsAliasInUse asserts aliasInUseError.
+func IsAliasInUse(err error) bool {
+	return microerror.Cause(err) == aliasInUseError
+}
+
+var providerImmutableError = &microerror.Error{
+	Kind: "providerImmutableError",
+	Desc: "The provider of an endpoint cannot be changed",
+}
+
+// IsProviderImmutable asserts providerImmutableError.
+func IsProviderImmutable(err error) bool {
+	return microerror.Cause(err) == providerImmutableError
+}
+
+var invalidImportError = &microerror.Error{
+	Kind: "invalidImportError",
+	Desc: "The endpoint definitions to import are not valid",
+}
+
+// IsInvalidImport asserts invalidImportError.
+func IsInvalidImport(err error) bool {
+	return microerror.Cause(err) == invalidImportError
+}
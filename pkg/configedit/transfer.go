@@ -0,0 +1,236 @@
+package configedit
+
+import (
+	"sort"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/pkg/ssotoken"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+// Results of importing an endpoint definition.
+const (
+	ImportStatusAdded     = "added"
+	ImportStatusUpdated   = "updated"
+	ImportStatusUnchanged = "unchanged"
+)
+
+// EndpointDefinition is an endpoint as exported and imported. It
+// deliberately contains no credentials.
+type EndpointDefinition struct {
+	Alias    string `yaml:"alias,omitempty"`
+	Provider string `yaml:"provider,omitempty"`
+	WebUIURL string `yaml:"webui_url,omitempty"`
+}
+
+// Definitions is the structure of an export file, mapping
+// endpoint URLs to their definitions.
+type Definitions struct {
+	Endpoints map[string]EndpointDefinition `yaml:"endpoints"`
+}
+
+// ImportResult describes what importing one endpoint definition did, or would do.
+type ImportResult struct {
+	Endpoint string
+	Alias    string
+	Status   string
+}
+
+// Export returns the definitions of the given endpoints as YAML.
+// Endpoints can be given by alias or URL. If none are given, all
+// endpoints are exported.
+func Export(aliasesOrURLs []string) ([]byte, error) {
+	var endpoints []string
+	if len(aliasesOrURLs) == 0 {
+		endpoints = config.Config.Endpoints()
+	} else {
+		for _, aliasOrURL := range aliasesOrURLs {
+			endpoint, err := ResolveEndpoint(aliasOrURL)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	definitions := Definitions{Endpoints: map[string]EndpointDefinition{}}
+	for _, endpoint := range endpoints {
+		ec := config.Config.EndpointConfig(endpoint)
+		definitions.Endpoints[endpoint] = EndpointDefinition{
+			Alias:    ec.Alias,
+			Provider: ec.Provider,
+			WebUIURL: webui.ConfiguredBaseURL(endpoint),
+		}
+	}
+
+	out, err := yaml.Marshal(definitions)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return out, nil
+}
+
+// Import adds the endpoint definitions given as YAML to the configuration,
+// or updates existing endpoints. Empty fields don't change existing values.
+// All definitions are validated before anything is written. With dryRun,
+// only the results are returned.
+func Import(data []byte, dryRun bool) ([]ImportResult, error) {
+	definitions := Definitions{}
+	err := yaml.UnmarshalStrict(data, &definitions)
+	if err != nil {
+		return nil, microerror.Maskf(invalidImportError, err.Error())
+	}
+	if len(definitions.Endpoints) == 0 {
+		return nil, microerror.Maskf(invalidImportError, "no endpoints found")
+	}
+
+	normalized, err := validateDefinitions(definitions)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	endpoints := make([]string, 0, len(normalized))
+	for endpoint := range normalized {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	var results []ImportResult
+	for _, endpoint := range endpoints {
+		d := normalized[endpoint]
+		result := ImportResult{Endpoint: endpoint, Alias: d.Alias, Status: ImportStatusUnchanged}
+
+		ec := config.Config.EndpointConfig(endpoint)
+		if ec == nil {
+			result.Status = ImportStatusAdded
+		} else {
+			if result.Alias == "" {
+				result.Alias = ec.Alias
+			}
+			if d.Alias != "" && d.Alias != ec.Alias ||
+				d.Provider != "" && d.Provider != ec.Provider ||
+				d.WebUIURL != "" && d.WebUIURL != webui.ConfiguredBaseURL(endpoint) {
+				result.Status = ImportStatusUpdated
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if dryRun {
+		return results, nil
+	}
+
+	// Web UI settings live in a separate file, which is only written
+	// once the configuration has been stored successfully.
+	baseURLs := map[string]string{}
+	for _, result := range results {
+		d := normalized[result.Endpoint]
+		if result.Status != ImportStatusUnchanged && d.WebUIURL != "" {
+			baseURLs[result.Endpoint] = d.WebUIURL
+		}
+	}
+
+	err = ssotoken.UpdateConfig(func() error {
+		for _, result := range results {
+			if result.Status == ImportStatusUnchanged {
+				continue
+			}
+
+			d := normalized[result.Endpoint]
+			if result.Status == ImportStatusAdded {
+				addEndpoint(result.Endpoint)
+			}
+
+			ec := config.Config.EndpointConfig(result.Endpoint)
+			if d.Alias != "" {
+				ec.Alias = d.Alias
+			}
+			if d.Provider != "" {
+				ec.Provider = d.Provider
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if len(baseURLs) > 0 {
+		err = webui.StoreBaseURLs(baseURLs)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return results, nil
+}
+
+// validateDefinitions checks all definitions against each other and against
+// the current configuration, and returns them keyed by normalized endpoint URL.
+func validateDefinitions(definitions Definitions) (map[string]EndpointDefinition, error) {
+	normalized := map[string]EndpointDefinition{}
+	aliases := map[string]string{}
+
+	for rawEndpoint, d := range definitions.Endpoints {
+		endpoint, err := normalizeEndpoint(rawEndpoint)
+		if err != nil {
+			return nil, microerror.Maskf(invalidImportError, "%q is not a valid endpoint URL", rawEndpoint)
+		}
+		if _, ok := normalized[endpoint]; ok {
+			return nil, microerror.Maskf(invalidImportError, "endpoint %s is defined more than once", endpoint)
+		}
+
+		ec := config.Config.EndpointConfig(endpoint)
+
+		if d.Alias != "" {
+			err = validateAlias(endpoint, d.Alias)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			if other, ok := aliases[d.Alias]; ok {
+				return nil, microerror.Maskf(aliasInUseError, "alias %q is used for both %s and %s", d.Alias, other, endpoint)
+			}
+			aliases[d.Alias] = endpoint
+		}
+
+		if d.Provider != "" {
+			current := ""
+			if ec != nil {
+				current = ec.Provider
+			}
+			err = validateProvider(current, d.Provider)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		}
+
+		if d.WebUIURL != "" {
+			err = webui.ValidateBaseURL(d.WebUIURL)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		}
+
+		normalized[endpoint] = d
+	}
+
+	return normalized, nil
+}
+
+// addEndpoint adds an endpoint without credentials to the configuration.
+// gscliauth only offers this through ChooseEndpoint, which also selects
+// the endpoint, so the previous selection gets restored afterwards.
+func addEndpoint(endpoint string) {
+	c := config.Config
+	selected, email, provider, refreshToken, scheme, token := c.SelectedEndpoint, c.Email, c.Provider, c.RefreshToken, c.Scheme, c.Token
+
+	c.ChooseEndpoint(endpoint)
+
+	c.SelectedEndpoint, c.Email, c.Provider, c.RefreshToken, c.Scheme, c.Token = selected, email, provider, refreshToken, scheme, token
+}
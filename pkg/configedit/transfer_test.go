@@ -0,0 +1,108 @@
+package configedit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+	"github.com/giantswarm/gsctl/webui"
+)
+
+// Test_ExportImport tests moving endpoint definitions to a different configuration.
+func Test_ExportImport(t *testing.T) {
+	tempConfig(t)
+
+	err := webui.StoreBaseURL("https://api.foo.example.com", "https://console.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := Export([]string{"foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if strings.Contains(string(exported), "token") || strings.Contains(string(exported), "email") {
+		t.Errorf("Export contains credentials:\n%s", exported)
+	}
+
+	// Import into a configuration with a different endpoint selected.
+	fs := afero.NewMemMapFs()
+	_, err = testutils.TempConfig(fs, `endpoints:
+  https://api.other.example.com:
+    email: email@example.com
+    token: other-token
+    provider: kvm
+selected_endpoint: https://api.other.example.com
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Import(exported, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(results) != 1 || results[0].Status != ImportStatusAdded || config.Config.NumEndpoints() != 1 {
+		t.Errorf("Unexpected dry run result %#v", results)
+	}
+
+	results, err = Import(exported, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(results) != 1 || results[0].Endpoint != "https://api.foo.example.com" || results[0].Alias != "foo" {
+		t.Errorf("Unexpected result %#v", results)
+	}
+
+	// Re-read the config file.
+	err = config.Initialize(fs, config.ConfigDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Config.SelectedEndpoint != "https://api.other.example.com" || config.Config.Token != "other-token" {
+		t.Errorf("Expected selection to be unchanged, got %q", config.Config.SelectedEndpoint)
+	}
+	ec := config.Config.EndpointConfig("https://api.foo.example.com")
+	if ec == nil || ec.Alias != "foo" || ec.Provider != "aws" || ec.Token != "" {
+		t.Errorf("Unexpected imported endpoint %#v", ec)
+	}
+	if webui.ConfiguredBaseURL("https://api.foo.example.com") != "https://console.example.com" {
+		t.Error("Expected web UI URL to be imported")
+	}
+
+	results, err = Import(exported, false)
+	if err != nil || results[0].Status != ImportStatusUnchanged {
+		t.Errorf("Expected unchanged result, got %#v, %v", results, err)
+	}
+}
+
+// Test_ImportInvalid tests that invalid definitions are rejected without changes.
+func Test_ImportInvalid(t *testing.T) {
+	var testCases = []struct {
+		data         string
+		errorMatcher func(error) bool
+	}{
+		{"endpoints: {}", IsInvalidImport},
+		{"endpoints:\n  https://api.new.example.com:\n    token: foo\n", IsInvalidImport},
+		{"endpoints:\n  https://api.new.example.com:\n    alias: foo\n", IsAliasInUse},
+		{"endpoints:\n  https://api.new.example.com:\n    alias: new\n  https://api.new2.example.com:\n    alias: new\n", IsAliasInUse},
+		{"endpoints:\n  https://api.new.example.com: {}\n  API.NEW.example.com: {}\n", IsInvalidImport},
+		{"endpoints:\n  https://api.bar.example.com:\n    provider: aws\n", IsProviderImmutable},
+		{"endpoints:\n  https://api.new.example.com:\n    webui_url: console\n", webui.IsInvalidBaseURL},
+	}
+
+	for i, tc := range testCases {
+		tempConfig(t)
+
+		_, err := Import([]byte(tc.data), false)
+		if !tc.errorMatcher(err) {
+			t.Errorf("Case %d - unexpected error %v", i, err)
+		}
+		if config.Config.NumEndpoints() != 2 {
+			t.Errorf("Case %d - expected no endpoints to be added", i)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package credentials provides helpers to validate and inspect the cloud
+// provider credentials of organizations.
+package credentials
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// awsRoleARNRegexp matches IAM role ARNs in all AWS partitions,
+	// e.g. arn:aws:iam::123456789012:role/GiantSwarmAdmin.
+	awsRoleARNRegexp = regexp.MustCompile(`^arn:aws(-cn|-us-gov)?:iam::[0-9]{12}:role/[A-Za-z0-9+=,.@_/-]{1,512}$`)
+
+	// azureGUIDRegexp matches GUIDs as used for Azure subscription, tenant
+	// and client IDs.
+	azureGUIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+const (
+	// AWSRoleARNFormat describes the expected format of AWS role ARNs to the user.
+	AWSRoleARNFormat = "arn:aws:iam::<AWS-ACCOUNT-ID>:role/<ROLE-NAME>"
+	// AzureGUIDFormat describes the expected format of Azure IDs to the user.
+	AzureGUIDFormat = "00000000-0000-0000-0000-000000000000"
+)
+
+// IsAWSRoleARN returns true if the given string is an AWS IAM role ARN.
+func IsAWSRoleARN(arn string) bool {
+	return awsRoleARNRegexp.MatchString(arn)
+}
+
+// IsAzureGUID returns true if the given string is a GUID like the ones
+// identifying Azure subscriptions, tenants and clients.
+func IsAzureGUID(guid string) bool {
+	return azureGUIDRegexp.MatchString(guid)
+}
+
+// AWSAccountID returns the AWS account ID contained in a role ARN,
+// or an empty string if the ARN cannot be parsed.
+func AWSAccountID(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+
+	return parts[4]
+}
@@ -0,0 +1,62 @@
+package credentials
+
+import (
+	"strconv"
+	"testing"
+)
+
+func Test_IsAWSRoleARN(t *testing.T) {
+	testCases := []struct {
+		arn   string
+		valid bool
+	}{
+		{"arn:aws:iam::123456789012:role/GiantSwarmAdmin", true},
+		{"arn:aws-cn:iam::123456789012:role/path/to/GiantSwarmAWSOperator", true},
+		{"arn:aws-us-gov:iam::123456789012:role/GiantSwarmAdmin", true},
+		{"", false},
+		{"GiantSwarmAdmin", false},
+		{"arn:aws:iam::12345:role/GiantSwarmAdmin", false},
+		{"arn:aws:iam::123456789012:user/jane", false},
+		{"arn:aws:iam::123456789012:role/", false},
+		{"arn:aws:s3:::my-bucket", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if IsAWSRoleARN(tc.arn) != tc.valid {
+				t.Errorf("Case %d - Expected %v for '%s'", i, tc.valid, tc.arn)
+			}
+		})
+	}
+}
+
+func Test_IsAzureGUID(t *testing.T) {
+	testCases := []struct {
+		guid  string
+		valid bool
+	}{
+		{"b5e4f0b4-7d3c-4a7e-9a8b-2c1d0e9f8a7b", true},
+		{"B5E4F0B4-7D3C-4A7E-9A8B-2C1D0E9F8A7B", true},
+		{"", false},
+		{"b5e4f0b47d3c4a7e9a8b2c1d0e9f8a7b", false},
+		{"{b5e4f0b4-7d3c-4a7e-9a8b-2c1d0e9f8a7b}", false},
+		{"x5e4f0b4-7d3c-4a7e-9a8b-2c1d0e9f8a7b", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if IsAzureGUID(tc.guid) != tc.valid {
+				t.Errorf("Case %d - Expected %v for '%s'", i, tc.valid, tc.guid)
+			}
+		})
+	}
+}
+
+func Test_AWSAccountID(t *testing.T) {
+	if id := AWSAccountID("arn:aws:iam::123456789012:role/GiantSwarmAdmin"); id != "123456789012" {
+		t.Errorf("Expected '123456789012', got '%s'", id)
+	}
+	if id := AWSAccountID("not-an-arn"); id != "" {
+		t.Errorf("Expected empty string, got '%s'", id)
+	}
+}
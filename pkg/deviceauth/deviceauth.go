@@ -0,0 +1,238 @@
+// Package deviceauth implements the OAuth 2.0 device authorization grant
+// (RFC 8628) for SSO logins on machines without a web browser, e. g. via SSH.
+//
+// The user opens a verification URL on any other device and enters a code,
+// while gsctl polls the identity provider's token endpoint. The same client
+// and scopes as in the gscliauth PKCE flow are used, so that the resulting
+// tokens can be stored and refreshed the same way.
+package deviceauth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// DefaultIssuerURL is the base URL of Giant Swarm's identity provider.
+	DefaultIssuerURL = "https://giantswarm.eu.auth0.com"
+
+	// DefaultClientID is the OAuth client ID of gsctl, as used by gscliauth.
+	DefaultClientID = "zQiFLUnrTFQwrybYzeY53hWWfhOKWRAU"
+
+	// DefaultScope are the requested scopes, as used by gscliauth.
+	// offline_access is required to get a refresh token.
+	DefaultScope = "openid email profile user_metadata https://giantswarm.io offline_access"
+
+	deviceCodePath = "/oauth/device/code"
+	tokenPath      = "/oauth/token"
+	grantType      = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultInterval is the polling interval to use if the identity
+	// provider doesn't specify one, as defined in RFC 8628.
+	defaultInterval = 5 * time.Second
+
+	requestTimeout = 30 * time.Second
+)
+
+// Error codes of the token endpoint, as defined in RFC 8628.
+const (
+	errorAuthorizationPending = "authorization_pending"
+	errorSlowDown             = "slow_down"
+	errorAccessDenied         = "access_denied"
+	errorExpiredToken         = "expired_token"
+)
+
+var (
+	// slowDownIncrement is added to the polling interval whenever the
+	// identity provider asks us to slow down.
+	slowDownIncrement = 5 * time.Second
+)
+
+// Config is the configuration of a Flow.
+type Config struct {
+	// IssuerURL is the base URL of the identity provider. Defaults to DefaultIssuerURL.
+	IssuerURL string
+
+	// ClientID defaults to DefaultClientID.
+	ClientID string
+
+	// Scope defaults to DefaultScope.
+	Scope string
+
+	// Audience is the API the token is requested for, usually the API endpoint URL.
+	Audience string
+
+	// PollInterval overrides the polling interval requested by
+	// the identity provider. Only meant to be used in tests.
+	PollInterval time.Duration
+
+	// HTTPClient defaults to a client with a 30 seconds timeout.
+	HTTPClient *http.Client
+}
+
+// Flow runs the device authorization grant.
+type Flow struct {
+	config Config
+}
+
+// DeviceCode is the response of the device authorization endpoint.
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// Token is the successful response of the token endpoint.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	TokenType    string `json:"token_type"`
+}
+
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// New creates a Flow, applying defaults to the configuration.
+func New(config Config) *Flow {
+	if config.IssuerURL == "" {
+		config.IssuerURL = DefaultIssuerURL
+	}
+	config.IssuerURL = strings.TrimSuffix(config.IssuerURL, "/")
+	if config.ClientID == "" {
+		config.ClientID = DefaultClientID
+	}
+	if config.Scope == "" {
+		config.Scope = DefaultScope
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: requestTimeout}
+	}
+
+	return &Flow{config: config}
+}
+
+// RequestDeviceCode starts the flow. The returned verification URI and
+// user code have to be presented to the user.
+func (f *Flow) RequestDeviceCode() (*DeviceCode, error) {
+	params := url.Values{}
+	params.Set("client_id", f.config.ClientID)
+	params.Set("scope", f.config.Scope)
+	if f.config.Audience != "" {
+		params.Set("audience", f.config.Audience)
+	}
+
+	code := &DeviceCode{}
+	errResponse, err := f.post(deviceCodePath, params, code)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if errResponse != nil {
+		return nil, microerror.Maskf(authorizationError, "%s: %s", errResponse.Error, errResponse.ErrorDescription)
+	}
+	if code.DeviceCode == "" || code.UserCode == "" || code.VerificationURI == "" {
+		return nil, microerror.Maskf(authorizationError, "incomplete response from the device authorization endpoint")
+	}
+
+	return code, nil
+}
+
+// PollToken polls the token endpoint until the user has approved or denied
+// the request, or the device code has expired.
+func (f *Flow) PollToken(code *DeviceCode) (*Token, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	if f.config.PollInterval > 0 {
+		interval = f.config.PollInterval
+	}
+
+	var deadline time.Time
+	if code.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", grantType)
+	params.Set("device_code", code.DeviceCode)
+	params.Set("client_id", f.config.ClientID)
+
+	for {
+		time.Sleep(interval)
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, microerror.Mask(expiredError)
+		}
+
+		token := &Token{}
+		errResponse, err := f.post(tokenPath, params, token)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if errResponse == nil {
+			if token.AccessToken == "" {
+				return nil, microerror.Maskf(authorizationError, "no access token in the response of the token endpoint")
+			}
+			return token, nil
+		}
+
+		switch errResponse.Error {
+		case errorAuthorizationPending:
+			continue
+		case errorSlowDown:
+			interval += slowDownIncrement
+		case errorAccessDenied:
+			return nil, microerror.Mask(accessDeniedError)
+		case errorExpiredToken:
+			return nil, microerror.Mask(expiredError)
+		default:
+			return nil, microerror.Maskf(authorizationError, "%s: %s", errResponse.Error, errResponse.ErrorDescription)
+		}
+	}
+}
+
+// post sends a form to the identity provider and decodes a successful
+// response into result. An OAuth error response is returned separately.
+func (f *Flow) post(path string, params url.Values, result interface{}) (*errorResponse, error) {
+	res, err := f.config.HTTPClient.PostForm(f.config.IssuerURL+path, params)
+	if err != nil {
+		return nil, microerror.Maskf(authorizationError, "could not reach the identity provider: %s", err.Error())
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, microerror.Maskf(authorizationError, "could not read the response of the identity provider: %s", err.Error())
+	}
+
+	if res.StatusCode != http.StatusOK {
+		errResponse := &errorResponse{}
+		err = json.Unmarshal(body, errResponse)
+		if err != nil || errResponse.Error == "" {
+			return nil, microerror.Maskf(authorizationError, "unexpected response from the identity provider with HTTP status %d", res.StatusCode)
+		}
+
+		return errResponse, nil
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return nil, microerror.Maskf(authorizationError, "could not parse the response of the identity provider: %s", err.Error())
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,105 @@
+package deviceauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// Test_Flow tests the complete flow against a fake identity provider.
+func Test_Flow(t *testing.T) {
+	slowDownIncrement = time.Millisecond
+
+	var testCases = []struct {
+		errors        []string
+		expectedPolls int
+		errorMatcher  func(error) bool
+	}{
+		{
+			expectedPolls: 1,
+		},
+		{
+			errors:        []string{"authorization_pending", "slow_down", "authorization_pending"},
+			expectedPolls: 4,
+		},
+		{
+			errors:        []string{"authorization_pending", "access_denied"},
+			expectedPolls: 2,
+			errorMatcher:  IsAccessDenied,
+		},
+		{
+			errors:        []string{"expired_token"},
+			expectedPolls: 1,
+			errorMatcher:  IsExpired,
+		},
+		{
+			errors:        []string{"invalid_client"},
+			expectedPolls: 1,
+			errorMatcher:  IsAuthorization,
+		},
+	}
+
+	for i, tc := range testCases {
+		idp := testutils.NewFakeIdP("access-token", "refresh-token", "id-token")
+		idp.Errors = tc.errors
+
+		flow := New(Config{
+			IssuerURL:    idp.URL + "/",
+			Audience:     "https://api.example.com",
+			PollInterval: time.Millisecond,
+		})
+
+		code, err := flow.RequestDeviceCode()
+		if err != nil {
+			t.Fatalf("Case %d - unexpected error: %s", i, err)
+		}
+		if code.UserCode != testutils.FakeIdPUserCode || code.VerificationURI != idp.URL+"/activate" {
+			t.Errorf("Case %d - unexpected device code %#v", i, code)
+		}
+
+		token, err := flow.PollToken(code)
+		if tc.errorMatcher != nil {
+			if !tc.errorMatcher(err) {
+				t.Errorf("Case %d - unexpected error %v", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("Case %d - unexpected error: %s", i, err)
+		} else if token.AccessToken != "access-token" || token.RefreshToken != "refresh-token" || token.IDToken != "id-token" {
+			t.Errorf("Case %d - unexpected token %#v", i, token)
+		}
+
+		if idp.TokenPolls() != tc.expectedPolls {
+			t.Errorf("Case %d - expected %d token requests, got %d", i, tc.expectedPolls, idp.TokenPolls())
+		}
+
+		idp.Close()
+	}
+}
+
+// Test_PollTokenExpired tests that polling stops once the device code has expired.
+func Test_PollTokenExpired(t *testing.T) {
+	idp := testutils.NewFakeIdP("access-token", "", "")
+	defer idp.Close()
+
+	flow := New(Config{IssuerURL: idp.URL, PollInterval: 1100 * time.Millisecond})
+	_, err := flow.PollToken(&DeviceCode{DeviceCode: testutils.FakeIdPDeviceCode, ExpiresIn: 1})
+	if !IsExpired(err) {
+		t.Errorf("Expected expired error, got %v", err)
+	}
+	if idp.TokenPolls() != 0 {
+		t.Errorf("Expected no token requests, got %d", idp.TokenPolls())
+	}
+}
+
+// Test_RequestDeviceCodeError tests an error response of the device authorization endpoint.
+func Test_RequestDeviceCodeError(t *testing.T) {
+	idp := testutils.NewFakeIdP("access-token", "", "")
+	defer idp.Close()
+
+	flow := New(Config{IssuerURL: idp.URL + "/unknown"})
+	_, err := flow.RequestDeviceCode()
+	if !IsAuthorization(err) {
+		t.Errorf("Expected authorization error, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package deviceauth
+
+import "github.com/giantswarm/microerror"
+
+var authorizationError = &microerror.Error{
+	Kind: "authorizationError",
+	Desc: "The identity provider returned an error",
+}
+
+// IsAuthorization asserts authorizationError.
+func IsAuthorization(err error) bool {
+	return microerror.Cause(err) == authorizationError
+}
+
+var accessDeniedError = &microerror.Error{
+	Kind: "accessDeniedError",
+	Desc: "The authorization request has been denied",
+}
+
+// IsAccessDenied asserts accessDeniedError.
+func IsAccessDenied(err error) bool {
+	return microerror.Cause(err) == accessDeniedError
+}
+
+var expiredError = &microerror.Error{
+	Kind: "expiredError",
+	Desc: "The device code expired before the authorization request was approved",
+}
+
+// IsExpired asserts expiredError.
+func IsExpired(err error) bool {
+	return microerror.Cause(err) == expiredError
+}
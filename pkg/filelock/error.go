@@ -0,0 +1,13 @@
+package filelock
+
+import "github.com/giantswarm/microerror"
+
+var timeoutError = &microerror.Error{
+	Kind: "timeoutError",
+	Desc: "The lock could not be acquired in time",
+}
+
+// IsTimeout asserts timeoutError.
+func IsTimeout(err error) bool {
+	return microerror.Cause(err) == timeoutError
+}
@@ -0,0 +1,222 @@
+// Package filelock provides a lock between processes, to protect a file
+// against concurrent writes. The lock is a separate file next to the
+// protected one, created exclusively.
+package filelock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+)
+
+const (
+	lockFileSuffix = ".lock"
+
+	// takeoverSuffix is the suffix of the file held while a stale lock
+	// gets removed, so that only one process at a time can do that.
+	takeoverSuffix = ".takeover"
+
+	retryInterval = 50 * time.Millisecond
+
+	// staleAfter is the age after which a lock file is considered to be
+	// left over from a crashed process, and gets removed.
+	staleAfter = 30 * time.Second
+)
+
+// Lock is an acquired lock.
+type Lock struct {
+	fs   afero.Fs
+	path string
+
+	// owner is the content of the lock file, unique to this lock.
+	owner string
+}
+
+// Acquire waits until the lock for the given file can be acquired,
+// at most for the given timeout.
+func Acquire(fs afero.Fs, filePath string, timeout time.Duration) (*Lock, error) {
+	lockPath := filePath + lockFileSuffix
+	deadline := time.Now().Add(timeout)
+
+	owner, err := newOwner()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	for {
+		acquired, err := tryAcquire(fs, lockPath, owner)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if acquired {
+			return &Lock{fs: fs, path: lockPath, owner: owner}, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, microerror.Maskf(timeoutError, "lock file %s still exists after %s", lockPath, timeout)
+		}
+
+		time.Sleep(retryInterval)
+	}
+}
+
+// Release removes the lock, unless it has been taken over by another
+// process as it was held for too long.
+func (l *Lock) Release() error {
+	content, err := afero.ReadFile(l.fs, l.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+	if string(content) != l.owner {
+		return nil
+	}
+
+	err = l.fs.Remove(l.path)
+	if err != nil && !os.IsNotExist(err) {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func tryAcquire(fs afero.Fs, lockPath, owner string) (bool, error) {
+	acquired, err := create(fs, lockPath, owner)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	if acquired {
+		return true, nil
+	}
+
+	removed, err := removeStale(fs, lockPath)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	if !removed {
+		return false, nil
+	}
+
+	acquired, err = create(fs, lockPath, owner)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return acquired, nil
+}
+
+// create creates the file exclusively, with the owner as content. It
+// returns false if the file exists already.
+func create(fs afero.Fs, filePath, owner string) (bool, error) {
+	// O_EXCL makes creating the file fail if another process
+	// created it in the meantime.
+	f, err := fs.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if os.IsExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	_, err = f.WriteString(owner)
+	if err != nil {
+		f.Close()
+		return false, microerror.Mask(err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
+
+// removeStale removes the lock file if it is stale, and returns whether
+// it did. Removing the stale lock and creating a new one is not atomic,
+// so two processes might both find the same stale lock, and the later one
+// would remove the lock the other one has created in the meantime. Hence
+// stale locks are only removed while holding the takeover file, and their
+// staleness is checked again once it is held.
+func removeStale(fs afero.Fs, lockPath string) (bool, error) {
+	stale, err := isStale(fs, lockPath)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	if !stale {
+		return false, nil
+	}
+
+	owner, err := newOwner()
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	takeoverPath := lockPath + takeoverSuffix
+	acquired, err := create(fs, takeoverPath, owner)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	if !acquired {
+		// The takeover file is only held for a moment. If it is stale,
+		// a process has crashed while holding it.
+		stale, err = isStale(fs, takeoverPath)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+		if stale {
+			err = fs.Remove(takeoverPath)
+			if err != nil && !os.IsNotExist(err) {
+				return false, microerror.Mask(err)
+			}
+		}
+
+		return false, nil
+	}
+	takeover := &Lock{fs: fs, path: takeoverPath, owner: owner}
+	defer takeover.Release()
+
+	// Another process might have taken over the stale lock before we
+	// got the takeover file.
+	stale, err = isStale(fs, lockPath)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	if !stale {
+		return false, nil
+	}
+
+	err = fs.Remove(lockPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
+
+// isStale returns true if the file exists and is older than staleAfter.
+func isStale(fs afero.Fs, filePath string) (bool, error) {
+	info, err := fs.Stat(filePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return time.Since(info.ModTime()) >= staleAfter, nil
+}
+
+// newOwner returns a random identifier for a lock.
+func newOwner() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
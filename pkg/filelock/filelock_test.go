@@ -0,0 +1,176 @@
+package filelock
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Test_Acquire tests that a lock is only held by one party at a time.
+func Test_Acquire(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filelock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fs := afero.NewOsFs()
+	filePath := path.Join(dir, "config.yaml")
+
+	lock, err := Acquire(fs, filePath, time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	_, err = Acquire(fs, filePath, 100*time.Millisecond)
+	if !IsTimeout(err) {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+
+	err = lock.Release()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Concurrent increments of a counter in the file must not get lost.
+	err = afero.WriteFile(fs, filePath, []byte{}, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			l, err := Acquire(fs, filePath, 5*time.Second)
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+			defer l.Release()
+
+			content, _ := afero.ReadFile(fs, filePath)
+			time.Sleep(time.Millisecond)
+			afero.WriteFile(fs, filePath, append(content, 'x'), 0600)
+		}()
+	}
+	wg.Wait()
+
+	content, _ := afero.ReadFile(fs, filePath)
+	if len(content) != 10 {
+		t.Errorf("Expected 10 increments, got %d", len(content))
+	}
+}
+
+// Test_AcquireStale tests that a left over lock file gets removed.
+func Test_AcquireStale(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	lockPath := "/config.yaml" + lockFileSuffix
+
+	err := afero.WriteFile(fs, lockPath, []byte{}, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fs.Chtimes(lockPath, time.Now(), time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := Acquire(fs, "/config.yaml", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	lock.Release()
+}
+
+// Test_AcquireStaleTakeover tests that a stale lock is not removed while
+// another process takes it over, and that a left over takeover file gets
+// removed.
+func Test_AcquireStaleTakeover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filelock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// MemMapFs doesn't support O_EXCL.
+	fs := afero.NewOsFs()
+	filePath := path.Join(dir, "config.yaml")
+	lockPath := filePath + lockFileSuffix
+	takeoverPath := lockPath + takeoverSuffix
+
+	for _, p := range []string{lockPath, takeoverPath} {
+		err = afero.WriteFile(fs, p, []byte("other"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = fs.Chtimes(p, time.Now(), time.Now().Add(-time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = fs.Chtimes(takeoverPath, time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Acquire(fs, filePath, 100*time.Millisecond)
+	if !IsTimeout(err) {
+		t.Errorf("Expected timeout error while the takeover file is held, got %v", err)
+	}
+
+	err = fs.Chtimes(takeoverPath, time.Now(), time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := Acquire(fs, filePath, time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer lock.Release()
+
+	if exists, _ := afero.Exists(fs, takeoverPath); exists {
+		t.Error("Expected takeover file to be removed")
+	}
+}
+
+// Test_ReleaseTakenOver tests that releasing a lock which has been taken
+// over by another process keeps the other process' lock.
+func Test_ReleaseTakenOver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filelock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// MemMapFs doesn't support O_EXCL.
+	fs := afero.NewOsFs()
+	filePath := path.Join(dir, "config.yaml")
+	lockPath := filePath + lockFileSuffix
+
+	lock, err := Acquire(fs, filePath, time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = afero.WriteFile(fs, lockPath, []byte("other"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = lock.Release()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if exists, _ := afero.Exists(fs, lockPath); !exists {
+		t.Error("Expected lock of the other process to be kept")
+	}
+}
@@ -0,0 +1,23 @@
+package labels
+
+import "github.com/giantswarm/microerror"
+
+var invalidSelectorError = &microerror.Error{
+	Kind: "invalidSelectorError",
+	Desc: "The label selector is not valid",
+}
+
+// IsInvalidSelector asserts invalidSelectorError.
+func IsInvalidSelector(err error) bool {
+	return microerror.Cause(err) == invalidSelectorError
+}
+
+var invalidLabelError = &microerror.Error{
+	Kind: "invalidLabelError",
+	Desc: "The label key or value is not valid",
+}
+
+// IsInvalidLabel asserts invalidLabelError.
+func IsInvalidLabel(err error) bool {
+	return microerror.Cause(err) == invalidLabelError
+}
@@ -0,0 +1,118 @@
+// Package labels validates cluster label keys and values, parses label
+// changes in the form 'key=value' and 'key-', and parses label selectors
+// using the Kubernetes syntax, including set-based requirements.
+package labels
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	maxNameLength   = 63
+	maxPrefixLength = 253
+)
+
+var (
+	nameRegexp   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	prefixRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateKey checks a label key. Keys consist of an optional DNS
+// subdomain prefix and a slash, followed by a name of up to 63
+// alphanumeric characters, '-', '_' or '.'.
+func ValidateKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+
+		if prefix == "" || len(prefix) > maxPrefixLength || !prefixRegexp.MatchString(prefix) {
+			return microerror.Maskf(invalidLabelError, "key '%s' has an invalid prefix, it must be a lower case DNS subdomain", key)
+		}
+	}
+
+	if name == "" {
+		return microerror.Maskf(invalidLabelError, "key '%s' has an empty name", key)
+	}
+	if len(name) > maxNameLength {
+		return microerror.Maskf(invalidLabelError, "key '%s' is too long, the name must have at most %d characters", key, maxNameLength)
+	}
+	if !nameRegexp.MatchString(name) {
+		return microerror.Maskf(invalidLabelError, "key '%s' is invalid, it must consist of alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character", key)
+	}
+
+	return nil
+}
+
+// ValidateValue checks a label value. Values may be empty, or follow
+// the same rules as the name part of a key.
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxNameLength {
+		return microerror.Maskf(invalidLabelError, "value '%s' is too long, it must have at most %d characters", value, maxNameLength)
+	}
+	if !nameRegexp.MatchString(value) {
+		return microerror.Maskf(invalidLabelError, "value '%s' is invalid, it must consist of alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character", value)
+	}
+
+	return nil
+}
+
+// Change is a modification of a single label.
+type Change struct {
+	Key string
+	// Value is the new value. Nil means the label is removed.
+	Value *string
+}
+
+// String formats the change the way it is given on the command line.
+func (c Change) String() string {
+	if c.Value == nil {
+		return c.Key + "-"
+	}
+
+	return c.Key + "=" + *c.Value
+}
+
+// ParseChanges parses label changes given as 'key=value' to set
+// a label, or as 'key-' to remove it.
+func ParseChanges(args []string) ([]Change, error) {
+	changes := []Change{}
+	seen := map[string]bool{}
+
+	for _, arg := range args {
+		var change Change
+
+		if i := strings.Index(arg, "="); i >= 0 {
+			value := arg[i+1:]
+			change = Change{Key: arg[:i], Value: &value}
+
+			err := ValidateValue(value)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		} else if strings.HasSuffix(arg, "-") {
+			change = Change{Key: strings.TrimSuffix(arg, "-")}
+		} else {
+			return nil, microerror.Maskf(invalidLabelError, "'%s' is neither 'key=value' nor 'key-'", arg)
+		}
+
+		err := ValidateKey(change.Key)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if seen[change.Key] {
+			return nil, microerror.Maskf(invalidLabelError, "key '%s' is given more than once", change.Key)
+		}
+		seen[change.Key] = true
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
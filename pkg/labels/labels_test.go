@@ -0,0 +1,140 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Test_ParseSelector tests parsing valid and invalid selectors.
+func Test_ParseSelector(t *testing.T) {
+	testCases := []struct {
+		selector     string
+		expected     string
+		matches      map[string]string
+		noMatch      map[string]string
+		errorMatcher func(error) bool
+	}{
+		{
+			selector: "environment=dev",
+			expected: "environment=dev",
+			matches:  map[string]string{"environment": "dev"},
+			noMatch:  map[string]string{"environment": "prod"},
+		},
+		{
+			selector: "environment == dev , team!=a",
+			expected: "environment=dev,team!=a",
+			matches:  map[string]string{"environment": "dev"},
+			noMatch:  map[string]string{"environment": "dev", "team": "a"},
+		},
+		{
+			selector: "environment in (dev, testing),team notin (a,b)",
+			expected: "environment in (dev,testing),team notin (a,b)",
+			matches:  map[string]string{"environment": "testing", "team": "c"},
+			noMatch:  map[string]string{"environment": "testing", "team": "b"},
+		},
+		{
+			selector: "example.com/team,!deprecated",
+			expected: "example.com/team,!deprecated",
+			matches:  map[string]string{"example.com/team": "a"},
+			noMatch:  map[string]string{"example.com/team": "a", "deprecated": "true"},
+		},
+		{
+			selector: "! environment",
+			expected: "!environment",
+			matches:  map[string]string{},
+			noMatch:  map[string]string{"environment": ""},
+		},
+		{
+			selector:     "",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "environment=dev,",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "environment in (dev",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "environment in ()",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "environment => dev",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "-env=dev",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "Example.com/team=a",
+			errorMatcher: IsInvalidSelector,
+		},
+		{
+			selector:     "environment=dev!",
+			errorMatcher: IsInvalidSelector,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			s, err := ParseSelector(tc.selector)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("Error not matching expectation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if s.String() != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, s.String())
+			}
+			if !s.Matches(tc.matches) {
+				t.Errorf("Expected selector to match %v", tc.matches)
+			}
+			if s.Matches(tc.noMatch) {
+				t.Errorf("Expected selector not to match %v", tc.noMatch)
+			}
+		})
+	}
+}
+
+// Test_ParseChanges tests parsing label changes.
+func Test_ParseChanges(t *testing.T) {
+	changes, err := ParseChanges([]string{"environment=dev", "team-", "example.com/empty="})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	formatted := []string{}
+	for _, c := range changes {
+		formatted = append(formatted, c.String())
+	}
+	if diff := cmp.Diff([]string{"environment=dev", "team-", "example.com/empty="}, formatted); diff != "" {
+		t.Errorf("Changes unequal (-want +got):\n%s", diff)
+	}
+	if changes[1].Value != nil {
+		t.Errorf("Expected removal, got value %q", *changes[1].Value)
+	}
+
+	invalid := [][]string{
+		{"environment"},
+		{"=dev"},
+		{"environment=dev", "environment-"},
+		{"environment=not valid"},
+		{"-"},
+		{"a_very_long_label_name_which_exceeds_the_maximum_of_sixty_three_chars=x"},
+	}
+	for _, args := range invalid {
+		_, err := ParseChanges(args)
+		if !IsInvalidLabel(err) {
+			t.Errorf("Expected invalidLabelError for %v, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package labels
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+// Operators of selector requirements.
+const (
+	OperatorExists       = "exists"
+	OperatorDoesNotExist = "!"
+	OperatorEquals       = "="
+	OperatorNotEquals    = "!="
+	OperatorIn           = "in"
+	OperatorNotIn        = "notin"
+)
+
+var (
+	equalityRegexp = regexp.MustCompile(`^([^\s=!()]+)\s*(==|!=|=)\s*([^\s=!(),]*)$`)
+	setRegexp      = regexp.MustCompile(`^([^\s=!()]+)\s+(in|notin)\s*\((.*)\)$`)
+	existsRegexp   = regexp.MustCompile(`^!?\s*([^\s=!()]+)$`)
+)
+
+// Requirement is a single condition of a selector, e. g. 'env in (dev,test)'.
+type Requirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// String formats the requirement in the selector syntax.
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OperatorExists:
+		return r.Key
+	case OperatorDoesNotExist:
+		return "!" + r.Key
+	case OperatorEquals, OperatorNotEquals:
+		return r.Key + r.Operator + r.Values[0]
+	default:
+		return r.Key + " " + r.Operator + " (" + strings.Join(r.Values, ",") + ")"
+	}
+}
+
+// Matches returns whether the labels fulfill the requirement.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+
+	switch r.Operator {
+	case OperatorExists:
+		return ok
+	case OperatorDoesNotExist:
+		return !ok
+	case OperatorEquals:
+		return ok && value == r.Values[0]
+	case OperatorNotEquals:
+		return !ok || value != r.Values[0]
+	case OperatorIn:
+		return ok && contains(r.Values, value)
+	case OperatorNotIn:
+		return !ok || !contains(r.Values, value)
+	}
+
+	return false
+}
+
+// Selector selects clusters by their labels. All requirements must be met.
+type Selector []Requirement
+
+// String formats the selector in the canonical syntax, as sent to the API.
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, r := range s {
+		parts[i] = r.String()
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Matches returns whether the labels fulfill all requirements.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseSelector parses a label selector like kubectl does. Requirements
+// are separated by commas and can have these forms:
+//
+//	key                   label is present
+//	!key                  label is not present
+//	key=value, key==value label has the value
+//	key!=value            label is missing or has another value
+//	key in (v1,v2)        label has one of the values
+//	key notin (v1,v2)     label is missing or has none of the values
+func ParseSelector(selector string) (Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, microerror.Maskf(invalidSelectorError, "the selector is empty")
+	}
+
+	parts, err := splitRequirements(selector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	s := Selector{}
+	for _, part := range parts {
+		r, err := parseRequirement(strings.TrimSpace(part))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		s = append(s, r)
+	}
+
+	return s, nil
+}
+
+// splitRequirements splits the selector at commas which are
+// not within parentheses.
+func splitRequirements(selector string) ([]string, error) {
+	parts := []string{}
+	depth := 0
+	start := 0
+
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, microerror.Maskf(invalidSelectorError, "nested parentheses in '%s'", selector)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, microerror.Maskf(invalidSelectorError, "unbalanced parentheses in '%s'", selector)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, microerror.Maskf(invalidSelectorError, "unbalanced parentheses in '%s'", selector)
+	}
+
+	return append(parts, selector[start:]), nil
+}
+
+func parseRequirement(part string) (Requirement, error) {
+	var r Requirement
+
+	if part == "" {
+		return r, microerror.Maskf(invalidSelectorError, "empty requirement, please check for duplicate or trailing commas")
+	}
+
+	if m := setRegexp.FindStringSubmatch(part); m != nil {
+		r = Requirement{Key: m[1], Operator: m[2]}
+		for _, v := range strings.Split(m[3], ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				return r, microerror.Maskf(invalidSelectorError, "empty value in '%s'", part)
+			}
+			r.Values = append(r.Values, v)
+		}
+	} else if m := equalityRegexp.FindStringSubmatch(part); m != nil {
+		operator := m[2]
+		if operator == "==" {
+			operator = OperatorEquals
+		}
+		r = Requirement{Key: m[1], Operator: operator, Values: []string{m[3]}}
+	} else if m := existsRegexp.FindStringSubmatch(part); m != nil {
+		r = Requirement{Key: m[1], Operator: OperatorExists}
+		if strings.HasPrefix(part, "!") {
+			r.Operator = OperatorDoesNotExist
+		}
+	} else {
+		return r, microerror.Maskf(invalidSelectorError, "cannot parse '%s', expected 'key', '!key', 'key=value', 'key!=value', 'key in (values)' or 'key notin (values)'", part)
+	}
+
+	err := ValidateKey(r.Key)
+	if err != nil {
+		return r, microerror.Maskf(invalidSelectorError, "%s", err.Error())
+	}
+	for _, v := range r.Values {
+		err = ValidateValue(v)
+		if err != nil {
+			return r, microerror.Maskf(invalidSelectorError, "%s", err.Error())
+		}
+	}
+
+	return r, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
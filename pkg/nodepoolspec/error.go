@@ -0,0 +1,13 @@
+package nodepoolspec
+
+import "github.com/giantswarm/microerror"
+
+var unavailableZonesError = &microerror.Error{
+	Kind: "unavailableZonesError",
+	Desc: "Some availability zones are not available in the target installation",
+}
+
+// IsUnavailableZones asserts unavailableZonesError.
+func IsUnavailableZones(err error) bool {
+	return microerror.Cause(err) == unavailableZonesError
+}
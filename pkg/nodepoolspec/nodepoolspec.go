@@ -0,0 +1,153 @@
+// Package nodepoolspec creates node pool creation requests based on existing node pools.
+package nodepoolspec
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/giantswarm/microerror"
+)
+
+// AddRequestFromNodePool returns a request to create a node pool with the
+// same name, availability zones, node spec and scaling as the given one.
+func AddRequestFromNodePool(np *models.V5GetNodePoolResponse) *models.V5AddNodePoolRequest {
+	request := &models.V5AddNodePoolRequest{
+		Name:     np.Name,
+		NodeSpec: &models.V5AddNodePoolRequestNodeSpec{},
+	}
+
+	if len(np.AvailabilityZones) > 0 {
+		zones := make([]string, len(np.AvailabilityZones))
+		copy(zones, np.AvailabilityZones)
+		request.AvailabilityZones = &models.V5AddNodePoolRequestAvailabilityZones{
+			Zones: zones,
+		}
+	}
+
+	if np.NodeSpec != nil && np.NodeSpec.Aws != nil {
+		useAlikeInstanceTypes := np.NodeSpec.Aws.UseAlikeInstanceTypes
+		request.NodeSpec.Aws = &models.V5AddNodePoolRequestNodeSpecAws{
+			InstanceType:          np.NodeSpec.Aws.InstanceType,
+			UseAlikeInstanceTypes: &useAlikeInstanceTypes,
+		}
+
+		if np.NodeSpec.Aws.InstanceDistribution != nil {
+			onDemandBaseCapacity := np.NodeSpec.Aws.InstanceDistribution.OnDemandBaseCapacity
+			onDemandPercentageAboveBaseCapacity := np.NodeSpec.Aws.InstanceDistribution.OnDemandPercentageAboveBaseCapacity
+			request.NodeSpec.Aws.InstanceDistribution = &models.V5AddNodePoolRequestNodeSpecAwsInstanceDistribution{
+				OnDemandBaseCapacity:                &onDemandBaseCapacity,
+				OnDemandPercentageAboveBaseCapacity: &onDemandPercentageAboveBaseCapacity,
+			}
+		}
+	}
+
+	if np.NodeSpec != nil && np.NodeSpec.Azure != nil {
+		request.NodeSpec.Azure = &models.V5AddNodePoolRequestNodeSpecAzure{
+			VMSize: np.NodeSpec.Azure.VMSize,
+		}
+
+		if np.NodeSpec.Azure.SpotInstances != nil {
+			enabled := np.NodeSpec.Azure.SpotInstances.Enabled
+			maxPrice := np.NodeSpec.Azure.SpotInstances.MaxPrice
+			request.NodeSpec.Azure.SpotInstances = &models.V5AddNodePoolRequestNodeSpecAzureSpotInstances{
+				Enabled:  &enabled,
+				MaxPrice: &maxPrice,
+			}
+		}
+	}
+
+	if np.Scaling != nil {
+		request.Scaling = &models.V5AddNodePoolRequestScaling{
+			Max: np.Scaling.Max,
+		}
+		if np.Scaling.Min != nil {
+			min := *np.Scaling.Min
+			request.Scaling.Min = &min
+		}
+	}
+
+	return request
+}
+
+// NodePoolFromListItem converts a node pool list item into the type returned
+// for a single node pool, which has the same structure.
+func NodePoolFromListItem(item *models.V5GetNodePoolsResponseItems) (*models.V5GetNodePoolResponse, error) {
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	np := &models.V5GetNodePoolResponse{}
+	err = json.Unmarshal(itemJSON, np)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return np, nil
+}
+
+// MapAvailabilityZones checks the given zones against the zones available
+// in the target installation. AWS zones of another region are mapped to the
+// zone with the same letter in the target region, e. g. 'eu-west-1b' becomes
+// 'eu-central-1b'. Zones which can't be mapped result in an error.
+//
+// If the list of available zones is empty, the zones are returned unchanged.
+func MapAvailabilityZones(zones []string, availableZones []string) ([]string, error) {
+	if len(availableZones) == 0 {
+		return zones, nil
+	}
+
+	available := map[string]bool{}
+	for _, zone := range availableZones {
+		available[zone] = true
+	}
+
+	mapped := []string{}
+	unavailable := []string{}
+
+	for _, zone := range zones {
+		if available[zone] {
+			mapped = append(mapped, zone)
+			continue
+		}
+
+		if candidate := zoneWithSameLetter(zone, availableZones); candidate != "" {
+			mapped = append(mapped, candidate)
+			continue
+		}
+
+		unavailable = append(unavailable, zone)
+	}
+
+	if len(unavailable) > 0 {
+		return nil, microerror.Maskf(unavailableZonesError, "zone(s) %s not available, available zones are %s", strings.Join(unavailable, ", "), strings.Join(availableZones, ", "))
+	}
+
+	return mapped, nil
+}
+
+// zoneWithSameLetter returns the only available AWS zone with the same
+// trailing letter as the given zone, or an empty string.
+func zoneWithSameLetter(zone string, availableZones []string) string {
+	if len(zone) < 2 {
+		return ""
+	}
+
+	letter := zone[len(zone)-1]
+	if letter < 'a' || letter > 'z' {
+		return ""
+	}
+
+	candidate := ""
+	for _, availableZone := range availableZones {
+		if len(availableZone) > 1 && availableZone[len(availableZone)-1] == letter {
+			if candidate != "" {
+				return ""
+			}
+			candidate = availableZone
+		}
+	}
+
+	return candidate
+}
@@ -0,0 +1,152 @@
+package nodepoolspec
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/gsclientgen/v2/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAddRequestFromNodePool(t *testing.T) {
+	testCases := []struct {
+		name         string
+		nodePoolJSON string
+		expectedJSON string
+	}{
+		{
+			name: "aws",
+			nodePoolJSON: `{
+				"id": "a7k",
+				"name": "General purpose",
+				"availability_zones": ["eu-central-1a", "eu-central-1b"],
+				"node_spec": {
+					"aws": {
+						"instance_type": "m5.xlarge",
+						"use_alike_instance_types": true,
+						"instance_distribution": {"on_demand_base_capacity": 2, "on_demand_percentage_above_base_capacity": 50}
+					},
+					"volume_sizes_gb": {"docker": 100, "kubelet": 100}
+				},
+				"scaling": {"min": 3, "max": 10},
+				"status": {"nodes": 3, "nodes_ready": 3}
+			}`,
+			expectedJSON: `{
+				"availability_zones": {"zones": ["eu-central-1a", "eu-central-1b"]},
+				"name": "General purpose",
+				"node_spec": {
+					"aws": {
+						"instance_distribution": {"on_demand_base_capacity": 2, "on_demand_percentage_above_base_capacity": 50},
+						"instance_type": "m5.xlarge",
+						"use_alike_instance_types": true
+					}
+				},
+				"scaling": {"max": 10, "min": 3}
+			}`,
+		},
+		{
+			name: "azure",
+			nodePoolJSON: `{
+				"id": "b8l",
+				"name": "Spot",
+				"availability_zones": ["1"],
+				"node_spec": {
+					"azure": {
+						"vm_size": "Standard_D4s_v3",
+						"spot_instances": {"enabled": true, "max_price": 0.5}
+					}
+				},
+				"scaling": {"min": 0, "max": 5}
+			}`,
+			expectedJSON: `{
+				"availability_zones": {"zones": ["1"]},
+				"name": "Spot",
+				"node_spec": {
+					"azure": {
+						"spot_instances": {"enabled": true, "max_price": 0.5},
+						"vm_size": "Standard_D4s_v3"
+					}
+				},
+				"scaling": {"max": 5, "min": 0}
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			np := &models.V5GetNodePoolResponse{}
+			err := json.Unmarshal([]byte(tc.nodePoolJSON), np)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			request := AddRequestFromNodePool(np)
+
+			requestJSON, err := json.Marshal(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, expected interface{}
+			_ = json.Unmarshal(requestJSON, &got)
+			_ = json.Unmarshal([]byte(tc.expectedJSON), &expected)
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("Request not as expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapAvailabilityZones(t *testing.T) {
+	testCases := []struct {
+		zones          []string
+		availableZones []string
+		expected       []string
+		errorMatcher   func(error) bool
+	}{
+		{
+			zones:          []string{"eu-central-1a", "eu-central-1c"},
+			availableZones: []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+			expected:       []string{"eu-central-1a", "eu-central-1c"},
+		},
+		{
+			zones:          []string{"eu-west-1b"},
+			availableZones: []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+			expected:       []string{"eu-central-1b"},
+		},
+		{
+			zones:          []string{"eu-west-1d"},
+			availableZones: []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+			errorMatcher:   IsUnavailableZones,
+		},
+		{
+			zones:          []string{"1", "3"},
+			availableZones: []string{"1", "2"},
+			errorMatcher:   IsUnavailableZones,
+		},
+		{
+			zones:          []string{"1", "3"},
+			availableZones: nil,
+			expected:       []string{"1", "3"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			mapped, err := MapAvailabilityZones(tc.zones, tc.availableZones)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Errorf("Error not matching expectation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.expected, mapped); diff != "" {
+				t.Errorf("Zones not as expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
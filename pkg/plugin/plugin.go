@@ -0,0 +1,156 @@
+// Package plugin discovers external gsctl plugins.
+//
+// A plugin is an executable named gsctl-<name> anywhere in the PATH. It is
+// run as 'gsctl <name>', similar to kubectl plugins. If several executables
+// with the same name exist, the first one in the PATH is used.
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Prefix is the file name prefix of plugin executables.
+	Prefix = "gsctl-"
+
+	// Environment variables set for plugins.
+	EnvEndpoint   = "GSCTL_ENDPOINT"
+	EnvAuthHeader = "GSCTL_AUTH_HEADER"
+	EnvConfigDir  = "GSCTL_CONFIG_DIR"
+	EnvOutput     = "GSCTL_OUTPUT"
+)
+
+// Plugin is an executable found in the PATH.
+type Plugin struct {
+	// Name is the subcommand name, e. g. "foo" for gsctl-foo.
+	Name string
+
+	// Path is the path of the executable used.
+	Path string
+
+	// ShadowedPaths are executables with the same name that appear later
+	// in the PATH and are therefore ignored.
+	ShadowedPaths []string
+}
+
+// Discover returns the plugins found in the directories of the given
+// PATH-style list, sorted by name.
+func Discover(pathList string) []Plugin {
+	byName := map[string]*Plugin{}
+	names := []string{}
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			// Non-existing directories in the PATH are common.
+			continue
+		}
+
+		for _, file := range files {
+			name, ok := pluginName(file)
+			if !ok {
+				continue
+			}
+
+			filePath := filepath.Join(dir, file.Name())
+			if p, exists := byName[name]; exists {
+				if p.Path != filePath {
+					p.ShadowedPaths = append(p.ShadowedPaths, filePath)
+				}
+				continue
+			}
+
+			byName[name] = &Plugin{Name: name, Path: filePath}
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	plugins := []Plugin{}
+	for _, name := range names {
+		plugins = append(plugins, *byName[name])
+	}
+
+	return plugins
+}
+
+// Find returns the plugin with the given name, if there is one.
+func Find(pathList, name string) (Plugin, bool) {
+	for _, p := range Discover(pathList) {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Plugin{}, false
+}
+
+// pluginName returns the subcommand name for a plugin executable and
+// false if the file is not a plugin.
+func pluginName(file os.FileInfo) (string, bool) {
+	if !strings.HasPrefix(file.Name(), Prefix) || file.IsDir() {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(file.Name(), Prefix)
+
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if !isWindowsExecutable(ext) {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if file.Mode().Perm()&0111 == 0 {
+		return "", false
+	}
+
+	if name == "" || strings.HasPrefix(name, "-") {
+		return "", false
+	}
+
+	return name, true
+}
+
+func isWindowsExecutable(ext string) bool {
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".com;.exe;.bat;.cmd"
+	}
+
+	for _, e := range strings.Split(strings.ToLower(pathExt), ";") {
+		if e != "" && e == ext {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsBuiltin returns true if name is a command or alias of the root command.
+// Such plugins can't be run, as the built-in command takes precedence.
+func IsBuiltin(root *cobra.Command, name string) bool {
+	// cobra adds the help command only on execution.
+	if name == "help" {
+		return true
+	}
+
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+
+	return false
+}
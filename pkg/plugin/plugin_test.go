@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// writeFiles creates files with the given modes in a new temp directory.
+func writeFiles(t *testing.T, files map[string]os.FileMode) string {
+	dir, err := ioutil.TempDir("", "plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, mode := range files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+// Test_Discover tests finding plugins in several PATH directories.
+func Test_Discover(t *testing.T) {
+	dir1 := writeFiles(t, map[string]os.FileMode{
+		"gsctl-foo":  0755,
+		"gsctl-list": 0755,
+		"gsctl-text": 0644,
+		"gsctl-":     0755,
+		"kubectl":    0755,
+	})
+	defer os.RemoveAll(dir1)
+	dir2 := writeFiles(t, map[string]os.FileMode{
+		"gsctl-foo": 0755,
+		"gsctl-bar": 0700,
+	})
+	defer os.RemoveAll(dir2)
+
+	pathList := strings.Join([]string{dir1, "/does/not/exist", "", dir2}, string(os.PathListSeparator))
+
+	expected := []Plugin{
+		{Name: "bar", Path: filepath.Join(dir2, "gsctl-bar")},
+		{Name: "foo", Path: filepath.Join(dir1, "gsctl-foo"), ShadowedPaths: []string{filepath.Join(dir2, "gsctl-foo")}},
+		{Name: "list", Path: filepath.Join(dir1, "gsctl-list")},
+	}
+
+	plugins := Discover(pathList)
+	if !reflect.DeepEqual(plugins, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, plugins)
+	}
+
+	p, ok := Find(pathList, "foo")
+	if !ok || p.Path != filepath.Join(dir1, "gsctl-foo") {
+		t.Errorf("Unexpected result %#v, %v", p, ok)
+	}
+
+	_, ok = Find(pathList, "text")
+	if ok {
+		t.Error("Non-executable file must not be found")
+	}
+}
+
+func Test_IsBuiltin(t *testing.T) {
+	root := &cobra.Command{Use: "gsctl"}
+	root.AddCommand(&cobra.Command{Use: "list", Aliases: []string{"ls"}})
+
+	for name, expected := range map[string]bool{"list": true, "ls": true, "help": true, "foo": false} {
+		if IsBuiltin(root, name) != expected {
+			t.Errorf("Expected IsBuiltin(%q) to be %v", name, expected)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package selfupdate
+
+import "github.com/giantswarm/microerror"
+
+var downloadFailedError = &microerror.Error{
+	Kind: "downloadFailedError",
+	Desc: "A release file could not be downloaded",
+}
+
+// IsDownloadFailed asserts downloadFailedError.
+func IsDownloadFailed(err error) bool {
+	return microerror.Cause(err) == downloadFailedError
+}
+
+var checksumMismatchError = &microerror.Error{
+	Kind: "checksumMismatchError",
+	Desc: "The checksum of the downloaded archive does not match",
+}
+
+// IsChecksumMismatch asserts checksumMismatchError.
+func IsChecksumMismatch(err error) bool {
+	return microerror.Cause(err) == checksumMismatchError
+}
+
+var invalidSignatureError = &microerror.Error{
+	Kind: "invalidSignatureError",
+	Desc: "The signature of the checksums file is invalid",
+}
+
+// IsInvalidSignature asserts invalidSignatureError.
+func IsInvalidSignature(err error) bool {
+	return microerror.Cause(err) == invalidSignatureError
+}
+
+var noSigningKeyError = &microerror.Error{
+	Kind: "noSigningKeyError",
+	Desc: "This build contains no key to verify release signatures",
+}
+
+// IsNoSigningKey asserts noSigningKeyError.
+func IsNoSigningKey(err error) bool {
+	return microerror.Cause(err) == noSigningKeyError
+}
+
+var binaryNotFoundError = &microerror.Error{
+	Kind: "binaryNotFoundError",
+	Desc: "The release archive does not contain the binary",
+}
+
+// IsBinaryNotFound asserts binaryNotFoundError.
+func IsBinaryNotFound(err error) bool {
+	return microerror.Cause(err) == binaryNotFoundError
+}
+
+var noBackupError = &microerror.Error{
+	Kind: "noBackupError",
+	Desc: "There is no backup of a previous binary",
+}
+
+// IsNoBackup asserts noBackupError.
+func IsNoBackup(err error) bool {
+	return microerror.Cause(err) == noBackupError
+}
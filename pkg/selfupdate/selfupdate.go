@@ -0,0 +1,410 @@
+// Package selfupdate downloads gsctl releases, verifies them and replaces
+// the running binary.
+//
+// A release consists of one archive per platform, named like
+// gsctl-<version>-<os>-<arch>.tar.gz (.zip for Windows), and a checksums
+// file gsctl-<version>-checksums.txt in the format of sha256sum. The
+// checksums file is signed using ed25519, with the signature in
+// gsctl-<version>-checksums.txt.sig.
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// DefaultBaseURL is the URL releases are downloaded from by default.
+	// Files are expected at <base URL>/<version>/<file name>.
+	DefaultBaseURL = "https://github.com/giantswarm/gsctl/releases/download"
+
+	// BackupSuffix is appended to the path of the binary to get the path
+	// of the backup created before replacing it.
+	BackupSuffix = ".bak"
+
+	programName = "gsctl"
+
+	newSuffix = ".new"
+	oldSuffix = ".old"
+
+	// maxDownloadSize limits the size of downloaded files.
+	maxDownloadSize = 200 * 1024 * 1024
+)
+
+// Config configures an Updater.
+type Config struct {
+	// BaseURL to download releases from. Defaults to DefaultBaseURL.
+	BaseURL string
+
+	// OS and Arch select the release archive, like "linux" and "amd64".
+	OS   string
+	Arch string
+
+	// PublicKey is the base64 encoded ed25519 key to verify the signature
+	// of the checksums file with.
+	PublicKey string
+
+	// SkipSignature disables the signature verification. The checksum of the
+	// archive is still compared, but as the checksums file is downloaded from
+	// the same base URL, this only detects broken downloads. No authenticity
+	// check is left.
+	SkipSignature bool
+
+	HTTPClient *http.Client
+}
+
+// Updater downloads and installs releases.
+type Updater struct {
+	baseURL       string
+	os            string
+	arch          string
+	publicKey     ed25519.PublicKey
+	skipSignature bool
+	httpClient    *http.Client
+}
+
+// New creates an Updater.
+func New(config Config) (*Updater, error) {
+	u := &Updater{
+		baseURL:       strings.TrimSuffix(config.BaseURL, "/"),
+		os:            config.OS,
+		arch:          config.Arch,
+		skipSignature: config.SkipSignature,
+		httpClient:    config.HTTPClient,
+	}
+
+	if u.baseURL == "" {
+		u.baseURL = DefaultBaseURL
+	}
+	if u.httpClient == nil {
+		u.httpClient = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	if config.PublicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(config.PublicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, microerror.Maskf(noSigningKeyError, "the public key is not a base64 encoded ed25519 key")
+		}
+		u.publicKey = ed25519.PublicKey(key)
+	}
+
+	return u, nil
+}
+
+// ArchiveName returns the file name of the release archive for a platform.
+func ArchiveName(version, goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+
+	return fmt.Sprintf("%s-%s-%s-%s.%s", programName, version, goos, goarch, ext)
+}
+
+// ChecksumsName returns the file name of the checksums file of a release.
+func ChecksumsName(version string) string {
+	return fmt.Sprintf("%s-%s-checksums.txt", programName, version)
+}
+
+// Download fetches the release archive of the given version, verifies it and
+// returns the binary contained in it.
+func (u *Updater) Download(version string) ([]byte, error) {
+	if u.publicKey == nil && !u.skipSignature {
+		return nil, microerror.Mask(noSigningKeyError)
+	}
+
+	checksums, err := u.get(version, ChecksumsName(version))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if !u.skipSignature {
+		signature, err := u.get(version, ChecksumsName(version)+".sig")
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		err = verifySignature(u.publicKey, checksums, signature)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	archiveName := ArchiveName(version, u.os, u.arch)
+	archive, err := u.get(version, archiveName)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	err = verifyChecksum(checksums, archiveName, archive)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	binary, err := extractBinary(archive, u.os)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return binary, nil
+}
+
+func (u *Updater) get(version, fileName string) ([]byte, error) {
+	fileURL := fmt.Sprintf("%s/%s/%s", u.baseURL, version, fileName)
+
+	resp, err := u.httpClient.Get(fileURL)
+	if err != nil {
+		return nil, microerror.Maskf(downloadFailedError, "%s: %s", fileURL, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, microerror.Maskf(downloadFailedError, "%s: HTTP status %d", fileURL, resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDownloadSize))
+	if err != nil {
+		return nil, microerror.Maskf(downloadFailedError, "%s: %s", fileURL, err.Error())
+	}
+
+	return data, nil
+}
+
+// verifySignature checks the ed25519 signature of data. The signature may
+// be given raw or base64 encoded.
+func verifySignature(key ed25519.PublicKey, data, signature []byte) error {
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil {
+			return microerror.Maskf(invalidSignatureError, "the signature is neither raw nor base64 encoded")
+		}
+		signature = decoded
+	}
+
+	if !ed25519.Verify(key, data, signature) {
+		return microerror.Mask(invalidSignatureError)
+	}
+
+	return nil
+}
+
+// verifyChecksum compares the SHA256 checksum of data with the one listed
+// for fileName in the checksums file.
+func verifyChecksum(checksums []byte, fileName string, data []byte) error {
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != fileName {
+			continue
+		}
+
+		if !strings.EqualFold(fields[0], actual) {
+			return microerror.Maskf(checksumMismatchError, "expected %s, got %s for %s", fields[0], actual, fileName)
+		}
+
+		return nil
+	}
+
+	return microerror.Maskf(checksumMismatchError, "no checksum listed for %s", fileName)
+}
+
+// extractBinary returns the gsctl binary from a release archive.
+func extractBinary(archive []byte, goos string) ([]byte, error) {
+	if goos == "windows" {
+		return extractFromZip(archive, programName+".exe")
+	}
+
+	return extractFromTarGz(archive, programName)
+}
+
+func extractFromTarGz(archive []byte, binaryName string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == binaryName {
+			data, err := ioutil.ReadAll(io.LimitReader(tr, maxDownloadSize))
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			return data, nil
+		}
+	}
+
+	return nil, microerror.Maskf(binaryNotFoundError, "no file %s in the archive", binaryName)
+}
+
+func extractFromZip(archive []byte, binaryName string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || path.Base(f.Name) != binaryName {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		defer rc.Close()
+
+		data, err := ioutil.ReadAll(io.LimitReader(rc, maxDownloadSize))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		return data, nil
+	}
+
+	return nil, microerror.Maskf(binaryNotFoundError, "no file %s in the archive", binaryName)
+}
+
+// Replace replaces the binary at executablePath with the given one and
+// keeps a copy of the previous binary at executablePath + BackupSuffix.
+// The new binary is written next to the old one first and then renamed,
+// so the executable path never points to a partially written file.
+func Replace(executablePath string, binary []byte) error {
+	info, err := os.Stat(executablePath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	newPath := executablePath + newSuffix
+	err = ioutil.WriteFile(newPath, binary, info.Mode().Perm())
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer os.Remove(newPath)
+
+	backupPath := executablePath + BackupSuffix
+
+	// Windows doesn't allow replacing a running binary, but renaming it.
+	if isWindows() {
+		os.Remove(backupPath)
+		err = os.Rename(executablePath, backupPath)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = os.Rename(newPath, executablePath)
+		if err != nil {
+			os.Rename(backupPath, executablePath)
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err = copyFile(executablePath, backupPath, info.Mode().Perm())
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = os.Rename(newPath, executablePath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Rollback restores the backup created by Replace.
+func Rollback(executablePath string) error {
+	backupPath := executablePath + BackupSuffix
+
+	_, err := os.Stat(backupPath)
+	if os.IsNotExist(err) {
+		return microerror.Maskf(noBackupError, "%s does not exist", backupPath)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if isWindows() {
+		oldPath := executablePath + oldSuffix
+		os.Remove(oldPath)
+		err = os.Rename(executablePath, oldPath)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	err = os.Rename(backupPath, executablePath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// ExecutablePath returns the path of the running binary, with symlinks resolved.
+func ExecutablePath() (string, error) {
+	executablePath, err := os.Executable()
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	executablePath, err = filepath.EvalSymlinks(executablePath)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return executablePath, nil
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return microerror.Mask(err)
+	}
+
+	return microerror.Mask(out.Close())
+}
+
+func isWindows() bool {
+	return runtime.GOOS == "windows"
+}
@@ -0,0 +1,147 @@
+package selfupdate
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+func newKey(t *testing.T) (string, ed25519.PrivateKey) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(public), private
+}
+
+// Test_Download tests downloading and verifying releases.
+func Test_Download(t *testing.T) {
+	publicKey, privateKey := newKey(t)
+	otherPublicKey, _ := newKey(t)
+	binary := []byte("new binary")
+
+	var testCases = []struct {
+		os            string
+		arch          string
+		publicKey     string
+		skipSignature bool
+		modify        func(files map[string][]byte)
+		errorMatcher  func(error) bool
+	}{
+		{os: "linux", arch: "amd64", publicKey: publicKey},
+		{os: "windows", arch: "386", publicKey: publicKey},
+		{os: "darwin", arch: "amd64", skipSignature: true},
+		{os: "linux", arch: "amd64", errorMatcher: IsNoSigningKey},
+		{os: "linux", arch: "amd64", publicKey: otherPublicKey, errorMatcher: IsInvalidSignature},
+		{os: "linux", arch: "arm64", publicKey: publicKey, errorMatcher: IsDownloadFailed},
+		{
+			os:        "linux",
+			arch:      "amd64",
+			publicKey: publicKey,
+			modify: func(files map[string][]byte) {
+				files["/1.2.3/gsctl-1.2.3-linux-amd64.tar.gz"] = append(files["/1.2.3/gsctl-1.2.3-linux-amd64.tar.gz"], 0)
+			},
+			errorMatcher: IsChecksumMismatch,
+		},
+		{
+			os:        "linux",
+			arch:      "amd64",
+			publicKey: publicKey,
+			modify: func(files map[string][]byte) {
+				files["/1.2.3/gsctl-1.2.3-checksums.txt"] = []byte("tampered")
+			},
+			errorMatcher: IsInvalidSignature,
+		},
+	}
+
+	for i, tc := range testCases {
+		release, err := testutils.NewFakeRelease("1.2.3", []string{"linux-amd64", "darwin-amd64", "windows-386"}, binary, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.modify != nil {
+			tc.modify(release.Files)
+		}
+
+		updater, err := New(Config{
+			BaseURL:       release.URL + "/",
+			OS:            tc.os,
+			Arch:          tc.arch,
+			PublicKey:     tc.publicKey,
+			SkipSignature: tc.skipSignature,
+		})
+		if err != nil {
+			t.Fatalf("Case %d - unexpected error %s", i, err)
+		}
+
+		got, err := updater.Download("1.2.3")
+		if tc.errorMatcher != nil {
+			if !tc.errorMatcher(err) {
+				t.Errorf("Case %d - unexpected error %v", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("Case %d - unexpected error %s", i, err)
+		} else if string(got) != string(binary) {
+			t.Errorf("Case %d - unexpected binary %q", i, got)
+		}
+
+		release.Close()
+	}
+}
+
+// Test_ReplaceAndRollback tests replacing a binary and restoring the backup.
+func Test_ReplaceAndRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "selfupdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	executablePath := path.Join(dir, "gsctl")
+	err = ioutil.WriteFile(executablePath, []byte("old binary"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Rollback(executablePath)
+	if !IsNoBackup(err) {
+		t.Errorf("Expected no backup error, got %v", err)
+	}
+
+	err = Replace(executablePath, []byte("new binary"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	content, _ := ioutil.ReadFile(executablePath)
+	if string(content) != "new binary" {
+		t.Errorf("Unexpected content %q", content)
+	}
+	info, _ := os.Stat(executablePath)
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Unexpected mode %s", info.Mode())
+	}
+	content, _ = ioutil.ReadFile(executablePath + BackupSuffix)
+	if string(content) != "old binary" {
+		t.Errorf("Unexpected backup content %q", content)
+	}
+	if _, err := os.Stat(executablePath + newSuffix); !os.IsNotExist(err) {
+		t.Errorf("Expected temporary file to be removed")
+	}
+
+	err = Rollback(executablePath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	content, _ = ioutil.ReadFile(executablePath)
+	if string(content) != "old binary" {
+		t.Errorf("Unexpected content after rollback %q", content)
+	}
+}
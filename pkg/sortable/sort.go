@@ -2,6 +2,7 @@ package sortable
 
 import (
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 
@@ -88,3 +89,51 @@ func GetCompareFunc(t string) func(string, string, string) bool {
 		return CompareStrings
 	}
 }
+
+// Compare compares two values of the given type. The result is 0 if the values
+// are equal, -1 if a is less than b, and 1 if a is greater than b.
+// Values which can't be parsed as the given type are compared as strings.
+func Compare(t string, a string, b string) int {
+	switch t {
+	case Semver:
+		verA, errA := semver.NewVersion(a)
+		verB, errB := semver.NewVersion(b)
+		if errA == nil && errB == nil {
+			return verA.Compare(verB)
+		}
+
+	case Date:
+		dateA := parseDate(a)
+		dateB := parseDate(b)
+		if !dateA.IsZero() && !dateB.IsZero() {
+			switch {
+			case dateA.Before(dateB):
+				return -1
+			case dateA.After(dateB):
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	if strings.EqualFold(a, b) {
+		return 0
+	}
+	if CompareStrings(a, b, ASC) {
+		return -1
+	}
+
+	return 1
+}
+
+// parseDate parses a date in any format understood by util.ParseDate,
+// or a plain day like '2020-01-02'.
+func parseDate(s string) time.Time {
+	day, err := time.Parse("2006-01-02", s)
+	if err == nil {
+		return day
+	}
+
+	return util.ParseDate(s)
+}
@@ -195,3 +195,71 @@ func Test_CompareDates(t *testing.T) {
 		})
 	}
 }
+
+func Test_Compare(t *testing.T) {
+	testCases := []struct {
+		sortType       string
+		a              string
+		b              string
+		expectedResult int
+	}{
+		{
+			sortType:       String,
+			a:              "Acme",
+			b:              "acme",
+			expectedResult: 0,
+		},
+		{
+			sortType:       String,
+			a:              "node-2",
+			b:              "node-10",
+			expectedResult: -1,
+		},
+		{
+			sortType:       Semver,
+			a:              "12.0.0",
+			b:              "9.1.0",
+			expectedResult: 1,
+		},
+		{
+			sortType:       Semver,
+			a:              "12.0",
+			b:              "12.0.0",
+			expectedResult: 0,
+		},
+		{
+			sortType:       Semver,
+			a:              "n/a",
+			b:              "12.0.0",
+			expectedResult: 1,
+		},
+		{
+			sortType:       Date,
+			a:              "2020-01-02T15:04:05.000Z",
+			b:              "2020-01-03",
+			expectedResult: -1,
+		},
+		{
+			sortType:       Date,
+			a:              "2020 Jan 03, 15:04 UTC",
+			b:              "2020-01-03",
+			expectedResult: 1,
+		},
+		{
+			sortType:       Date,
+			a:              "2020-01-03",
+			b:              "2020-01-03T00:00:00Z",
+			expectedResult: 0,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			result := Compare(tc.sortType, tc.a, tc.b)
+
+			if result != tc.expectedResult {
+				t.Errorf("Case %d - Expected %d, got %d", i, tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package ssotoken
+
+import "github.com/giantswarm/microerror"
+
+var reloginRequiredError = &microerror.Error{
+	Kind: "reloginRequiredError",
+	Desc: "The SSO token has expired and cannot be refreshed",
+}
+
+// IsReloginRequired asserts reloginRequiredError.
+func IsReloginRequired(err error) bool {
+	return microerror.Cause(err) == reloginRequiredError
+}
+
+var invalidTokenError = &microerror.Error{
+	Kind: "invalidTokenError",
+	Desc: "The token is not a valid JWT",
+}
+
+// IsInvalidToken asserts invalidTokenError.
+func IsInvalidToken(err error) bool {
+	return microerror.Cause(err) == invalidTokenError
+}
+
+var refreshFailedError = &microerror.Error{
+	Kind: "refreshFailedError",
+	Desc: "The identity provider did not return a new access token",
+}
+
+// IsRefreshFailed asserts refreshFailedError.
+func IsRefreshFailed(err error) bool {
+	return microerror.Cause(err) == refreshFailedError
+}
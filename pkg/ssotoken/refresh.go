@@ -0,0 +1,86 @@
+package ssotoken
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// clientID and tokenURL are the ones gscliauth uses for the login.
+	clientID = "zQiFLUnrTFQwrybYzeY53hWWfhOKWRAU"
+	tokenURL = "https://giantswarm.eu.auth0.com/oauth/token"
+
+	// refreshTimeout limits the refresh request, which is made while the
+	// config file lock is held. It has to be well below the age after which
+	// other processes consider the lock stale, as they would otherwise use
+	// the same refresh token concurrently.
+	refreshTimeout = 15 * time.Second
+)
+
+var refreshClient = &http.Client{Timeout: refreshTimeout}
+
+// refreshResponse is the response of the token endpoint to a refresh request.
+// Unlike oidc.RefreshResponse of gscliauth, it contains the new refresh token
+// the identity provider returns if it rotates refresh tokens.
+type refreshResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type refreshRequest struct {
+	ClientID     string `json:"client_id"`
+	GrantType    string `json:"grant_type"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// requestRefresh uses the refresh token to get a new access token, like
+// oidc.RefreshToken of gscliauth does.
+func requestRefresh(refreshToken string) (refreshResponse, error) {
+	response := refreshResponse{}
+
+	payload, err := json.Marshal(refreshRequest{
+		ClientID:     clientID,
+		GrantType:    "refresh_token",
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return response, microerror.Mask(err)
+	}
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(string(payload)))
+	if err != nil {
+		return response, microerror.Mask(err)
+	}
+	req.Header.Add("content-type", "application/json")
+
+	res, err := refreshClient.Do(req)
+	if err != nil {
+		return response, microerror.Mask(err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return response, microerror.Mask(err)
+	}
+
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return response, microerror.Maskf(refreshFailedError, "unparseable response with status %d", res.StatusCode)
+	}
+	if response.Error != "" {
+		return response, microerror.Maskf(refreshFailedError, "%s: %s", response.Error, response.ErrorDescription)
+	}
+	if res.StatusCode != http.StatusOK {
+		return response, microerror.Maskf(refreshFailedError, "unexpected status %d", res.StatusCode)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,408 @@
+// Package ssotoken provides the Authorization header for API requests and
+// keeps SSO access tokens valid.
+//
+// SSO access tokens are refreshed shortly before they expire, using the
+// refresh token stored with the endpoint. Refreshes are serialized between
+// goroutines, and between gsctl processes using a lock on the config file.
+// Only the tokens of the endpoint get changed in the file, which is replaced
+// atomically when the new tokens are stored.
+package ssotoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/gsctl/pkg/filelock"
+)
+
+const (
+	// Scheme is the authorization scheme used with SSO tokens.
+	Scheme = "Bearer"
+
+	// RefreshMargin is the remaining validity below which a token gets
+	// refreshed before it is used, so it doesn't expire during a request.
+	RefreshMargin = time.Minute
+
+	// PreflightMargin is the remaining validity below which EnsureValid
+	// refreshes a token, so that it stays valid while a command runs.
+	PreflightMargin = 5 * time.Minute
+
+	lockTimeout = 10 * time.Second
+)
+
+var (
+	// refreshToken requests a new access token. Replaced in tests.
+	refreshToken = requestRefresh
+
+	// refreshMutex serializes refreshes within this process.
+	refreshMutex sync.Mutex
+)
+
+// Claims are the claims of an SSO token we make use of.
+type Claims struct {
+	Subject string
+	Email   string
+	Groups  []string
+
+	// Expiry is the zero time if the token has no expiry.
+	Expiry time.Time
+}
+
+// ParseClaims decodes the claims of a JWT. The signature is not verified.
+// Groups are taken from the 'groups' claim, or from a namespaced custom
+// claim ending in '/groups' as used by Auth0.
+func ParseClaims(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, microerror.Maskf(invalidTokenError, "expected 3 parts, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return Claims{}, microerror.Maskf(invalidTokenError, err.Error())
+	}
+
+	raw := map[string]interface{}{}
+	err = json.Unmarshal(payload, &raw)
+	if err != nil {
+		return Claims{}, microerror.Maskf(invalidTokenError, err.Error())
+	}
+
+	claims := Claims{}
+	claims.Subject, _ = raw["sub"].(string)
+	claims.Email, _ = raw["email"].(string)
+	if exp, ok := raw["exp"].(float64); ok && exp != 0 {
+		claims.Expiry = time.Unix(int64(exp), 0)
+	}
+
+	for name, value := range raw {
+		if name != "groups" && !strings.HasSuffix(name, "/groups") {
+			continue
+		}
+		if groups, ok := value.([]interface{}); ok {
+			for _, g := range groups {
+				if group, ok := g.(string); ok {
+					claims.Groups = append(claims.Groups, group)
+				}
+			}
+		}
+	}
+	sort.Strings(claims.Groups)
+
+	return claims, nil
+}
+
+// Expiry returns the expiry time of a JWT, given by its 'exp' claim.
+// The signature is not verified. A zero time is returned if the token
+// has no expiry.
+func Expiry(token string) (time.Time, error) {
+	claims, err := ParseClaims(token)
+	if err != nil {
+		return time.Time{}, microerror.Mask(err)
+	}
+
+	return claims.Expiry, nil
+}
+
+// AuthHeaderGetter returns a function that returns the Authorization header
+// for the given endpoint. It replaces the getter of gscliauth, adding the
+// refresh before expiry, the locking and a clear error if the user has to
+// log in again.
+func AuthHeaderGetter(endpoint, overridingToken string) func() (string, error) {
+	return func() (string, error) {
+		token := config.Config.ChooseToken(endpoint, overridingToken)
+		scheme := config.Config.ChooseScheme(endpoint, overridingToken)
+
+		if scheme != Scheme {
+			return scheme + " " + token, nil
+		}
+
+		token, err := validToken(endpoint, RefreshMargin)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		return scheme + " " + token, nil
+	}
+}
+
+// EnsureValid checks the SSO token of an endpoint before a command starts,
+// and refreshes it if it expires soon. It returns an error if the user has
+// to log in again. Endpoints not using SSO are not checked.
+func EnsureValid(endpoint, overridingToken string) error {
+	if overridingToken != "" {
+		return nil
+	}
+
+	ec := config.Config.EndpointConfig(endpoint)
+	if ec == nil || ec.Scheme != Scheme {
+		return nil
+	}
+
+	_, err := validToken(endpoint, PreflightMargin)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// validToken returns the access token of the endpoint, after refreshing it
+// if it is valid for less than the given margin.
+func validToken(endpoint string, margin time.Duration) (string, error) {
+	refreshMutex.Lock()
+	defer refreshMutex.Unlock()
+
+	ec := config.Config.EndpointConfig(endpoint)
+	if ec == nil {
+		return "", microerror.Maskf(reloginRequiredError, "endpoint %s is not defined in the configuration", endpoint)
+	}
+	if !expiresWithin(ec.Token, margin) {
+		return ec.Token, nil
+	}
+	if ec.RefreshToken == "" {
+		return "", microerror.Maskf(reloginRequiredError, "the SSO token for %s has expired and there is no refresh token", endpoint)
+	}
+
+	lock, err := filelock.Acquire(config.FileSystem, config.ConfigFilePath, lockTimeout)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	defer lock.Release()
+
+	// Another gsctl process might have refreshed the token in the meantime.
+	stored, err := readStoredTokens(endpoint)
+	if err == nil && stored.Token != "" && !expiresWithin(stored.Token, margin) {
+		setTokens(endpoint, stored.Token, stored.RefreshToken)
+		return stored.Token, nil
+	}
+	if err == nil && stored.RefreshToken != "" {
+		ec.RefreshToken = stored.RefreshToken
+	}
+
+	response, err := refreshToken(ec.RefreshToken)
+	if err != nil {
+		return "", microerror.Maskf(reloginRequiredError, "the SSO token for %s could not be refreshed: %s", endpoint, err.Error())
+	}
+
+	// The identity provider may rotate the refresh token.
+	newRefreshToken := ec.RefreshToken
+	if response.RefreshToken != "" {
+		newRefreshToken = response.RefreshToken
+	}
+
+	setTokens(endpoint, response.AccessToken, newRefreshToken)
+
+	err = writeTokens(endpoint, response.AccessToken, newRefreshToken)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return response.AccessToken, nil
+}
+
+// expiresWithin returns true if the token is invalid or expires within the margin.
+func expiresWithin(token string, margin time.Duration) bool {
+	expiry, err := Expiry(token)
+	if err != nil {
+		return true
+	}
+	if expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(margin).After(expiry)
+}
+
+func setTokens(endpoint, token, refreshToken string) {
+	ec := config.Config.EndpointConfig(endpoint)
+	ec.Token = token
+	ec.RefreshToken = refreshToken
+
+	if config.Config.SelectedEndpoint == endpoint {
+		config.Config.Token = token
+		config.Config.RefreshToken = refreshToken
+	}
+}
+
+// UpdateConfig applies changes to the configuration and writes it to the
+// config file, while holding the lock used for token refreshes. Before,
+// tokens which other gsctl processes have refreshed since the configuration
+// has been read are taken over from the file, so that writing the
+// configuration doesn't replace them with outdated ones. As the lock is
+// held, update must not make API calls, which could refresh a token.
+func UpdateConfig(update func() error) error {
+	refreshMutex.Lock()
+	defer refreshMutex.Unlock()
+
+	lock, err := filelock.Acquire(config.FileSystem, config.ConfigFilePath, lockTimeout)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer lock.Release()
+
+	err = mergeStoredTokens()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = update()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = config.WriteToFile()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// mergeStoredTokens takes over the tokens of endpoints from the config
+// file, where they expire later than the ones in memory. Endpoints without
+// a token in memory, e. g. after logging out, are left as they are.
+func mergeStoredTokens() error {
+	stored, err := readStoredEndpoints()
+	if os.IsNotExist(microerror.Cause(err)) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for endpoint, tokens := range stored {
+		ec := config.Config.EndpointConfig(endpoint)
+		if ec == nil || ec.Token == "" || tokens.Token == ec.Token {
+			continue
+		}
+
+		storedExpiry, err := Expiry(tokens.Token)
+		if err != nil || storedExpiry.IsZero() {
+			continue
+		}
+		currentExpiry, err := Expiry(ec.Token)
+		if err != nil || storedExpiry.After(currentExpiry) {
+			setTokens(endpoint, tokens.Token, tokens.RefreshToken)
+		}
+	}
+
+	return nil
+}
+
+type storedTokens struct {
+	Token        string `yaml:"token"`
+	RefreshToken string `yaml:"refresh_token"`
+}
+
+// readStoredTokens reads the current tokens of an endpoint from the config file.
+func readStoredTokens(endpoint string) (storedTokens, error) {
+	stored, err := readStoredEndpoints()
+	if err != nil {
+		return storedTokens{}, microerror.Mask(err)
+	}
+
+	return stored[endpoint], nil
+}
+
+// readStoredEndpoints reads the current tokens of all endpoints from the
+// config file, keyed by endpoint URL.
+func readStoredEndpoints() (map[string]storedTokens, error) {
+	data, err := afero.ReadFile(config.FileSystem, config.ConfigFilePath)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	file := struct {
+		Endpoints map[string]storedTokens `yaml:"endpoints"`
+	}{}
+	err = yaml.Unmarshal(data, &file)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return file.Endpoints, nil
+}
+
+// writeTokens stores the tokens of an endpoint in the config file. The file
+// is read again while the lock is held, and only the tokens of the endpoint
+// are changed, so that changes written by other gsctl processes since this
+// one has read the configuration are kept. The file is written to a
+// temporary file first which then replaces the config file, so that other
+// processes never read a partially written file.
+func writeTokens(endpoint, token, refreshToken string) error {
+	data, err := afero.ReadFile(config.FileSystem, config.ConfigFilePath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	file := yaml.MapSlice{}
+	err = yaml.Unmarshal(data, &file)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	endpoints, _ := mapValue(file, "endpoints").(yaml.MapSlice)
+	ec, ok := mapValue(endpoints, endpoint).(yaml.MapSlice)
+	if !ok {
+		// The endpoint has been removed by another process in the meantime.
+		return nil
+	}
+
+	ec = setMapValue(ec, "token", token)
+	ec = setMapValue(ec, "refresh_token", refreshToken)
+	endpoints = setMapValue(endpoints, endpoint, ec)
+	file = setMapValue(file, "endpoints", endpoints)
+	file = setMapValue(file, "updated", time.Now().Format(time.RFC3339))
+
+	data, err = yaml.Marshal(file)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	tempPath := config.ConfigFilePath + ".tmp"
+	err = afero.WriteFile(config.FileSystem, tempPath, data, config.ConfigFilePermission)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = config.FileSystem.Rename(tempPath, config.ConfigFilePath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// mapValue returns the value of a key in a YAML mapping, or nil.
+func mapValue(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+
+	return nil
+}
+
+// setMapValue sets the value of a key in a YAML mapping, keeping the
+// order of existing keys.
+func setMapValue(m yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i, item := range m {
+		if item.Key == key {
+			m[i].Value = value
+			return m
+		}
+	}
+
+	return append(m, yaml.MapItem{Key: key, Value: value})
+}
@@ -0,0 +1,284 @@
+package ssotoken
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// jwt returns an unsigned JWT expiring at the given time.
+func jwt(expiry time.Time) string {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	payload := enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user","exp":%d}`, expiry.Unix())))
+	return header + "." + payload + ".signature"
+}
+
+func tempConfig(t *testing.T, token, refresh string) afero.Fs {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, fmt.Sprintf(`endpoints:
+  https://api.sso.example.com:
+    email: email@example.com
+    token: %s
+    refresh_token: %s
+    auth_scheme: Bearer
+  https://api.other.example.com:
+    email: email@example.com
+    token: some-token
+    auth_scheme: giantswarm
+selected_endpoint: https://api.sso.example.com
+`, token, refresh))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fs
+}
+
+func Test_Expiry(t *testing.T) {
+	expiry := time.Unix(1600000000, 0)
+
+	got, err := Expiry(jwt(expiry))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !got.Equal(expiry) {
+		t.Errorf("Expected %s, got %s", expiry, got)
+	}
+
+	_, err = Expiry("not-a-jwt")
+	if !IsInvalidToken(err) {
+		t.Errorf("Expected invalid token error, got %v", err)
+	}
+}
+
+func Test_ParseClaims(t *testing.T) {
+	enc := base64.RawURLEncoding
+	payload := enc.EncodeToString([]byte(`{"sub":"auth0|123","email":"user@example.com","exp":1600000000,"https://giantswarm.io/groups":["ops","dev"]}`))
+
+	claims, err := ParseClaims("header." + payload + ".signature")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if claims.Subject != "auth0|123" || claims.Email != "user@example.com" {
+		t.Errorf("Unexpected claims %#v", claims)
+	}
+	if strings.Join(claims.Groups, ",") != "dev,ops" {
+		t.Errorf("Unexpected groups %v", claims.Groups)
+	}
+	if claims.Expiry.Unix() != 1600000000 {
+		t.Errorf("Unexpected expiry %s", claims.Expiry)
+	}
+}
+
+// Test_AuthHeaderGetter tests that tokens are refreshed shortly before they
+// expire and that the new token gets stored.
+func Test_AuthHeaderGetter(t *testing.T) {
+	validToken := jwt(time.Now().Add(time.Hour))
+	refreshedToken := jwt(time.Now().Add(2 * time.Hour))
+
+	var testCases = []struct {
+		token         string
+		refreshToken  string
+		refreshErr    error
+		expected      string
+		expectRefresh bool
+		errorMatcher  func(error) bool
+	}{
+		{token: validToken, refreshToken: "refresh", expected: validToken},
+		{token: jwt(time.Now().Add(30 * time.Second)), refreshToken: "refresh", expected: refreshedToken, expectRefresh: true},
+		{token: jwt(time.Now().Add(-time.Hour)), refreshToken: "refresh", expected: refreshedToken, expectRefresh: true},
+		{token: jwt(time.Now().Add(-time.Hour)), refreshToken: "", errorMatcher: IsReloginRequired},
+		{token: jwt(time.Now().Add(-time.Hour)), refreshToken: "revoked", refreshErr: errors.New("invalid_grant"), expectRefresh: true, errorMatcher: IsReloginRequired},
+	}
+
+	defer func() { refreshToken = requestRefresh }()
+
+	for i, tc := range testCases {
+		fs := tempConfig(t, tc.token, tc.refreshToken)
+
+		refreshed := false
+		refreshToken = func(rt string) (refreshResponse, error) {
+			refreshed = true
+			if rt != tc.refreshToken {
+				t.Errorf("Case %d - unexpected refresh token %q", i, rt)
+			}
+			return refreshResponse{AccessToken: refreshedToken}, tc.refreshErr
+		}
+
+		header, err := AuthHeaderGetter("https://api.sso.example.com", "")()
+		if tc.errorMatcher != nil {
+			if !tc.errorMatcher(err) {
+				t.Errorf("Case %d - unexpected error %v", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("Case %d - unexpected error %v", i, err)
+		} else if header != "Bearer "+tc.expected {
+			t.Errorf("Case %d - unexpected header %q", i, header)
+		}
+
+		if refreshed != tc.expectRefresh {
+			t.Errorf("Case %d - expected refresh %v, got %v", i, tc.expectRefresh, refreshed)
+		}
+
+		if tc.expectRefresh && tc.errorMatcher == nil {
+			stored, _ := afero.ReadFile(fs, config.ConfigFilePath)
+			if !strings.Contains(string(stored), refreshedToken) || !strings.Contains(string(stored), "refresh_token: refresh") {
+				t.Errorf("Case %d - expected refreshed token to be stored, got:\n%s", i, stored)
+			}
+			if config.Config.Token != refreshedToken {
+				t.Errorf("Case %d - expected refreshed token to be selected", i)
+			}
+			if exists, _ := afero.Exists(fs, config.ConfigFilePath+".lock"); exists {
+				t.Errorf("Case %d - expected lock to be released", i)
+			}
+		}
+	}
+}
+
+// Test_AuthHeaderGetterRefreshedElsewhere tests that a token refreshed by
+// another process is used instead of refreshing again.
+func Test_AuthHeaderGetterRefreshedElsewhere(t *testing.T) {
+	fs := tempConfig(t, jwt(time.Now().Add(-time.Hour)), "refresh")
+
+	newToken := jwt(time.Now().Add(time.Hour))
+	stored, _ := afero.ReadFile(fs, config.ConfigFilePath)
+	stored = []byte(strings.Replace(string(stored), config.Config.Token, newToken, 1))
+	afero.WriteFile(fs, config.ConfigFilePath, stored, 0600)
+
+	refreshToken = func(rt string) (refreshResponse, error) {
+		t.Error("Unexpected refresh")
+		return refreshResponse{}, nil
+	}
+	defer func() { refreshToken = requestRefresh }()
+
+	header, err := AuthHeaderGetter("https://api.sso.example.com", "")()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if header != "Bearer "+newToken {
+		t.Errorf("Unexpected header %q", header)
+	}
+}
+
+// Test_EnsureValid tests the check before a command starts.
+func Test_EnsureValid(t *testing.T) {
+	tempConfig(t, jwt(time.Now().Add(2*time.Minute)), "")
+
+	err := EnsureValid("https://api.sso.example.com", "")
+	if !IsReloginRequired(err) {
+		t.Errorf("Expected relogin required error, got %v", err)
+	}
+
+	// Tokens given via flag and other schemes are not checked.
+	err = EnsureValid("https://api.sso.example.com", "flag-token")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	err = EnsureValid("https://api.other.example.com", "")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	header, err := AuthHeaderGetter("https://api.other.example.com", "")()
+	if err != nil || header != "giantswarm some-token" {
+		t.Errorf("Unexpected header %q, error %v", header, err)
+	}
+}
+
+// Test_AuthHeaderGetterRotatedRefreshToken tests that a new refresh token
+// returned by the identity provider is stored, and that changes written to
+// the config file by another process in the meantime are kept.
+func Test_AuthHeaderGetterRotatedRefreshToken(t *testing.T) {
+	fs := tempConfig(t, jwt(time.Now().Add(-time.Hour)), "refresh")
+
+	// Another process selects a different endpoint.
+	stored, _ := afero.ReadFile(fs, config.ConfigFilePath)
+	stored = []byte(strings.Replace(string(stored), "selected_endpoint: https://api.sso.example.com", "selected_endpoint: https://api.other.example.com", 1))
+	afero.WriteFile(fs, config.ConfigFilePath, stored, 0600)
+
+	refreshedToken := jwt(time.Now().Add(time.Hour))
+	refreshToken = func(rt string) (refreshResponse, error) {
+		return refreshResponse{AccessToken: refreshedToken, RefreshToken: "rotated"}, nil
+	}
+	defer func() { refreshToken = requestRefresh }()
+
+	_, err := AuthHeaderGetter("https://api.sso.example.com", "")()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if config.Config.RefreshToken != "rotated" {
+		t.Errorf("Expected rotated refresh token to be selected, got %q", config.Config.RefreshToken)
+	}
+
+	stored, _ = afero.ReadFile(fs, config.ConfigFilePath)
+	if !strings.Contains(string(stored), "refresh_token: rotated") || !strings.Contains(string(stored), refreshedToken) {
+		t.Errorf("Expected refreshed tokens to be stored, got:\n%s", stored)
+	}
+	if !strings.Contains(string(stored), "selected_endpoint: https://api.other.example.com") {
+		t.Errorf("Expected change of another process to be kept, got:\n%s", stored)
+	}
+	if !strings.Contains(string(stored), "token: some-token") {
+		t.Errorf("Expected other endpoint to be kept, got:\n%s", stored)
+	}
+}
+
+// Test_UpdateConfig tests that writing the configuration keeps tokens
+// another process has refreshed in the meantime, unless they have been
+// removed in this process.
+func Test_UpdateConfig(t *testing.T) {
+	fs := tempConfig(t, jwt(time.Now().Add(time.Minute)), "refresh")
+
+	// Another process refreshes the token.
+	newToken := jwt(time.Now().Add(time.Hour))
+	stored, _ := afero.ReadFile(fs, config.ConfigFilePath)
+	stored = []byte(strings.Replace(string(stored), config.Config.Token, newToken, 1))
+	stored = []byte(strings.Replace(string(stored), "refresh_token: refresh", "refresh_token: rotated", 1))
+	afero.WriteFile(fs, config.ConfigFilePath, stored, 0600)
+
+	err := UpdateConfig(func() error {
+		config.Config.EndpointConfig("https://api.sso.example.com").Alias = "sso"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	stored, _ = afero.ReadFile(fs, config.ConfigFilePath)
+	if !strings.Contains(string(stored), newToken) || !strings.Contains(string(stored), "refresh_token: rotated") {
+		t.Errorf("Expected tokens refreshed elsewhere to be kept, got:\n%s", stored)
+	}
+	if !strings.Contains(string(stored), "alias: sso") {
+		t.Errorf("Expected change to be written, got:\n%s", stored)
+	}
+	if config.Config.Token != newToken {
+		t.Error("Expected token refreshed elsewhere to be selected")
+	}
+	if exists, _ := afero.Exists(fs, config.ConfigFilePath+".lock"); exists {
+		t.Error("Expected lock to be released")
+	}
+
+	// Logging out must not bring back the stored tokens.
+	err = UpdateConfig(func() error {
+		config.Config.Logout("https://api.sso.example.com")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	stored, _ = afero.ReadFile(fs, config.ConfigFilePath)
+	if strings.Contains(string(stored), newToken) || strings.Contains(string(stored), "rotated") {
+		t.Errorf("Expected tokens to be removed, got:\n%s", stored)
+	}
+}
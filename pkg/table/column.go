@@ -14,7 +14,10 @@ type Column struct {
 	Name string
 	// DisplayName represents the table header visible in the printed table.
 	DisplayName string
-	Hidden      bool
+	// Aliases are additional names the column can be referred to by,
+	// e. g. the field name used in the JSON output.
+	Aliases []string
+	Hidden  bool
 }
 
 // GetHeader gets the table header for the current column.
@@ -21,3 +21,12 @@ var multipleFieldsMatchingError = &microerror.Error{
 func IsMultipleFieldsMatchingError(err error) bool {
 	return microerror.Cause(err) == multipleFieldsMatchingError
 }
+
+var invalidFilterError = &microerror.Error{
+	Kind: "invalidFilterError",
+}
+
+// IsInvalidFilterError asserts invalidFilterError.
+func IsInvalidFilterError(err error) bool {
+	return microerror.Cause(err) == invalidFilterError
+}
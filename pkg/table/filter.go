@@ -0,0 +1,172 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/gsctl/pkg/sortable"
+)
+
+// The comparison operators available in filter expressions.
+const (
+	OperatorEqual          = "="
+	OperatorNotEqual       = "!="
+	OperatorGreater        = ">"
+	OperatorGreaterOrEqual = ">="
+	OperatorLess           = "<"
+	OperatorLessOrEqual    = "<="
+)
+
+// operators is ordered so that two-character operators are tried first.
+var operators = []string{
+	OperatorNotEqual,
+	OperatorGreaterOrEqual,
+	OperatorLessOrEqual,
+	"==",
+	OperatorEqual,
+	OperatorGreater,
+	OperatorLess,
+}
+
+// Condition represents the comparison of a column's value with a given value.
+type Condition struct {
+	Column   Column
+	Operator string
+	Value    string
+}
+
+// Filter is a list of conditions, all of which have to be met by a row.
+type Filter []Condition
+
+// ParseFilter parses a filter expression like 'release>=12.0.0,organization=acme'
+// into a Filter. Column names can be given by their initials, like for sorting.
+func (t *Table) ParseFilter(expr string) (Filter, error) {
+	var f Filter
+
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		opIndex := strings.IndexAny(part, "!=<>")
+		if opIndex < 1 {
+			return nil, microerror.Maskf(invalidFilterError, "condition '%s' must have the form <attribute><operator><value>", part)
+		}
+
+		operator := ""
+		for _, op := range operators {
+			if strings.HasPrefix(part[opIndex:], op) {
+				operator = op
+				break
+			}
+		}
+		if operator == "" {
+			return nil, microerror.Maskf(invalidFilterError, "condition '%s' has no valid operator, use one of =, !=, >, >=, <, <=", part)
+		}
+
+		name := strings.TrimSpace(part[:opIndex])
+		value := strings.TrimSpace(part[opIndex+len(operator):])
+		if value == "" {
+			return nil, microerror.Maskf(invalidFilterError, "condition '%s' has no value", part)
+		}
+		if operator == "==" {
+			operator = OperatorEqual
+		}
+
+		colName, err := t.GetColumnNameFromInitials(name)
+		if IsMultipleFieldsMatchingError(err) {
+			return nil, microerror.Maskf(invalidFilterError, "attribute '%s' is ambiguous, available fields: %s", name, strings.Join(t.columnNames(), ", "))
+		} else if err != nil {
+			return nil, microerror.Maskf(invalidFilterError, "attribute '%s' does not exist, available fields: %s", name, strings.Join(t.columnNames(), ", "))
+		}
+		_, column, err := t.GetColumnByName(colName)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		f = append(f, Condition{
+			Column:   column,
+			Operator: operator,
+			Value:    value,
+		})
+	}
+
+	return f, nil
+}
+
+// Matches returns whether the given value fulfills the condition.
+// An unknown value ('n/a') only matches when checking for inequality,
+// unless it is explicitly compared with 'n/a'.
+func (c Condition) Matches(value string) bool {
+	if value == "n/a" && c.Value != "n/a" {
+		return c.Operator == OperatorNotEqual
+	}
+
+	cmp := sortable.Compare(c.Column.SortType, value, c.Value)
+
+	switch c.Operator {
+	case OperatorNotEqual:
+		return cmp != 0
+	case OperatorGreater:
+		return cmp > 0
+	case OperatorGreaterOrEqual:
+		return cmp >= 0
+	case OperatorLess:
+		return cmp < 0
+	case OperatorLessOrEqual:
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Matches returns whether all conditions of the filter are met. The value
+// function must return the value of the column with the given name.
+func (f Filter) Matches(value func(colName string) string) bool {
+	for _, c := range f {
+		if !c.Matches(value(c.Column.Name)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterRows removes all rows from the table which don't match the filter.
+func (t *Table) FilterRows(f Filter) {
+	if len(f) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(t.rows))
+	for _, row := range t.rows {
+		matches := f.Matches(func(colName string) string {
+			colIndex, _, err := t.GetColumnByName(colName)
+			if err != nil || colIndex >= len(row) {
+				return "n/a"
+			}
+
+			return RemoveColors(row[colIndex])
+		})
+
+		if matches {
+			rows = append(rows, row)
+		}
+	}
+
+	t.rows = rows
+}
+
+// columnNames returns the names of all named columns.
+func (t *Table) columnNames() []string {
+	names := make([]string, 0, len(t.columns))
+	for _, col := range t.columns {
+		if col.Name != "" {
+			names = append(names, col.Name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,165 @@
+package table
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/gsctl/pkg/sortable"
+)
+
+var filterTestColumns = []Column{
+	{
+		Name:     "id",
+		Sortable: sortable.Sortable{SortType: sortable.String},
+	},
+	{
+		Name:     "organization",
+		Aliases:  []string{"owner"},
+		Sortable: sortable.Sortable{SortType: sortable.String},
+	},
+	{
+		Name:     "release",
+		Sortable: sortable.Sortable{SortType: sortable.Semver},
+	},
+	{
+		Name:     "created",
+		Sortable: sortable.Sortable{SortType: sortable.Date},
+	},
+}
+
+func Test_ParseFilter(t *testing.T) {
+	testCases := []struct {
+		expr           string
+		expectedResult []string
+		errorMatcher   func(error) bool
+	}{
+		{
+			expr:           "release>=12.0.0,owner=acme",
+			expectedResult: []string{"release >= 12.0.0", "organization = acme"},
+		},
+		{
+			expr:           " r < 12 , o!=acme ,",
+			expectedResult: []string{"release < 12", "organization != acme"},
+		},
+		{
+			expr:           "id==abc12",
+			expectedResult: []string{"id = abc12"},
+		},
+		{
+			expr:         "release",
+			errorMatcher: IsInvalidFilterError,
+		},
+		{
+			expr:         "=12.0.0",
+			errorMatcher: IsInvalidFilterError,
+		},
+		{
+			expr:         "release>=",
+			errorMatcher: IsInvalidFilterError,
+		},
+		{
+			expr:         "release!12.0.0",
+			errorMatcher: IsInvalidFilterError,
+		},
+		{
+			expr:         "flower=rose",
+			errorMatcher: IsInvalidFilterError,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			table := New()
+			table.SetColumns(filterTestColumns)
+
+			f, err := table.ParseFilter(tc.expr)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("Case %d - Unexpected error: %v", i, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+
+			result := []string{}
+			for _, c := range f {
+				result = append(result, c.Column.Name+" "+c.Operator+" "+c.Value)
+			}
+			if diff := cmp.Diff(tc.expectedResult, result); diff != "" {
+				t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
+			}
+		})
+	}
+}
+
+func Test_FilterRows(t *testing.T) {
+	testCases := []struct {
+		expr           string
+		expectedResult []string
+	}{
+		{
+			expr:           "",
+			expectedResult: []string{"a1", "b2", "c3", "d4"},
+		},
+		{
+			expr:           "release>=12.0.0",
+			expectedResult: []string{"a1", "c3"},
+		},
+		{
+			expr:           "release>=12.0.0,owner=ACME",
+			expectedResult: []string{"a1"},
+		},
+		{
+			expr:           "release=9.0.1",
+			expectedResult: []string{"b2"},
+		},
+		{
+			expr:           "created<2020-01-03",
+			expectedResult: []string{"a1"},
+		},
+		{
+			expr:           "organization!=acme",
+			expectedResult: []string{"b2", "c3", "d4"},
+		},
+		{
+			expr:           "release!=12.0.1",
+			expectedResult: []string{"b2", "c3", "d4"},
+		},
+		{
+			expr:           "release=n/a",
+			expectedResult: []string{"d4"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			table := New()
+			table.SetColumns(filterTestColumns)
+			table.SetRows([][]string{
+				{"a1", "acme", "12.0.1", "2020 Jan 02, 15:04 UTC"},
+				{"b2", color.RedString("acme-labs"), "9.0.1", "2020 Jan 03, 15:21 UTC"},
+				{"c3", "other", "12.1.0", "2020 Jan 03, 15:04 UTC"},
+				{"d4", "other", "n/a", "n/a"},
+			})
+
+			f, err := table.ParseFilter(tc.expr)
+			if err != nil {
+				t.Fatalf("Case %d - Unexpected error: %s", i, err)
+			}
+			table.FilterRows(f)
+
+			result := []string{}
+			for _, row := range table.rows {
+				result = append(result, row[0])
+			}
+			if diff := cmp.Diff(tc.expectedResult, result); diff != "" {
+				t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
+			}
+		})
+	}
+}
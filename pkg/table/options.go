@@ -0,0 +1,68 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/pflag"
+)
+
+// AddFlags adds the --sort and --filter flags of a list command to the flag
+// set. things is the plural of what gets listed, e. g. "node pools", and
+// example is a filter expression shown in the help.
+func AddFlags(flagSet *pflag.FlagSet, sortBy, filter *string, columns []string, defaultSort, things, example string) {
+	flagSet.StringVarP(sortBy, "sort", "s", defaultSort, fmt.Sprintf("Sort by one of the fields %s", strings.Join(columns, ", ")))
+	flagSet.StringVarP(filter, "filter", "", "", fmt.Sprintf("Only list %s matching all of the comma-separated conditions, e. g. '%s'. Supported operators are %s.", things, example, strings.Join(operatorsHelp, ", ")))
+}
+
+// operatorsHelp are the operators documented for --filter.
+var operatorsHelp = []string{
+	OperatorEqual,
+	OperatorNotEqual,
+	OperatorGreater,
+	OperatorGreaterOrEqual,
+	OperatorLess,
+	OperatorLessOrEqual,
+}
+
+// ValidateOptions checks whether sortBy, if given, identifies a column of the
+// table and whether filter is a valid filter expression for it.
+func (t *Table) ValidateOptions(sortBy, filter string) error {
+	if sortBy != "" {
+		_, err := t.GetColumnNameFromInitials(sortBy)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	_, err := t.ParseFilter(filter)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// IsOptionsError asserts the errors caused by an invalid --sort or --filter value.
+func IsOptionsError(err error) bool {
+	return IsFieldNotFoundError(err) || IsMultipleFieldsMatchingError(err) || IsInvalidFilterError(err)
+}
+
+// OptionsErrorMessage returns the headline and subtext to print for an
+// error asserted by IsOptionsError.
+func OptionsErrorMessage(err error, sortBy string, columns []string) (headline, subtext string) {
+	switch {
+	case IsFieldNotFoundError(err):
+		headline = fmt.Sprintf("Cannot sort by attribute '%s'.", sortBy)
+		subtext = fmt.Sprintf("The attribute '%s' does not exist.\nYou can sort by any of these attributes: %s", sortBy, strings.Join(columns, ", "))
+	case IsMultipleFieldsMatchingError(err):
+		headline = fmt.Sprintf("Multiple attributes found for token '%s'.", sortBy)
+		subtext = fmt.Sprintf("Please provide the complete attribute.\nYou can sort by any of these attributes: %s", strings.Join(columns, ", "))
+	case IsInvalidFilterError(err):
+		headline = "Invalid filter expression"
+		subtext = err.Error()
+	}
+
+	return headline, subtext
+}
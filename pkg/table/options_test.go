@@ -0,0 +1,71 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func Test_ValidateOptions(t *testing.T) {
+	testCases := []struct {
+		sortBy       string
+		filter       string
+		errorMatcher func(error) bool
+	}{
+		{sortBy: "", filter: ""},
+		{sortBy: "o", filter: "release>=12.0.0"},
+		{sortBy: "unknown", errorMatcher: IsFieldNotFoundError},
+		{sortBy: "r"},
+		{filter: "release~12", errorMatcher: IsInvalidFilterError},
+	}
+
+	for i, tc := range testCases {
+		tbl := New()
+		tbl.SetColumns(filterTestColumns)
+
+		err := tbl.ValidateOptions(tc.sortBy, tc.filter)
+		if tc.errorMatcher != nil {
+			if !tc.errorMatcher(err) || !IsOptionsError(err) {
+				t.Errorf("Case %d - unexpected error %v", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("Case %d - unexpected error %s", i, err)
+		}
+	}
+}
+
+func Test_OptionsErrorMessage(t *testing.T) {
+	tbl := New()
+	tbl.SetColumns(filterTestColumns)
+	columns := []string{"id", "organization", "release", "created"}
+
+	err := tbl.ValidateOptions("unknown", "")
+	headline, subtext := OptionsErrorMessage(err, "unknown", columns)
+	if headline != "Cannot sort by attribute 'unknown'." || !strings.HasSuffix(subtext, "id, organization, release, created") {
+		t.Errorf("Unexpected message %q, %q", headline, subtext)
+	}
+
+	err = tbl.ValidateOptions("", "release~12")
+	headline, _ = OptionsErrorMessage(err, "", columns)
+	if headline != "Invalid filter expression" {
+		t.Errorf("Unexpected headline %q", headline)
+	}
+}
+
+func Test_AddFlags(t *testing.T) {
+	var sortBy, filter string
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddFlags(flagSet, &sortBy, &filter, []string{"id", "name"}, "id", "things", "name=foo")
+
+	err := flagSet.Parse([]string{"--filter", "name=bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sortBy != "id" || filter != "name=bar" {
+		t.Errorf("Unexpected values %q, %q", sortBy, filter)
+	}
+	if usage := flagSet.Lookup("filter").Usage; !strings.Contains(usage, "Only list things matching") || !strings.Contains(usage, "e. g. 'name=foo'") {
+		t.Errorf("Unexpected usage %q", usage)
+	}
+}
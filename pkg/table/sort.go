@@ -1,6 +1,7 @@
 package table
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/acarl005/stripansi"
@@ -16,16 +17,16 @@ func SortMapSliceUsingColumnData(mapSlice []map[string]interface{}, byCol Column
 		iField := "n/a"
 		{
 			iValue, ok := mapSlice[i][fieldMapping[byCol.Name]]
-			if ok {
-				iField = iValue.(string)
+			if ok && iValue != nil {
+				iField = fmt.Sprintf("%v", iValue)
 			}
 		}
 
 		jField := "n/a"
 		{
 			jValue, ok := mapSlice[j][fieldMapping[byCol.Name]]
-			if ok {
-				jField = jValue.(string)
+			if ok && jValue != nil {
+				jField = fmt.Sprintf("%v", jValue)
 			}
 		}
 
@@ -33,6 +34,53 @@ func SortMapSliceUsingColumnData(mapSlice []map[string]interface{}, byCol Column
 	})
 }
 
+// SortSliceUsingColumnData sorts any slice by the values of a column, like
+// SortByColumnName does for table rows. value must return the value of the
+// column for the i-th element of the slice. The sorting is stable, so that
+// elements with equal values keep their previous order.
+func SortSliceUsingColumnData(slice interface{}, byCol Column, direction string, value func(i int, colName string) string) {
+	sortDir := direction
+	if sortDir != sortable.ASC && sortDir != sortable.DESC {
+		sortDir = sortable.ASC
+	}
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		cmp := sortable.Compare(byCol.SortType, value(i, byCol.Name), value(j, byCol.Name))
+		if sortDir == sortable.DESC {
+			return cmp > 0
+		}
+
+		return cmp < 0
+	})
+}
+
+// FilterMapSliceUsingColumnData returns the elements of mapSlice matching the filter.
+// This is the counterpart of Table.FilterRows for non-table data types.
+func FilterMapSliceUsingColumnData(mapSlice []map[string]interface{}, f Filter, fieldMapping map[string]string) []map[string]interface{} {
+	if len(f) == 0 {
+		return mapSlice
+	}
+
+	result := make([]map[string]interface{}, 0, len(mapSlice))
+	for _, item := range mapSlice {
+		matches := f.Matches(func(colName string) string {
+			value, ok := item[fieldMapping[colName]]
+			if !ok || value == nil {
+				return "n/a"
+			}
+
+			return fmt.Sprintf("%v", value)
+		})
+
+		if matches {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// RemoveColors strips all color codes from a string.
 func RemoveColors(s string) string {
 	t := stripansi.Strip(s)
 
@@ -213,3 +213,77 @@ func Test_RemoveColors(t *testing.T) {
 		})
 	}
 }
+
+func Test_FilterMapSliceUsingColumnData(t *testing.T) {
+	mapSlice := []map[string]interface{}{
+		{
+			"id":              "as712",
+			"owner":           "acme",
+			"release_version": "12.0.1",
+		},
+		{
+			"id":              "saf91",
+			"owner":           "acme",
+			"release_version": "9.0.1",
+		},
+		{
+			"id":    "d91ns",
+			"owner": "other",
+		},
+	}
+	fieldMapping := map[string]string{
+		"id":           "id",
+		"organization": "owner",
+		"release":      "release_version",
+	}
+
+	table := New()
+	table.SetColumns(filterTestColumns)
+	f, err := table.ParseFilter("org=acme,release<12.0.0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	result := FilterMapSliceUsingColumnData(mapSlice, f, fieldMapping)
+	if len(result) != 1 || result[0]["id"] != "saf91" {
+		t.Errorf("Unexpected result %v", result)
+	}
+}
+
+func Test_SortSliceUsingColumnData(t *testing.T) {
+	testCases := []struct {
+		column         Column
+		direction      string
+		expectedResult []string
+	}{
+		{
+			column:         Column{Name: "version", Sortable: sortable.Sortable{SortType: sortable.Semver}},
+			direction:      sortable.ASC,
+			expectedResult: []string{"9.0.1", "11.0.0", "12.0.1", "12.0.1"},
+		},
+		{
+			column:         Column{Name: "version", Sortable: sortable.Sortable{SortType: sortable.Semver}},
+			direction:      sortable.DESC,
+			expectedResult: []string{"12.0.1", "12.0.1", "11.0.0", "9.0.1"},
+		},
+		{
+			column:         Column{Name: "version", Sortable: sortable.Sortable{SortType: sortable.String}},
+			direction:      "",
+			expectedResult: []string{"9.0.1", "11.0.0", "12.0.1", "12.0.1"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			versions := []string{"12.0.1", "9.0.1", "12.0.1", "11.0.0"}
+
+			SortSliceUsingColumnData(versions, tc.column, tc.direction, func(i int, colName string) string {
+				return versions[i]
+			})
+
+			if diff := cmp.Diff(tc.expectedResult, versions); diff != "" {
+				t.Errorf("Case %d - Result did not match.\nOutput: %s", i, diff)
+			}
+		})
+	}
+}
@@ -39,6 +39,11 @@ func (t *Table) SetRows(r [][]string) {
 	t.rows = r[:][:]
 }
 
+// NumRows returns the number of rows in the table.
+func (t *Table) NumRows() int {
+	return len(t.rows)
+}
+
 // SortByColumnName sorts the table by a column name, in the given direction.
 func (t *Table) SortByColumnName(n string, direction string) error {
 	// Skip if there is nothing to sort, or if there's no column name provided.
@@ -114,6 +119,13 @@ func (t *Table) GetColumnNameFromInitials(i string) (string, error) {
 		columnNames   = make([]string, 0, len(t.columns))
 		matchingNames []string
 	)
+	for _, col := range t.columns {
+		for _, alias := range col.Aliases {
+			if strings.ToLower(alias) == i {
+				return col.Name, nil
+			}
+		}
+	}
 	for _, col := range t.columns {
 		if col.Name != "" {
 			columnNames = append(columnNames, col.Name)
@@ -130,7 +142,7 @@ func (t *Table) GetColumnNameFromInitials(i string) (string, error) {
 	}
 
 	if len(matchingNames) == 0 {
-		return "", microerror.Maskf(fieldNotFoundError, "available fields for sorting: %v", strings.Join(columnNames, ", "))
+		return "", microerror.Maskf(fieldNotFoundError, "available fields: %v", strings.Join(columnNames, ", "))
 	} else if len(matchingNames) > 1 {
 		return "", microerror.Maskf(multipleFieldsMatchingError, "%v", strings.Join(matchingNames, ", "))
 	}
@@ -138,28 +150,49 @@ func (t *Table) GetColumnNameFromInitials(i string) (string, error) {
 	return matchingNames[0], nil
 }
 
-// String makes the Table data structure implement the Stringer interface,
-// so we can easily pretty-print it.
-func (t *Table) String() string {
-	rows := make([]string, 0, len(t.rows)+1)
-
-	{
-		columns := make([]string, 0, len(t.columns))
-		for _, col := range t.columns {
-			if !col.Hidden {
-				columns = append(columns, col.GetHeader())
-			}
+// Header returns the headers of all visible columns.
+func (t *Table) Header() []string {
+	header := make([]string, 0, len(t.columns))
+	for _, col := range t.columns {
+		if !col.Hidden {
+			header = append(header, col.GetHeader())
 		}
-		rows = append(rows, strings.Join(columns, "|"))
 	}
 
-	{
-		for _, row := range t.rows {
-			rows = append(rows, strings.Join(row, "|"))
+	return header
+}
+
+// VisibleRows returns the cells of all rows, omitting the cells of hidden columns.
+func (t *Table) VisibleRows() [][]string {
+	rows := make([][]string, 0, len(t.rows))
+	for _, row := range t.rows {
+		cells := make([]string, 0, len(row))
+		for i, cell := range row {
+			if i < len(t.columns) && t.columns[i].Hidden {
+				continue
+			}
+			cells = append(cells, cell)
 		}
+		rows = append(rows, cells)
 	}
 
-	formattedTable := columnize.Format(rows, t.columnizeConfig)
+	return rows
+}
+
+// Format formats rows with cells separated by '|' like the table itself.
+func (t *Table) Format(rows []string) string {
+	return columnize.Format(rows, t.columnizeConfig)
+}
+
+// String makes the Table data structure implement the Stringer interface,
+// so we can easily pretty-print it. Cells of hidden columns are omitted.
+func (t *Table) String() string {
+	rows := make([]string, 0, len(t.rows)+1)
+	rows = append(rows, strings.Join(t.Header(), "|"))
+
+	for _, row := range t.VisibleRows() {
+		rows = append(rows, strings.Join(row, "|"))
+	}
 
-	return formattedTable
+	return t.Format(rows)
 }
@@ -924,6 +924,37 @@ Good dog      2016 Dec 05, 14:41 UTC   12.0.1
 Good cat      2016 Dec 25, 14:41 UTC   12.0.1
 Good parrot   2016 Dec 25, 15:41 UTC   9.0.1`,
 		},
+		{
+			columns: []Column{
+				{
+					Name:        "some-col",
+					DisplayName: "SOME COLUMN",
+				},
+				{
+					Name:   "some-hidden-col",
+					Hidden: true,
+				},
+				{
+					Name:        "some-random-col",
+					DisplayName: "Some Random Column",
+				},
+			},
+			rows: [][]string{
+				{
+					"Good dog",
+					"2016 Dec 05, 14:41 UTC",
+					"12.0.1",
+				},
+				{
+					"Good parrot",
+					"2016 Dec 25, 15:41 UTC",
+					"9.0.1",
+				},
+			},
+			expectedResult: `SOME COLUMN   Some Random Column
+Good dog      12.0.1
+Good parrot   9.0.1`,
+		},
 	}
 
 	for i, tc := range testCases {
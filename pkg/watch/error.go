@@ -0,0 +1,21 @@
+package watch
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidIntervalError = &microerror.Error{
+	Kind: "invalidIntervalError",
+}
+
+// IsInvalidInterval asserts invalidIntervalError.
+func IsInvalidInterval(err error) bool {
+	return microerror.Cause(err) == invalidIntervalError
+}
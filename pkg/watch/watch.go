@@ -0,0 +1,395 @@
+// Package watch provides repeatedly fetching and displaying the state of
+// resources, as used by the --watch flag of several commands.
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/columnize"
+	"github.com/giantswarm/microerror"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/giantswarm/gsctl/pkg/table"
+)
+
+const (
+	// DefaultInterval is the default time between two refreshes.
+	DefaultInterval = 10 * time.Second
+
+	// MinInterval is the shortest interval allowed, to not put too much load on the API.
+	MinInterval = 2 * time.Second
+
+	// clearScreen moves the cursor to the top left corner and clears the terminal.
+	clearScreen = "\033[H\033[2J"
+)
+
+// The types of changes reported in non-interactive mode.
+const (
+	EventAdded    = "ADDED"
+	EventModified = "MODIFIED"
+	EventDeleted  = "DELETED"
+)
+
+// Frame is the state of the watched resources at one point in time.
+type Frame struct {
+	// Header is an optional header row, displayed above the rows.
+	Header []string
+
+	// Rows contains the cells of each row. The first cell identifies
+	// the row and must be unique within the frame.
+	Rows [][]string
+
+	// Objects contains the data structure of each resource, keyed by ID.
+	// It is used instead of Rows in JSON mode.
+	Objects map[string]interface{}
+
+	// Footer is optional text displayed below the rows in interactive mode.
+	Footer string
+
+	// Format formats rows, with cells separated by '|', for display.
+	// Defaults to columnize.SimpleFormat.
+	Format func(rows []string) string
+}
+
+// Event represents a change of a resource in JSON mode.
+type Event struct {
+	Type   string      `json:"type"`
+	Time   time.Time   `json:"time"`
+	ID     string      `json:"id"`
+	Object interface{} `json:"object,omitempty"`
+}
+
+// Config is the configuration for Run.
+type Config struct {
+	// Fetch returns the current state of the watched resources.
+	Fetch func() (*Frame, error)
+
+	// Interval is the time between two calls of Fetch.
+	Interval time.Duration
+
+	// Interactive makes Run redraw the output in place on every refresh,
+	// highlighting changed cells. Otherwise only changed rows are printed.
+	Interactive bool
+
+	// JSON makes Run print a JSON event for every changed resource.
+	JSON bool
+
+	// Iterations limits the number of refreshes. 0 means no limit.
+	Iterations int
+
+	// Out is where output gets written to. Defaults to os.Stdout.
+	Out io.Writer
+
+	// Title is displayed on top of the output in interactive mode.
+	Title string
+}
+
+// NewFrame creates a frame from rows with cells separated by '|', the
+// format columnize expects. If withHeader is true, the first row is
+// used as the header.
+func NewFrame(rows []string, withHeader bool) *Frame {
+	f := &Frame{}
+
+	for i, row := range rows {
+		cells := strings.Split(row, "|")
+		if withHeader && i == 0 {
+			f.Header = cells
+			continue
+		}
+
+		f.Rows = append(f.Rows, cells)
+	}
+
+	return f
+}
+
+// IsTerminal returns true if standard output is connected to a terminal,
+// so that the output can be redrawn in place.
+func IsTerminal() bool {
+	return terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// ValidateInterval returns an error if the interval is too short.
+func ValidateInterval(interval time.Duration) error {
+	if interval < MinInterval {
+		return microerror.Maskf(invalidIntervalError, "the interval must be at least %s, got %s", MinInterval, interval)
+	}
+
+	return nil
+}
+
+type watcher struct {
+	config   Config
+	previous *Frame
+}
+
+// Run calls the configured Fetch function repeatedly and prints the
+// result, until the configured number of iterations is reached or the
+// process is interrupted. An error from the first call of Fetch is returned,
+// later errors are displayed and the next refresh is attempted.
+func Run(config Config) error {
+	if config.Fetch == nil {
+		return microerror.Maskf(invalidConfigError, "%T.Fetch must not be empty", config)
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.Out == nil {
+		config.Out = os.Stdout
+	}
+
+	w := &watcher{config: config}
+
+	for i := 0; config.Iterations == 0 || i < config.Iterations; i++ {
+		if i > 0 {
+			time.Sleep(config.Interval)
+		}
+
+		frame, err := config.Fetch()
+		if err != nil {
+			if w.previous == nil {
+				return microerror.Mask(err)
+			}
+
+			fmt.Fprintln(config.Out, color.RedString("%s Error: %s", time.Now().Format(time.RFC3339), err.Error()))
+			continue
+		}
+
+		switch {
+		case config.JSON:
+			err = w.printEvents(frame)
+		case config.Interactive:
+			w.printFrame(frame)
+		default:
+			w.printChanges(frame)
+		}
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		w.previous = frame
+	}
+
+	return nil
+}
+
+// printFrame redraws the complete output, highlighting all cells
+// which changed since the previous refresh.
+func (w *watcher) printFrame(f *Frame) {
+	previousRows := rowsByID(w.previous)
+
+	rows := make([]string, 0, len(f.Rows)+1)
+	if len(f.Header) > 0 {
+		rows = append(rows, strings.Join(f.Header, "|"))
+	}
+	for _, row := range f.Rows {
+		cells := make([]string, len(row))
+		copy(cells, row)
+
+		if w.previous != nil {
+			previousRow, ok := previousRows[row[0]]
+			for i := range cells {
+				if !ok || i >= len(previousRow) || table.RemoveColors(previousRow[i]) != table.RemoveColors(cells[i]) {
+					cells[i] = highlight(cells[i])
+				}
+			}
+		}
+
+		rows = append(rows, strings.Join(cells, "|"))
+	}
+
+	out := w.config.Out
+	fmt.Fprint(out, clearScreen)
+	if w.config.Title != "" {
+		fmt.Fprint(out, w.config.Title+"  ")
+	}
+	fmt.Fprintln(out, color.WhiteString("Every %s, last update %s. Press Ctrl+C to stop.", w.config.Interval, time.Now().Format("15:04:05")))
+	fmt.Fprintln(out)
+	if len(rows) > 0 {
+		fmt.Fprintln(out, f.format(rows))
+	}
+	if f.Footer != "" {
+		if len(rows) > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintln(out, f.Footer)
+	}
+}
+
+// printChanges prints the complete table for the first refresh,
+// and only rows which have been added, modified or deleted afterwards.
+func (w *watcher) printChanges(f *Frame) {
+	out := w.config.Out
+
+	if w.previous == nil {
+		rows := make([]string, 0, len(f.Rows)+1)
+		if len(f.Header) > 0 {
+			rows = append(rows, strings.Join(f.Header, "|"))
+		}
+		for _, row := range f.Rows {
+			rows = append(rows, strings.Join(row, "|"))
+		}
+
+		if len(rows) > 0 {
+			fmt.Fprintln(out, f.format(rows))
+		}
+		if f.Footer != "" {
+			fmt.Fprintln(out, f.Footer)
+		}
+
+		return
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	previousRows := rowsByID(w.previous)
+	currentRows := rowsByID(f)
+
+	for _, row := range f.Rows {
+		previousRow, ok := previousRows[row[0]]
+		eventType := EventAdded
+		if ok {
+			if !rowChanged(previousRow, row) {
+				continue
+			}
+			eventType = EventModified
+		}
+
+		fmt.Fprintf(out, "%s %s %s\n", now, eventType, joinCells(row))
+	}
+
+	for _, row := range w.previous.Rows {
+		if _, ok := currentRows[row[0]]; !ok {
+			fmt.Fprintf(out, "%s %s %s\n", now, EventDeleted, table.RemoveColors(row[0]))
+		}
+	}
+}
+
+// printEvents prints one JSON event per line for every resource which
+// has been added, modified or deleted since the previous refresh.
+func (w *watcher) printEvents(f *Frame) error {
+	now := time.Now().UTC()
+
+	var previousObjects map[string]interface{}
+	if w.previous != nil {
+		previousObjects = w.previous.Objects
+	}
+
+	var events []Event
+	for _, id := range sortedIDs(f.Objects) {
+		object := f.Objects[id]
+		previousObject, ok := previousObjects[id]
+
+		eventType := EventAdded
+		if ok {
+			changed, err := objectChanged(previousObject, object)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			if !changed {
+				continue
+			}
+			eventType = EventModified
+		}
+
+		events = append(events, Event{Type: eventType, Time: now, ID: id, Object: object})
+	}
+
+	for _, id := range sortedIDs(previousObjects) {
+		if _, ok := f.Objects[id]; !ok {
+			events = append(events, Event{Type: EventDeleted, Time: now, ID: id})
+		}
+	}
+
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		fmt.Fprintln(w.config.Out, string(line))
+	}
+
+	return nil
+}
+
+func (f *Frame) format(rows []string) string {
+	if f.Format != nil {
+		return f.Format(rows)
+	}
+
+	return columnize.SimpleFormat(rows)
+}
+
+func rowsByID(f *Frame) map[string][]string {
+	rows := map[string][]string{}
+	if f == nil {
+		return rows
+	}
+
+	for _, row := range f.Rows {
+		if len(row) > 0 {
+			rows[row[0]] = row
+		}
+	}
+
+	return rows
+}
+
+func rowChanged(a, b []string) bool {
+	if len(a) != len(b) {
+		return true
+	}
+
+	for i := range a {
+		if table.RemoveColors(a[i]) != table.RemoveColors(b[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func objectChanged(a, b interface{}) (bool, error) {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return string(aJSON) != string(bJSON), nil
+}
+
+func sortedIDs(objects map[string]interface{}) []string {
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// joinCells returns the cells of a row as plain text, separated by spaces.
+func joinCells(row []string) string {
+	cells := make([]string, 0, len(row))
+	for _, cell := range row {
+		cells = append(cells, strings.TrimSpace(table.RemoveColors(cell)))
+	}
+
+	return strings.Join(cells, "  ")
+}
+
+// highlight marks a changed cell, regardless of its previous color.
+func highlight(cell string) string {
+	return color.New(color.ReverseVideo).Sprint(table.RemoveColors(cell))
+}
@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// frames returns a Fetch function returning the given frames one after another.
+func frames(fs ...*Frame) func() (*Frame, error) {
+	i := 0
+	return func() (*Frame, error) {
+		f := fs[i]
+		i++
+		if f == nil {
+			return nil, errors.New("temporary failure")
+		}
+		return f, nil
+	}
+}
+
+// Test_RunChanges tests that only changed rows are printed in non-interactive mode.
+func Test_RunChanges(t *testing.T) {
+	var out bytes.Buffer
+
+	err := Run(Config{
+		Fetch: frames(
+			NewFrame([]string{"ID|NODES", "a1|3", "b2|5"}, true),
+			NewFrame([]string{"ID|NODES", "a1|3", "b2|6", "c3|1"}, true),
+			nil,
+			NewFrame([]string{"ID|NODES", "b2|6", "c3|1"}, true),
+		),
+		Interval:   time.Millisecond,
+		Iterations: 4,
+		Out:        &out,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	expectedSuffixes := []string{
+		"ID  NODES",
+		"a1  3",
+		"b2  5",
+		" MODIFIED b2  6",
+		" ADDED c3  1",
+		"Error: temporary failure",
+		" DELETED a1",
+	}
+	if len(lines) != len(expectedSuffixes) {
+		t.Fatalf("Unexpected output:\n%s", out.String())
+	}
+	for i, suffix := range expectedSuffixes {
+		if !strings.HasSuffix(lines[i], suffix) {
+			t.Errorf("Line %d - expected suffix %q, got %q", i, suffix, lines[i])
+		}
+	}
+}
+
+// Test_RunEvents tests the JSON events printed in JSON mode.
+func Test_RunEvents(t *testing.T) {
+	var out bytes.Buffer
+
+	err := Run(Config{
+		Fetch: frames(
+			&Frame{Objects: map[string]interface{}{"a1": map[string]int{"nodes": 3}, "b2": map[string]int{"nodes": 5}}},
+			&Frame{Objects: map[string]interface{}{"b2": map[string]int{"nodes": 6}}},
+			&Frame{Objects: map[string]interface{}{"b2": map[string]int{"nodes": 6}}},
+		),
+		Interval:   time.Millisecond,
+		Iterations: 3,
+		JSON:       true,
+		Out:        &out,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var e Event
+		err = json.Unmarshal([]byte(line), &e)
+		if err != nil {
+			t.Fatalf("Line is not a valid JSON event: %s", line)
+		}
+		got = append(got, e.Type+" "+e.ID)
+	}
+
+	expected := "ADDED a1,ADDED b2,MODIFIED b2,DELETED a1"
+	if strings.Join(got, ",") != expected {
+		t.Errorf("Expected events %s, got %s", expected, strings.Join(got, ","))
+	}
+}
+
+// Test_RunInteractive tests that the output is redrawn on every refresh.
+func Test_RunInteractive(t *testing.T) {
+	var out bytes.Buffer
+
+	err := Run(Config{
+		Fetch: frames(
+			NewFrame([]string{"Name:|foo", "Nodes:|3"}, false),
+			&Frame{Rows: [][]string{{"Name:", "foo"}, {"Nodes:", "4"}}, Footer: "Some footer"},
+		),
+		Interactive: true,
+		Interval:    time.Millisecond,
+		Iterations:  2,
+		Out:         &out,
+		Title:       "gsctl show cluster foo",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	screens := strings.Split(out.String(), clearScreen)
+	if len(screens) != 3 {
+		t.Fatalf("Expected 2 screens, got:\n%q", out.String())
+	}
+	if !strings.HasPrefix(screens[2], "gsctl show cluster foo") || !strings.Contains(screens[2], "Nodes:  4") || !strings.Contains(screens[2], "Some footer") {
+		t.Errorf("Unexpected screen:\n%s", screens[2])
+	}
+}
+
+// Test_RunFirstError tests that an error on the first refresh is returned.
+func Test_RunFirstError(t *testing.T) {
+	err := Run(Config{
+		Fetch:      frames(nil),
+		Iterations: 1,
+		Out:        &bytes.Buffer{},
+	})
+	if err == nil || err.Error() != "temporary failure" {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+
+	err = Run(Config{})
+	if !IsInvalidConfig(err) {
+		t.Errorf("Expected invalid config error, got %v", err)
+	}
+}
+
+func Test_ValidateInterval(t *testing.T) {
+	if err := ValidateInterval(DefaultInterval); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if err := ValidateInterval(time.Second); !IsInvalidInterval(err) {
+		t.Errorf("Expected invalid interval error, got %v", err)
+	}
+}
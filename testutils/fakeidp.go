@@ -0,0 +1,111 @@
+package testutils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+const (
+	// FakeIdPDeviceCode is the device code issued by FakeIdP.
+	FakeIdPDeviceCode = "fake-device-code"
+
+	// FakeIdPUserCode is the user code issued by FakeIdP.
+	FakeIdPUserCode = "WDJB-MJHT"
+)
+
+// FakeIdP is a local identity provider implementing the endpoints of
+// the OAuth device authorization grant, for testing.
+type FakeIdP struct {
+	*httptest.Server
+
+	// Errors are the OAuth error codes returned for the first token
+	// requests, in order, e. g. "authorization_pending". Afterwards,
+	// tokens are issued.
+	Errors []string
+
+	// AccessToken, RefreshToken and IDToken are issued once
+	// all errors have been returned.
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+
+	mutex      sync.Mutex
+	tokenPolls int
+}
+
+// NewFakeIdP starts a FakeIdP. It has to be closed after use.
+func NewFakeIdP(accessToken, refreshToken, idToken string) *FakeIdP {
+	idp := &FakeIdP{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		IDToken:      idToken,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/device/code", idp.handleDeviceCode)
+	mux.HandleFunc("/oauth/token", idp.handleToken)
+	idp.Server = httptest.NewServer(mux)
+
+	return idp
+}
+
+// TokenPolls returns the number of token requests received.
+func (idp *FakeIdP) TokenPolls() int {
+	idp.mutex.Lock()
+	defer idp.mutex.Unlock()
+
+	return idp.tokenPolls
+}
+
+func (idp *FakeIdP) handleDeviceCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.FormValue("client_id") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid_request"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":               FakeIdPDeviceCode,
+		"user_code":                 FakeIdPUserCode,
+		"verification_uri":          idp.URL + "/activate",
+		"verification_uri_complete": idp.URL + "/activate?user_code=" + FakeIdPUserCode,
+		"expires_in":                900,
+		"interval":                  5,
+	})
+}
+
+func (idp *FakeIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "unsupported_grant_type"})
+		return
+	}
+	if r.FormValue("device_code") != FakeIdPDeviceCode {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"error": "invalid_grant", "error_description": "Invalid or expired device code."})
+		return
+	}
+
+	idp.mutex.Lock()
+	poll := idp.tokenPolls
+	idp.tokenPolls++
+	idp.mutex.Unlock()
+
+	if poll < len(idp.Errors) {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"error": idp.Errors[poll]})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  idp.AccessToken,
+		"refresh_token": idp.RefreshToken,
+		"id_token":      idp.IDToken,
+		"token_type":    "Bearer",
+		"expires_in":    86400,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
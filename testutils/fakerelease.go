@@ -0,0 +1,131 @@
+package testutils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// FakeRelease is a local server providing gsctl release files the way
+// GitHub releases do, for testing.
+type FakeRelease struct {
+	*httptest.Server
+
+	// Files maps paths like "/1.2.3/gsctl-1.2.3-checksums.txt" to their
+	// content. Tests may modify them to simulate broken releases.
+	Files map[string][]byte
+
+	// LatestVersion is the version /latest redirects to.
+	LatestVersion string
+}
+
+// NewFakeRelease starts a FakeRelease serving one release of the given version
+// for the given platforms (like "linux-amd64", "windows-386"), each containing
+// the given binary. The checksums file is signed with key, unless it is nil.
+// It has to be closed after use.
+func NewFakeRelease(version string, platforms []string, binary []byte, key ed25519.PrivateKey) (*FakeRelease, error) {
+	r := &FakeRelease{
+		Files:         map[string][]byte{},
+		LatestVersion: version,
+	}
+
+	checksums := ""
+	for _, platform := range platforms {
+		dir := fmt.Sprintf("gsctl-%s-%s", version, platform)
+
+		var name string
+		var archive []byte
+		var err error
+		if strings.HasPrefix(platform, "windows") {
+			name = dir + ".zip"
+			archive, err = zipArchive(dir+"/gsctl.exe", binary)
+		} else {
+			name = dir + ".tar.gz"
+			archive, err = tarGzArchive(dir+"/gsctl", binary)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(archive)
+		checksums += fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+		r.Files["/"+version+"/"+name] = archive
+	}
+
+	checksumsPath := fmt.Sprintf("/%s/gsctl-%s-checksums.txt", version, version)
+	r.Files[checksumsPath] = []byte(checksums)
+	if key != nil {
+		r.Files[checksumsPath+".sig"] = ed25519.Sign(key, []byte(checksums))
+	}
+
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/latest" {
+			w.Header().Set("Location", "https://example.com/releases/tag/"+r.LatestVersion)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+
+		data, ok := r.Files[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+
+	return r, nil
+}
+
+func tarGzArchive(name string, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	if err != nil {
+		return nil, err
+	}
+	_, err = tw.Write(content)
+	if err != nil {
+		return nil, err
+	}
+	err = tw.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = gz.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func zipArchive(name string, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	_, err = w.Write(content)
+	if err != nil {
+		return nil, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
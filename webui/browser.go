@@ -0,0 +1,48 @@
+package webui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/giantswarm/microerror"
+)
+
+// browserCommand returns the command and arguments that open
+// the given URL in the default web browser of the platform.
+func browserCommand(goos string, url string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// OpenInBrowser launches the default web browser with the given URL,
+// without waiting for it to exit.
+func OpenInBrowser(url string) error {
+	name, args := browserCommand(runtime.GOOS, url)
+
+	err := exec.Command(name, args...).Start()
+	if err != nil {
+		return microerror.Maskf(launchFailedError, err.Error())
+	}
+
+	return nil
+}
+
+// Launch opens the URL in the web browser. If printOnly is true, the URL is
+// only printed, which is useful in terminals without a browser.
+func Launch(url string, printOnly bool) error {
+	if printOnly {
+		fmt.Println(url)
+		return nil
+	}
+
+	fmt.Printf("Opening %s\n", url)
+
+	return OpenInBrowser(url)
+}
@@ -0,0 +1,31 @@
+package webui
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_browserCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"linux", "xdg-open", []string{"https://happa.example.com"}},
+		{"freebsd", "xdg-open", []string{"https://happa.example.com"}},
+		{"darwin", "open", []string{"https://happa.example.com"}},
+		{"windows", "rundll32", []string{"url.dll,FileProtocolHandler", "https://happa.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := browserCommand(tt.goos, "https://happa.example.com")
+			if name != tt.wantName {
+				t.Errorf("got command %q, want %q", name, tt.wantName)
+			}
+			if diff := cmp.Diff(tt.wantArgs, args); diff != "" {
+				t.Errorf("arguments not as expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,3 +20,23 @@ var missingArgumentError = &microerror.Error{
 func IsMissingArgument(err error) bool {
 	return microerror.Cause(err) == missingArgumentError
 }
+
+var invalidBaseURLError = &microerror.Error{
+	Kind: "invalidBaseURLError",
+	Desc: "The web UI base URL is not valid",
+}
+
+// IsInvalidBaseURL asserts invalidBaseURLError.
+func IsInvalidBaseURL(err error) bool {
+	return microerror.Cause(err) == invalidBaseURLError
+}
+
+var launchFailedError = &microerror.Error{
+	Kind: "launchFailedError",
+	Desc: "The web browser could not be launched",
+}
+
+// IsLaunchFailed asserts launchFailedError.
+func IsLaunchFailed(err error) bool {
+	return microerror.Cause(err) == launchFailedError
+}
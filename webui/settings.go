@@ -0,0 +1,141 @@
+package webui
+
+import (
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	settingsFileName = "webui.yaml"
+)
+
+// EndpointSettings holds the web UI settings for one API endpoint.
+type EndpointSettings struct {
+	// BaseURL is the web UI URL to use instead of the one derived
+	// from the API endpoint host name.
+	BaseURL string `yaml:"base_url,omitempty"`
+}
+
+// Settings is the file structure of the web UI settings file, mapping
+// API endpoint URLs to their settings.
+type Settings struct {
+	Endpoints map[string]EndpointSettings `yaml:"endpoints"`
+}
+
+// ConfiguredBaseURL returns the web UI base URL configured for an endpoint,
+// or an empty string if there is none.
+func ConfiguredBaseURL(apiEndpoint string) string {
+	settings, err := readSettings(config.FileSystem)
+	if err != nil {
+		return ""
+	}
+
+	return settings.Endpoints[apiEndpoint].BaseURL
+}
+
+// StoreBaseURL configures the web UI base URL for an endpoint.
+// An empty base URL removes the setting.
+func StoreBaseURL(apiEndpoint string, baseURL string) error {
+	err := StoreBaseURLs(map[string]string{apiEndpoint: baseURL})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// StoreBaseURLs configures the web UI base URLs for several endpoints,
+// keyed by endpoint URL, writing the settings file only once.
+// An empty base URL removes the setting for the endpoint.
+func StoreBaseURLs(baseURLs map[string]string) error {
+	for apiEndpoint, baseURL := range baseURLs {
+		if apiEndpoint == "" {
+			return microerror.Maskf(missingArgumentError, "endpoint must be given")
+		}
+
+		if baseURL != "" {
+			err := ValidateBaseURL(baseURL)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	fs := config.FileSystem
+
+	settings, err := readSettings(fs)
+	if os.IsNotExist(err) {
+		settings = &Settings{Endpoints: map[string]EndpointSettings{}}
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for apiEndpoint, baseURL := range baseURLs {
+		if baseURL == "" {
+			delete(settings.Endpoints, apiEndpoint)
+		} else {
+			settings.Endpoints[apiEndpoint] = EndpointSettings{BaseURL: baseURL}
+		}
+	}
+
+	err = writeSettings(fs, settings)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// ValidateBaseURL returns an error if the given web UI base URL is not
+// an absolute HTTP(S) URL.
+func ValidateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return microerror.Maskf(invalidBaseURLError, err.Error())
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" || parsed.Host == "" {
+		return microerror.Maskf(invalidBaseURLError, "base URL must be an absolute HTTP(S) URL")
+	}
+
+	return nil
+}
+
+func readSettings(fs afero.Fs) (*Settings, error) {
+	if fs == nil {
+		return nil, microerror.Mask(missingArgumentError)
+	}
+
+	filePath := path.Join(config.ConfigDirPath, settingsFileName)
+	yamlBytes, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &Settings{}
+	err = yaml.Unmarshal(yamlBytes, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Endpoints == nil {
+		settings.Endpoints = map[string]EndpointSettings{}
+	}
+
+	return settings, nil
+}
+
+func writeSettings(fs afero.Fs, settings *Settings) error {
+	filePath := path.Join(config.ConfigDirPath, settingsFileName)
+	output, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fs, filePath, output, config.ConfigFilePermission)
+}
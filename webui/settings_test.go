@@ -0,0 +1,106 @@
+package webui
+
+import (
+	"path"
+	"testing"
+
+	"github.com/giantswarm/gscliauth/config"
+	"github.com/spf13/afero"
+
+	"github.com/giantswarm/gsctl/testutils"
+)
+
+// TestEndpointBaseURL tests that a configured base URL takes precedence
+// over the derived one.
+func TestEndpointBaseURL(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint := "https://api.g8s.example.com"
+	customEndpoint := "https://gs-api.internal.example.com"
+
+	got, err := EndpointBaseURL(endpoint)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if got != "https://happa.g8s.example.com" {
+		t.Errorf("Expected derived base URL, got %q", got)
+	}
+
+	_, err = EndpointBaseURL(customEndpoint)
+	if !IsUnsupportedHostName(err) {
+		t.Errorf("Expected unsupportedHostNameError, got %v", err)
+	}
+
+	err = StoreBaseURL(customEndpoint, "https://console.example.com/")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	got, err = AppCatalogURL(customEndpoint)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if got != "https://console.example.com/apps" {
+		t.Errorf("Expected configured base URL to be used, got %q", got)
+	}
+
+	err = StoreBaseURL(customEndpoint, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ConfiguredBaseURL(customEndpoint) != "" {
+		t.Error("Expected base URL setting to be removed")
+	}
+
+	for _, invalid := range []string{"console.example.com", "ftp://console.example.com", "https://"} {
+		err = StoreBaseURL(customEndpoint, invalid)
+		if !IsInvalidBaseURL(err) {
+			t.Errorf("Expected invalidBaseURLError for %q, got %v", invalid, err)
+		}
+	}
+}
+
+// TestStoreBaseURLs tests storing several base URLs at once, and that
+// an unreadable settings file is not replaced.
+func TestStoreBaseURLs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := testutils.TempConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = StoreBaseURLs(map[string]string{
+		"https://api.one.example.com": "https://one.example.com",
+		"https://api.two.example.com": "https://two.example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ConfiguredBaseURL("https://api.one.example.com") != "https://one.example.com" ||
+		ConfiguredBaseURL("https://api.two.example.com") != "https://two.example.com" {
+		t.Error("Expected both base URLs to be stored")
+	}
+
+	filePath := path.Join(config.ConfigDirPath, settingsFileName)
+	err = afero.WriteFile(fs, filePath, []byte("endpoints: [broken"), config.ConfigFilePermission)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = StoreBaseURL("https://api.one.example.com", "https://other.example.com")
+	if err == nil {
+		t.Error("Expected error for an unparseable settings file, got nil")
+	}
+
+	content, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "endpoints: [broken" {
+		t.Errorf("Expected settings file to be left unchanged, got %q", string(content))
+	}
+}
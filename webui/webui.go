@@ -1,4 +1,5 @@
-// Package webui provides a method to find the Web UI (happa) URL, based on the installations's API endpoint URL.
+// Package webui provides methods to find Web UI (happa) URLs, based on the installations's API endpoint URL
+// or on a base URL configured for the endpoint.
 package webui
 
 import (
@@ -40,6 +41,17 @@ func BaseURL(apiEndpoint string) (string, error) {
 	return "https://" + webUIFullHostName, nil
 }
 
+// EndpointBaseURL returns the web UI base URL for an API endpoint. A base URL
+// configured for the endpoint takes precedence over the one derived from the
+// API host name.
+func EndpointBaseURL(apiEndpoint string) (string, error) {
+	if configured := ConfiguredBaseURL(apiEndpoint); configured != "" {
+		return strings.TrimSuffix(configured, "/"), nil
+	}
+
+	return BaseURL(apiEndpoint)
+}
+
 // ClusterDetailsURL returns the URL of a cluster details page in the web UI.
 func ClusterDetailsURL(apiEndpoint string, clusterID string, organization string) (string, error) {
 	if clusterID == "" {
@@ -49,7 +61,7 @@ func ClusterDetailsURL(apiEndpoint string, clusterID string, organization string
 		return "", microerror.Maskf(missingArgumentError, "organization ID must be given")
 	}
 
-	baseURL, err := BaseURL(apiEndpoint)
+	baseURL, err := EndpointBaseURL(apiEndpoint)
 	if err != nil {
 		return "", microerror.Mask(err)
 	}
@@ -58,3 +70,55 @@ func ClusterDetailsURL(apiEndpoint string, clusterID string, organization string
 
 	return url, nil
 }
+
+// NodePoolDetailsURL returns the URL of a node pool within the cluster details page in the web UI.
+func NodePoolDetailsURL(apiEndpoint string, clusterID string, nodePoolID string, organization string) (string, error) {
+	if nodePoolID == "" {
+		return "", microerror.Maskf(missingArgumentError, "node pool ID must be given")
+	}
+
+	clusterURL, err := ClusterDetailsURL(apiEndpoint, clusterID, organization)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return fmt.Sprintf("%s/nodepools/%s", clusterURL, nodePoolID), nil
+}
+
+// OrganizationDetailsURL returns the URL of an organization details page in the web UI.
+func OrganizationDetailsURL(apiEndpoint string, organization string) (string, error) {
+	if organization == "" {
+		return "", microerror.Maskf(missingArgumentError, "organization ID must be given")
+	}
+
+	baseURL, err := EndpointBaseURL(apiEndpoint)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return fmt.Sprintf("%s/organizations/%s", baseURL, organization), nil
+}
+
+// ReleaseDetailsURL returns the URL of a release details page in the web UI.
+func ReleaseDetailsURL(apiEndpoint string, releaseVersion string) (string, error) {
+	if releaseVersion == "" {
+		return "", microerror.Maskf(missingArgumentError, "release version must be given")
+	}
+
+	baseURL, err := EndpointBaseURL(apiEndpoint)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return fmt.Sprintf("%s/releases/%s", baseURL, strings.TrimPrefix(releaseVersion, "v")), nil
+}
+
+// AppCatalogURL returns the URL of the app catalog page in the web UI.
+func AppCatalogURL(apiEndpoint string) (string, error) {
+	baseURL, err := EndpointBaseURL(apiEndpoint)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return baseURL + "/apps", nil
+}
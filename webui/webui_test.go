@@ -133,3 +133,57 @@ func TestClusterDetailsURL(t *testing.T) {
 		})
 	}
 }
+
+func TestPageURLs(t *testing.T) {
+	endpoint := "https://api.g8s.mydomain.org"
+
+	tests := []struct {
+		name    string
+		getURL  func() (string, error)
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "node pool",
+			getURL: func() (string, error) { return NodePoolDetailsURL(endpoint, "dah45", "a7k9m", "acme") },
+			want:   "https://happa.g8s.mydomain.org/organizations/acme/clusters/dah45/nodepools/a7k9m",
+		},
+		{
+			name:    "node pool without ID",
+			getURL:  func() (string, error) { return NodePoolDetailsURL(endpoint, "dah45", "", "acme") },
+			wantErr: true,
+		},
+		{
+			name:   "organization",
+			getURL: func() (string, error) { return OrganizationDetailsURL(endpoint, "acme") },
+			want:   "https://happa.g8s.mydomain.org/organizations/acme",
+		},
+		{
+			name:    "organization without ID",
+			getURL:  func() (string, error) { return OrganizationDetailsURL(endpoint, "") },
+			wantErr: true,
+		},
+		{
+			name:   "release with v prefix",
+			getURL: func() (string, error) { return ReleaseDetailsURL(endpoint, "v11.5.0") },
+			want:   "https://happa.g8s.mydomain.org/releases/11.5.0",
+		},
+		{
+			name:   "apps",
+			getURL: func() (string, error) { return AppCatalogURL(endpoint) },
+			want:   "https://happa.g8s.mydomain.org/apps",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.getURL()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}